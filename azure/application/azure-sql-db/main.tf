@@ -1,17 +1,6 @@
 # azure-application-azure-sql-db module
 # Description: Creates an Azure SQL Database with comprehensive security and monitoring features
 
-terraform {
-  required_version = ">= 1.0"
-
-  required_providers {
-    azurerm = {
-      source  = "hashicorp/azurerm"
-      version = "~> 3.0"
-    }
-  }
-}
-
 # Data sources
 data "azurerm_client_config" "current" {}
 
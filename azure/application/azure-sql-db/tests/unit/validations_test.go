@@ -0,0 +1,28 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/azuresqldb"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	fixture := offline.LoadFixture(t, "sql_server")
+	server := fixture.Data["data.azurerm_mssql_server.main"]
+	terraformOptions := zrrtest.ModuleOptions(t, azuresqldb.Inputs{
+		Name:              "offline-db",
+		ResourceGroupName: server["resource_group_name"].(string),
+		SQLServerName:     tfvars.String(server["name"].(string)),
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, fixture)
+}
@@ -1,2 +1,10 @@
-# Terraform version requirements are defined in main.tf
-# This file is kept for consistency with ZRR module standards
\ No newline at end of file
+terraform {
+  required_version = ">= 1.0"
+
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0"
+    }
+  }
+}
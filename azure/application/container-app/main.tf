@@ -1,11 +1,16 @@
-terraform {
-  required_version = ">= 1.0"
-  required_providers {
-    azurerm = {
-      source  = "hashicorp/azurerm"
-      version = ">= 3.0"
+# azure-application-container-app module
+# Description: Manages Azure Container Apps with ingress, scaling, secrets, and container registry integration
+
+# Local values
+locals {
+  common_tags = merge(
+    var.common_tags,
+    {
+      "ManagedBy" = "Terraform"
+      "Module"    = "zrr-tf-module-lib/azure/application/container-app"
+      "Layer"     = "application"
     }
-  }
+  )
 }
 
 # Data sources
@@ -282,11 +287,7 @@ resource "azurerm_container_app" "main" {
   }
 
   tags = merge(
-    var.common_tags,
-    {
-      Module = "zrr-tf-module-lib/azure/application/container-app"
-      Layer  = "application"
-    },
+    local.common_tags,
     var.container_app_tags
   )
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/containerapp"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, containerapp.Inputs{
+		Name:                      "ca-offline-test",
+		ResourceGroupName:         "rg-test",
+		ContainerAppEnvironmentID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test/providers/Microsoft.App/managedEnvironments/cae-test",
+		Containers: []containerapp.Container{
+			{Name: "app", Image: "nginx:latest", CPU: 0.25, Memory: "0.5Gi"},
+		},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
@@ -2,10 +2,10 @@ package test
 
 import (
 	"fmt"
-	"strings"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/naming"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,11 +15,12 @@ func TestContainerInstanceCreation(t *testing.T) {
 	t.Parallel()
 
 	// Generate a random suffix for unique resource names
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	containerName := fmt.Sprintf("test-container-%s", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-container-rg-%s", randomSuffix)
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 
 		Vars: map[string]interface{}{
@@ -33,51 +34,51 @@ func TestContainerInstanceCreation(t *testing.T) {
 			},
 		},
 
-		// Retry configuration for handling transient errors
-		RetryableTerraformErrors: map[string]string{
-			".*": "Transient error occurred",
-		},
+		// Transient Azure errors are retried by zrrtest.WithDefaults
 		MaxRetries: 3,
-	}
+	}))
 
 	// Clean up resources after test
-	defer terraform.Destroy(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
 
 	// Initialize and apply Terraform
-	terraform.InitAndApply(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate outputs
-	containerGroupID := terraform.Output(t, terraformOptions, "container_group_id")
-	assert.NotEmpty(t, containerGroupID)
+	stages.Validate(func() {
+		// Validate outputs
+		containerGroupID := terraform.Output(t, terraformOptions, "container_group_id")
+		assert.NotEmpty(t, containerGroupID)
 
-	containerGroupName := terraform.Output(t, terraformOptions, "container_group_name")
-	assert.Equal(t, containerName, containerGroupName)
+		containerGroupName := terraform.Output(t, terraformOptions, "container_group_name")
+		assert.Equal(t, containerName, containerGroupName)
 
-	ipAddress := terraform.Output(t, terraformOptions, "ip_address")
-	assert.NotEmpty(t, ipAddress)
+		ipAddress := terraform.Output(t, terraformOptions, "ip_address")
+		assert.NotEmpty(t, ipAddress)
 
-	fqdn := terraform.Output(t, terraformOptions, "fqdn")
-	assert.NotEmpty(t, fqdn)
-	assert.Contains(t, fqdn, fmt.Sprintf("test-container-%s", randomSuffix))
+		fqdn := terraform.Output(t, terraformOptions, "fqdn")
+		assert.NotEmpty(t, fqdn)
+		assert.Contains(t, fqdn, fmt.Sprintf("test-container-%s", randomSuffix))
 
-	containerCount := terraform.Output(t, terraformOptions, "container_count")
-	assert.Equal(t, "1", containerCount)
+		containerCount := terraform.Output(t, terraformOptions, "container_count")
+		assert.Equal(t, "1", containerCount)
 
-	totalCPU := terraform.Output(t, terraformOptions, "total_cpu_allocation")
-	assert.Equal(t, "1", totalCPU)
+		totalCPU := terraform.Output(t, terraformOptions, "total_cpu_allocation")
+		assert.Equal(t, "1", totalCPU)
 
-	totalMemory := terraform.Output(t, terraformOptions, "total_memory_allocation")
-	assert.Equal(t, "1.5", totalMemory)
+		totalMemory := terraform.Output(t, terraformOptions, "total_memory_allocation")
+		assert.Equal(t, "1.5", totalMemory)
+	})
 }
 
 func TestContainerInstanceWithMultipleContainers(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	containerName := fmt.Sprintf("multi-container-%s", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-multi-rg-%s", randomSuffix)
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -135,48 +136,51 @@ func TestContainerInstanceWithMultipleContainers(t *testing.T) {
 				"TestID":      randomSuffix,
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate container group
-	containerGroupID := terraform.Output(t, terraformOptions, "id")
-	assert.NotEmpty(t, containerGroupID)
+	stages.Validate(func() {
+		// Validate container group
+		containerGroupID := terraform.Output(t, terraformOptions, "id")
+		assert.NotEmpty(t, containerGroupID)
 
-	containerGroupName := terraform.Output(t, terraformOptions, "name")
-	assert.Equal(t, containerName, containerGroupName)
+		containerGroupName := terraform.Output(t, terraformOptions, "name")
+		assert.Equal(t, containerName, containerGroupName)
 
-	// Validate multiple containers
-	containerCount := terraform.Output(t, terraformOptions, "container_count")
-	assert.Equal(t, "2", containerCount)
+		// Validate multiple containers
+		containerCount := terraform.Output(t, terraformOptions, "container_count")
+		assert.Equal(t, "2", containerCount)
 
-	primaryContainerName := terraform.Output(t, terraformOptions, "primary_container_name")
-	assert.Equal(t, "frontend", primaryContainerName)
+		primaryContainerName := terraform.Output(t, terraformOptions, "primary_container_name")
+		assert.Equal(t, "frontend", primaryContainerName)
 
-	// Validate resource allocation
-	totalCPU := terraform.Output(t, terraformOptions, "total_cpu_allocation")
-	assert.Equal(t, "1.5", totalCPU)
+		// Validate resource allocation
+		totalCPU := terraform.Output(t, terraformOptions, "total_cpu_allocation")
+		assert.Equal(t, "1.5", totalCPU)
 
-	totalMemory := terraform.Output(t, terraformOptions, "total_memory_allocation")
-	assert.Equal(t, "2.5", totalMemory)
+		totalMemory := terraform.Output(t, terraformOptions, "total_memory_allocation")
+		assert.Equal(t, "2.5", totalMemory)
 
-	// Validate IP and FQDN
-	ipAddress := terraform.Output(t, terraformOptions, "ip_address")
-	assert.NotEmpty(t, ipAddress)
+		// Validate IP and FQDN
+		ipAddress := terraform.Output(t, terraformOptions, "ip_address")
+		assert.NotEmpty(t, ipAddress)
 
-	fqdn := terraform.Output(t, terraformOptions, "fqdn")
-	assert.NotEmpty(t, fqdn)
+		fqdn := terraform.Output(t, terraformOptions, "fqdn")
+		assert.NotEmpty(t, fqdn)
+	})
 }
 
 func TestContainerInstanceWithMonitoring(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	containerName := fmt.Sprintf("monitored-container-%s", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-monitoring-rg-%s", randomSuffix)
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -201,49 +205,52 @@ func TestContainerInstanceWithMonitoring(t *testing.T) {
 				"TestID":      randomSuffix,
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate monitoring configuration
-	monitoringEnabled := terraform.Output(t, terraformOptions, "monitoring_enabled")
-	assert.Equal(t, "true", monitoringEnabled)
+	stages.Validate(func() {
+		// Validate monitoring configuration
+		monitoringEnabled := terraform.Output(t, terraformOptions, "monitoring_enabled")
+		assert.Equal(t, "true", monitoringEnabled)
 
-	logAnalyticsWorkspaceID := terraform.Output(t, terraformOptions, "log_analytics_workspace_id")
-	assert.NotEmpty(t, logAnalyticsWorkspaceID)
+		logAnalyticsWorkspaceID := terraform.Output(t, terraformOptions, "log_analytics_workspace_id")
+		assert.NotEmpty(t, logAnalyticsWorkspaceID)
 
-	cpuAlertID := terraform.Output(t, terraformOptions, "cpu_alert_id")
-	assert.NotEmpty(t, cpuAlertID)
+		cpuAlertID := terraform.Output(t, terraformOptions, "cpu_alert_id")
+		assert.NotEmpty(t, cpuAlertID)
 
-	memoryAlertID := terraform.Output(t, terraformOptions, "memory_alert_id")
-	assert.NotEmpty(t, memoryAlertID)
+		memoryAlertID := terraform.Output(t, terraformOptions, "memory_alert_id")
+		assert.NotEmpty(t, memoryAlertID)
 
-	// Validate alert thresholds
-	alertThresholds := terraform.OutputMap(t, terraformOptions, "alert_thresholds")
-	assert.Equal(t, "75", alertThresholds["cpu_threshold"])
-	assert.Equal(t, "80", alertThresholds["memory_threshold"])
+		// Validate alert thresholds
+		alertThresholds := terraform.OutputMap(t, terraformOptions, "alert_thresholds")
+		assert.Equal(t, "75", alertThresholds["cpu_threshold"])
+		assert.Equal(t, "80", alertThresholds["memory_threshold"])
+	})
 }
 
 func TestContainerInstanceNamingConvention(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	baseName := fmt.Sprintf("test-%s", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-naming-rg-%s", randomSuffix)
 	environment := "dev"
 	locationShort := "eus"
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
-			"name":                   baseName,
-			"location":               "East US",
-			"resource_group_name":    resourceGroupName,
-			"use_naming_convention":  true,
-			"environment":            environment,
-			"location_short":         locationShort,
+			"name":                  baseName,
+			"location":              "East US",
+			"resource_group_name":   resourceGroupName,
+			"use_naming_convention": true,
+			"environment":           environment,
+			"location_short":        locationShort,
 			"containers": []map[string]interface{}{
 				{
 					"name":   "test-container",
@@ -258,33 +265,40 @@ func TestContainerInstanceNamingConvention(t *testing.T) {
 				"TestID":      randomSuffix,
 			},
 		},
-	}
-
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Validate naming convention
-	containerGroupName := terraform.Output(t, terraformOptions, "name")
-	expectedName := fmt.Sprintf("aci-%s-%s-%s", baseName, environment, locationShort)
-	assert.Equal(t, expectedName, containerGroupName)
-
-	// Validate naming details
-	nameDetails := terraform.OutputMap(t, terraformOptions, "container_name_details")
-	assert.Equal(t, baseName, nameDetails["original_name"])
-	assert.Equal(t, expectedName, nameDetails["final_name"])
-	assert.Equal(t, environment, nameDetails["environment"])
-	assert.Equal(t, locationShort, nameDetails["location_short"])
-	assert.Equal(t, "true", nameDetails["naming_convention"])
+	}))
+
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Validate naming convention
+		containerGroupName := terraform.Output(t, terraformOptions, "name")
+		expectedName := naming.MustBuild(naming.ContainerGroup, naming.Parts{
+			Name:          baseName,
+			Environment:   environment,
+			LocationShort: locationShort,
+		})
+		assert.Equal(t, expectedName, containerGroupName)
+
+		// Validate naming details
+		nameDetails := terraform.OutputMap(t, terraformOptions, "container_name_details")
+		assert.Equal(t, baseName, nameDetails["original_name"])
+		assert.Equal(t, expectedName, nameDetails["final_name"])
+		assert.Equal(t, environment, nameDetails["environment"])
+		assert.Equal(t, locationShort, nameDetails["location_short"])
+		assert.Equal(t, "true", nameDetails["naming_convention"])
+	})
 }
 
 func TestContainerInstanceWithVolumes(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	containerName := fmt.Sprintf("volume-container-%s", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-volume-rg-%s", randomSuffix)
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -327,34 +341,37 @@ func TestContainerInstanceWithVolumes(t *testing.T) {
 				"TestID":      randomSuffix,
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate volume configuration
-	volumeCount := terraform.Output(t, terraformOptions, "volume_count")
-	assert.Equal(t, "2", volumeCount)
+	stages.Validate(func() {
+		// Validate volume configuration
+		volumeCount := terraform.Output(t, terraformOptions, "volume_count")
+		assert.Equal(t, "2", volumeCount)
 
-	volumesConfigured := terraform.OutputMapOfObjects(t, terraformOptions, "volumes_configured")
-	require.Contains(t, volumesConfigured, "data-volume")
-	require.Contains(t, volumesConfigured, "config-volume")
+		volumesConfigured := terraform.OutputMapOfObjects(t, terraformOptions, "volumes_configured")
+		require.Contains(t, volumesConfigured, "data-volume")
+		require.Contains(t, volumesConfigured, "config-volume")
 
-	dataVolume := volumesConfigured["data-volume"].(map[string]interface{})
-	assert.Equal(t, true, dataVolume["empty_dir"])
+		dataVolume := volumesConfigured["data-volume"].(map[string]interface{})
+		assert.Equal(t, true, dataVolume["empty_dir"])
 
-	configVolume := volumesConfigured["config-volume"].(map[string]interface{})
-	assert.Equal(t, true, configVolume["empty_dir"])
+		configVolume := volumesConfigured["config-volume"].(map[string]interface{})
+		assert.Equal(t, true, configVolume["empty_dir"])
+	})
 }
 
 func TestContainerInstanceWithHealthChecks(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	containerName := fmt.Sprintf("health-container-%s", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-health-rg-%s", randomSuffix)
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -381,8 +398,8 @@ func TestContainerInstanceWithHealthChecks(t *testing.T) {
 							},
 						},
 						"initial_delay_seconds": 30,
-						"period_seconds":       10,
-						"failure_threshold":    3,
+						"period_seconds":        10,
+						"failure_threshold":     3,
 					},
 					"readiness_probe": map[string]interface{}{
 						"http_get": []map[string]interface{}{
@@ -392,8 +409,8 @@ func TestContainerInstanceWithHealthChecks(t *testing.T) {
 							},
 						},
 						"initial_delay_seconds": 5,
-						"period_seconds":       5,
-						"failure_threshold":    3,
+						"period_seconds":        5,
+						"failure_threshold":     3,
 					},
 				},
 			},
@@ -403,23 +420,26 @@ func TestContainerInstanceWithHealthChecks(t *testing.T) {
 				"TestID":      randomSuffix,
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate health check configuration
-	livenessProbes := terraform.Output(t, terraformOptions, "containers_with_liveness_probes")
-	assert.Equal(t, "1", livenessProbes)
+	stages.Validate(func() {
+		// Validate health check configuration
+		livenessProbes := terraform.Output(t, terraformOptions, "containers_with_liveness_probes")
+		assert.Equal(t, "1", livenessProbes)
 
-	readinessProbes := terraform.Output(t, terraformOptions, "containers_with_readiness_probes")
-	assert.Equal(t, "1", readinessProbes)
+		readinessProbes := terraform.Output(t, terraformOptions, "containers_with_readiness_probes")
+		assert.Equal(t, "1", readinessProbes)
+	})
 }
 
 func TestContainerInstanceTagging(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	containerName := fmt.Sprintf("tagging-container-%s", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-tagging-rg-%s", randomSuffix)
 
@@ -435,15 +455,15 @@ func TestContainerInstanceTagging(t *testing.T) {
 		"Purpose":     "testing",
 	}
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
-			"name":                     containerName,
-			"location":                 "East US",
-			"resource_group_name":      resourceGroupName,
-			"common_tags":              commonTags,
-			"container_instance_tags":  containerInstanceTags,
+			"name":                    containerName,
+			"location":                "East US",
+			"resource_group_name":     resourceGroupName,
+			"common_tags":             commonTags,
+			"container_instance_tags": containerInstanceTags,
 			"containers": []map[string]interface{}{
 				{
 					"name":   "tagged-app",
@@ -453,26 +473,28 @@ func TestContainerInstanceTagging(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Validate tags are applied
-	appliedTags := terraform.OutputMap(t, terraformOptions, "tags")
-
-	// Check common tags
-	for key, value := range commonTags {
-		assert.Equal(t, value, appliedTags[key])
-	}
-
-	// Check container instance specific tags
-	for key, value := range containerInstanceTags {
-		assert.Equal(t, value, appliedTags[key])
-	}
-
-	// Check automatic tags
-	assert.Equal(t, "Terraform", appliedTags["ManagedBy"])
-	assert.Equal(t, "zrr-tf-module-lib/azure/application/container-instance", appliedTags["Module"])
-	assert.Equal(t, "application", appliedTags["Layer"])
-}
\ No newline at end of file
+	}))
+
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Validate tags are applied
+		appliedTags := terraform.OutputMap(t, terraformOptions, "tags")
+
+		// Check common tags
+		for key, value := range commonTags {
+			assert.Equal(t, value, appliedTags[key])
+		}
+
+		// Check container instance specific tags
+		for key, value := range containerInstanceTags {
+			assert.Equal(t, value, appliedTags[key])
+		}
+
+		// Check automatic tags
+		assert.Equal(t, "Terraform", appliedTags["ManagedBy"])
+		assert.Equal(t, "zrr-tf-module-lib/azure/application/container-instance", appliedTags["Module"])
+		assert.Equal(t, "application", appliedTags["Layer"])
+	})
+}
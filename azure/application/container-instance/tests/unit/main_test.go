@@ -3,6 +3,8 @@ package test
 import (
 	"testing"
 
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 )
@@ -10,7 +12,7 @@ import (
 func TestContainerInstanceUnitValidation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -30,25 +32,32 @@ func TestContainerInstanceUnitValidation(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-
-		// Only run terraform plan for unit tests
-		PlanFilePath: "./unit-test.tfplan",
-	}
-
-	// Run terraform plan
-	terraform.Plan(t, terraformOptions)
-
-	// Test basic outputs (plan outputs)
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
-
-	// Validate planned resources exist
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_container_group.main")
+	})
+
+	planned := plan.InitAndPlan(t, terraformOptions)
+
+	// Validate the container group and its tags
+	planned.Count("azurerm_container_group.main", 1).
+		Absent("azurerm_log_analytics_workspace.container_logs").
+		Absent("random_string.dns_suffix")
+
+	planned.Resource("azurerm_container_group.main").
+		Attr("name").Equals("test-container").
+		Attr("container").HasLen(1).
+		Attr("container.0.name").Equals("test-app").
+		Attr("container.0.image").Equals("nginx:latest").
+		Attr("container.0.cpu").Equals(1).
+		Attr("container.0.memory").Equals(1.5).
+		Attr("tags.Environment").Equals("test").
+		Attr("tags.ManagedBy").Equals("Terraform").
+		Attr("tags.Module").Equals("zrr-tf-module-lib/azure/application/container-instance").
+		Attr("tags.Layer").Equals("application")
 }
 
 func TestContainerInstanceWithMultipleContainers(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -101,18 +110,27 @@ func TestContainerInstanceWithMultipleContainers(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
-
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
-
-	// Validate container group is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_container_group.main")
+	})
+
+	planned := plan.InitAndPlan(t, terraformOptions)
+
+	// Validate both containers and the exposed ports are planned
+	planned.Resource("azurerm_container_group.main").
+		Attr("ip_address_type").Equals("Public").
+		Attr("container").HasLen(2).
+		Attr("container.0.name").Equals("frontend").
+		Attr("container.0.ports.0.port").Equals(80).
+		Attr("container.1.name").Equals("backend").
+		Attr("container.1.image").Equals("node:16-alpine").
+		Attr("container.1.ports.0.port").Equals(3000).
+		Attr("container.1.environment_variables.NODE_ENV").Equals("production").
+		Attr("exposed_port").HasLen(2)
 }
 
 func TestContainerInstanceWithPrivateNetworking(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -134,18 +152,22 @@ func TestContainerInstanceWithPrivateNetworking(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
+	})
 
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
-	// Validate container group is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_container_group.main")
+	// Validate the container group is attached to the subnet without exposed ports
+	planned.Resource("azurerm_container_group.main").
+		Attr("ip_address_type").Equals("Private").
+		Attr("subnet_ids").Equals([]string{"/subscriptions/test/resourceGroups/test/providers/Microsoft.Network/virtualNetworks/test-vnet/subnets/test-subnet"}).
+		Attr("exposed_port").HasLen(0).
+		Attr("dns_name_label").Null()
 }
 
 func TestContainerInstanceWithMonitoring(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -169,23 +191,34 @@ func TestContainerInstanceWithMonitoring(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
-
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
+	})
 
-	// Validate container group is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_container_group.main")
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Validate monitoring resources are planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_log_analytics_workspace.container_logs")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.container_cpu")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.container_memory")
+	planned.Count("azurerm_container_group.main", 1).
+		Count("azurerm_log_analytics_workspace.container_logs", 1).
+		Count("azurerm_monitor_metric_alert.container_cpu", 1).
+		Count("azurerm_monitor_metric_alert.container_memory", 1)
+
+	planned.Resource("azurerm_log_analytics_workspace.container_logs[0]").
+		Attr("name").Equals("test-monitored-container-logs").
+		Resource("azurerm_monitor_metric_alert.container_cpu[0]").
+		Attr("name").Equals("test-monitored-container-cpu-alert").
+		Attr("criteria.0.metric_name").Equals("CpuUsage").
+		Attr("criteria.0.threshold").Equals(80).
+		Resource("azurerm_monitor_metric_alert.container_memory[0]").
+		Attr("criteria.0.metric_name").Equals("MemoryUsage").
+		Attr("criteria.0.threshold").Equals(85)
+
+	planned.Resource("azurerm_container_group.main").
+		Attr("diagnostics").HasLen(1)
 }
 
 func TestContainerInstanceWithVolumes(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -220,18 +253,20 @@ func TestContainerInstanceWithVolumes(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
+	})
 
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Validate container group is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_container_group.main")
+	planned.Count("azurerm_container_group.main", 1).
+		Resource("azurerm_container_group.main").
+		Attr("container.0.name").Equals("app-with-storage")
 }
 
 func TestContainerInstanceNamingConvention(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -254,27 +289,19 @@ func TestContainerInstanceNamingConvention(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
+	})
 
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
-
-	// Validate container group is planned with naming convention
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_container_group.main")
-
-	// Extract planned values to verify naming convention
-	plannedValues := terraform.GetPlannedValues(t, planStruct)
-	containerGroup := plannedValues.RootModule.Resources["azurerm_container_group.main"]
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify naming convention is applied: aci-name-environment-location_short
-	expectedName := "aci-test-app-dev-eus"
-	actualName := containerGroup.AttributeValues["name"].(string)
-	assert.Equal(t, expectedName, actualName, "Container group name should follow naming convention")
+	planned.Resource("azurerm_container_group.main").
+		Attr("name").Equals("aci-test-app-dev-eus")
 }
 
 func TestContainerInstanceWithHealthChecks(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -322,12 +349,17 @@ func TestContainerInstanceWithHealthChecks(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
+	})
 
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
-	// Validate container group is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_container_group.main")
+	// Validate the probes are planned on the main container
+	planned.Resource("azurerm_container_group.main").
+		Attr("container.0.liveness_probe.0.http_get.0.path").Equals("/health").
+		Attr("container.0.liveness_probe.0.http_get.0.port").Equals(80).
+		Attr("container.0.liveness_probe.0.initial_delay_seconds").Equals(30).
+		Attr("container.0.readiness_probe.0.http_get.0.path").Equals("/ready").
+		Attr("container.0.readiness_probe.0.period_seconds").Equals(5)
 }
 
 func TestContainerInstanceVariableValidation(t *testing.T) {
@@ -458,10 +490,10 @@ func TestContainerInstanceVariableValidation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			terraformOptions := &terraform.Options{
+			terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 				TerraformDir: "../../",
 				Vars:         tc.vars,
-			}
+			})
 
 			if tc.expectError {
 				_, err := terraform.InitAndPlanE(t, terraformOptions)
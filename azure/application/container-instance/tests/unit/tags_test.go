@@ -0,0 +1,15 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tagpolicy"
+)
+
+// TestExamplesTagPolicy checks that every taggable resource the module plans
+// in its examples carries the ManagedBy, Module and Layer tags.
+func TestExamplesTagPolicy(t *testing.T) {
+	t.Parallel()
+
+	tagpolicy.Examples(t, nil)
+}
@@ -0,0 +1,34 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/containerinstance"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, containerinstance.Inputs{
+		Name:              "ci-offline-test",
+		Location:          "eastus",
+		ResourceGroupName: "rg-test",
+		Containers: []containerinstance.Container{
+			{Name: "app", Image: "nginx:latest", CPU: 1, Memory: 1.5},
+		},
+		// Let the conditions on lists and objects be checked, and their
+		// attributes be changed one at a time.
+		ExposedPorts:    []containerinstance.ExposedPort{{Port: 80, Protocol: "TCP"}},
+		Volumes:         []containerinstance.Volume{{Name: "data", EmptyDir: tfvars.Bool(true)}},
+		DNSConfig:       &containerinstance.DNSConfig{Nameservers: []string{"10.0.0.4"}},
+		ManagedIdentity: &containerinstance.ManagedIdentity{Type: "SystemAssigned"},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
@@ -402,8 +402,8 @@ variable "location_short" {
   default     = "eus"
 
   validation {
-    condition     = can(regex("^[a-z]{2,5}$", var.location_short))
-    error_message = "Location short must be 2-5 lowercase letters."
+    condition     = can(regex("^[a-z][a-z0-9]{1,4}$", var.location_short))
+    error_message = "Location short must be 2-5 lowercase letters or digits, starting with a letter."
   }
 }
 
@@ -1,11 +1,16 @@
-terraform {
-  required_version = ">= 1.0"
-  required_providers {
-    azurerm = {
-      source  = "hashicorp/azurerm"
-      version = ">= 3.0"
+# azure-infrastructure-container-app-environment module
+# Description: Manages Azure Container App Environments with workload profiles, storage, certificates, and Dapr components
+
+# Local values
+locals {
+  common_tags = merge(
+    var.common_tags,
+    {
+      "ManagedBy" = "Terraform"
+      "Module"    = "zrr-tf-module-lib/azure/infrastructure/container-app-environment"
+      "Layer"     = "infrastructure"
     }
-  }
+  )
 }
 
 # Data sources
@@ -37,11 +42,7 @@ resource "azurerm_container_app_environment" "main" {
   }
 
   tags = merge(
-    var.common_tags,
-    {
-      Module = "zrr-tf-module-lib/azure/infrastructure/container-app-environment"
-      Layer  = "infrastructure"
-    },
+    local.common_tags,
     var.environment_tags
   )
 }
@@ -68,10 +69,9 @@ resource "azurerm_container_app_environment_certificate" "certificates" {
   certificate_password         = each.value.certificate_password
 
   tags = merge(
-    var.common_tags,
+    local.common_tags,
     {
-      Module = "zrr-tf-module-lib/azure/infrastructure/container-app-environment"
-      Type   = "certificate"
+      Type = "certificate"
     }
   )
 }
@@ -0,0 +1,24 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/containerappenvironment"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, containerappenvironment.Inputs{
+		Name:                    "cae-offline-test",
+		ResourceGroupName:       "rg-test",
+		LogAnalyticsWorkspaceID: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test/providers/Microsoft.OperationalInsights/workspaces/log-test",
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
@@ -1,11 +1,16 @@
-terraform {
-  required_version = ">= 1.0"
-  required_providers {
-    azurerm = {
-      source  = "hashicorp/azurerm"
-      version = ">= 3.0"
+# azure-infrastructure-container-registry module
+# Description: Manages Azure Container Registries with network rules, geo-replication, and scheduled image imports
+
+# Local values
+locals {
+  common_tags = merge(
+    var.common_tags,
+    {
+      "ManagedBy" = "Terraform"
+      "Module"    = "zrr-tf-module-lib/azure/infrastructure/container-registry"
+      "Layer"     = "infrastructure"
     }
-  }
+  )
 }
 
 # Data source for current subscription
@@ -88,11 +93,7 @@ resource "azurerm_container_registry" "main" {
   }
 
   tags = merge(
-    var.common_tags,
-    {
-      Module = "zrr-tf-module-lib/azure/infrastructure/container-registry"
-      Layer  = "infrastructure"
-    },
+    local.common_tags,
     var.acr_tags
   )
 }
@@ -147,10 +148,9 @@ resource "azurerm_container_registry_task" "import_task" {
   }
 
   tags = merge(
-    var.common_tags,
+    local.common_tags,
     {
-      Module = "zrr-tf-module-lib/azure/infrastructure/container-registry"
-      Task   = "import"
+      Task = "import"
     }
   )
 }
\ No newline at end of file
@@ -0,0 +1,23 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/containerregistry"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, containerregistry.Inputs{
+		Name:              "acrofflinetest",
+		ResourceGroupName: "rg-test",
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
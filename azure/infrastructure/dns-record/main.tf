@@ -1,10 +1,6 @@
 # azure-infrastructure-dns-record module
 # Description: Manages Azure DNS Records with comprehensive record type support, validation, monitoring, and enterprise governance capabilities
 
-terraform {
-  required_version = ">= 1.0"
-}
-
 # Data sources
 data "azurerm_dns_zone" "main" {
   count               = var.dns_zone_name != null ? 1 : 0
@@ -0,0 +1,27 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+)
+
+// API versions of the zones the suites create.
+const (
+	dnsZonesAPIVersion        = "2018-05-01"
+	privateDNSZonesAPIVersion = "2018-09-01"
+)
+
+// createDNSZone creates the public DNS zone the test's records go in.
+// Deleting its resource group deletes it.
+func createDNSZone(t *testing.T, resourceGroupName, zoneName string) {
+	id := zrrtest.ResourceID(t, "", resourceGroupName, "Microsoft.Network/dnsZones", zoneName)
+	zrrtest.CreateResource(t, "", id, dnsZonesAPIVersion, "global", map[string]interface{}{})
+}
+
+// createPrivateDNSZone creates the private DNS zone the test's records go
+// in. Deleting its resource group deletes it.
+func createPrivateDNSZone(t *testing.T, resourceGroupName, zoneName string) {
+	id := zrrtest.ResourceID(t, "", resourceGroupName, "Microsoft.Network/privateDnsZones", zoneName)
+	zrrtest.CreateResource(t, "", id, privateDNSZonesAPIVersion, "global", map[string]interface{}{})
+}
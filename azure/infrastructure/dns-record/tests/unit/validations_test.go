@@ -0,0 +1,31 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/dnsrecord"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf, including the TTL bounds and the MX and
+// SRV record fields, and checks each fails with its error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, dnsrecord.Inputs{
+		Name:       "www",
+		RecordType: "A",
+		Records:    []string{"10.0.0.1"},
+		// Let the record fields be changed one at a time.
+		MxRecords: []dnsrecord.MxRecord{
+			{Preference: 10, Exchange: "mail.example.com."},
+		},
+		SrvRecords: []dnsrecord.SrvRecord{
+			{Priority: 10, Weight: 5, Port: 443, Target: "sip.example.com."},
+		},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
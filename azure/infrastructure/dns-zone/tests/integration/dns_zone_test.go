@@ -2,11 +2,10 @@ package test
 
 import (
 	"fmt"
-	"strings"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/azure"
-	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/naming"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,11 +15,12 @@ func TestDNSZoneCreation(t *testing.T) {
 	t.Parallel()
 
 	// Generate a random suffix for unique resource names
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	zoneName := fmt.Sprintf("test-%s.example.com", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-dns-rg-%s", randomSuffix)
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 
 		Vars: map[string]interface{}{
@@ -33,54 +33,54 @@ func TestDNSZoneCreation(t *testing.T) {
 			},
 		},
 
-		// Retry configuration for handling transient errors
-		RetryableTerraformErrors: map[string]string{
-			".*": "Transient error occurred",
-		},
+		// Transient Azure errors are retried by zrrtest.WithDefaults
 		MaxRetries: 3,
-	}
+	}))
 
 	// Clean up resources after test
-	defer terraform.Destroy(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
 
 	// Initialize and apply Terraform
-	terraform.InitAndApply(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate outputs
-	dnsZoneID := terraform.Output(t, terraformOptions, "dns_zone_id")
-	assert.NotEmpty(t, dnsZoneID)
+	stages.Validate(func() {
+		// Validate outputs
+		dnsZoneID := terraform.Output(t, terraformOptions, "dns_zone_id")
+		assert.NotEmpty(t, dnsZoneID)
 
-	dnsZoneName := terraform.Output(t, terraformOptions, "dns_zone_name")
-	assert.Equal(t, zoneName, dnsZoneName)
+		dnsZoneName := terraform.Output(t, terraformOptions, "dns_zone_name")
+		assert.Equal(t, zoneName, dnsZoneName)
 
-	nameServers := terraform.OutputList(t, terraformOptions, "name_servers")
-	assert.NotEmpty(t, nameServers)
-	assert.Len(t, nameServers, 4) // Azure DNS provides 4 name servers
+		nameServers := terraform.OutputList(t, terraformOptions, "name_servers")
+		assert.NotEmpty(t, nameServers)
+		assert.Len(t, nameServers, 4) // Azure DNS provides 4 name servers
 
-	primaryNameServer := terraform.Output(t, terraformOptions, "primary_name_server")
-	assert.NotEmpty(t, primaryNameServer)
-	assert.Contains(t, nameServers, primaryNameServer)
+		primaryNameServer := terraform.Output(t, terraformOptions, "primary_name_server")
+		assert.NotEmpty(t, primaryNameServer)
+		assert.Contains(t, nameServers, primaryNameServer)
 
-	recordCount := terraform.Output(t, terraformOptions, "record_count")
-	assert.Equal(t, "3", recordCount) // 2 A records + 1 CNAME record from basic example
+		recordCount := terraform.Output(t, terraformOptions, "record_count")
+		assert.Equal(t, "3", recordCount) // 2 A records + 1 CNAME record from basic example
+	})
 }
 
 func TestDNSZoneWithAdvancedFeatures(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	zoneName := fmt.Sprintf("advanced-%s.example.com", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-dns-advanced-rg-%s", randomSuffix)
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
-			"name":                         zoneName,
-			"resource_group_name":          resourceGroupName,
-			"enable_monitoring":            true,
-			"query_volume_threshold":       5000,
-			"record_set_count_threshold":   1000,
+			"name":                       zoneName,
+			"resource_group_name":        resourceGroupName,
+			"enable_monitoring":          true,
+			"query_volume_threshold":     5000,
+			"record_set_count_threshold": 1000,
 
 			// Test various record types
 			"a_records": []map[string]interface{}{
@@ -148,110 +148,120 @@ func TestDNSZoneWithAdvancedFeatures(t *testing.T) {
 				"TestID":      randomSuffix,
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate DNS zone
-	dnsZoneID := terraform.Output(t, terraformOptions, "id")
-	assert.NotEmpty(t, dnsZoneID)
+	stages.Validate(func() {
+		// Validate DNS zone
+		dnsZoneID := terraform.Output(t, terraformOptions, "id")
+		assert.NotEmpty(t, dnsZoneID)
 
-	zoneName = terraform.Output(t, terraformOptions, "name")
-	assert.NotEmpty(t, zoneName)
+		zoneName = terraform.Output(t, terraformOptions, "name")
+		assert.NotEmpty(t, zoneName)
 
-	// Validate record creation
-	aRecords := terraform.OutputMapOfObjects(t, terraformOptions, "a_records")
-	assert.Len(t, aRecords, 2)
+		// Validate record creation
+		aRecords := terraform.OutputMapOfObjects(t, terraformOptions, "a_records")
+		assert.Len(t, aRecords, 2)
 
-	aaaaRecords := terraform.OutputMapOfObjects(t, terraformOptions, "aaaa_records")
-	assert.Len(t, aaaaRecords, 1)
+		aaaaRecords := terraform.OutputMapOfObjects(t, terraformOptions, "aaaa_records")
+		assert.Len(t, aaaaRecords, 1)
 
-	cnameRecords := terraform.OutputMapOfObjects(t, terraformOptions, "cname_records")
-	assert.Len(t, cnameRecords, 1)
+		cnameRecords := terraform.OutputMapOfObjects(t, terraformOptions, "cname_records")
+		assert.Len(t, cnameRecords, 1)
 
-	mxRecords := terraform.OutputMapOfObjects(t, terraformOptions, "mx_records")
-	assert.Len(t, mxRecords, 1)
+		mxRecords := terraform.OutputMapOfObjects(t, terraformOptions, "mx_records")
+		assert.Len(t, mxRecords, 1)
 
-	txtRecords := terraform.OutputMapOfObjects(t, terraformOptions, "txt_records")
-	assert.Len(t, txtRecords, 1)
+		txtRecords := terraform.OutputMapOfObjects(t, terraformOptions, "txt_records")
+		assert.Len(t, txtRecords, 1)
 
-	srvRecords := terraform.OutputMapOfObjects(t, terraformOptions, "srv_records")
-	assert.Len(t, srvRecords, 1)
+		srvRecords := terraform.OutputMapOfObjects(t, terraformOptions, "srv_records")
+		assert.Len(t, srvRecords, 1)
 
-	// Validate monitoring
-	monitoringEnabled := terraform.Output(t, terraformOptions, "monitoring_enabled")
-	assert.Equal(t, "true", monitoringEnabled)
+		// Validate monitoring
+		monitoringEnabled := terraform.Output(t, terraformOptions, "monitoring_enabled")
+		assert.Equal(t, "true", monitoringEnabled)
 
-	queryVolumeAlertID := terraform.Output(t, terraformOptions, "query_volume_alert_id")
-	assert.NotEmpty(t, queryVolumeAlertID)
+		queryVolumeAlertID := terraform.Output(t, terraformOptions, "query_volume_alert_id")
+		assert.NotEmpty(t, queryVolumeAlertID)
 
-	recordCountAlertID := terraform.Output(t, terraformOptions, "record_count_alert_id")
-	assert.NotEmpty(t, recordCountAlertID)
+		recordCountAlertID := terraform.Output(t, terraformOptions, "record_count_alert_id")
+		assert.NotEmpty(t, recordCountAlertID)
 
-	// Validate record count
-	recordTypesSum := terraform.OutputMapOfObjects(t, terraformOptions, "record_types_summary")
-	totalRecords := 0
-	for _, count := range recordTypesSum {
-		if countFloat, ok := count.(float64); ok {
-			totalRecords += int(countFloat)
+		// Validate record count
+		recordTypesSum := terraform.OutputMapOfObjects(t, terraformOptions, "record_types_summary")
+		totalRecords := 0
+		for _, count := range recordTypesSum {
+			if countFloat, ok := count.(float64); ok {
+				totalRecords += int(countFloat)
+			}
 		}
-	}
-	assert.Equal(t, 6, totalRecords) // 2A + 1AAAA + 1CNAME + 1MX + 1TXT + 1SRV
+		assert.Equal(t, 6, totalRecords) // 2A + 1AAAA + 1CNAME + 1MX + 1TXT + 1SRV
+	})
 }
 
 func TestDNSZoneNamingConvention(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	baseName := fmt.Sprintf("test-%s", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-naming-rg-%s", randomSuffix)
 	environment := "dev"
 	domainSuffix := "internal"
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
-			"name":                   baseName,
-			"resource_group_name":    resourceGroupName,
-			"use_naming_convention":  true,
-			"environment":            environment,
-			"domain_suffix":          domainSuffix,
+			"name":                  baseName,
+			"resource_group_name":   resourceGroupName,
+			"use_naming_convention": true,
+			"environment":           environment,
+			"domain_suffix":         domainSuffix,
 			"common_tags": map[string]string{
 				"Environment": environment,
 				"Project":     "terratest",
 				"TestID":      randomSuffix,
 			},
 		},
-	}
-
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Validate naming convention
-	zoneName := terraform.Output(t, terraformOptions, "name")
-	expectedName := fmt.Sprintf("%s.%s.%s", baseName, environment, domainSuffix)
-	assert.Equal(t, expectedName, zoneName)
-
-	// Validate naming details
-	zoneNameDetails := terraform.OutputMap(t, terraformOptions, "zone_name_details")
-	assert.Equal(t, baseName, zoneNameDetails["original_name"])
-	assert.Equal(t, expectedName, zoneNameDetails["final_zone_name"])
-	assert.Equal(t, environment, zoneNameDetails["environment"])
-	assert.Equal(t, domainSuffix, zoneNameDetails["domain_suffix"])
-	assert.Equal(t, "true", zoneNameDetails["naming_convention"])
+	}))
+
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Validate naming convention
+		zoneName := terraform.Output(t, terraformOptions, "name")
+		expectedName := naming.MustBuild(naming.DNSZone, naming.Parts{
+			Name:         baseName,
+			Environment:  environment,
+			DomainSuffix: domainSuffix,
+		})
+		assert.Equal(t, expectedName, zoneName)
+
+		// Validate naming details
+		zoneNameDetails := terraform.OutputMap(t, terraformOptions, "zone_name_details")
+		assert.Equal(t, baseName, zoneNameDetails["original_name"])
+		assert.Equal(t, expectedName, zoneNameDetails["final_zone_name"])
+		assert.Equal(t, environment, zoneNameDetails["environment"])
+		assert.Equal(t, domainSuffix, zoneNameDetails["domain_suffix"])
+		assert.Equal(t, "true", zoneNameDetails["naming_convention"])
+	})
 }
 
 func TestDNSZoneRecordValidation(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	zoneName := fmt.Sprintf("validation-%s.example.com", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-validation-rg-%s", randomSuffix)
 
 	// Test with complex record configurations
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -302,44 +312,47 @@ func TestDNSZoneRecordValidation(t *testing.T) {
 				"TestID":      randomSuffix,
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate A records with different TTLs
-	aRecords := terraform.OutputMapOfObjects(t, terraformOptions, "a_records")
-	require.Contains(t, aRecords, "www")
-	require.Contains(t, aRecords, "api")
+	stages.Validate(func() {
+		// Validate A records with different TTLs
+		aRecords := terraform.OutputMapOfObjects(t, terraformOptions, "a_records")
+		require.Contains(t, aRecords, "www")
+		require.Contains(t, aRecords, "api")
 
-	wwwRecord := aRecords["www"].(map[string]interface{})
-	assert.Equal(t, float64(3600), wwwRecord["ttl"])
+		wwwRecord := aRecords["www"].(map[string]interface{})
+		assert.Equal(t, float64(3600), wwwRecord["ttl"])
 
-	apiRecord := aRecords["api"].(map[string]interface{})
-	assert.Equal(t, float64(300), apiRecord["ttl"])
+		apiRecord := aRecords["api"].(map[string]interface{})
+		assert.Equal(t, float64(300), apiRecord["ttl"])
 
-	// Validate CNAME records
-	cnameRecords := terraform.OutputMapOfObjects(t, terraformOptions, "cname_records")
-	require.Contains(t, cnameRecords, "test-app")
+		// Validate CNAME records
+		cnameRecords := terraform.OutputMapOfObjects(t, terraformOptions, "cname_records")
+		require.Contains(t, cnameRecords, "test-app")
 
-	testAppRecord := cnameRecords["test-app"].(map[string]interface{})
-	assert.Equal(t, float64(7200), testAppRecord["ttl"])
+		testAppRecord := cnameRecords["test-app"].(map[string]interface{})
+		assert.Equal(t, float64(7200), testAppRecord["ttl"])
 
-	// Validate MX records
-	mxRecords := terraform.OutputMapOfObjects(t, terraformOptions, "mx_records")
-	require.Contains(t, mxRecords, "@")
+		// Validate MX records
+		mxRecords := terraform.OutputMapOfObjects(t, terraformOptions, "mx_records")
+		require.Contains(t, mxRecords, "@")
 
-	// Validate record count summary
-	recordTypesSum := terraform.OutputMap(t, terraformOptions, "record_types_summary")
-	assert.Equal(t, "2", recordTypesSum["a_records"])
-	assert.Equal(t, "1", recordTypesSum["cname_records"])
-	assert.Equal(t, "1", recordTypesSum["mx_records"])
+		// Validate record count summary
+		recordTypesSum := terraform.OutputMap(t, terraformOptions, "record_types_summary")
+		assert.Equal(t, "2", recordTypesSum["a_records"])
+		assert.Equal(t, "1", recordTypesSum["cname_records"])
+		assert.Equal(t, "1", recordTypesSum["mx_records"])
+	})
 }
 
 func TestDNSZoneTagging(t *testing.T) {
 	t.Parallel()
 
-	randomSuffix := strings.ToLower(random.UniqueId())
+	stages := zrrtest.NewStages(t)
+	randomSuffix := stages.UniqueID()
 	zoneName := fmt.Sprintf("tagging-%s.example.com", randomSuffix)
 	resourceGroupName := fmt.Sprintf("test-tagging-rg-%s", randomSuffix)
 
@@ -355,7 +368,7 @@ func TestDNSZoneTagging(t *testing.T) {
 		"Purpose": "testing",
 	}
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -364,33 +377,28 @@ func TestDNSZoneTagging(t *testing.T) {
 			"common_tags":         commonTags,
 			"dns_zone_tags":       dnsZoneTags,
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate tags are applied
-	appliedTags := terraform.OutputMap(t, terraformOptions, "tags")
+	stages.Validate(func() {
+		// Validate tags are applied
+		appliedTags := terraform.OutputMap(t, terraformOptions, "tags")
 
-	// Check common tags
-	for key, value := range commonTags {
-		assert.Equal(t, value, appliedTags[key])
-	}
+		// Check common tags
+		for key, value := range commonTags {
+			assert.Equal(t, value, appliedTags[key])
+		}
 
-	// Check DNS zone specific tags
-	for key, value := range dnsZoneTags {
-		assert.Equal(t, value, appliedTags[key])
-	}
+		// Check DNS zone specific tags
+		for key, value := range dnsZoneTags {
+			assert.Equal(t, value, appliedTags[key])
+		}
 
-	// Check automatic tags
-	assert.Equal(t, "Terraform", appliedTags["ManagedBy"])
-	assert.Equal(t, "zrr-tf-module-lib/azure/infrastructure/dns-zone", appliedTags["Module"])
-	assert.Equal(t, "infrastructure", appliedTags["Layer"])
+		// Check automatic tags
+		assert.Equal(t, "Terraform", appliedTags["ManagedBy"])
+		assert.Equal(t, "zrr-tf-module-lib/azure/infrastructure/dns-zone", appliedTags["Module"])
+		assert.Equal(t, "infrastructure", appliedTags["Layer"])
+	})
 }
-
-// Helper function to check if DNS zone exists
-func dnsZoneExists(t *testing.T, subscriptionID string, resourceGroupName string, zoneName string) bool {
-	exists, err := azure.DNSZoneExistsE(zoneName, resourceGroupName, subscriptionID)
-	require.NoError(t, err)
-	return exists
-}
\ No newline at end of file
@@ -3,6 +3,8 @@ package test
 import (
 	"testing"
 
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 )
@@ -10,7 +12,7 @@ import (
 func TestDNSZoneUnitValidation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -21,25 +23,31 @@ func TestDNSZoneUnitValidation(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-
-		// Only run terraform plan for unit tests
-		PlanFilePath: "./unit-test.tfplan",
-	}
-
-	// Run terraform plan
-	terraform.Plan(t, terraformOptions)
-
-	// Test basic outputs (plan outputs)
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
-
-	// Validate planned resources exist
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_zone.main")
+	})
+
+	planned := plan.InitAndPlan(t, terraformOptions)
+
+	// Validate the zone and its tags; no optional resources without inputs
+	planned.Count("azurerm_dns_zone.main", 1).
+		Absent("azurerm_dns_a_record.a_records").
+		Absent("azurerm_dns_ns_record.delegation").
+		Absent("azurerm_monitor_metric_alert.dns_query_volume")
+
+	planned.Resource("azurerm_dns_zone.main").
+		Attr("name").Equals("test.example.com").
+		Attr("resource_group_name").Equals("test-rg").
+		Attr("tags.Environment").Equals("test").
+		Attr("tags.Project").Equals("terratest").
+		Attr("tags.ManagedBy").Equals("Terraform").
+		Attr("tags.Module").Equals("zrr-tf-module-lib/azure/infrastructure/dns-zone").
+		Attr("tags.Layer").Equals("infrastructure").
+		Attr("name_servers").Unknown()
 }
 
 func TestDNSZoneWithRecords(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -64,24 +72,32 @@ func TestDNSZoneWithRecords(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
-
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
-
-	// Validate DNS zone is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_zone.main")
-
-	// Validate A record is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_a_record.a_records")
-
-	// Validate CNAME record is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_cname_record.cname_records")
+	})
+
+	planned := plan.InitAndPlan(t, terraformOptions)
+
+	// Validate DNS zone and one record of each type are planned
+	planned.Count("azurerm_dns_zone.main", 1).
+		Count("azurerm_dns_a_record.a_records", 1).
+		Count("azurerm_dns_cname_record.cname_records", 1)
+
+	// Validate A record
+	planned.Resource(`azurerm_dns_a_record.a_records["www"]`).
+		Attr("zone_name").Equals("test-records.example.com").
+		Attr("ttl").Equals(3600).
+		Attr("records").Equals([]string{"1.2.3.4"}).
+		Attr("tags.ManagedBy").Equals("Terraform")
+
+	// Validate CNAME record
+	planned.Resource(`azurerm_dns_cname_record.cname_records["blog"]`).
+		Attr("record").Equals("www.test-records.example.com").
+		Attr("ttl").Equals(3600)
 }
 
 func TestDNSZoneWithDelegation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -95,21 +111,25 @@ func TestDNSZoneWithDelegation(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
+	})
 
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
-	// Validate DNS zone is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_zone.main")
+	// Validate DNS zone and the delegation NS record are planned
+	planned.Count("azurerm_dns_zone.main", 1).
+		Count("azurerm_dns_ns_record.delegation", 1)
 
-	// Validate delegation NS record is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_ns_record.delegation")
+	// The NS record lives in the parent zone and points at the new name servers
+	planned.Resource("azurerm_dns_ns_record.delegation[0]").
+		Attr("zone_name").Equals("example.com").
+		Attr("name").Equals("subdomain").
+		Attr("records").Unknown()
 }
 
 func TestDNSZoneWithMonitoring(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -124,22 +144,29 @@ func TestDNSZoneWithMonitoring(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
+	})
 
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
-
-	// Validate DNS zone is planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_zone.main")
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Validate monitoring alerts are planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.dns_query_volume")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.dns_record_set_count")
+	planned.Count("azurerm_dns_zone.main", 1).
+		Count("azurerm_monitor_metric_alert.dns_query_volume", 1).
+		Count("azurerm_monitor_metric_alert.dns_record_set_count", 1)
+
+	planned.Resource("azurerm_monitor_metric_alert.dns_query_volume[0]").
+		Attr("name").Equals("monitored.example.com-query-volume-alert").
+		Attr("criteria.0.metric_name").Equals("QueryVolume").
+		Attr("criteria.0.threshold").Equals(5000).
+		Resource("azurerm_monitor_metric_alert.dns_record_set_count[0]").
+		Attr("name").Equals("monitored.example.com-record-count-alert").
+		Attr("criteria.0.metric_name").Equals("RecordSetCount").
+		Attr("criteria.0.threshold").Equals(1000)
 }
 
 func TestDNSZoneNamingConvention(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -153,27 +180,19 @@ func TestDNSZoneNamingConvention(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
+	})
 
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
-
-	// Validate DNS zone is planned with naming convention
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_zone.main")
-
-	// Extract planned values to verify naming convention
-	plannedValues := terraform.GetPlannedValues(t, planStruct)
-	dnsZone := plannedValues.RootModule.Resources["azurerm_dns_zone.main"]
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify naming convention is applied: name.environment.domain_suffix
-	expectedName := "test-app.dev.internal"
-	actualName := dnsZone.AttributeValues["name"].(string)
-	assert.Equal(t, expectedName, actualName, "DNS zone name should follow naming convention")
+	planned.Resource("azurerm_dns_zone.main").
+		Attr("name").Equals("test-app.dev.internal")
 }
 
 func TestDNSZoneComplexRecords(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -233,17 +252,31 @@ func TestDNSZoneComplexRecords(t *testing.T) {
 				"Project":     "terratest",
 			},
 		},
-	}
+	})
 
-	planStruct := terraform.InitAndPlanAndShow(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Validate all record types are planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_zone.main")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_a_record.a_records")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_aaaa_record.aaaa_records")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_mx_record.mx_records")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_txt_record.txt_records")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_dns_srv_record.srv_records")
+	planned.Count("azurerm_dns_zone.main", 1).
+		Count("azurerm_dns_a_record.a_records", 1).
+		Count("azurerm_dns_aaaa_record.aaaa_records", 1).
+		Count("azurerm_dns_mx_record.mx_records", 1).
+		Count("azurerm_dns_txt_record.txt_records", 1).
+		Count("azurerm_dns_srv_record.srv_records", 1)
+
+	// Validate the nested record blocks
+	planned.Resource(`azurerm_dns_a_record.a_records["www"]`).
+		Attr("records").HasLen(2).
+		Resource(`azurerm_dns_aaaa_record.aaaa_records["www"]`).
+		Attr("records").Contains("2001:db8::1").
+		Resource(`azurerm_dns_mx_record.mx_records["@"]`).
+		Attr("record.0.preference").Equals(10).
+		Attr("record.0.exchange").Equals("mail.complex.example.com").
+		Resource(`azurerm_dns_txt_record.txt_records["@"]`).
+		Attr("record.0.value").Equals("v=spf1 include:_spf.google.com ~all").
+		Resource(`azurerm_dns_srv_record.srv_records["_sip._tcp"]`).
+		Attr("record.0.port").Equals(5060).
+		Attr("record.0.target").Equals("sip.complex.example.com")
 }
 
 func TestDNSZoneVariableValidation(t *testing.T) {
@@ -322,10 +355,10 @@ func TestDNSZoneVariableValidation(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			terraformOptions := &terraform.Options{
+			terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 				TerraformDir: "../../",
 				Vars:         tc.vars,
-			}
+			})
 
 			if tc.expectError {
 				_, err := terraform.InitAndPlanE(t, terraformOptions)
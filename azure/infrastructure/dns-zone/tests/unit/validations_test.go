@@ -0,0 +1,36 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/dnszone"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf, including those on the names and values
+// of each record type, and checks each fails with its error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, dnszone.Inputs{
+		Name:              "example.com",
+		ResourceGroupName: "rg-test",
+		// One record of each type, whose attributes are changed one at a
+		// time.
+		ARecords:     []dnszone.ARecord{{Name: "www", TTL: 300, Records: []string{"10.0.0.1"}}},
+		AaaaRecords:  []dnszone.AaaaRecord{{Name: "www", TTL: 300, Records: []string{"2001:db8::1"}}},
+		CnameRecords: []dnszone.CnameRecord{{Name: "docs", TTL: 300, Record: "www.example.com"}},
+		MxRecords: []dnszone.MxRecord{{Name: "@", TTL: 300, Records: []dnszone.MxRecordRecord{
+			{Preference: 10, Exchange: "mail.example.com"},
+		}}},
+		TxtRecords: []dnszone.TxtRecord{{Name: "@", TTL: 300, Records: []string{"v=spf1 -all"}}},
+		SrvRecords: []dnszone.SrvRecord{{Name: "_sip._tcp", TTL: 300, Records: []dnszone.SrvRecordRecord{
+			{Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com"},
+		}}},
+		PtrRecords: []dnszone.PtrRecord{{Name: "1", TTL: 300, Records: []string{"www.example.com"}}},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
@@ -1,11 +1,16 @@
-terraform {
-  required_version = ">= 1.0"
-  required_providers {
-    azurerm = {
-      source  = "hashicorp/azurerm"
-      version = ">= 3.0"
+# azure-infrastructure-log-analytics-workspace module
+# Description: Manages Azure Log Analytics Workspaces with solutions, data collection rules, and saved searches
+
+# Local values
+locals {
+  common_tags = merge(
+    var.common_tags,
+    {
+      "ManagedBy" = "Terraform"
+      "Module"    = "zrr-tf-module-lib/azure/infrastructure/log-analytics-workspace"
+      "Layer"     = "infrastructure"
     }
-  }
+  )
 }
 
 # Data sources
@@ -38,11 +43,7 @@ resource "azurerm_log_analytics_workspace" "main" {
   }
 
   tags = merge(
-    var.common_tags,
-    {
-      Module = "zrr-tf-module-lib/azure/infrastructure/log-analytics-workspace"
-      Layer  = "infrastructure"
-    },
+    local.common_tags,
     var.workspace_tags
   )
 }
@@ -63,9 +64,8 @@ resource "azurerm_log_analytics_solution" "solutions" {
   }
 
   tags = merge(
-    var.common_tags,
+    local.common_tags,
     {
-      Module   = "zrr-tf-module-lib/azure/infrastructure/log-analytics-workspace"
       Solution = each.key
     }
   )
@@ -125,10 +125,9 @@ resource "azurerm_monitor_data_collection_rule" "main" {
   }
 
   tags = merge(
-    var.common_tags,
+    local.common_tags,
     {
-      Module = "zrr-tf-module-lib/azure/infrastructure/log-analytics-workspace"
-      Type   = "data-collection-rule"
+      Type = "data-collection-rule"
     }
   )
 }
@@ -147,10 +146,9 @@ resource "azurerm_log_analytics_saved_search" "searches" {
   function_parameters = each.value.function_parameters
 
   tags = merge(
-    var.common_tags,
+    local.common_tags,
     {
-      Module = "zrr-tf-module-lib/azure/infrastructure/log-analytics-workspace"
-      Type   = "saved-search"
+      Type = "saved-search"
     }
   )
 }
\ No newline at end of file
@@ -3,6 +3,8 @@ package test
 import (
 	"testing"
 
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 )
@@ -10,7 +12,7 @@ import (
 func TestMySQLDatabaseUnit(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 		Vars: map[string]interface{}{
 			"database_name":            "test_db",
@@ -20,28 +22,28 @@ func TestMySQLDatabaseUnit(t *testing.T) {
 			"charset":                  "utf8mb4",
 			"collation":               "utf8mb4_unicode_ci",
 		},
-		PlanOnly: true,
 	})
 
-	defer terraform.Destroy(t, terraformOptions)
-
 	// Run terraform plan
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
-
-	// Verify resources will be created
-	resourceCounts := terraform.GetResourceCount(t, planStruct)
-	assert.Greater(t, resourceCounts.Add, 0, "Should plan to create resources")
-	assert.Equal(t, resourceCounts.Change, 0, "Should not plan to change existing resources")
-	assert.Equal(t, resourceCounts.Destroy, 0, "Should not plan to destroy existing resources")
-
-	// Verify specific resources are planned for Flexible Server
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "module.mysql_database_basic.azurerm_mysql_flexible_database.main[0]")
+	planned := plan.InitAndPlan(t, terraformOptions)
+
+	// Verify resources will only be created, on the Flexible Server
+	planned.OnlyCreates().
+		Count("module.mysql_database_basic.azurerm_mysql_flexible_database.main", 1).
+		Absent("module.mysql_database_basic.azurerm_mysql_database.main")
+
+	planned.Resource("module.mysql_database_basic.azurerm_mysql_flexible_database.main[0]").
+		Attr("name").Equals("test_db").
+		Attr("server_name").Equals("test-mysql-server").
+		Attr("resource_group_name").Equals("test-rg").
+		Attr("charset").Equals("utf8mb4").
+		Attr("collation").Equals("utf8mb4_unicode_ci")
 }
 
 func TestMySQLDatabaseSingleServerUnit(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 		Vars: map[string]interface{}{
 			"database_name":       "test_db",
@@ -51,26 +53,27 @@ func TestMySQLDatabaseSingleServerUnit(t *testing.T) {
 			"charset":             "utf8mb4",
 			"collation":          "utf8mb4_unicode_ci",
 		},
-		PlanOnly: true,
 	})
 
-	defer terraform.Destroy(t, terraformOptions)
-
 	// Run terraform plan
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
-
-	// Verify resources will be created
-	resourceCounts := terraform.GetResourceCount(t, planStruct)
-	assert.Greater(t, resourceCounts.Add, 0, "Should plan to create resources")
-
-	// Verify specific resources are planned for Single Server
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "module.mysql_database_basic.azurerm_mysql_database.main[0]")
+	planned := plan.InitAndPlan(t, terraformOptions)
+
+	// Verify resources will only be created, on the Single Server
+	planned.OnlyCreates().
+		Count("module.mysql_database_basic.azurerm_mysql_database.main", 1).
+		Absent("module.mysql_database_basic.azurerm_mysql_flexible_database.main")
+
+	planned.Resource("module.mysql_database_basic.azurerm_mysql_database.main[0]").
+		Attr("name").Equals("test_db").
+		Attr("server_name").Equals("test-mysql-server").
+		Attr("charset").Equals("utf8mb4").
+		Attr("collation").Equals("utf8mb4_unicode_ci")
 }
 
 func TestMySQLDatabaseAdvancedUnit(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 		Vars: map[string]interface{}{
 			"database_name":            "primary_db",
@@ -81,42 +84,44 @@ func TestMySQLDatabaseAdvancedUnit(t *testing.T) {
 			"enable_audit_logging":     true,
 			"enable_slow_query_log":    true,
 		},
-		PlanOnly: true,
 	})
 
-	defer terraform.Destroy(t, terraformOptions)
-
 	// Run terraform plan
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
-
-	// Verify resources will be created
-	resourceCounts := terraform.GetResourceCount(t, planStruct)
-	assert.Greater(t, resourceCounts.Add, 3, "Should plan to create multiple resources for advanced config")
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify key advanced resources are planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "module.mysql_database_advanced.azurerm_mysql_database.main[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "module.mysql_database_advanced.azurerm_monitor_metric_alert.database_connections[0]")
+	planned.OnlyCreates().
+		Count("module.mysql_database_advanced.azurerm_mysql_database.main", 1).
+		Count("module.mysql_database_advanced.azurerm_monitor_metric_alert.database_connections", 1).
+		Count("module.mysql_database_advanced.azurerm_mysql_configuration.audit_log", 1).
+		Count("module.mysql_database_advanced.azurerm_mysql_configuration.slow_query_log", 1)
+
+	planned.Resource("module.mysql_database_advanced.azurerm_mysql_database.main[0]").
+		Attr("name").Equals("primary_db").
+		Resource("module.mysql_database_advanced.azurerm_monitor_metric_alert.database_connections[0]").
+		Attr("name").Equals("primary_db-db-connections-alert").
+		Attr("tags.ManagedBy").Equals("Terraform").
+		Attr("tags.Module").Equals("zrr-tf-module-lib/azure/infrastructure/mysql-database")
 }
 
 func TestMySQLDatabaseValidation(t *testing.T) {
 	t.Parallel()
 
 	// Test invalid database name
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                     "invalid-name!",
 			"resource_group_name":      "test-rg",
 			"mysql_server_name":        "test-mysql-server",
 		},
-		PlanOnly: true,
 	})
 
 	_, err := terraform.InitAndPlanE(t, terraformOptions)
 	assert.Error(t, err, "Should fail with invalid database name")
 
 	// Test invalid character set
-	terraformOptions2 := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions2 := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                "test_db",
@@ -124,14 +129,13 @@ func TestMySQLDatabaseValidation(t *testing.T) {
 			"mysql_server_name":   "test-mysql-server",
 			"charset":             "invalid_charset",
 		},
-		PlanOnly: true,
 	})
 
 	_, err2 := terraform.InitAndPlanE(t, terraformOptions2)
 	assert.Error(t, err2, "Should fail with invalid character set")
 
 	// Test invalid alert threshold
-	terraformOptions3 := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions3 := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                        "test_db",
@@ -139,7 +143,6 @@ func TestMySQLDatabaseValidation(t *testing.T) {
 			"mysql_server_name":           "test-mysql-server",
 			"connection_alert_threshold":  2000,
 		},
-		PlanOnly: true,
 	})
 
 	_, err3 := terraform.InitAndPlanE(t, terraformOptions3)
@@ -165,7 +168,7 @@ func TestMySQLDatabaseCharacterSets(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.charset+"_"+tc.collation, func(t *testing.T) {
-			terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+			terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 				TerraformDir: "../../",
 				Vars: map[string]interface{}{
 					"name":                "test_db",
@@ -174,8 +177,7 @@ func TestMySQLDatabaseCharacterSets(t *testing.T) {
 					"charset":             tc.charset,
 					"collation":          tc.collation,
 				},
-				PlanOnly: true,
-			})
+					})
 
 			_, err := terraform.InitAndPlanE(t, terraformOptions)
 			if tc.valid {
@@ -190,7 +192,7 @@ func TestMySQLDatabaseCharacterSets(t *testing.T) {
 func TestMySQLDatabaseAdditionalDatabases(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                "primary_db",
@@ -210,20 +212,28 @@ func TestMySQLDatabaseAdditionalDatabases(t *testing.T) {
 				},
 			},
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
+
+	// Verify additional databases will be created on the Flexible Server only
+	planned.Count("azurerm_mysql_flexible_database.additional", 2).
+		Absent("azurerm_mysql_database.additional")
 
-	// Verify additional databases will be created
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_flexible_database.additional[\"analytics_db\"]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_flexible_database.additional[\"logging_db\"]")
+	planned.Resource(`azurerm_mysql_flexible_database.additional["analytics_db"]`).
+		Attr("name").Equals("analytics_db").
+		Attr("charset").Equals("utf8mb4").
+		Attr("collation").Equals("utf8mb4_unicode_ci").
+		Resource(`azurerm_mysql_flexible_database.additional["logging_db"]`).
+		Attr("name").Equals("logging_db").
+		Attr("charset").Equals("utf8").
+		Attr("collation").Equals("utf8_general_ci")
 }
 
 func TestMySQLDatabaseUsers(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                "test_db",
@@ -233,7 +243,7 @@ func TestMySQLDatabaseUsers(t *testing.T) {
 			"database_users": []map[string]interface{}{
 				{
 					"username": "app_user",
-					"password": "SecurePassword123!",
+					"password": zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "app_user"),
 					"privileges": []map[string]interface{}{
 						{
 							"type":     "SELECT",
@@ -247,19 +257,18 @@ func TestMySQLDatabaseUsers(t *testing.T) {
 				},
 			},
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify database user will be created
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_user.users[\"app_user\"]")
+	planned.Exists(`azurerm_mysql_user.users["app_user"]`)
 }
 
 func TestMySQLDatabaseMonitoring(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                         "test_db",
@@ -270,20 +279,27 @@ func TestMySQLDatabaseMonitoring(t *testing.T) {
 			"connection_alert_threshold":   100,
 			"storage_alert_threshold":      80,
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify monitoring resources will be created
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.database_connections[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.database_storage[0]")
+	planned.Count("azurerm_monitor_metric_alert.database_connections", 1).
+		Count("azurerm_monitor_metric_alert.database_storage", 1)
+
+	planned.Resource("azurerm_monitor_metric_alert.database_connections[0]").
+		Attr("name").Equals("test_db-db-connections-alert").
+		Attr("criteria.0.threshold").Equals(100).
+		Attr("action.0.action_group_id").Equals("/subscriptions/test/resourceGroups/test/providers/microsoft.insights/actionGroups/test").
+		Resource("azurerm_monitor_metric_alert.database_storage[0]").
+		Attr("name").Equals("test_db-db-storage-alert").
+		Attr("criteria.0.threshold").Equals(80)
 }
 
 func TestMySQLDatabasePerformanceConfig(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                "test_db",
@@ -297,21 +313,25 @@ func TestMySQLDatabasePerformanceConfig(t *testing.T) {
 				"long_query_time":         "2",
 			},
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify performance configurations will be applied
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_configuration.performance_configs[\"innodb_buffer_pool_size\"]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_configuration.performance_configs[\"max_connections\"]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_configuration.performance_configs[\"slow_query_log\"]")
+	planned.Count("azurerm_mysql_configuration.performance_configs", 4)
+
+	planned.Resource(`azurerm_mysql_configuration.performance_configs["innodb_buffer_pool_size"]`).
+		Attr("value").Equals("75").
+		Resource(`azurerm_mysql_configuration.performance_configs["max_connections"]`).
+		Attr("value").Equals("200").
+		Resource(`azurerm_mysql_configuration.performance_configs["slow_query_log"]`).
+		Attr("value").Equals("ON")
 }
 
 func TestMySQLDatabaseAuditLogging(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                  "test_db",
@@ -323,14 +343,18 @@ func TestMySQLDatabaseAuditLogging(t *testing.T) {
 			"enable_slow_query_log": true,
 			"slow_query_threshold":  2,
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify audit logging configurations will be applied
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_configuration.audit_log[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_configuration.audit_log_events[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_configuration.slow_query_log[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_configuration.long_query_time[0]")
+	planned.Resource("azurerm_mysql_configuration.audit_log[0]").
+		Attr("name").Equals("audit_log_enabled").
+		Attr("value").Equals("ON").
+		Resource("azurerm_mysql_configuration.audit_log_events[0]").
+		Attr("value").Equals("CONNECTION,DML,DDL").
+		Resource("azurerm_mysql_configuration.slow_query_log[0]").
+		Attr("value").Equals("ON").
+		Resource("azurerm_mysql_configuration.long_query_time[0]").
+		Attr("value").Equals("2")
 }
\ No newline at end of file
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestMySQLDatabaseOfflinePlan plans the module against a mocked azurerm
+// provider, so looking up the server by ID works without an Azure login.
+func TestMySQLDatabaseOfflinePlan(t *testing.T) {
+	t.Parallel()
+
+	fixture := offline.LoadFixture(t, "flexible_server")
+	server := fixture.Data["data.azurerm_mysql_flexible_server.mysql_flexible_server"]
+	terraformOptions := zrrtest.ModuleOptions(t, map[string]interface{}{
+		"name":                     "offline_db",
+		"resource_group_name":      "rg-offline-test",
+		"mysql_flexible_server_id": server["id"],
+		"use_naming_convention":    false,
+	})
+
+	planned := offline.InitAndPlan(t, terraformOptions, fixture)
+
+	// Validate the database lands on the server resolved from the fixture
+	planned.OnlyCreates().
+		Count("azurerm_mysql_flexible_database.main", 1).
+		Absent("azurerm_mysql_database.main").
+		Resource("azurerm_mysql_flexible_database.main[0]").
+		Attr("name").Equals("offline_db").
+		Attr("server_name").Equals(server["name"])
+}
@@ -0,0 +1,41 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	fixture := offline.LoadFixture(t, "flexible_server")
+	server := fixture.Data["data.azurerm_mysql_flexible_server.mysql_flexible_server"]
+	terraformOptions := zrrtest.ModuleOptions(t, map[string]interface{}{
+		"name":                     "offline_db",
+		"resource_group_name":      "rg-offline-test",
+		"mysql_flexible_server_id": server["id"],
+		"use_naming_convention":    false,
+		// Let the database and user attributes be changed one at a time.
+		// The plan is mocked, so the password is never used.
+		"additional_databases": []map[string]interface{}{
+			{"name": "analytics_db"},
+		},
+		"database_users": []map[string]interface{}{
+			{
+				"username": "app_user",
+				"password": "not-a-real-password",
+				"privileges": []map[string]interface{}{
+					{"type": "SELECT", "database": "offline_db"},
+				},
+			},
+		},
+	})
+
+	fuzz.Validations(t, terraformOptions, fixture)
+}
@@ -3,6 +3,8 @@ package test
 import (
 	"testing"
 
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 )
@@ -10,41 +12,40 @@ import (
 func TestMySQLFlexibleServerUnit(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 		Vars: map[string]interface{}{
 			"resource_group_name":    "test-rg",
 			"mysql_server_name":      "test-mysql-server",
-			"administrator_password": "TestPassword123!",
+			"administrator_password": zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
 			"location":               "East US",
 		},
-		PlanOnly: true,
 	})
 
-	defer terraform.Destroy(t, terraformOptions)
-
 	// Run terraform plan
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
-
-	// Verify resources will be created
-	resourceCounts := terraform.GetResourceCount(t, planStruct)
-	assert.Greater(t, resourceCounts.Add, 0, "Should plan to create resources")
-	assert.Equal(t, resourceCounts.Change, 0, "Should not plan to change existing resources")
-	assert.Equal(t, resourceCounts.Destroy, 0, "Should not plan to destroy existing resources")
-
-	// Verify specific resources are planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "module.mysql_server_basic.azurerm_mysql_flexible_server.main")
+	planned := plan.InitAndPlan(t, terraformOptions)
+
+	// Verify resources will only be created
+	planned.OnlyCreates().
+		Count("module.mysql_server_example.azurerm_mysql_flexible_server.main", 1)
+
+	planned.Resource("module.mysql_server_example.azurerm_mysql_flexible_server.main").
+		Attr("name").Equals("test-mysql-server").
+		Attr("tags.ManagedBy").Equals("Terraform").
+		Attr("tags.Module").Equals("zrr-tf-module-lib/azure/infrastructure/mysql-flexible-server").
+		Attr("tags.Layer").Equals("infrastructure").
+		Attr("fqdn").Unknown()
 }
 
 func TestMySQLFlexibleServerAdvancedUnit(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 		Vars: map[string]interface{}{
 			"resource_group_name":           "test-rg",
 			"mysql_server_name":             "test-mysql-advanced",
-			"administrator_password":        "TestPassword123!",
+			"administrator_password":        zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
 			"location":                      "East US",
 			"high_availability_mode":        "ZoneRedundant",
 			"standby_availability_zone":     "2",
@@ -55,69 +56,66 @@ func TestMySQLFlexibleServerAdvancedUnit(t *testing.T) {
 			"enable_monitoring":             true,
 			"enable_diagnostic_settings":    true,
 		},
-		PlanOnly: true,
 	})
 
-	defer terraform.Destroy(t, terraformOptions)
-
 	// Run terraform plan
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
-
-	// Verify resources will be created
-	resourceCounts := terraform.GetResourceCount(t, planStruct)
-	assert.Greater(t, resourceCounts.Add, 5, "Should plan to create multiple resources for advanced config")
-	assert.Equal(t, resourceCounts.Change, 0, "Should not plan to change existing resources")
-	assert.Equal(t, resourceCounts.Destroy, 0, "Should not plan to destroy existing resources")
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify key advanced resources are planned
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "module.mysql_server_advanced.azurerm_mysql_flexible_server.main")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "module.mysql_server_advanced.azurerm_monitor_action_group.main[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "module.mysql_server_advanced.azurerm_monitor_metric_alert.cpu[0]")
+	planned.OnlyCreates().
+		Count("module.mysql_server_advanced.azurerm_mysql_flexible_server.main", 1).
+		Count("module.mysql_server_advanced.azurerm_monitor_metric_alert.cpu_alert", 1).
+		Count("module.mysql_server_advanced.azurerm_monitor_diagnostic_setting.mysql", 1)
+
+	planned.Resource("module.mysql_server_advanced.azurerm_mysql_flexible_server.main").
+		Attr("name").Equals("test-mysql-advanced").
+		Attr("high_availability.0.mode").Equals("ZoneRedundant").
+		Attr("high_availability.0.standby_availability_zone").Equals("2").
+		Attr("zone").Equals("1").
+		Attr("backup_retention_days").Equals(35).
+		Attr("geo_redundant_backup_enabled").Equals(true)
 }
 
 func TestMySQLFlexibleServerValidation(t *testing.T) {
 	t.Parallel()
 
 	// Test invalid SKU name
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                   "test-mysql",
 			"resource_group_name":    "test-rg",
-			"administrator_password": "TestPassword123!",
+			"administrator_password": zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
 			"sku_name":               "InvalidSKU",
 		},
-		PlanOnly: true,
 	})
 
 	_, err := terraform.InitAndPlanE(t, terraformOptions)
 	assert.Error(t, err, "Should fail with invalid SKU name")
 
 	// Test invalid MySQL version
-	terraformOptions2 := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions2 := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                   "test-mysql",
 			"resource_group_name":    "test-rg",
-			"administrator_password": "TestPassword123!",
+			"administrator_password": zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
 			"mysql_version":          "7.0",
 		},
-		PlanOnly: true,
 	})
 
 	_, err2 := terraform.InitAndPlanE(t, terraformOptions2)
 	assert.Error(t, err2, "Should fail with invalid MySQL version")
 
 	// Test invalid backup retention
-	terraformOptions3 := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions3 := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                   "test-mysql",
 			"resource_group_name":    "test-rg",
-			"administrator_password": "TestPassword123!",
+			"administrator_password": zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
 			"backup_retention_days":  50,
 		},
-		PlanOnly: true,
 	})
 
 	_, err3 := terraform.InitAndPlanE(t, terraformOptions3)
@@ -127,60 +125,59 @@ func TestMySQLFlexibleServerValidation(t *testing.T) {
 func TestMySQLFlexibleServerHighAvailability(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
-			"name":                       "test-mysql-ha",
-			"resource_group_name":        "test-rg",
-			"administrator_password":     "TestPassword123!",
-			"high_availability_mode":     "ZoneRedundant",
-			"availability_zone":          "1",
-			"standby_availability_zone":  "2",
+			"name":                      "test-mysql-ha",
+			"resource_group_name":       "test-rg",
+			"administrator_password":    zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
+			"high_availability_mode":    "ZoneRedundant",
+			"availability_zone":         "1",
+			"standby_availability_zone": "2",
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify high availability configuration
-	mysql := terraform.PlannedValuesForResource(t, planStruct, "azurerm_mysql_flexible_server.main")
-	assert.Equal(t, "ZoneRedundant", mysql["high_availability_mode"], "High availability mode should be ZoneRedundant")
-	assert.Equal(t, "1", mysql["availability_zone"], "Primary zone should be 1")
-	assert.Equal(t, "2", mysql["standby_availability_zone"], "Standby zone should be 2")
+	planned.Resource("azurerm_mysql_flexible_server.main").
+		Attr("high_availability").HasLen(1).
+		Attr("high_availability.0.mode").Equals("ZoneRedundant").
+		Attr("zone").Equals("1").
+		Attr("high_availability.0.standby_availability_zone").Equals("2")
 }
 
 func TestMySQLFlexibleServerBackupConfiguration(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
-			"name":                          "test-mysql-backup",
-			"resource_group_name":           "test-rg",
-			"administrator_password":        "TestPassword123!",
-			"backup_retention_days":         30,
-			"geo_redundant_backup_enabled":  true,
+			"name":                         "test-mysql-backup",
+			"resource_group_name":          "test-rg",
+			"administrator_password":       zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
+			"backup_retention_days":        30,
+			"geo_redundant_backup_enabled": true,
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify backup configuration
-	mysql := terraform.PlannedValuesForResource(t, planStruct, "azurerm_mysql_flexible_server.main")
-	assert.Equal(t, float64(30), mysql["backup_retention_days"], "Backup retention should be 30 days")
-	assert.Equal(t, true, mysql["geo_redundant_backup_enabled"], "Geo-redundant backup should be enabled")
+	planned.Resource("azurerm_mysql_flexible_server.main").
+		Attr("backup_retention_days").Equals(30).
+		Attr("geo_redundant_backup_enabled").Equals(true)
 }
 
 func TestMySQLFlexibleServerDatabases(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                   "test-mysql-db",
 			"resource_group_name":    "test-rg",
-			"administrator_password": "TestPassword123!",
+			"administrator_password": zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
 			"databases": []map[string]interface{}{
 				{
 					"name":      "app_db",
@@ -194,39 +191,54 @@ func TestMySQLFlexibleServerDatabases(t *testing.T) {
 				},
 			},
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify databases will be created
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_flexible_database.databases[\"app_db\"]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_mysql_flexible_database.databases[\"analytics_db\"]")
+	planned.Count("azurerm_mysql_flexible_database.databases", 2)
+
+	planned.Resource(`azurerm_mysql_flexible_database.databases["app_db"]`).
+		Attr("name").Equals("app_db").
+		Attr("server_name").Equals("test-mysql-db").
+		Attr("charset").Equals("utf8mb4").
+		Resource(`azurerm_mysql_flexible_database.databases["analytics_db"]`).
+		Attr("name").Equals("analytics_db").
+		Attr("collation").Equals("utf8mb4_unicode_ci")
 }
 
 func TestMySQLFlexibleServerMonitoring(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
-			"name":                        "test-mysql-monitoring",
-			"resource_group_name":         "test-rg",
-			"administrator_password":      "TestPassword123!",
-			"enable_monitoring":           true,
-			"alert_email_addresses":       []string{"admin@test.com", "dba@test.com"},
-			"cpu_alert_threshold":         85,
-			"memory_alert_threshold":      90,
-			"connection_alert_threshold":  150,
+			"name":                       "test-mysql-monitoring",
+			"resource_group_name":        "test-rg",
+			"administrator_password":     zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
+			"enable_monitoring":          true,
+			"alert_email_addresses":      []string{"admin@test.com", "dba@test.com"},
+			"cpu_alert_threshold":        85,
+			"memory_alert_threshold":     90,
+			"connection_alert_threshold": 150,
 		},
-		PlanOnly: true,
 	})
 
-	planStruct := terraform.InitAndPlan(t, terraformOptions)
+	planned := plan.InitAndPlan(t, terraformOptions)
 
 	// Verify monitoring resources will be created
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_action_group.main[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.cpu[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.memory[0]")
-	terraform.RequirePlannedValuesMapKeyExists(t, planStruct, "azurerm_monitor_metric_alert.connections[0]")
-}
\ No newline at end of file
+	planned.Count("azurerm_monitor_action_group.mysql_alerts", 1).
+		Count("azurerm_monitor_metric_alert.cpu_alert", 1).
+		Count("azurerm_monitor_metric_alert.memory_alert", 1).
+		Count("azurerm_monitor_metric_alert.connection_alert", 1)
+
+	planned.Resource("azurerm_monitor_action_group.mysql_alerts[0]").
+		Attr("name").Equals("test-mysql-monitoring-alerts").
+		Attr("email_receiver").HasLen(2).
+		Resource("azurerm_monitor_metric_alert.cpu_alert[0]").
+		Attr("criteria.0.threshold").Equals(85).
+		Resource("azurerm_monitor_metric_alert.memory_alert[0]").
+		Attr("criteria.0.threshold").Equals(90).
+		Resource("azurerm_monitor_metric_alert.connection_alert[0]").
+		Attr("criteria.0.threshold").Equals(150)
+}
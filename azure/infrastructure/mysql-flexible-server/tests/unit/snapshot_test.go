@@ -0,0 +1,30 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/snapshot"
+)
+
+// exampleVars are the variables the examples need to plan. The password is
+// sensitive, so it never reaches the golden files.
+var exampleVars = map[string]map[string]interface{}{
+	"basic": {
+		"resource_group_name":    "test-rg",
+		"administrator_password": zrrtest.MustPassword(zrrtest.MySQLPasswordPolicy, "mysqladmin"),
+	},
+	"advanced": {
+		"resource_group_name":    "test-rg",
+		"administrator_password": zrrtest.MustPassword(zrrtest.MySQLPasswordPolicy, "mysqladmin"),
+	},
+}
+
+// TestExamplesPlanSnapshot compares the plan of every example with its golden
+// file under tests/testdata. Run with ZRR_UPDATE_SNAPSHOTS=1 to accept an
+// intended change.
+func TestExamplesPlanSnapshot(t *testing.T) {
+	t.Parallel()
+
+	snapshot.Examples(t, exampleVars)
+}
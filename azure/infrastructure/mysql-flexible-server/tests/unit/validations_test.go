@@ -0,0 +1,28 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/mysqlflexibleserver"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, mysqlflexibleserver.Inputs{
+		Name:                  "mysql-offline-test",
+		ResourceGroupName:     "rg-test",
+		AdministratorLogin:    "mysqladmin",
+		AdministratorPassword: zrrtest.Password(t, zrrtest.MySQLPasswordPolicy, "mysqladmin"),
+		// Lets the maintenance_window condition be checked and its
+		// attributes be changed one at a time.
+		MaintenanceWindow: &mysqlflexibleserver.MaintenanceWindow{DayOfWeek: 0, StartHour: 2, StartMinute: 0},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
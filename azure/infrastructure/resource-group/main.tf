@@ -4,6 +4,13 @@
 # Data sources
 data "azurerm_subscription" "current" {}
 
+# Short code of the location for the naming convention
+module "location" {
+  source = "../../shared/locations"
+
+  location = var.location
+}
+
 # Local values
 locals {
   common_tags = merge(
@@ -16,8 +23,11 @@ locals {
     }
   )
 
+  # Derive location_short from location unless it is given
+  location_short = var.location_short != "" ? var.location_short : module.location.short
+
   # Construct resource group name with naming convention
-  resource_group_name = var.use_naming_convention ? "rg-${var.environment}-${var.name}-${var.location_short}" : var.name
+  resource_group_name = var.use_naming_convention ? "rg-${var.environment}-${var.name}-${local.location_short}" : var.name
 
   # Default budget start date (first day of current month, or user-specified)
   # Uses a fixed date when budget_start_date is not provided to ensure idempotency
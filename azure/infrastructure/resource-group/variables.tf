@@ -46,7 +46,7 @@ variable "environment" {
 
 # Optional variables
 variable "location_short" {
-  description = "Short abbreviation for the Azure region (e.g., eus for eastus)"
+  description = "Short abbreviation for the Azure region (e.g., eus for eastus); derived from location when empty"
   type        = string
   default     = ""
 }
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/storageaccount"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, storageaccount.Inputs{
+		Name:              "stofflinetest",
+		ResourceGroupName: "rg-test",
+		Environment:       "dev",
+		// Let the container and file share attributes be changed one at a
+		// time.
+		Containers: []storageaccount.Container{
+			{Name: "data", AccessType: tfvars.String("private")},
+		},
+		FileShares: []storageaccount.FileShare{
+			{Name: "share", Protocol: tfvars.String("SMB"), AccessTier: tfvars.String("Hot")},
+		},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
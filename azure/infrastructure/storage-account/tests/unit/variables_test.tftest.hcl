@@ -593,7 +593,7 @@ run "valid_location_short_test" {
   }
 
   assert {
-    condition     = can(regex("^[a-z]{2,4}$", var.location_short))
+    condition     = can(regex("^[a-z][a-z0-9]{1,3}$", var.location_short))
     error_message = "Location short should be valid format"
   }
 }
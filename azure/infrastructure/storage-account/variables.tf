@@ -630,8 +630,8 @@ variable "location_short" {
   default     = "eus"
 
   validation {
-    condition     = can(regex("^[a-z]{2,4}$", var.location_short))
-    error_message = "Location short must be 2-4 lowercase letters."
+    condition     = can(regex("^[a-z][a-z0-9]{1,3}$", var.location_short))
+    error_message = "Location short must be 2-4 lowercase letters or digits, starting with a letter."
   }
 }
 
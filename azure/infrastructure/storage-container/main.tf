@@ -1,10 +1,6 @@
 # azure-infrastructure-storage-container module
 # Description: Manages Azure Storage Containers with comprehensive security, access management, and enterprise features
 
-terraform {
-  required_version = ">= 1.0"
-}
-
 # Data sources
 data "azurerm_storage_account" "main" {
   count               = var.storage_account_name != null ? 1 : 0
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/storagecontainer"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, storagecontainer.Inputs{
+		Name:                            "data",
+		StorageAccountName:              tfvars.String("stofflinetest"),
+		StorageAccountResourceGroupName: tfvars.String("rg-test"),
+		// Let the conditions on the lifecycle rules, legal hold and
+		// immutability policy be checked, and their attributes be changed
+		// one at a time.
+		LifecycleRules: []storagecontainer.LifecycleRule{{
+			Name:                   "archive",
+			Enabled:                true,
+			PrefixMatch:            []string{"logs/"},
+			BlobTypes:              []string{"blockBlob"},
+			TierToCoolAfterDays:    tfvars.Number(30),
+			TierToArchiveAfterDays: tfvars.Number(90),
+			DeleteAfterDays:        tfvars.Number(365),
+		}},
+		LegalHold:          &storagecontainer.LegalHold{Tags: []string{"audit"}},
+		ImmutabilityPolicy: &storagecontainer.ImmutabilityPolicy{PeriodInDays: 30, Locked: false},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
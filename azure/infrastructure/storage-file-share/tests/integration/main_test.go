@@ -4,10 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"testing"
-	"time"
 
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
 	"github.com/gruntwork-io/terratest/modules/azure"
-	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,9 +17,20 @@ func TestStorageFileShareCreation(t *testing.T) {
 	t.Parallel()
 
 	// Generate random names to avoid conflicts
-	uniqueID := random.UniqueId()
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
 	expectedName := fmt.Sprintf("test-share-%s", strings.ToLower(uniqueID))
 	location := "East US"
+	resourceGroupName := fmt.Sprintf("test-share-rg-%s", uniqueID)
+	storageAccountName := fmt.Sprintf("testshare%s", uniqueID)
+
+	// Create the resource group and storage account the share goes in
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueID, resourceGroupName, location, storageAccountName)
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
 	// Expected tags
 	expectedTags := map[string]string{
@@ -32,12 +42,12 @@ func TestStorageFileShareCreation(t *testing.T) {
 		"Layer":       "infrastructure",
 	}
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 		Vars: map[string]interface{}{
 			"file_share_name":      expectedName,
-			"storage_account_name": "teststorageaccount", // This needs to be a real storage account for integration tests
-			"resource_group_name":  "test-rg",            // This needs to be a real resource group for integration tests
+			"storage_account_name": storageAccountName,
+			"resource_group_name":  resourceGroupName,
 			"location":             location,
 			"quota_gb":             100,
 			"enable_backup":        false, // Disable backup for simpler testing
@@ -50,40 +60,42 @@ func TestStorageFileShareCreation(t *testing.T) {
 				"Purpose": "testing",
 			},
 		},
-	}
-
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Validate outputs
-	fileShareID := terraform.Output(t, terraformOptions, "file_share_id")
-	assert.NotEmpty(t, fileShareID, "File share ID should not be empty")
-
-	fileShareName := terraform.Output(t, terraformOptions, "file_share_name")
-	assert.Equal(t, expectedName, fileShareName, "File share name should match expected")
-
-	fileShareURL := terraform.Output(t, terraformOptions, "file_share_url")
-	assert.Contains(t, fileShareURL, expectedName, "File share URL should contain the name")
-	assert.Contains(t, fileShareURL, "file.core.windows.net", "File share URL should be a valid Azure Files URL")
-
-	// Validate tags output
-	actualTags := terraform.OutputMap(t, terraformOptions, "tags")
-
-	// Check required tags are present
-	for key, expectedValue := range expectedTags {
-		if key == "Owner" {
-			// Owner comes from common_tags
-			continue
-		}
-		actualValue, exists := actualTags[key]
-		assert.True(t, exists, fmt.Sprintf("Tag %s should exist", key))
-		if exists {
-			assert.Equal(t, expectedValue, actualValue, fmt.Sprintf("Tag %s should have value %s", key, expectedValue))
+	}))
+
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Validate outputs
+		fileShareID := terraform.Output(t, terraformOptions, "file_share_id")
+		assert.NotEmpty(t, fileShareID, "File share ID should not be empty")
+
+		fileShareName := terraform.Output(t, terraformOptions, "file_share_name")
+		assert.Equal(t, expectedName, fileShareName, "File share name should match expected")
+
+		fileShareURL := terraform.Output(t, terraformOptions, "file_share_url")
+		assert.Contains(t, fileShareURL, expectedName, "File share URL should contain the name")
+		assert.Contains(t, fileShareURL, "file.core.windows.net", "File share URL should be a valid Azure Files URL")
+
+		// Validate tags output
+		actualTags := terraform.OutputMap(t, terraformOptions, "tags")
+
+		// Check required tags are present
+		for key, expectedValue := range expectedTags {
+			if key == "Owner" {
+				// Owner comes from common_tags
+				continue
+			}
+			actualValue, exists := actualTags[key]
+			assert.True(t, exists, fmt.Sprintf("Tag %s should exist", key))
+			if exists {
+				assert.Equal(t, expectedValue, actualValue, fmt.Sprintf("Tag %s should have value %s", key, expectedValue))
+			}
 		}
-	}
 
-	// Check custom tag
-	assert.Equal(t, "testing", actualTags["Purpose"])
+		// Check custom tag
+		assert.Equal(t, "testing", actualTags["Purpose"])
+	})
 }
 
 // TestStorageFileShareWithBackup tests file share creation with backup enabled
@@ -91,16 +103,27 @@ func TestStorageFileShareWithBackup(t *testing.T) {
 	t.Parallel()
 
 	// Generate random names to avoid conflicts
-	uniqueID := random.UniqueId()
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
 	expectedName := fmt.Sprintf("test-backup-%s", strings.ToLower(uniqueID))
 	location := "East US"
-
-	terraformOptions := &terraform.Options{
+	resourceGroupName := fmt.Sprintf("test-share-rg-%s", uniqueID)
+	storageAccountName := fmt.Sprintf("testshare%s", uniqueID)
+
+	// Create the resource group and storage account the share goes in
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueID, resourceGroupName, location, storageAccountName)
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
+
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 		Vars: map[string]interface{}{
 			"file_share_name":      expectedName,
-			"storage_account_name": "teststorageaccount", // This needs to be a real storage account
-			"resource_group_name":  "test-rg",            // This needs to be a real resource group
+			"storage_account_name": storageAccountName,
+			"resource_group_name":  resourceGroupName,
 			"location":             location,
 			"quota_gb":             50,
 			"enable_backup":        true,
@@ -110,27 +133,25 @@ func TestStorageFileShareWithBackup(t *testing.T) {
 				"Owner":       "automation",
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate file share outputs
-	fileShareID := terraform.Output(t, terraformOptions, "file_share_id")
-	assert.NotEmpty(t, fileShareID, "File share ID should not be empty")
+	stages.Validate(func() {
+		// Validate file share outputs
+		fileShareID := terraform.Output(t, terraformOptions, "file_share_id")
+		assert.NotEmpty(t, fileShareID, "File share ID should not be empty")
 
-	// Validate backup outputs
-	backupVaultID := terraform.Output(t, terraformOptions, "backup_vault_id")
-	assert.NotEmpty(t, backupVaultID, "Backup vault ID should not be empty when backup is enabled")
+		// Validate backup outputs
+		backupVaultID := terraform.Output(t, terraformOptions, "backup_vault_id")
+		assert.NotEmpty(t, backupVaultID, "Backup vault ID should not be empty when backup is enabled")
 
-	// Extract subscription ID and resource group from file share ID for Azure API calls
-	subscriptionID := azure.GetSubscriptionIDFromResourceID(t, fileShareID)
-	resourceGroupName := azure.GetResourceGroupNameFromResourceID(t, fileShareID)
-
-	// Verify the backup vault exists in Azure
-	backupVault := azure.GetRecoveryServicesVault(t, subscriptionID, resourceGroupName, terraform.Output(t, terraformOptions, "backup_vault_name"))
-	assert.NotNil(t, backupVault, "Backup vault should exist in Azure")
-	assert.Equal(t, location, *backupVault.Location, "Backup vault should be in the correct location")
+		// Verify the backup vault exists in Azure
+		backupVault, err := azure.GetRecoveryServicesVaultE(terraform.Output(t, terraformOptions, "backup_vault_name"), resourceGroupName, "")
+		require.NoError(t, err, "Backup vault should exist in Azure")
+		assert.Equal(t, location, *backupVault.Location, "Backup vault should be in the correct location")
+	})
 }
 
 // TestStorageFileShareWithDirectories tests file share with custom directories
@@ -138,16 +159,28 @@ func TestStorageFileShareWithDirectories(t *testing.T) {
 	t.Parallel()
 
 	// Generate random names to avoid conflicts
-	uniqueID := random.UniqueId()
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
 	expectedName := fmt.Sprintf("test-dirs-%s", strings.ToLower(uniqueID))
+	resourceGroupName := fmt.Sprintf("test-share-rg-%s", uniqueID)
+	storageAccountName := fmt.Sprintf("testshare%s", uniqueID)
+	location := "East US"
+
+	// Create the resource group and storage account the share goes in
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueID, resourceGroupName, location, storageAccountName)
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                 expectedName,
-			"storage_account_name": "teststorageaccount", // This needs to be a real storage account
-			"resource_group_name":  "test-rg",            // This needs to be a real resource group
-			"location":             "East US",
+			"storage_account_name": storageAccountName,
+			"resource_group_name":  resourceGroupName,
+			"location":             location,
 			"quota_gb":             100,
 			"enable_backup":        false,
 			"directories": []map[string]interface{}{
@@ -170,25 +203,27 @@ func TestStorageFileShareWithDirectories(t *testing.T) {
 				"Owner":       "automation",
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate directories output
-	directories := terraform.OutputMap(t, terraformOptions, "directories")
-	assert.Len(t, directories, 2, "Should create exactly 2 directories")
+	stages.Validate(func() {
+		// Validate directories output
+		directories := terraform.OutputMap(t, terraformOptions, "directories")
+		assert.Len(t, directories, 2, "Should create exactly 2 directories")
 
-	// Check that both directories exist in the output
-	assert.Contains(t, directories, "documents", "Should contain documents directory")
-	assert.Contains(t, directories, "backups", "Should contain backups directory")
+		// Check that both directories exist in the output
+		assert.Contains(t, directories, "documents", "Should contain documents directory")
+		assert.Contains(t, directories, "backups", "Should contain backups directory")
+	})
 }
 
 // TestStorageFileShareValidation tests input validation
 func TestStorageFileShareValidation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                 "invalid@name!",
@@ -196,7 +231,7 @@ func TestStorageFileShareValidation(t *testing.T) {
 			"resource_group_name":  "test-rg",
 			"location":             "East US",
 		},
-	}
+	})
 
 	// This should fail due to invalid name
 	_, err := terraform.InitAndPlanE(t, terraformOptions)
@@ -208,7 +243,7 @@ func TestStorageFileShareValidation(t *testing.T) {
 func TestStorageFileShareQuotaValidation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                 "test-quota",
@@ -217,7 +252,7 @@ func TestStorageFileShareQuotaValidation(t *testing.T) {
 			"location":             "East US",
 			"quota_gb":             0, // Invalid quota
 		},
-	}
+	})
 
 	// This should fail due to invalid quota
 	_, err := terraform.InitAndPlanE(t, terraformOptions)
@@ -229,7 +264,7 @@ func TestStorageFileShareQuotaValidation(t *testing.T) {
 func TestStorageFileShareAccessTierValidation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                 "test-tier",
@@ -238,7 +273,7 @@ func TestStorageFileShareAccessTierValidation(t *testing.T) {
 			"location":             "East US",
 			"access_tier":          "InvalidTier", // Invalid access tier
 		},
-	}
+	})
 
 	// This should fail due to invalid access tier
 	_, err := terraform.InitAndPlanE(t, terraformOptions)
@@ -250,17 +285,17 @@ func TestStorageFileShareAccessTierValidation(t *testing.T) {
 func TestStorageFileShareEmailValidation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
-			"name":                   "test-email",
-			"storage_account_name":   "teststorageaccount",
-			"resource_group_name":    "test-rg",
-			"location":               "East US",
-			"enable_monitoring":      true,
+			"name":                  "test-email",
+			"storage_account_name":  "teststorageaccount",
+			"resource_group_name":   "test-rg",
+			"location":              "East US",
+			"enable_monitoring":     true,
 			"alert_email_addresses": []string{"invalid-email", "admin@company.com"}, // One invalid email
 		},
-	}
+	})
 
 	// This should fail due to invalid email address
 	_, err := terraform.InitAndPlanE(t, terraformOptions)
@@ -272,7 +307,7 @@ func TestStorageFileShareEmailValidation(t *testing.T) {
 func TestStorageFileShareTagsValidation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 		Vars: map[string]interface{}{
 			"name":                 "test-tags",
@@ -283,16 +318,10 @@ func TestStorageFileShareTagsValidation(t *testing.T) {
 				"ManagedBy": "Terraform", // Missing required Environment and Project tags
 			},
 		},
-	}
+	})
 
 	// This should fail due to missing required tags
 	_, err := terraform.InitAndPlanE(t, terraformOptions)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "validation failed", "Should fail validation for missing required tags")
 }
-
-// Helper function to generate random string
-func generateRandomString(length int) string {
-	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
-	return random.UniqueId()[:length]
-}
\ No newline at end of file
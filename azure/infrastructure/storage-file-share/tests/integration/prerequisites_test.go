@@ -0,0 +1,27 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/gruntwork-io/terratest/modules/azure"
+	"github.com/stretchr/testify/require"
+)
+
+// createResourceGroup creates the resource group of the test with the
+// storage account its file share goes in. Deleting the group deletes both.
+func createResourceGroup(t *testing.T, uniqueID, resourceGroupName, location, storageAccountName string) {
+	zrrtest.CreateResourceGroup(t, "", resourceGroupName, location, zrrtest.TestTags(uniqueID))
+
+	client, err := azure.CreateStorageAccountClientE("")
+	require.NoError(t, err)
+	future, err := client.Create(context.Background(), resourceGroupName, storageAccountName, storage.AccountCreateParameters{
+		Sku:      &storage.Sku{Name: storage.StandardLRS},
+		Kind:     storage.StorageV2,
+		Location: &location,
+	})
+	require.NoError(t, err)
+	require.NoError(t, future.WaitForCompletionRef(context.Background(), client.Client))
+}
@@ -0,0 +1,28 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/snapshot"
+)
+
+// exampleVars are the variables the examples need to plan.
+var exampleVars = map[string]map[string]interface{}{
+	"basic": {
+		"storage_account_name": "teststorageaccount",
+		"resource_group_name":  "test-rg",
+	},
+	"advanced": {
+		"storage_account_name": "teststorageaccount",
+		"resource_group_name":  "test-rg",
+	},
+}
+
+// TestExamplesPlanSnapshot compares the plan of every example with its golden
+// file under tests/testdata. Run with ZRR_UPDATE_SNAPSHOTS=1 to accept an
+// intended change.
+func TestExamplesPlanSnapshot(t *testing.T) {
+	t.Parallel()
+
+	snapshot.Examples(t, exampleVars)
+}
@@ -0,0 +1,26 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf, including the quota bounds, and checks
+// each fails with its error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	fixture := offline.LoadFixture(t, "storage_account")
+	account := fixture.Data["data.azurerm_storage_account.main"]
+	terraformOptions := zrrtest.ModuleOptions(t, map[string]interface{}{
+		"name":                 "offline-share",
+		"storage_account_name": account["name"],
+		"resource_group_name":  account["resource_group_name"],
+	})
+
+	fuzz.Validations(t, terraformOptions, fixture)
+}
@@ -1,4 +1,4 @@
-# azure-infrastructure-vnet module
+# azure-infrastructure-virtual-network module
 # Description: Manages Azure Virtual Networks with subnets, NSGs, and advanced networking features
 
 # Data sources
@@ -15,7 +15,7 @@ locals {
     var.vnet_tags,
     {
       "ManagedBy" = "Terraform"
-      "Module"    = "zrr-tf-module-lib/azure/infrastructure/vnet"
+      "Module"    = "zrr-tf-module-lib/azure/infrastructure/virtual-network"
       "Layer"     = "infrastructure"
     }
   )
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestVirtualNetworkOfflinePlan plans the module against a mocked azurerm
+// provider, so it runs without an Azure login.
+func TestVirtualNetworkOfflinePlan(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, map[string]interface{}{
+		"name":                "offline",
+		"resource_group_name": "rg-offline-test",
+		"address_space":       []string{"10.0.0.0/16"},
+		"environment":         "test",
+		"location_short":      "eus",
+	})
+
+	planned := offline.InitAndPlan(t, terraformOptions, offline.LoadFixture(t, "resource_group"))
+
+	// Validate the network is placed in the resource group from the fixture
+	planned.OnlyCreates().
+		Resource("azurerm_virtual_network.main").
+		Attr("name").Equals("vnet-test-offline-eus").
+		Attr("location").Equals("eastus").
+		Attr("resource_group_name").Equals("rg-offline-test").
+		Attr("address_space").Equals([]string{"10.0.0.0/16"}).
+		Attr("tags.ManagedBy").Equals("Terraform")
+}
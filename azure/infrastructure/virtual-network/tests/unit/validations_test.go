@@ -0,0 +1,27 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message. The CIDR check of address_space calls cidrhost, which the
+// generator cannot evaluate, so it is logged as not exercised.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, map[string]interface{}{
+		"name":                "offline",
+		"resource_group_name": "rg-offline-test",
+		"address_space":       []string{"10.0.0.0/16"},
+		"environment":         "test",
+		"location_short":      "eus",
+	})
+
+	fuzz.Validations(t, terraformOptions, offline.LoadFixture(t, "resource_group"))
+}
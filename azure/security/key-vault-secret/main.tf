@@ -1,10 +1,6 @@
 # azure-security-key-vault-secret module
 # Description: Manages Azure Key Vault secrets with enterprise standards compliance
 
-terraform {
-  required_version = ">= 1.0"
-}
-
 # Data sources
 data "azurerm_key_vault" "main" {
   count               = var.key_vault_name != null ? 1 : 0
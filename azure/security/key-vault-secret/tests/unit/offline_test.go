@@ -0,0 +1,32 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestKeyVaultSecretOfflinePlan plans the module against a mocked azurerm
+// provider, so looking up the vault by name works without an Azure login.
+func TestKeyVaultSecretOfflinePlan(t *testing.T) {
+	t.Parallel()
+
+	fixture := offline.LoadFixture(t, "key_vault")
+	vault := fixture.Data["data.azurerm_key_vault.main"]
+	terraformOptions := zrrtest.ModuleOptions(t, map[string]interface{}{
+		"name":                          "offline-secret",
+		"value":                         "offline-value",
+		"key_vault_name":                vault["name"],
+		"key_vault_resource_group_name": vault["resource_group_name"],
+		"common_tags":                   zrrtest.TestTags(zrrtest.UniqueID()),
+	})
+
+	planned := offline.InitAndPlan(t, terraformOptions, fixture)
+
+	// Validate the secret is stored in the vault from the fixture
+	planned.OnlyCreates().
+		Resource("azurerm_key_vault_secret.main").
+		Attr("name").Equals("offline-secret").
+		Attr("key_vault_id").Equals(vault["id"])
+}
@@ -0,0 +1,16 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestTerraformTest runs the run blocks of variables_test.tftest.hcl against
+// mocked providers, each as a subtest.
+func TestTerraformTest(t *testing.T) {
+	t.Parallel()
+
+	offline.RunTests(t, zrrtest.ModuleOptions(t, nil), offline.LoadFixture(t, "key_vault"))
+}
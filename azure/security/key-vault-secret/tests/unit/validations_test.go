@@ -0,0 +1,27 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	fixture := offline.LoadFixture(t, "key_vault")
+	vault := fixture.Data["data.azurerm_key_vault.main"]
+	terraformOptions := zrrtest.ModuleOptions(t, map[string]interface{}{
+		"name":                          "offline-secret",
+		"value":                         "offline-value",
+		"key_vault_name":                vault["name"],
+		"key_vault_resource_group_name": vault["resource_group_name"],
+	})
+
+	fuzz.Validations(t, terraformOptions, fixture)
+}
@@ -0,0 +1,31 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/keyvault"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, keyvault.Inputs{
+		Name: "kv-offline-test",
+		// Lets the network_acls condition be checked and its attributes be
+		// changed one at a time.
+		NetworkACLs: &keyvault.NetworkACLs{
+			DefaultAction:           "Deny",
+			Bypass:                  "AzureServices",
+			IPRules:                 []string{},
+			VirtualNetworkSubnetIDs: []string{},
+		},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, offline.LoadFixture(t, "client_config"))
+}
@@ -1,10 +1,6 @@
 # azure-security-mysql-firewall-rule module
 # Description: Manages Azure MySQL Firewall Rules with comprehensive security features, IP range management, and enterprise governance capabilities
 
-terraform {
-  required_version = ">= 1.0"
-}
-
 # Data sources
 data "azurerm_mysql_server" "main" {
   count               = var.mysql_server_name != null ? 1 : 0
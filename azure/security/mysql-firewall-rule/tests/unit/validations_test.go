@@ -0,0 +1,29 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/mysqlfirewallrule"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message. The CIDR check of allow_application_subnets calls cidrhost,
+// which the generator cannot evaluate, so it is logged as not exercised.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, mysqlfirewallrule.Inputs{
+		MySQLFlexibleServerName:              tfvars.String("mysql-offline-test"),
+		MySQLFlexibleServerResourceGroupName: tfvars.String("rg-test"),
+		// Lets the rule attributes be changed one at a time.
+		FirewallRules: []mysqlfirewallrule.FirewallRule{
+			{Name: "office", StartIPAddress: "203.0.113.10", EndIPAddress: "203.0.113.20"},
+		},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, nil)
+}
@@ -1,17 +1,6 @@
 # azure-security-network-security-group module
 # Description: Creates and manages Azure Network Security Groups with configurable security rules
 
-terraform {
-  required_version = ">= 1.0"
-
-  required_providers {
-    azurerm = {
-      source  = "hashicorp/azurerm"
-      version = "~> 3.0"
-    }
-  }
-}
-
 # Data sources
 data "azurerm_resource_group" "main" {
   count = var.resource_group_name != null ? 1 : 0
@@ -5,23 +5,28 @@ import (
 	"testing"
 	"time"
 
-	"github.com/gruntwork-io/terratest/modules/azure"
-	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/networksecuritygroup"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// networkSecurityGroupsAPIVersion is the API version the suite reads network
+// security groups with.
+const networkSecurityGroupsAPIVersion = "2023-05-01"
+
 func TestNetworkSecurityGroupCreation(t *testing.T) {
 	t.Parallel()
 
 	// Generate random names to avoid conflicts
-	uniqueID := random.UniqueId()
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
 	resourceGroupName := "test-nsg-" + uniqueID
 	nsgName := "test-nsg-" + uniqueID
 	location := "East US"
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 
 		Vars: map[string]interface{}{
@@ -33,43 +38,39 @@ func TestNetworkSecurityGroupCreation(t *testing.T) {
 				"Owner":       "integration-test",
 			},
 		},
-	}
+	}))
 
 	// Clean up resources with retry
-	defer terraform.Destroy(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
 
 	// Initialize and apply Terraform configuration
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Validate outputs
-	nsgID := terraform.Output(t, terraformOptions, "id")
-	assert.NotEmpty(t, nsgID, "NSG ID should not be empty")
-
-	nsgName = terraform.Output(t, terraformOptions, "name")
-	assert.Contains(t, nsgName, "example-nsg", "NSG name should contain 'example-nsg'")
-
-	nsgLocation := terraform.Output(t, terraformOptions, "location")
-	assert.Equal(t, location, nsgLocation, "NSG location should match input")
-
-	// Validate security rules were created
-	securityRulesCount := terraform.Output(t, terraformOptions, "effective_security_rules_count")
-	assert.Equal(t, "2", securityRulesCount, "Should have created 2 security rules")
-
-	hasInboundRules := terraform.Output(t, terraformOptions, "has_inbound_rules")
-	assert.Equal(t, "true", hasInboundRules, "Should have inbound rules")
-
-	inboundRulesCount := terraform.Output(t, terraformOptions, "inbound_rules_count")
-	assert.Equal(t, "2", inboundRulesCount, "Should have 2 inbound rules")
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Validate outputs
+		outputs := networksecuritygroup.NewOutputs(t, terraformOptions)
+		assert.NotEmpty(t, outputs.ID(), "NSG ID should not be empty")
+
+		nsgName = outputs.Name()
+		assert.Contains(t, nsgName, "example-nsg", "NSG name should contain 'example-nsg'")
+		assert.Equal(t, location, outputs.Location(), "NSG location should match input")
+
+		// Validate security rules were created
+		assert.Equal(t, 2, outputs.EffectiveSecurityRulesCount(), "Should have created 2 security rules")
+		assert.True(t, outputs.HasInboundRules(), "Should have inbound rules")
+		assert.Equal(t, 2, outputs.InboundRulesCount(), "Should have 2 inbound rules")
+	})
 }
 
 func TestNetworkSecurityGroupWithResourceGroup(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
 	resourceGroupName := "test-nsg-rg-" + uniqueID
 	location := "West US"
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 
 		Vars: map[string]interface{}{
@@ -81,28 +82,33 @@ func TestNetworkSecurityGroupWithResourceGroup(t *testing.T) {
 				"Project":     "terratest-rg",
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate resource group was created
-	resourceGroupID := terraform.Output(t, terraformOptions, "resource_group_id")
-	assert.NotEmpty(t, resourceGroupID, "Resource group ID should not be empty")
+	stages.Validate(func() {
+		outputs := networksecuritygroup.NewOutputs(t, terraformOptions)
 
-	// Validate NSG was created in the new resource group
-	nsgResourceGroupName := terraform.Output(t, terraformOptions, "resource_group_name")
-	assert.Equal(t, resourceGroupName, nsgResourceGroupName, "NSG should be in the created resource group")
+		// Validate resource group was created
+		resourceGroupID := outputs.ResourceGroupID()
+		require.NotNil(t, resourceGroupID, "Resource group ID should be set")
+		assert.NotEmpty(t, *resourceGroupID, "Resource group ID should not be empty")
+
+		// Validate NSG was created in the new resource group
+		assert.Equal(t, resourceGroupName, outputs.ResourceGroupName(), "NSG should be in the created resource group")
+	})
 }
 
 func TestAdvancedNetworkSecurityGroup(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
 	resourceGroupName := "test-advanced-nsg-" + uniqueID
 	location := "Central US"
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 
 		Vars: map[string]interface{}{
@@ -117,32 +123,26 @@ func TestAdvancedNetworkSecurityGroup(t *testing.T) {
 				"Owner":       "security-team",
 			},
 		},
-	}
-
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Validate advanced configuration
-	securityRulesCount := terraform.Output(t, terraformOptions, "effective_security_rules_count")
-	assert.Equal(t, "6", securityRulesCount, "Should have created 6 security rules")
-
-	hasInboundRules := terraform.Output(t, terraformOptions, "has_inbound_rules")
-	assert.Equal(t, "true", hasInboundRules, "Should have inbound rules")
-
-	hasOutboundRules := terraform.Output(t, terraformOptions, "has_outbound_rules")
-	assert.Equal(t, "true", hasOutboundRules, "Should have outbound rules")
-
-	inboundRulesCount := terraform.Output(t, terraformOptions, "inbound_rules_count")
-	assert.Equal(t, "4", inboundRulesCount, "Should have 4 inbound rules")
-
-	outboundRulesCount := terraform.Output(t, terraformOptions, "outbound_rules_count")
-	assert.Equal(t, "2", outboundRulesCount, "Should have 2 outbound rules")
+	}))
+
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Validate advanced configuration
+		outputs := networksecuritygroup.NewOutputs(t, terraformOptions)
+		assert.Equal(t, 6, outputs.EffectiveSecurityRulesCount(), "Should have created 6 security rules")
+		assert.True(t, outputs.HasInboundRules(), "Should have inbound rules")
+		assert.True(t, outputs.HasOutboundRules(), "Should have outbound rules")
+		assert.Equal(t, 4, outputs.InboundRulesCount(), "Should have 4 inbound rules")
+		assert.Equal(t, 2, outputs.OutboundRulesCount(), "Should have 2 outbound rules")
+	})
 }
 
 func TestNetworkSecurityGroupValidation(t *testing.T) {
 	t.Parallel()
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -167,7 +167,7 @@ func TestNetworkSecurityGroupValidation(t *testing.T) {
 				"Project":     "validation",
 			},
 		},
-	}
+	})
 
 	// This should pass validation
 	terraform.InitAndPlan(t, terraformOptions)
@@ -176,18 +176,12 @@ func TestNetworkSecurityGroupValidation(t *testing.T) {
 func TestNetworkSecurityGroupAzureIntegration(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
 	resourceGroupName := "test-nsg-integration-" + uniqueID
-	nsgName := "test-nsg-integration-" + uniqueID
 	location := "East US"
-	subscriptionID := "" // Set this if running against real Azure
 
-	// Skip if no subscription ID is provided
-	if subscriptionID == "" {
-		t.Skip("Skipping Azure integration test - no subscription ID provided")
-	}
-
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/basic",
 
 		Vars: map[string]interface{}{
@@ -198,29 +192,32 @@ func TestNetworkSecurityGroupAzureIntegration(t *testing.T) {
 				"Project":     "azure-integration",
 			},
 		},
-	}
+	}))
 
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Get the actual NSG name from Terraform output
-	actualNSGName := terraform.Output(t, terraformOptions, "name")
+	stages.Validate(func() {
+		// Get the actual NSG name from Terraform output
+		actualNSGName := networksecuritygroup.NewOutputs(t, terraformOptions).Name()
 
-	// Validate using Azure Go SDK
-	nsgExists := azure.NetworkSecurityGroupExists(t, actualNSGName, resourceGroupName, subscriptionID)
-	require.True(t, nsgExists, "NSG should exist in Azure")
+		// Validate using the Azure API
+		nsgID := zrrtest.ResourceID(t, "", resourceGroupName, "Microsoft.Network/networkSecurityGroups", actualNSGName)
+		nsg := zrrtest.Resource(t, "", nsgID, networkSecurityGroupsAPIVersion)
+		assert.Equal(t, location, *nsg.Location, "NSG location should match")
 
-	// Validate NSG properties
-	nsg := azure.GetNetworkSecurityGroup(t, actualNSGName, resourceGroupName, subscriptionID)
-	assert.Equal(t, location, *nsg.Location, "NSG location should match")
-	assert.NotEmpty(t, nsg.SecurityRules, "NSG should have security rules")
-	assert.Len(t, *nsg.SecurityRules, 2, "NSG should have exactly 2 security rules")
+		// Validate NSG properties
+		properties, ok := nsg.Properties.(map[string]interface{})
+		require.True(t, ok, "NSG should have properties")
+		assert.Len(t, properties["securityRules"], 2, "NSG should have exactly 2 security rules")
+	})
 }
 
 func TestNetworkSecurityGroupPerformance(t *testing.T) {
 	t.Parallel()
 
-	uniqueID := random.UniqueId()
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
 	resourceGroupName := "test-perf-nsg-" + uniqueID
 
 	// Test with many security rules to validate performance
@@ -240,7 +237,7 @@ func TestNetworkSecurityGroupPerformance(t *testing.T) {
 		}
 	}
 
-	terraformOptions := &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -257,17 +254,19 @@ func TestNetworkSecurityGroupPerformance(t *testing.T) {
 		// Set longer timeout for performance test
 		MaxRetries:         3,
 		TimeBetweenRetries: 10 * time.Second,
-	}
+	}))
 
+	defer stages.Teardown(terraformOptions)
 	startTime := time.Now()
-	defer terraform.Destroy(t, terraformOptions)
-	terraform.InitAndApply(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 	duration := time.Since(startTime)
 
-	// Validate that deployment completed in reasonable time (adjust as needed)
-	assert.Less(t, duration.Minutes(), 10.0, "Deployment should complete within 10 minutes")
+	stages.Validate(func() {
+		// Validate that deployment completed in reasonable time (adjust as needed)
+		assert.Less(t, duration.Minutes(), 10.0, "Deployment should complete within 10 minutes")
 
-	// Validate all rules were created
-	rulesCount := terraform.Output(t, terraformOptions, "effective_security_rules_count")
-	assert.Equal(t, "20", rulesCount, "Should have created 20 security rules")
-}
\ No newline at end of file
+		// Validate all rules were created
+		rulesCount := networksecuritygroup.NewOutputs(t, terraformOptions).EffectiveSecurityRulesCount()
+		assert.Equal(t, 20, rulesCount, "Should have created 20 security rules")
+	})
+}
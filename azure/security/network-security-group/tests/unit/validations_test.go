@@ -0,0 +1,43 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/networksecuritygroup"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	fixture := offline.LoadFixture(t, "resource_group")
+	resourceGroup := fixture.Data["data.azurerm_resource_group.main"]
+	terraformOptions := zrrtest.ModuleOptions(t, networksecuritygroup.Inputs{
+		Name:              "nsg-offline-test",
+		ResourceGroupName: tfvars.String(resourceGroup["name"].(string)),
+		// Flow logs are enabled so that leaving out their storage account
+		// breaks its validation.
+		EnableFlowLogs:          tfvars.Bool(true),
+		FlowLogStorageAccountID: tfvars.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/test-rg/providers/Microsoft.Storage/storageAccounts/stflowlogs"),
+		// Lets the rule attributes be changed one at a time.
+		SecurityRules: []networksecuritygroup.SecurityRule{{
+			Name:                     "allow-https",
+			Priority:                 100,
+			Direction:                "Inbound",
+			Access:                   "Allow",
+			Protocol:                 "Tcp",
+			SourcePortRange:          tfvars.String("*"),
+			DestinationPortRange:     tfvars.String("443"),
+			SourceAddressPrefix:      tfvars.String("*"),
+			DestinationAddressPrefix: tfvars.String("*"),
+		}},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, fixture)
+}
@@ -1,3 +1,10 @@
 terraform {
   required_version = ">= 1.0"
-}
\ No newline at end of file
+
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0"
+    }
+  }
+}
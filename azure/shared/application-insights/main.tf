@@ -1,4 +1,4 @@
-# Azure Shared Application Insights module
+# azure-shared-application-insights module
 # Description: Manages Azure Application Insights with comprehensive monitoring, alerting, and analytics features
 
 # Data sources
@@ -4,8 +4,7 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/gruntwork-io/terratest/modules/azure"
-	"github.com/gruntwork-io/terratest/modules/random"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -14,8 +13,9 @@ import (
 func TestApplicationInsightsAdvanced(t *testing.T) {
 	t.Parallel()
 
-	// Generate unique resource names
-	uniqueId := random.UniqueId()
+	// Generate unique resource names, kept between runs that skip setup
+	stages := zrrtest.NewStages(t)
+	uniqueId := stages.UniqueID()
 	resourceGroupName := fmt.Sprintf("test-adv-rg-%s", uniqueId)
 	workspaceName := fmt.Sprintf("test-adv-workspace-%s", uniqueId)
 	appInsightsName := fmt.Sprintf("test-adv-insights-%s", uniqueId)
@@ -25,40 +25,45 @@ func TestApplicationInsightsAdvanced(t *testing.T) {
 	region := "East US"
 
 	// Create workspace ID path for the test
-	subscriptionID := azure.GetSubscriptionIDFromCLI(t)
-	workspaceId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s",
-		subscriptionID, resourceGroupName, workspaceName)
-	actionGroupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Insights/actionGroups/%s",
-		subscriptionID, resourceGroupName, actionGroupName)
+	workspaceId := workspaceID(t, resourceGroupName, workspaceName)
+	actionGroupId := actionGroupID(t, resourceGroupName, actionGroupName)
+
+	// Create prerequisite resources
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueId, resourceGroupName, region, workspaceName, actionGroupName)
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
 	// Terraform options
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 		Vars: map[string]interface{}{
-			"name":                                     appInsightsName,
-			"location":                                 region,
-			"resource_group_name":                      resourceGroupName,
-			"application_type":                         "web",
-			"workspace_id":                             workspaceId,
-			"environment":                              "prod",
-			"criticality":                              "critical",
-			"retention_in_days":                        730,
-			"daily_data_cap_gb":                        10,
-			"daily_data_cap_notifications_disabled":   false,
-			"sampling_percentage":                      100,
-			"disable_ip_masking":                       false,
-			"local_authentication_disabled":           true,
-			"internet_ingestion_enabled":               true,
-			"internet_query_enabled":                   true,
-			"force_customer_storage_for_profiler":     false,
-			"enable_standard_alerts":                   true,
-			"alert_severity":                           1,
-			"server_response_time_threshold":           3000,
-			"failure_rate_threshold":                   5,
-			"exception_rate_threshold":                 3,
-			"action_group_ids":                         []string{actionGroupId},
-			"enable_continuous_export":                 true,
-			"compliance_requirements":                  []string{"SOX", "PCI-DSS", "ISO27001"},
+			"name":                                  appInsightsName,
+			"location":                              region,
+			"resource_group_name":                   resourceGroupName,
+			"application_type":                      "web",
+			"workspace_id":                          workspaceId,
+			"environment":                           "prod",
+			"criticality":                           "critical",
+			"retention_in_days":                     730,
+			"daily_data_cap_gb":                     10,
+			"daily_data_cap_notifications_disabled": false,
+			"sampling_percentage":                   100,
+			"disable_ip_masking":                    false,
+			"local_authentication_disabled":         true,
+			"internet_ingestion_enabled":            true,
+			"internet_query_enabled":                true,
+			"force_customer_storage_for_profiler":   false,
+			"enable_standard_alerts":                true,
+			"alert_severity":                        1,
+			"server_response_time_threshold":        3000,
+			"failure_rate_threshold":                5,
+			"exception_rate_threshold":              3,
+			"action_group_ids":                      []string{actionGroupId},
+			"enable_continuous_export":              true,
+			"compliance_requirements":               []string{"SOX", "PCI-DSS", "ISO27001"},
 			"data_governance": map[string]interface{}{
 				"data_classification":   "confidential",
 				"data_retention_policy": "extended",
@@ -75,98 +80,90 @@ func TestApplicationInsightsAdvanced(t *testing.T) {
 				"Compliance":   "required",
 			},
 		},
-	})
+	}))
 
 	// Clean up resources at the end of the test
-	defer terraform.Destroy(t, terraformOptions)
-
-	// Create prerequisite resources
-	// Create resource group
-	azure.CreateResourceGroup(t, resourceGroupName, region, subscriptionID)
-	defer azure.DeleteResourceGroup(t, resourceGroupName, subscriptionID)
-
-	// Create Log Analytics workspace
-	azure.CreateLogAnalyticsWorkspace(t, workspaceName, resourceGroupName, region, subscriptionID)
-
-	// Create action group for alerts
-	azure.CreateActionGroup(t, actionGroupName, resourceGroupName, subscriptionID)
+	defer stages.Teardown(terraformOptions)
 
 	// Deploy the module
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Validate outputs
-	appInsightsId := terraform.Output(t, terraformOptions, "application_insights_id")
-	appInsightsName := terraform.Output(t, terraformOptions, "application_insights_name")
-	appId := terraform.Output(t, terraformOptions, "app_id")
-
-	// Assert outputs are not empty
-	assert.NotEmpty(t, appInsightsId)
-	assert.NotEmpty(t, appInsightsName)
-	assert.NotEmpty(t, appId)
-
-	// Validate Application Insights exists in Azure with advanced configuration
-	appInsights := azure.GetAppInsights(t, appInsightsName, resourceGroupName, subscriptionID)
-	assert.NotNil(t, appInsights)
-	assert.Equal(t, "web", *appInsights.ApplicationType)
-	assert.Equal(t, int32(730), *appInsights.RetentionInDays)
-
-	// Validate advanced monitoring configuration
-	monitoringConfig := terraform.OutputMap(t, terraformOptions, "monitoring_config")
-	assert.NotEmpty(t, monitoringConfig)
-	assert.Equal(t, "true", monitoringConfig["alerts_enabled"])
-
-	// Validate enterprise governance
-	dataGovernance := terraform.OutputMap(t, terraformOptions, "data_governance")
-	assert.NotEmpty(t, dataGovernance)
-	assert.Equal(t, "confidential", dataGovernance["data_classification"])
-	assert.Equal(t, "extended", dataGovernance["data_retention_policy"])
-	assert.Equal(t, "true", dataGovernance["pii_detection_enabled"])
-
-	// Validate security configuration
-	securityConfig := terraform.OutputMap(t, terraformOptions, "security_config")
-	assert.NotEmpty(t, securityConfig)
-	assert.Equal(t, "true", securityConfig["local_auth_disabled"])
-	assert.Equal(t, "true", securityConfig["internet_ingestion_enabled"])
-
-	// Validate web tests
-	webTests := terraform.OutputMap(t, terraformOptions, "web_tests")
-	assert.NotEmpty(t, webTests)
-
-	// Validate custom alerts
-	customAlerts := terraform.OutputMap(t, terraformOptions, "custom_alerts")
-	assert.NotEmpty(t, customAlerts)
-
-	// Validate smart detection rules
-	smartDetection := terraform.OutputMap(t, terraformOptions, "smart_detection_rules")
-	assert.NotEmpty(t, smartDetection)
-
-	// Validate analytics items
-	analyticsItems := terraform.OutputMap(t, terraformOptions, "analytics_items")
-	assert.NotEmpty(t, analyticsItems)
-
-	// Validate API keys
-	apiKeys := terraform.OutputMap(t, terraformOptions, "api_keys")
-	assert.NotEmpty(t, apiKeys)
-
-	// Validate workbook templates
-	workbookTemplates := terraform.OutputMap(t, terraformOptions, "workbook_templates")
-	assert.NotEmpty(t, workbookTemplates)
-
-	// Validate continuous export configuration
-	continuousExport := terraform.OutputMap(t, terraformOptions, "continuous_export_config")
-	assert.NotEmpty(t, continuousExport)
-	assert.Equal(t, "true", continuousExport["enabled"])
-
-	// Validate enterprise summary
-	enterpriseSummary := terraform.OutputMap(t, terraformOptions, "enterprise_summary")
-	assert.NotEmpty(t, enterpriseSummary)
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Validate outputs
+		appInsightsId := terraform.Output(t, terraformOptions, "application_insights_id")
+		appInsightsNameOutput := terraform.Output(t, terraformOptions, "application_insights_name")
+		appId := terraform.Output(t, terraformOptions, "app_id")
+
+		// Assert outputs are not empty
+		assert.NotEmpty(t, appInsightsId)
+		assert.NotEmpty(t, appInsightsNameOutput)
+		assert.NotEmpty(t, appId)
+
+		// Validate Application Insights exists in Azure with advanced configuration
+		properties := zrrtest.ResourceProperties(t, "", appInsightsId, componentsAPIVersion)
+		assert.NotEmpty(t, properties)
+		assert.Equal(t, "web", properties["Application_Type"])
+		assert.EqualValues(t, 730, properties["RetentionInDays"])
+
+		// Validate advanced monitoring configuration
+		monitoringConfig := terraform.OutputMap(t, terraformOptions, "monitoring_config")
+		assert.NotEmpty(t, monitoringConfig)
+		assert.Equal(t, "true", monitoringConfig["alerts_enabled"])
+
+		// Validate enterprise governance
+		dataGovernance := terraform.OutputMap(t, terraformOptions, "data_governance")
+		assert.NotEmpty(t, dataGovernance)
+		assert.Equal(t, "confidential", dataGovernance["data_classification"])
+		assert.Equal(t, "extended", dataGovernance["data_retention_policy"])
+		assert.Equal(t, "true", dataGovernance["pii_detection_enabled"])
+
+		// Validate security configuration
+		securityConfig := terraform.OutputMap(t, terraformOptions, "security_config")
+		assert.NotEmpty(t, securityConfig)
+		assert.Equal(t, "true", securityConfig["local_auth_disabled"])
+		assert.Equal(t, "true", securityConfig["internet_ingestion_enabled"])
+
+		// Validate web tests
+		webTests := terraform.OutputMap(t, terraformOptions, "web_tests")
+		assert.NotEmpty(t, webTests)
+
+		// Validate custom alerts
+		customAlerts := terraform.OutputMap(t, terraformOptions, "custom_alerts")
+		assert.NotEmpty(t, customAlerts)
+
+		// Validate smart detection rules
+		smartDetection := terraform.OutputMap(t, terraformOptions, "smart_detection_rules")
+		assert.NotEmpty(t, smartDetection)
+
+		// Validate analytics items
+		analyticsItems := terraform.OutputMap(t, terraformOptions, "analytics_items")
+		assert.NotEmpty(t, analyticsItems)
+
+		// Validate API keys
+		apiKeys := terraform.OutputMap(t, terraformOptions, "api_keys")
+		assert.NotEmpty(t, apiKeys)
+
+		// Validate workbook templates
+		workbookTemplates := terraform.OutputMap(t, terraformOptions, "workbook_templates")
+		assert.NotEmpty(t, workbookTemplates)
+
+		// Validate continuous export configuration
+		continuousExport := terraform.OutputMap(t, terraformOptions, "continuous_export_config")
+		assert.NotEmpty(t, continuousExport)
+		assert.Equal(t, "true", continuousExport["enabled"])
+
+		// Validate enterprise summary
+		enterpriseSummary := terraform.OutputMap(t, terraformOptions, "enterprise_summary")
+		assert.NotEmpty(t, enterpriseSummary)
+	})
 }
 
 func TestApplicationInsightsWebTests(t *testing.T) {
 	t.Parallel()
 
-	// Generate unique resource names
-	uniqueId := random.UniqueId()
+	// Generate unique resource names, kept between runs that skip setup
+	stages := zrrtest.NewStages(t)
+	uniqueId := stages.UniqueID()
 	resourceGroupName := fmt.Sprintf("test-webtests-rg-%s", uniqueId)
 	workspaceName := fmt.Sprintf("test-webtests-workspace-%s", uniqueId)
 	appInsightsName := fmt.Sprintf("test-webtests-insights-%s", uniqueId)
@@ -175,21 +172,27 @@ func TestApplicationInsightsWebTests(t *testing.T) {
 	region := "West US 2"
 
 	// Create workspace ID path for the test
-	subscriptionID := azure.GetSubscriptionIDFromCLI(t)
-	workspaceId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s",
-		subscriptionID, resourceGroupName, workspaceName)
+	workspaceId := workspaceID(t, resourceGroupName, workspaceName)
+
+	// Create prerequisite resources
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueId, resourceGroupName, region, workspaceName, "")
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
 	// Terraform options with web tests
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 		Vars: map[string]interface{}{
-			"name":                 appInsightsName,
-			"location":             region,
-			"resource_group_name":  resourceGroupName,
-			"application_type":     "web",
-			"workspace_id":         workspaceId,
-			"environment":          "test",
-			"criticality":          "high",
+			"name":                   appInsightsName,
+			"location":               region,
+			"resource_group_name":    resourceGroupName,
+			"application_type":       "web",
+			"workspace_id":           workspaceId,
+			"environment":            "test",
+			"criticality":            "high",
 			"enable_standard_alerts": false, // Disable standard alerts for this test
 			"web_tests": map[string]interface{}{
 				"homepage": map[string]interface{}{
@@ -209,34 +212,31 @@ func TestApplicationInsightsWebTests(t *testing.T) {
 				"Owner":       "automation",
 			},
 		},
-	})
+	}))
 
 	// Clean up resources at the end of the test
-	defer terraform.Destroy(t, terraformOptions)
-
-	// Create prerequisite resources
-	azure.CreateResourceGroup(t, resourceGroupName, region, subscriptionID)
-	defer azure.DeleteResourceGroup(t, resourceGroupName, subscriptionID)
-
-	azure.CreateLogAnalyticsWorkspace(t, workspaceName, resourceGroupName, region, subscriptionID)
+	defer stages.Teardown(terraformOptions)
 
 	// Deploy the module
-	terraform.InitAndApply(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate web tests were created
-	webTests := terraform.OutputMap(t, terraformOptions, "web_tests")
-	assert.NotEmpty(t, webTests)
+	stages.Validate(func() {
+		// Validate web tests were created
+		webTests := terraform.OutputMap(t, terraformOptions, "web_tests")
+		assert.NotEmpty(t, webTests)
 
-	// Validate specific web test properties
-	webTestsOutput := terraform.Output(t, terraformOptions, "web_tests")
-	assert.Contains(t, webTestsOutput, "homepage")
+		// Validate specific web test properties
+		webTestsOutput := terraform.Output(t, terraformOptions, "web_tests")
+		assert.Contains(t, webTestsOutput, "homepage")
+	})
 }
 
 func TestApplicationInsightsCustomAlerts(t *testing.T) {
 	t.Parallel()
 
-	// Generate unique resource names
-	uniqueId := random.UniqueId()
+	// Generate unique resource names, kept between runs that skip setup
+	stages := zrrtest.NewStages(t)
+	uniqueId := stages.UniqueID()
 	resourceGroupName := fmt.Sprintf("test-alerts-rg-%s", uniqueId)
 	workspaceName := fmt.Sprintf("test-alerts-workspace-%s", uniqueId)
 	appInsightsName := fmt.Sprintf("test-alerts-insights-%s", uniqueId)
@@ -246,14 +246,19 @@ func TestApplicationInsightsCustomAlerts(t *testing.T) {
 	region := "Central US"
 
 	// Create workspace and action group IDs
-	subscriptionID := azure.GetSubscriptionIDFromCLI(t)
-	workspaceId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s",
-		subscriptionID, resourceGroupName, workspaceName)
-	actionGroupId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Insights/actionGroups/%s",
-		subscriptionID, resourceGroupName, actionGroupName)
+	workspaceId := workspaceID(t, resourceGroupName, workspaceName)
+	actionGroupId := actionGroupID(t, resourceGroupName, actionGroupName)
+
+	// Create prerequisite resources
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueId, resourceGroupName, region, workspaceName, actionGroupName)
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
 	// Terraform options with custom alerts
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 		Vars: map[string]interface{}{
 			"name":                   appInsightsName,
@@ -286,36 +291,32 @@ func TestApplicationInsightsCustomAlerts(t *testing.T) {
 				"Owner":       "automation",
 			},
 		},
-	})
+	}))
 
 	// Clean up resources at the end of the test
-	defer terraform.Destroy(t, terraformOptions)
-
-	// Create prerequisite resources
-	azure.CreateResourceGroup(t, resourceGroupName, region, subscriptionID)
-	defer azure.DeleteResourceGroup(t, resourceGroupName, subscriptionID)
-
-	azure.CreateLogAnalyticsWorkspace(t, workspaceName, resourceGroupName, region, subscriptionID)
-	azure.CreateActionGroup(t, actionGroupName, resourceGroupName, subscriptionID)
+	defer stages.Teardown(terraformOptions)
 
 	// Deploy the module
-	terraform.InitAndApply(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate custom alerts were created
-	customAlerts := terraform.OutputMap(t, terraformOptions, "custom_alerts")
-	assert.NotEmpty(t, customAlerts)
+	stages.Validate(func() {
+		// Validate custom alerts were created
+		customAlerts := terraform.OutputMap(t, terraformOptions, "custom_alerts")
+		assert.NotEmpty(t, customAlerts)
 
-	// Validate monitoring configuration
-	monitoringConfig := terraform.OutputMap(t, terraformOptions, "monitoring_config")
-	assert.Equal(t, "false", monitoringConfig["alerts_enabled"]) // Standard alerts disabled
-	assert.Equal(t, "1", monitoringConfig["custom_alerts_count"])
+		// Validate monitoring configuration
+		monitoringConfig := terraform.OutputMap(t, terraformOptions, "monitoring_config")
+		assert.Equal(t, "false", monitoringConfig["alerts_enabled"]) // Standard alerts disabled
+		assert.Equal(t, "1", monitoringConfig["custom_alerts_count"])
+	})
 }
 
 func TestApplicationInsightsAnalyticsItems(t *testing.T) {
 	t.Parallel()
 
-	// Generate unique resource names
-	uniqueId := random.UniqueId()
+	// Generate unique resource names, kept between runs that skip setup
+	stages := zrrtest.NewStages(t)
+	uniqueId := stages.UniqueID()
 	resourceGroupName := fmt.Sprintf("test-analytics-rg-%s", uniqueId)
 	workspaceName := fmt.Sprintf("test-analytics-workspace-%s", uniqueId)
 	appInsightsName := fmt.Sprintf("test-analytics-insights-%s", uniqueId)
@@ -324,12 +325,18 @@ func TestApplicationInsightsAnalyticsItems(t *testing.T) {
 	region := "East US 2"
 
 	// Create workspace ID path
-	subscriptionID := azure.GetSubscriptionIDFromCLI(t)
-	workspaceId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s",
-		subscriptionID, resourceGroupName, workspaceName)
+	workspaceId := workspaceID(t, resourceGroupName, workspaceName)
+
+	// Create prerequisite resources
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueId, resourceGroupName, region, workspaceName, "")
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
 	// Terraform options with analytics items
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 		Vars: map[string]interface{}{
 			"name":                   appInsightsName,
@@ -360,30 +367,27 @@ func TestApplicationInsightsAnalyticsItems(t *testing.T) {
 				"Owner":       "automation",
 			},
 		},
-	})
+	}))
 
 	// Clean up resources at the end of the test
-	defer terraform.Destroy(t, terraformOptions)
-
-	// Create prerequisite resources
-	azure.CreateResourceGroup(t, resourceGroupName, region, subscriptionID)
-	defer azure.DeleteResourceGroup(t, resourceGroupName, subscriptionID)
-
-	azure.CreateLogAnalyticsWorkspace(t, workspaceName, resourceGroupName, region, subscriptionID)
+	defer stages.Teardown(terraformOptions)
 
 	// Deploy the module
-	terraform.InitAndApply(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate analytics items were created
-	analyticsItems := terraform.OutputMap(t, terraformOptions, "analytics_items")
-	assert.NotEmpty(t, analyticsItems)
+	stages.Validate(func() {
+		// Validate analytics items were created
+		analyticsItems := terraform.OutputMap(t, terraformOptions, "analytics_items")
+		assert.NotEmpty(t, analyticsItems)
+	})
 }
 
 func TestApplicationInsightsAPIKeys(t *testing.T) {
 	t.Parallel()
 
-	// Generate unique resource names
-	uniqueId := random.UniqueId()
+	// Generate unique resource names, kept between runs that skip setup
+	stages := zrrtest.NewStages(t)
+	uniqueId := stages.UniqueID()
 	resourceGroupName := fmt.Sprintf("test-apikeys-rg-%s", uniqueId)
 	workspaceName := fmt.Sprintf("test-apikeys-workspace-%s", uniqueId)
 	appInsightsName := fmt.Sprintf("test-apikeys-insights-%s", uniqueId)
@@ -392,12 +396,18 @@ func TestApplicationInsightsAPIKeys(t *testing.T) {
 	region := "West US"
 
 	// Create workspace ID path
-	subscriptionID := azure.GetSubscriptionIDFromCLI(t)
-	workspaceId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s",
-		subscriptionID, resourceGroupName, workspaceName)
+	workspaceId := workspaceID(t, resourceGroupName, workspaceName)
+
+	// Create prerequisite resources
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueId, resourceGroupName, region, workspaceName, "")
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
 	// Terraform options with API keys
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 		Vars: map[string]interface{}{
 			"name":                   appInsightsName,
@@ -424,35 +434,32 @@ func TestApplicationInsightsAPIKeys(t *testing.T) {
 				"Owner":       "automation",
 			},
 		},
-	})
+	}))
 
 	// Clean up resources at the end of the test
-	defer terraform.Destroy(t, terraformOptions)
-
-	// Create prerequisite resources
-	azure.CreateResourceGroup(t, resourceGroupName, region, subscriptionID)
-	defer azure.DeleteResourceGroup(t, resourceGroupName, subscriptionID)
-
-	azure.CreateLogAnalyticsWorkspace(t, workspaceName, resourceGroupName, region, subscriptionID)
+	defer stages.Teardown(terraformOptions)
 
 	// Deploy the module
-	terraform.InitAndApply(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Validate API keys were created
-	apiKeys := terraform.OutputMap(t, terraformOptions, "api_keys")
-	assert.NotEmpty(t, apiKeys)
+	stages.Validate(func() {
+		// Validate API keys were created
+		apiKeys := terraform.OutputMap(t, terraformOptions, "api_keys")
+		assert.NotEmpty(t, apiKeys)
 
-	// Verify API key values exist (but are sensitive)
-	require.NotPanics(t, func() {
-		terraform.Output(t, terraformOptions, "api_key_values")
-	}, "API key values should be accessible as sensitive output")
+		// Verify API key values exist (but are sensitive)
+		require.NotPanics(t, func() {
+			terraform.Output(t, terraformOptions, "api_key_values")
+		}, "API key values should be accessible as sensitive output")
+	})
 }
 
 func TestApplicationInsightsEnterprise(t *testing.T) {
 	t.Parallel()
 
-	// Generate unique resource names
-	uniqueId := random.UniqueId()
+	// Generate unique resource names, kept between runs that skip setup
+	stages := zrrtest.NewStages(t)
+	uniqueId := stages.UniqueID()
 	resourceGroupName := fmt.Sprintf("test-enterprise-rg-%s", uniqueId)
 	workspaceName := fmt.Sprintf("test-enterprise-workspace-%s", uniqueId)
 	appInsightsName := fmt.Sprintf("test-enterprise-insights-%s", uniqueId)
@@ -461,29 +468,35 @@ func TestApplicationInsightsEnterprise(t *testing.T) {
 	region := "North Central US"
 
 	// Create workspace ID path
-	subscriptionID := azure.GetSubscriptionIDFromCLI(t)
-	workspaceId := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.OperationalInsights/workspaces/%s",
-		subscriptionID, resourceGroupName, workspaceName)
+	workspaceId := workspaceID(t, resourceGroupName, workspaceName)
+
+	// Create prerequisite resources
+	stages.Run(zrrtest.StageSetup, func() {
+		createResourceGroup(t, uniqueId, resourceGroupName, region, workspaceName, "")
+	})
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
 	// Terraform options with full enterprise configuration
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../examples/advanced",
 		Vars: map[string]interface{}{
-			"name":                                     appInsightsName,
-			"location":                                 region,
-			"resource_group_name":                      resourceGroupName,
-			"application_type":                         "web",
-			"workspace_id":                             workspaceId,
-			"environment":                              "prod",
-			"criticality":                              "critical",
-			"retention_in_days":                        730,
-			"daily_data_cap_gb":                        10,
-			"sampling_percentage":                      100,
-			"local_authentication_disabled":           true,
-			"disable_ip_masking":                       false,
-			"enable_standard_alerts":                   false, // Disable for test simplicity
-			"enable_continuous_export":                 true,
-			"compliance_requirements":                  []string{"SOX", "PCI-DSS", "ISO27001", "GDPR", "HIPAA"},
+			"name":                          appInsightsName,
+			"location":                      region,
+			"resource_group_name":           resourceGroupName,
+			"application_type":              "web",
+			"workspace_id":                  workspaceId,
+			"environment":                   "prod",
+			"criticality":                   "critical",
+			"retention_in_days":             730,
+			"daily_data_cap_gb":             10,
+			"sampling_percentage":           100,
+			"local_authentication_disabled": true,
+			"disable_ip_masking":            false,
+			"enable_standard_alerts":        false, // Disable for test simplicity
+			"enable_continuous_export":      true,
+			"compliance_requirements":       []string{"SOX", "PCI-DSS", "ISO27001", "GDPR", "HIPAA"},
 			"data_governance": map[string]interface{}{
 				"data_classification":   "confidential",
 				"data_retention_policy": "extended",
@@ -504,73 +517,73 @@ func TestApplicationInsightsEnterprise(t *testing.T) {
 				"SLA":            "99.99",
 			},
 		},
-	})
+	}))
 
 	// Clean up resources at the end of the test
-	defer terraform.Destroy(t, terraformOptions)
-
-	// Create prerequisite resources
-	azure.CreateResourceGroup(t, resourceGroupName, region, subscriptionID)
-	defer azure.DeleteResourceGroup(t, resourceGroupName, subscriptionID)
-
-	azure.CreateLogAnalyticsWorkspace(t, workspaceName, resourceGroupName, region, subscriptionID)
+	defer stages.Teardown(terraformOptions)
 
 	// Deploy the module
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Validate all enterprise outputs
-	enterpriseOutputs := []string{
-		"application_insights_id",
-		"application_insights_name",
-		"app_id",
-		"workspace_id",
-		"monitoring_config",
-		"data_governance",
-		"security_config",
-		"continuous_export_config",
-		"resource_details",
-		"enterprise_summary",
-	}
-
-	for _, output := range enterpriseOutputs {
-		value := terraform.Output(t, terraformOptions, output)
-		assert.NotEmpty(t, value, fmt.Sprintf("Enterprise output %s should not be empty", output))
-	}
-
-	// Validate enterprise-specific values
-	assert.Equal(t, appInsightsName, terraform.Output(t, terraformOptions, "application_insights_name"))
-
-	// Validate data governance compliance
-	dataGovernance := terraform.OutputMap(t, terraformOptions, "data_governance")
-	assert.Equal(t, "confidential", dataGovernance["data_classification"])
-	assert.Equal(t, "extended", dataGovernance["data_retention_policy"])
-	assert.Equal(t, "true", dataGovernance["pii_detection_enabled"])
-	assert.Equal(t, "true", dataGovernance["data_masking_enabled"])
-
-	// Validate security posture
-	securityConfig := terraform.OutputMap(t, terraformOptions, "security_config")
-	assert.Equal(t, "true", securityConfig["local_auth_disabled"])
-	assert.Equal(t, "false", securityConfig["ip_masking_disabled"]) // IP masking enabled
-
-	// Validate continuous export
-	continuousExport := terraform.OutputMap(t, terraformOptions, "continuous_export_config")
-	assert.Equal(t, "true", continuousExport["enabled"])
-
-	// Validate enterprise summary structure
-	enterpriseSummary := terraform.OutputMap(t, terraformOptions, "enterprise_summary")
-	assert.Equal(t, "prod", enterpriseSummary["environment"])
-	assert.Equal(t, "critical", enterpriseSummary["criticality"])
-
-	// Validate Application Insights resource in Azure
-	appInsights := azure.GetAppInsights(t, appInsightsName, resourceGroupName, subscriptionID)
-	assert.Equal(t, int32(730), *appInsights.RetentionInDays)
-	assert.Equal(t, float64(10), *appInsights.DailyDataCapInGB)
-
-	// Validate enterprise tags
-	tags := appInsights.Tags
-	assert.Equal(t, "prod", *tags["Environment"])
-	assert.Equal(t, "enterprise-infrastructure", *tags["Project"])
-	assert.Equal(t, "confidential", *tags["DataClass"])
-	assert.Equal(t, "required", *tags["Compliance"])
-	assert.Equal(t, "99.99", *tags["SLA"])
-}
\ No newline at end of file
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Validate all enterprise outputs
+		enterpriseOutputs := []string{
+			"application_insights_id",
+			"application_insights_name",
+			"app_id",
+			"workspace_id",
+			"monitoring_config",
+			"data_governance",
+			"security_config",
+			"continuous_export_config",
+			"resource_details",
+			"enterprise_summary",
+		}
+
+		for _, output := range enterpriseOutputs {
+			value := terraform.Output(t, terraformOptions, output)
+			assert.NotEmpty(t, value, fmt.Sprintf("Enterprise output %s should not be empty", output))
+		}
+
+		// Validate enterprise-specific values
+		assert.Equal(t, appInsightsName, terraform.Output(t, terraformOptions, "application_insights_name"))
+
+		// Validate data governance compliance
+		dataGovernance := terraform.OutputMap(t, terraformOptions, "data_governance")
+		assert.Equal(t, "confidential", dataGovernance["data_classification"])
+		assert.Equal(t, "extended", dataGovernance["data_retention_policy"])
+		assert.Equal(t, "true", dataGovernance["pii_detection_enabled"])
+		assert.Equal(t, "true", dataGovernance["data_masking_enabled"])
+
+		// Validate security posture
+		securityConfig := terraform.OutputMap(t, terraformOptions, "security_config")
+		assert.Equal(t, "true", securityConfig["local_auth_disabled"])
+		assert.Equal(t, "false", securityConfig["ip_masking_disabled"]) // IP masking enabled
+
+		// Validate continuous export
+		continuousExport := terraform.OutputMap(t, terraformOptions, "continuous_export_config")
+		assert.Equal(t, "true", continuousExport["enabled"])
+
+		// Validate enterprise summary structure
+		enterpriseSummary := terraform.OutputMap(t, terraformOptions, "enterprise_summary")
+		assert.Equal(t, "prod", enterpriseSummary["environment"])
+		assert.Equal(t, "critical", enterpriseSummary["criticality"])
+
+		// Validate the daily cap, which ARM keeps among the billing features
+		assert.Equal(t, "10", dataGovernance["daily_cap_gb"])
+
+		// Validate Application Insights resource in Azure
+		appInsightsId := terraform.Output(t, terraformOptions, "application_insights_id")
+		appInsights := zrrtest.Resource(t, "", appInsightsId, componentsAPIVersion)
+		properties, _ := appInsights.Properties.(map[string]interface{})
+		assert.EqualValues(t, 730, properties["RetentionInDays"])
+
+		// Validate enterprise tags
+		tags := appInsights.Tags
+		assert.Equal(t, "prod", *tags["Environment"])
+		assert.Equal(t, "enterprise-infrastructure", *tags["Project"])
+		assert.Equal(t, "confidential", *tags["DataClass"])
+		assert.Equal(t, "required", *tags["Compliance"])
+		assert.Equal(t, "99.99", *tags["SLA"])
+	})
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+)
+
+// API versions of the resources the suites create and read.
+const (
+	componentsAPIVersion   = "2020-02-02"
+	workspacesAPIVersion   = "2022-10-01"
+	actionGroupsAPIVersion = "2023-01-01"
+)
+
+// workspaceID returns the ID of the Log Analytics workspace the test sends
+// its telemetry to.
+func workspaceID(t *testing.T, resourceGroupName, workspaceName string) string {
+	return zrrtest.ResourceID(t, "", resourceGroupName, "Microsoft.OperationalInsights/workspaces", workspaceName)
+}
+
+// actionGroupID returns the ID of the action group the test's alerts notify.
+func actionGroupID(t *testing.T, resourceGroupName, actionGroupName string) string {
+	return zrrtest.ResourceID(t, "", resourceGroupName, "Microsoft.Insights/actionGroups", actionGroupName)
+}
+
+// createResourceGroup creates the resource group of the test, with its Log
+// Analytics workspace and, unless actionGroupName is empty, an action group
+// without receivers. Deleting the group deletes them all.
+func createResourceGroup(t *testing.T, uniqueID, resourceGroupName, region, workspaceName, actionGroupName string) {
+	zrrtest.CreateResourceGroup(t, "", resourceGroupName, region, zrrtest.TestTags(uniqueID))
+	zrrtest.CreateResource(t, "", workspaceID(t, resourceGroupName, workspaceName), workspacesAPIVersion, region, map[string]interface{}{
+		"sku":             map[string]interface{}{"name": "PerGB2018"},
+		"retentionInDays": 30,
+	})
+	if actionGroupName != "" {
+		zrrtest.CreateResource(t, "", actionGroupID(t, resourceGroupName, actionGroupName), actionGroupsAPIVersion, "global", map[string]interface{}{
+			"groupShortName": "zrrtest",
+			"enabled":        true,
+		})
+	}
+}
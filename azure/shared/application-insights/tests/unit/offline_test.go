@@ -0,0 +1,33 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+)
+
+// TestApplicationInsightsOfflinePlan plans the module against mocked azurerm
+// and null providers, so it runs without an Azure login.
+func TestApplicationInsightsOfflinePlan(t *testing.T) {
+	t.Parallel()
+
+	fixture := offline.LoadFixture(t, "workspace")
+	terraformOptions := zrrtest.ModuleOptions(t, map[string]interface{}{
+		"name":                          "appi-offline-test",
+		"location":                      "eastus",
+		"resource_group_name":           "rg-offline-test",
+		"workspace_name":                "law-offline-test",
+		"workspace_resource_group_name": "rg-monitoring",
+		"environment":                   "test",
+	})
+
+	planned := offline.InitAndPlan(t, terraformOptions, fixture)
+
+	// Validate the component is linked to the workspace from the fixture
+	planned.OnlyCreates().
+		Resource("azurerm_application_insights.this").
+		Attr("name").Equals("appi-offline-test").
+		Attr("workspace_id").Equals(fixture.Data["data.azurerm_log_analytics_workspace.this"]["id"]).
+		Attr("tags.Environment").Equals("test")
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/applicationinsights"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+)
+
+// TestVariableValidations plans invalid values generated from every
+// validation block in variables.tf and checks each fails with its
+// error_message.
+func TestVariableValidations(t *testing.T) {
+	t.Parallel()
+
+	terraformOptions := zrrtest.ModuleOptions(t, applicationinsights.Inputs{
+		Name:                       "appi-offline-test",
+		Location:                   "eastus",
+		ResourceGroupName:          "rg-offline-test",
+		WorkspaceName:              tfvars.String("law-offline-test"),
+		WorkspaceResourceGroupName: tfvars.String("rg-monitoring"),
+		Environment:                tfvars.String("test"),
+		// One entry in each map of objects, whose attributes are changed
+		// one at a time.
+		WebTests: map[string]applicationinsights.WebTest{
+			"home": {
+				Kind:          "ping",
+				Description:   "Home page",
+				Frequency:     300,
+				Timeout:       30,
+				Enabled:       true,
+				RetryEnabled:  true,
+				GeoLocations:  []string{"us-va-ash-azr"},
+				Configuration: "<WebTest/>",
+			},
+		},
+		CustomAlerts: map[string]applicationinsights.CustomAlert{
+			"latency": {
+				Description:     "Slow requests",
+				Severity:        2,
+				Frequency:       "PT5M",
+				WindowSize:      "PT15M",
+				Enabled:         true,
+				MetricNamespace: "microsoft.insights/components",
+				MetricName:      "requests/duration",
+				Aggregation:     "Average",
+				Operator:        "GreaterThan",
+				Threshold:       1000,
+				Dimensions:      []applicationinsights.CustomAlertDimension{},
+			},
+		},
+		SmartDetectionRules: map[string]applicationinsights.SmartDetectionRule{
+			"slow": {Enabled: true, AdditionalEmailRecipients: []string{"ops@example.com"}},
+		},
+		AnalyticsItems: map[string]applicationinsights.AnalyticsItem{
+			"errors": {Type: "query", Scope: "shared", Content: "exceptions"},
+		},
+		APIKeys: map[string]applicationinsights.APIKey{
+			"reader": {ReadPermissions: []string{"api"}, WritePermissions: []string{"annotations"}},
+		},
+		WorkbookTemplates: map[string]applicationinsights.WorkbookTemplate{
+			"overview": {
+				Author:          "ops",
+				Priority:        1,
+				TemplateItems:   []interface{}{},
+				GalleryCategory: "workbook",
+				GalleryName:     "Overview",
+				GalleryOrder:    100,
+			},
+		},
+	}.Vars())
+
+	fuzz.Validations(t, terraformOptions, offline.LoadFixture(t, "workspace"))
+}
@@ -1,17 +1,6 @@
 # azure-shared-application-service-plan module
 # Description: Creates an Azure App Service Plan with comprehensive scaling, performance, and monitoring features
 
-terraform {
-  required_version = ">= 1.0"
-
-  required_providers {
-    azurerm = {
-      source  = "hashicorp/azurerm"
-      version = "~> 3.0"
-    }
-  }
-}
-
 # Data sources
 data "azurerm_client_config" "current" {}
 
@@ -0,0 +1,118 @@
+# Code generated by `go generate ./location`. DO NOT EDIT.
+# Source: location/regions.go
+
+locals {
+  # Canonical Azure region name to naming-convention short code
+  location_short_codes = {
+    australiacentral   = "acl"
+    australiacentral2  = "acl2"
+    australiaeast      = "ae"
+    australiasoutheast = "ase"
+    brazilsouth        = "brs"
+    brazilsoutheast    = "brse"
+    canadacentral      = "cac"
+    canadaeast         = "cae"
+    centralindia       = "inc"
+    centralus          = "cus"
+    eastasia           = "ea"
+    eastus             = "eus"
+    eastus2            = "eus2"
+    francecentral      = "frc"
+    francesouth        = "frs"
+    germanynorth       = "gn"
+    germanywestcentral = "gwc"
+    israelcentral      = "ilc"
+    italynorth         = "itn"
+    japaneast          = "jpe"
+    japanwest          = "jpw"
+    jioindiacentral    = "jic"
+    jioindiawest       = "jiw"
+    koreacentral       = "krc"
+    koreasouth         = "krs"
+    mexicocentral      = "mxc"
+    newzealandnorth    = "nzn"
+    northcentralus     = "ncus"
+    northeurope        = "neu"
+    norwayeast         = "nwe"
+    norwaywest         = "nww"
+    polandcentral      = "plc"
+    qatarcentral       = "qac"
+    southafricanorth   = "san"
+    southafricawest    = "saw"
+    southcentralus     = "scus"
+    southeastasia      = "sea"
+    southindia         = "ins"
+    spaincentral       = "spc"
+    swedencentral      = "sdc"
+    swedensouth        = "sds"
+    switzerlandnorth   = "szn"
+    switzerlandwest    = "szw"
+    uaecentral         = "uac"
+    uaenorth           = "uan"
+    uksouth            = "uks"
+    ukwest             = "ukw"
+    westcentralus      = "wcus"
+    westeurope         = "weu"
+    westindia          = "inw"
+    westus             = "wus"
+    westus2            = "wus2"
+    westus3            = "wus3"
+  }
+
+  # Portal display name to canonical Azure region name
+  location_canonical_names = {
+    "Australia Central"    = "australiacentral"
+    "Australia Central 2"  = "australiacentral2"
+    "Australia East"       = "australiaeast"
+    "Australia Southeast"  = "australiasoutheast"
+    "Brazil South"         = "brazilsouth"
+    "Brazil Southeast"     = "brazilsoutheast"
+    "Canada Central"       = "canadacentral"
+    "Canada East"          = "canadaeast"
+    "Central India"        = "centralindia"
+    "Central US"           = "centralus"
+    "East Asia"            = "eastasia"
+    "East US"              = "eastus"
+    "East US 2"            = "eastus2"
+    "France Central"       = "francecentral"
+    "France South"         = "francesouth"
+    "Germany North"        = "germanynorth"
+    "Germany West Central" = "germanywestcentral"
+    "Israel Central"       = "israelcentral"
+    "Italy North"          = "italynorth"
+    "Japan East"           = "japaneast"
+    "Japan West"           = "japanwest"
+    "Jio India Central"    = "jioindiacentral"
+    "Jio India West"       = "jioindiawest"
+    "Korea Central"        = "koreacentral"
+    "Korea South"          = "koreasouth"
+    "Mexico Central"       = "mexicocentral"
+    "New Zealand North"    = "newzealandnorth"
+    "North Central US"     = "northcentralus"
+    "North Europe"         = "northeurope"
+    "Norway East"          = "norwayeast"
+    "Norway West"          = "norwaywest"
+    "Poland Central"       = "polandcentral"
+    "Qatar Central"        = "qatarcentral"
+    "South Africa North"   = "southafricanorth"
+    "South Africa West"    = "southafricawest"
+    "South Central US"     = "southcentralus"
+    "South India"          = "southindia"
+    "Southeast Asia"       = "southeastasia"
+    "Spain Central"        = "spaincentral"
+    "Sweden Central"       = "swedencentral"
+    "Sweden South"         = "swedensouth"
+    "Switzerland North"    = "switzerlandnorth"
+    "Switzerland West"     = "switzerlandwest"
+    "UAE Central"          = "uaecentral"
+    "UAE North"            = "uaenorth"
+    "UK South"             = "uksouth"
+    "UK West"              = "ukwest"
+    "West Central US"      = "westcentralus"
+    "West Europe"          = "westeurope"
+    "West India"           = "westindia"
+    "West US"              = "westus"
+    "West US 2"            = "westus2"
+    "West US 3"            = "westus3"
+  }
+}
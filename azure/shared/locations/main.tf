@@ -0,0 +1,12 @@
+# azure-shared-locations module
+# Description: Resolves an Azure location to its canonical name and naming-convention short code
+
+# Local values
+locals {
+  # Accept both the canonical name (eastus) and the display name (East US),
+  # ignoring case and spaces like location.Lookup: every canonical name is
+  # its display name in lowercase without spaces
+  location_name = lower(replace(var.location, " ", ""))
+
+  location_display_names = { for display_name, name in local.location_canonical_names : name => display_name }
+}
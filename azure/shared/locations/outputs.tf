@@ -0,0 +1,20 @@
+# Primary outputs
+output "name" {
+  description = "Canonical name of the location (e.g., eastus)"
+  value       = local.location_name
+
+  precondition {
+    condition     = contains(keys(local.location_short_codes), local.location_name)
+    error_message = "Location must be a known Azure region name (e.g., eastus) or display name (e.g., East US)."
+  }
+}
+
+output "display_name" {
+  description = "Display name of the location (e.g., East US)"
+  value       = try(local.location_display_names[local.location_name], null)
+}
+
+output "short" {
+  description = "Naming-convention short code of the location (e.g., eus)"
+  value       = try(local.location_short_codes[local.location_name], null)
+}
@@ -0,0 +1,5 @@
+# Required variables
+variable "location" {
+  description = "Azure region, either its canonical name (eastus) or its display name (East US)"
+  type        = string
+}
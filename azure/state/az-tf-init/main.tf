@@ -6,6 +6,13 @@ data "azurerm_client_config" "current" {}
 
 data "azurerm_subscription" "current" {}
 
+# Short code of the location for the naming convention
+module "location" {
+  source = "../../shared/locations"
+
+  location = var.location
+}
+
 # Local values
 locals {
   common_tags = merge(
@@ -19,10 +26,13 @@ locals {
     }
   )
 
+  # Derive location_short from location unless it is given
+  location_short = var.location_short != null ? var.location_short : module.location.short
+
   # Generate standardized names
-  resource_group_name = var.use_naming_convention ? "rg-${var.project_name}-${var.environment}-tfstate-${var.location_short}" : var.resource_group_name
+  resource_group_name = var.use_naming_convention ? "rg-${var.project_name}-${var.environment}-tfstate-${local.location_short}" : var.resource_group_name
 
-  storage_account_name = var.use_naming_convention ? "sa${var.project_name}${var.environment}tfstate${var.location_short}${random_string.suffix.result}" : var.storage_account_name
+  storage_account_name = var.use_naming_convention ? "sa${var.project_name}${var.environment}tfstate${local.location_short}${random_string.suffix.result}" : var.storage_account_name
 
   key_vault_name = var.use_naming_convention ? "kv-${var.project_name}-${var.environment}-tfstate-${random_string.suffix.result}" : var.key_vault_name
 
@@ -6,8 +6,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/naming"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/modules/aztfinit"
 	"github.com/gruntwork-io/terratest/modules/azure"
-	"github.com/gruntwork-io/terratest/modules/random"
 	"github.com/gruntwork-io/terratest/modules/terraform"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,60 +20,60 @@ func TestAzTfInitBasicExample(t *testing.T) {
 	t.Parallel()
 
 	// Generate a random suffix to ensure uniqueness
-	uniqueId := random.UniqueId()
-	projectName := fmt.Sprintf("test%s", strings.ToLower(uniqueId)[0:6])
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
+	projectName := fmt.Sprintf("test%s", strings.ToLower(uniqueID)[0:6])
 
 	// Azure region for testing
 	azureRegion := "East US"
 
 	// Construct the terraform options with default retryable errors
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		// The path to where our Terraform code is located
 		TerraformDir: "../../examples/basic",
 
 		// Variables to pass to our Terraform code using -var options
 		Vars: map[string]interface{}{
-			"project_name":    projectName,
-			"environment":     "test",
-			"location":        azureRegion,
-			"location_short":  "eus",
+			"project_name":   projectName,
+			"environment":    "test",
+			"location":       azureRegion,
+			"location_short": "eus",
 		},
-
-		// Environment variables to set when running Terraform
-		EnvVars: map[string]string{
-			"ARM_SUBSCRIPTION_ID": azure.GetSubscriptionID(),
-		},
-	})
+	}))
 
 	// At the end of the test, run `terraform destroy` to clean up any resources that were created
-	defer terraform.Destroy(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
 
 	// This will run `terraform init` and `terraform apply` and fail the test if there are any errors
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Test that the storage account was created successfully
-	storageAccountName := terraform.Output(t, terraformOptions, "storage_account_name")
-	resourceGroupName := terraform.Output(t, terraformOptions, "resource_group_name")
-	containerName := terraform.Output(t, terraformOptions, "container_name")
-
-	assert.NotEmpty(t, storageAccountName)
-	assert.NotEmpty(t, resourceGroupName)
-	assert.Equal(t, "tfstate", containerName)
-
-	// Test that the storage account exists in Azure
-	assert.True(t, azure.StorageAccountExists(t, storageAccountName, resourceGroupName, ""))
-
-	// Test storage account properties
-	storageAccount := azure.GetStorageAccount(t, storageAccountName, resourceGroupName, "")
-	assert.Equal(t, "Standard_LRS", storageAccount.Sku.Name)
-	assert.True(t, *storageAccount.EnableHTTPSTrafficOnly)
-
-	// Test backend configuration
-	backendConfig := terraform.OutputMap(t, terraformOptions, "terraform_backend_config")
-	assert.Equal(t, resourceGroupName, backendConfig["resource_group_name"])
-	assert.Equal(t, storageAccountName, backendConfig["storage_account_name"])
-	assert.Equal(t, containerName, backendConfig["container_name"])
-	assert.Equal(t, "terraform.tfstate", backendConfig["key"])
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Test that the storage account was created successfully
+		outputs := aztfinit.NewOutputs(t, terraformOptions)
+		storageAccountName := outputs.StorageAccountName()
+		resourceGroupName := outputs.ResourceGroupName()
+		containerName := outputs.ContainerName()
+
+		assert.NotEmpty(t, storageAccountName)
+		assert.NotEmpty(t, resourceGroupName)
+		assert.Equal(t, "tfstate", containerName)
+
+		// Test that the storage account exists in Azure
+		assert.True(t, azure.StorageAccountExists(t, storageAccountName, resourceGroupName, ""))
+
+		// Test storage account properties
+		storageAccount, err := azure.GetStorageAccountPropertyE(storageAccountName, resourceGroupName, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Standard_LRS", string(storageAccount.Sku.Name))
+		assert.True(t, *storageAccount.EnableHTTPSTrafficOnly)
+
+		// Test backend configuration
+		backendConfig := outputs.TerraformBackendConfig()
+		assert.Equal(t, resourceGroupName, backendConfig.ResourceGroupName)
+		assert.Equal(t, storageAccountName, backendConfig.StorageAccountName)
+		assert.Equal(t, containerName, backendConfig.ContainerName)
+		assert.Equal(t, "terraform.tfstate", backendConfig.Key)
+	})
 }
 
 // TestAzTfInitAdvancedExample tests the advanced enterprise configuration
@@ -79,84 +81,81 @@ func TestAzTfInitAdvancedExample(t *testing.T) {
 	t.Parallel()
 
 	// Generate a random suffix to ensure uniqueness
-	uniqueId := random.UniqueId()
-	projectName := fmt.Sprintf("test%s", strings.ToLower(uniqueId)[0:6])
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
+	projectName := fmt.Sprintf("test%s", strings.ToLower(uniqueID)[0:6])
 
 	// Azure region for testing
 	azureRegion := "East US"
 
 	// Construct the terraform options
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		// The path to where our Terraform code is located
 		TerraformDir: "../../examples/advanced",
 
 		// Variables to pass to our Terraform code using -var options
 		Vars: map[string]interface{}{
-			"project_name":                projectName,
-			"environment":                 "test",
-			"location":                    azureRegion,
-			"location_short":              "eus",
-			"create_automation_identity":  false, // Disable to simplify test
+			"project_name":               projectName,
+			"environment":                "test",
+			"location":                   azureRegion,
+			"location_short":             "eus",
+			"create_automation_identity": false,      // Disable to simplify test
 			"allowed_ip_ranges":          []string{}, // Empty for test
-			"terraform_contributors":      []string{},
+			"terraform_contributors":     []string{},
 			"terraform_readers":          []string{},
 			"terraform_admins":           []string{},
 			"terraform_users":            []string{},
 		},
 
-		// Environment variables to set when running Terraform
-		EnvVars: map[string]string{
-			"ARM_SUBSCRIPTION_ID": azure.GetSubscriptionID(),
-		},
-
-		// Increase timeout for complex deployment
-		RetryableTerraformErrors: map[string]string{
-			".*": "This is a retryable error",
-		},
+		// Transient Azure errors are retried by zrrtest.WithDefaults
 		MaxRetries:         3,
 		TimeBetweenRetries: 10 * time.Second,
-	})
+	}))
 
 	// At the end of the test, run `terraform destroy` to clean up any resources that were created
-	defer terraform.Destroy(t, terraformOptions)
+	defer stages.Teardown(terraformOptions)
 
 	// This will run `terraform init` and `terraform apply` and fail the test if there are any errors
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Test that the infrastructure was created successfully
-	storageDetails := terraform.OutputMap(t, terraformOptions, "storage_account_details")
-	keyVaultDetails := terraform.OutputMap(t, terraformOptions, "key_vault_details")
-	monitoringDetails := terraform.OutputMap(t, terraformOptions, "monitoring_details")
-
-	assert.NotEmpty(t, storageDetails["name"])
-	assert.NotEmpty(t, keyVaultDetails["name"])
-	assert.NotEmpty(t, monitoringDetails["log_analytics_workspace_name"])
-
-	// Test that the storage account exists with correct configuration
-	storageAccountName := storageDetails["name"]
-	resourceGroupName := storageDetails["resource_group_name"]
-
-	assert.True(t, azure.StorageAccountExists(t, storageAccountName, resourceGroupName, ""))
-
-	// Test storage account properties for enterprise configuration
-	storageAccount := azure.GetStorageAccount(t, storageAccountName, resourceGroupName, "")
-	assert.Equal(t, "Standard_GZRS", storageAccount.Sku.Name) // Geo-zone redundant
-	assert.True(t, *storageAccount.EnableHTTPSTrafficOnly)
-
-	// Test security summary
-	securitySummary := terraform.OutputMap(t, terraformOptions, "security_summary")
-	storageSecurityMap := securitySummary["storage_security"].(map[string]interface{})
-	keyVaultSecurityMap := securitySummary["key_vault_security"].(map[string]interface{})
-
-	assert.Equal(t, true, storageSecurityMap["shared_access_keys_disabled"])
-	assert.Equal(t, true, storageSecurityMap["public_access_disabled"])
-	assert.Equal(t, true, storageSecurityMap["network_restrictions_enabled"])
-	assert.Equal(t, true, storageSecurityMap["blob_versioning_enabled"])
-	assert.Equal(t, float64(90), storageSecurityMap["soft_delete_retention_days"])
-
-	assert.Equal(t, "premium", keyVaultSecurityMap["sku"])
-	assert.Equal(t, true, keyVaultSecurityMap["rbac_enabled"])
-	assert.Equal(t, true, keyVaultSecurityMap["purge_protection"])
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Test that the infrastructure was created successfully
+		storageDetails := terraform.OutputMap(t, terraformOptions, "storage_account_details")
+		keyVaultDetails := terraform.OutputMap(t, terraformOptions, "key_vault_details")
+		monitoringDetails := terraform.OutputMap(t, terraformOptions, "monitoring_details")
+
+		assert.NotEmpty(t, storageDetails["name"])
+		assert.NotEmpty(t, keyVaultDetails["name"])
+		assert.NotEmpty(t, monitoringDetails["log_analytics_workspace_name"])
+
+		// Test that the storage account exists with correct configuration
+		storageAccountName := storageDetails["name"]
+		resourceGroupName := storageDetails["resource_group_name"]
+
+		assert.True(t, azure.StorageAccountExists(t, storageAccountName, resourceGroupName, ""))
+
+		// Test storage account properties for enterprise configuration
+		storageAccount, err := azure.GetStorageAccountPropertyE(storageAccountName, resourceGroupName, "")
+		require.NoError(t, err)
+		assert.Equal(t, "Standard_GZRS", string(storageAccount.Sku.Name)) // Geo-zone redundant
+		assert.True(t, *storageAccount.EnableHTTPSTrafficOnly)
+
+		// Test security summary
+		var securitySummary map[string]map[string]interface{}
+		terraform.OutputStruct(t, terraformOptions, "security_summary", &securitySummary)
+		storageSecurityMap := securitySummary["storage_security"]
+		keyVaultSecurityMap := securitySummary["key_vault_security"]
+
+		assert.Equal(t, true, storageSecurityMap["shared_access_keys_disabled"])
+		assert.Equal(t, true, storageSecurityMap["public_access_disabled"])
+		assert.Equal(t, true, storageSecurityMap["network_restrictions_enabled"])
+		assert.Equal(t, true, storageSecurityMap["blob_versioning_enabled"])
+		assert.Equal(t, float64(90), storageSecurityMap["soft_delete_retention_days"])
+
+		assert.Equal(t, "premium", keyVaultSecurityMap["sku"])
+		assert.Equal(t, true, keyVaultSecurityMap["rbac_enabled"])
+		assert.Equal(t, true, keyVaultSecurityMap["purge_protection"])
+	})
 }
 
 // TestAzTfInitBasicConfiguration tests basic module configuration without examples
@@ -164,53 +163,60 @@ func TestAzTfInitBasicConfiguration(t *testing.T) {
 	t.Parallel()
 
 	// Generate a random suffix to ensure uniqueness
-	uniqueId := random.UniqueId()
-	projectName := fmt.Sprintf("test%s", strings.ToLower(uniqueId)[0:6])
-
-	// Create a temporary resource group for testing
-	resourceGroupName := fmt.Sprintf("rg-test-%s", projectName)
-	azure.CreateResourceGroup(t, resourceGroupName, "East US")
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
+	projectName := strings.ToLower(uniqueID)[0:3]
+
+	// Name the resources as the naming convention would, with the rest of
+	// the unique ID as suffix. The storage account name leaves 8
+	// characters for project and suffix together.
+	nameParts := naming.Parts{
+		Project:       projectName,
+		Environment:   "test",
+		LocationShort: "eus",
+		Suffix:        strings.ToLower(uniqueID)[3:6],
+	}
+	resourceGroupName := naming.MustBuild(naming.StateResourceGroup, nameParts)
+	storageAccountName := naming.MustBuild(naming.StateStorageAccount, nameParts)
+	stages.Run(zrrtest.StageSetup, func() {
+		zrrtest.CreateResourceGroup(t, "", resourceGroupName, "East US", zrrtest.TestTags(uniqueID))
+	})
 
 	// At the end of the test, clean up
-	defer func() {
-		azure.DeleteResourceGroup(t, resourceGroupName, "")
-	}()
+	defer stages.Run(zrrtest.StageTeardown, func() {
+		zrrtest.DeleteResourceGroup(t, "", resourceGroupName)
+	})
 
 	// Construct the terraform options for direct module testing
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
-			"project_name":           projectName,
-			"environment":            "test",
-			"location":               "East US",
-			"location_short":         "eus",
-			"use_naming_convention":  false,
-			"resource_group_name":    resourceGroupName,
-			"storage_account_name":   fmt.Sprintf("sa%s", projectName),
-			"enable_key_vault":       false,
-			"enable_monitoring":      false,
-		},
-
-		EnvVars: map[string]string{
-			"ARM_SUBSCRIPTION_ID": azure.GetSubscriptionID(),
+			"project_name":          projectName,
+			"environment":           "test",
+			"location":              "East US",
+			"location_short":        "eus",
+			"use_naming_convention": false,
+			"resource_group_name":   resourceGroupName,
+			"storage_account_name":  storageAccountName,
+			"enable_key_vault":      false,
+			"enable_monitoring":     false,
 		},
-	})
-
-	// Apply the configuration
-	terraform.InitAndApply(t, terraformOptions)
+	}))
 
 	// Clean up
-	defer terraform.Destroy(t, terraformOptions)
-
-	// Test outputs
-	storageAccountId := terraform.Output(t, terraformOptions, "storage_account_id")
-	storageAccountName := terraform.Output(t, terraformOptions, "storage_account_name")
-	containerName := terraform.Output(t, terraformOptions, "container_name")
+	defer stages.Teardown(terraformOptions)
 
-	assert.NotEmpty(t, storageAccountId)
-	assert.Equal(t, fmt.Sprintf("sa%s", projectName), storageAccountName)
-	assert.Equal(t, "tfstate", containerName)
+	// Apply the configuration
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Test outputs
+		outputs := aztfinit.NewOutputs(t, terraformOptions)
+		assert.NotEmpty(t, outputs.StorageAccountID())
+		assert.Equal(t, storageAccountName, outputs.StorageAccountName())
+		assert.Equal(t, "tfstate", outputs.ContainerName())
+	})
 }
 
 // TestAzTfInitSecurityConfiguration tests security features
@@ -218,11 +224,12 @@ func TestAzTfInitSecurityConfiguration(t *testing.T) {
 	t.Parallel()
 
 	// Generate a random suffix to ensure uniqueness
-	uniqueId := random.UniqueId()
-	projectName := fmt.Sprintf("sec%s", strings.ToLower(uniqueId)[0:6])
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
+	projectName := fmt.Sprintf("sec%s", strings.ToLower(uniqueID)[0:6])
 
 	// Construct the terraform options for security testing
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
@@ -236,24 +243,22 @@ func TestAzTfInitSecurityConfiguration(t *testing.T) {
 			"enable_key_vault":                     false,
 			"enable_monitoring":                    false,
 		},
+	}))
 
-		EnvVars: map[string]string{
-			"ARM_SUBSCRIPTION_ID": azure.GetSubscriptionID(),
-		},
-	})
+	// Clean up
+	defer stages.Teardown(terraformOptions)
 
 	// Apply the configuration
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Clean up
-	defer terraform.Destroy(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Test that security features are properly configured
-	configSummary := terraform.OutputMap(t, terraformOptions, "configuration_summary")
+	stages.Validate(func() {
+		// Test that security features are properly configured
+		configSummary := aztfinit.NewOutputs(t, terraformOptions).ConfigurationSummary()
 
-	assert.Equal(t, false, configSummary["public_access_enabled"])
-	assert.Equal(t, true, configSummary["blob_versioning_enabled"])
-	assert.Equal(t, true, configSummary["state_locking_enabled"])
+		assert.False(t, configSummary.PublicAccessEnabled)
+		assert.True(t, configSummary.BlobVersioningEnabled)
+		assert.True(t, configSummary.StateLockingEnabled)
+	})
 }
 
 // TestAzTfInitNamingConvention tests the naming convention functionality
@@ -261,47 +266,54 @@ func TestAzTfInitNamingConvention(t *testing.T) {
 	t.Parallel()
 
 	// Generate a random suffix to ensure uniqueness
-	uniqueId := random.UniqueId()
-	projectName := fmt.Sprintf("test%s", strings.ToLower(uniqueId)[0:6])
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
+	// The generated storage account name leaves 7 characters for project,
+	// environment and location short together, so the shortest are used.
+	nameParts := naming.Parts{
+		Project:       strings.ToLower(uniqueID)[0:3],
+		Environment:   "dr",
+		LocationShort: "eu",
+	}
 
 	// Construct the terraform options
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
-			"project_name":          projectName,
-			"environment":           "dev",
+			"project_name":          nameParts.Project,
+			"environment":           nameParts.Environment,
 			"location":              "East US",
-			"location_short":        "eus",
+			"location_short":        nameParts.LocationShort,
 			"use_naming_convention": true,
 			"enable_key_vault":      false,
 			"enable_monitoring":     false,
 		},
+	}))
 
-		EnvVars: map[string]string{
-			"ARM_SUBSCRIPTION_ID": azure.GetSubscriptionID(),
-		},
-	})
+	// Clean up
+	defer stages.Teardown(terraformOptions)
 
 	// Apply the configuration
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Clean up
-	defer terraform.Destroy(t, terraformOptions)
+	stages.Deploy(terraformOptions)
 
-	// Get the actual resource names
-	resourceGroupName := terraform.Output(t, terraformOptions, "resource_group_name")
-	storageAccountName := terraform.Output(t, terraformOptions, "storage_account_name")
+	stages.Validate(func() {
+		// Get the actual resource names
+		outputs := aztfinit.NewOutputs(t, terraformOptions)
+		resourceGroupName := outputs.ResourceGroupName()
+		storageAccountName := outputs.StorageAccountName()
 
-	// Test that the naming convention was applied
-	expectedRgPrefix := fmt.Sprintf("rg-%s-dev-tfstate-eus", projectName)
-	assert.Contains(t, resourceGroupName, expectedRgPrefix)
+		// Test that the naming convention was applied. The storage account
+		// ends in the module's random suffix.
+		assert.Equal(t, naming.MustBuild(naming.StateResourceGroup, nameParts), resourceGroupName)
 
-	expectedSaPrefix := fmt.Sprintf("sa%sdevtfstateeus", projectName)
-	assert.Contains(t, storageAccountName, expectedSaPrefix)
+		require.Greater(t, len(storageAccountName), 8)
+		nameParts.Suffix = storageAccountName[len(storageAccountName)-8:]
+		assert.Equal(t, naming.MustBuild(naming.StateStorageAccount, nameParts), storageAccountName)
 
-	// Verify the resources exist with the generated names
-	assert.True(t, azure.StorageAccountExists(t, storageAccountName, resourceGroupName, ""))
+		// Verify the resources exist with the generated names
+		assert.True(t, azure.StorageAccountExists(t, storageAccountName, resourceGroupName, ""))
+	})
 }
 
 // TestAzTfInitBackendConfiguration tests the generated backend configuration
@@ -309,46 +321,48 @@ func TestAzTfInitBackendConfiguration(t *testing.T) {
 	t.Parallel()
 
 	// Generate a random suffix to ensure uniqueness
-	uniqueId := random.UniqueId()
-	projectName := fmt.Sprintf("test%s", strings.ToLower(uniqueId)[0:6])
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
+	projectName := fmt.Sprintf("test%s", strings.ToLower(uniqueID)[0:6])
 
 	// Construct the terraform options
-	terraformOptions := terraform.WithDefaultRetryableErrors(t, &terraform.Options{
+	terraformOptions := stages.Options(zrrtest.WithDefaults(t, &terraform.Options{
 		TerraformDir: "../../",
 
 		Vars: map[string]interface{}{
-			"project_name":     projectName,
-			"environment":      "test",
-			"location":         "East US",
-			"enable_key_vault": false,
+			"project_name":      projectName,
+			"environment":       "test",
+			"location":          "East US",
+			"enable_key_vault":  false,
 			"enable_monitoring": false,
 		},
+	}))
 
-		EnvVars: map[string]string{
-			"ARM_SUBSCRIPTION_ID": azure.GetSubscriptionID(),
-		},
-	})
+	// Clean up
+	defer stages.Teardown(terraformOptions)
 
 	// Apply the configuration
-	terraform.InitAndApply(t, terraformOptions)
-
-	// Clean up
-	defer terraform.Destroy(t, terraformOptions)
-
-	// Test backend configuration outputs
-	backendConfig := terraform.OutputMap(t, terraformOptions, "terraform_backend_config")
-	backendHcl := terraform.Output(t, terraformOptions, "terraform_backend_hcl")
-	backendCommand := terraform.Output(t, terraformOptions, "terraform_backend_command")
-
-	// Verify backend configuration
-	assert.NotEmpty(t, backendConfig["resource_group_name"])
-	assert.NotEmpty(t, backendConfig["storage_account_name"])
-	assert.Equal(t, "tfstate", backendConfig["container_name"])
-	assert.Equal(t, "terraform.tfstate", backendConfig["key"])
-
-	// Verify HCL and command outputs contain expected values
-	assert.Contains(t, backendHcl, "backend \"azurerm\"")
-	assert.Contains(t, backendHcl, backendConfig["storage_account_name"])
-	assert.Contains(t, backendCommand, "terraform init")
-	assert.Contains(t, backendCommand, backendConfig["storage_account_name"])
-}
\ No newline at end of file
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		// Test backend configuration outputs
+		outputs := aztfinit.NewOutputs(t, terraformOptions)
+		backendConfig := outputs.TerraformBackendConfig()
+		backendHcl := outputs.TerraformBackendHCL()
+		backendCommand := outputs.TerraformBackendCommand()
+
+		// Verify backend configuration
+		assert.NotEmpty(t, backendConfig.ResourceGroupName)
+		assert.NotEmpty(t, backendConfig.StorageAccountName)
+		assert.Equal(t, "tfstate", backendConfig.ContainerName)
+		assert.Equal(t, "terraform.tfstate", backendConfig.Key)
+		assert.False(t, backendConfig.UseKeyVault)
+		assert.Nil(t, backendConfig.KeyVaultURI)
+
+		// Verify HCL and command outputs contain expected values
+		assert.Contains(t, backendHcl, "backend \"azurerm\"")
+		assert.Contains(t, backendHcl, backendConfig.StorageAccountName)
+		assert.Contains(t, backendCommand, "terraform init")
+		assert.Contains(t, backendCommand, backendConfig.StorageAccountName)
+	})
+}
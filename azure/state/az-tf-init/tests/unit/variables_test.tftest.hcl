@@ -400,11 +400,11 @@ run "valid_location_short_test" {
   command = plan
 
   variables {
-    location_short = "wus"
+    location_short = "wus2"
   }
 
   assert {
-    condition     = can(regex("^[a-z]{2,4}$", var.location_short))
+    condition     = can(regex("^[a-z][a-z0-9]{1,3}$", var.location_short))
     error_message = "Location short should be valid format"
   }
 }
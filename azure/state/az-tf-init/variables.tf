@@ -47,13 +47,13 @@ variable "location" {
 }
 
 variable "location_short" {
-  description = "Short location code for naming convention (e.g., 'eus' for East US)"
+  description = "Short location code for naming convention (e.g., 'eus' for East US); derived from location when null"
   type        = string
-  default     = "eus"
+  default     = null
 
   validation {
-    condition     = can(regex("^[a-z]{2,4}$", var.location_short))
-    error_message = "Location short must be 2-4 lowercase letters."
+    condition     = var.location_short == null || can(regex("^[a-z][a-z0-9]{1,3}$", var.location_short))
+    error_message = "Location short must be 2-4 lowercase letters or digits, starting with a letter."
   }
 }
 
@@ -2,7 +2,7 @@
 # Description: Terraform and provider version requirements
 
 terraform {
-  required_version = ">= 1.0"
+  required_version = ">= 1.2"
 
   required_providers {
     azurerm = {
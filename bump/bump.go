@@ -0,0 +1,369 @@
+// Package bump classifies the changes to a module's interface between two
+// revisions and proposes the semantic version the module should have.
+//
+// Callers of a module depend on its variables and outputs. A change that
+// can break a caller is major: a removed variable or output, a new required
+// variable, a variable whose type or validations no longer accept what they
+// did, an output made sensitive. A change that adds to the interface is
+// minor: a new optional variable or output, a loosened type or validation, a
+// changed default. Any other change to the module's .tf files is a patch.
+//
+//	old, _ := bump.LoadRevision(root, "v1.2.0", "azure/security/key-vault")
+//	new, _ := bump.Load(filepath.Join(root, "azure/security/key-vault"))
+//	changes := bump.Compare(old, new)
+//	next, _ := bump.Next("1.2.0", bump.Highest(changes)) // 2.0.0 if any change is major
+//
+// The zrr-registry bump command compares the modules of two revisions and
+// writes the proposed versions into module-registry.json.
+package bump
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Level is the part of the semantic version a change calls for bumping.
+type Level int
+
+const (
+	// None is the level of no change at all.
+	None Level = iota
+	Patch
+	Minor
+	Major
+)
+
+// String returns the level's name, as in the output of zrr-registry bump.
+func (l Level) String() string {
+	switch l {
+	case None:
+		return "none"
+	case Patch:
+		return "patch"
+	case Minor:
+		return "minor"
+	case Major:
+		return "major"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// Change is a change to a module between two revisions.
+type Change struct {
+	Level Level
+
+	// Kind is "variable", "output" or "file", and Name the name of what
+	// changed.
+	Kind string
+	Name string
+
+	Message string
+}
+
+// String returns the change as level: kind name: message.
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s %s: %s", c.Level, c.Kind, c.Name, c.Message)
+}
+
+// Highest returns the highest level of changes, or None if there are none.
+func Highest(changes []Change) Level {
+	level := None
+	for _, change := range changes {
+		if change.Level > level {
+			level = change.Level
+		}
+	}
+	return level
+}
+
+// Compare returns the changes from the interface old to new, variables
+// first, then outputs, then files, each sorted by name.
+func Compare(old, new *Interface) []Change {
+	var changes []Change
+	add := func(level Level, kind, name, format string, args ...interface{}) {
+		changes = append(changes, Change{Level: level, Kind: kind, Name: name, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, name := range union(keys(old.Variables), keys(new.Variables)) {
+		before, inOld := old.Variables[name]
+		after, inNew := new.Variables[name]
+		switch {
+		case !inNew:
+			add(Major, "variable", name, "removed")
+		case !inOld && after.Default == cty.NilVal:
+			add(Major, "variable", name, "added without a default, so callers must set it")
+		case !inOld:
+			add(Minor, "variable", name, "added")
+		default:
+			for _, change := range compareVariable(before, after) {
+				change.Kind, change.Name = "variable", name
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	for _, name := range union(keys(old.Outputs), keys(new.Outputs)) {
+		before, inOld := old.Outputs[name]
+		after, inNew := new.Outputs[name]
+		switch {
+		case !inNew:
+			add(Major, "output", name, "removed")
+		case !inOld:
+			add(Minor, "output", name, "added")
+		case !before.Sensitive && after.Sensitive:
+			add(Major, "output", name, "made sensitive")
+		case before.Sensitive && !after.Sensitive:
+			add(Minor, "output", name, "no longer sensitive")
+		case before.Value != after.Value:
+			add(Patch, "output", name, "value changed")
+		case before.Description != after.Description:
+			add(Patch, "output", name, "description changed")
+		}
+	}
+
+	for _, name := range union(keys(old.Files), keys(new.Files)) {
+		before, inOld := old.Files[name]
+		after, inNew := new.Files[name]
+		switch {
+		case !inNew:
+			add(Patch, "file", name, "removed")
+		case !inOld:
+			add(Patch, "file", name, "added")
+		case !bytes.Equal(before, after):
+			add(Patch, "file", name, "changed")
+		}
+	}
+
+	// Changed files matter only when the interface did not change: they
+	// hold the changes above.
+	if Highest(changes) > Patch {
+		filtered := changes[:0]
+		for _, change := range changes {
+			if change.Kind != "file" {
+				filtered = append(filtered, change)
+			}
+		}
+		changes = filtered
+	}
+	return changes
+}
+
+// compareVariable returns the changes to a variable in both revisions.
+func compareVariable(before, after Variable) []Change {
+	var changes []Change
+	add := func(level Level, format string, args ...interface{}) {
+		changes = append(changes, Change{Level: level, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if level := compareType(before.Type, after.Type); level != None {
+		add(level, "type changed from %s to %s", typeString(before.Type), typeString(after.Type))
+	}
+
+	switch {
+	case before.Default != cty.NilVal && after.Default == cty.NilVal:
+		add(Major, "default removed, so callers must set it")
+	case before.Default == cty.NilVal && after.Default != cty.NilVal:
+		add(Minor, "default added")
+	case before.Default != cty.NilVal && !sameValue(before.Default, after.Default):
+		add(Minor, "default changed")
+	}
+
+	switch {
+	case before.Nullable && !after.Nullable:
+		add(Major, "no longer nullable")
+	case !before.Nullable && after.Nullable:
+		add(Minor, "made nullable")
+	}
+
+	var added, removed []string
+	for _, condition := range after.Conditions {
+		if !containsString(before.Conditions, condition) {
+			added = append(added, condition)
+		}
+	}
+	for _, condition := range before.Conditions {
+		if !containsString(after.Conditions, condition) {
+			removed = append(removed, condition)
+		}
+	}
+	// Conditions added and removed in the same order are taken as changed
+	// in place. Unless only a regex pattern changed, whether the new one
+	// accepts every value the old one did cannot be told from its text, so
+	// the change is major until someone has reviewed it.
+	for i, condition := range added {
+		if i >= len(removed) {
+			add(Major, "validation added: %s", condition)
+			continue
+		}
+		switch compareConditions(removed[i], condition) {
+		case loosened:
+			add(Minor, "validation loosened: %s", condition)
+		case tightened:
+			add(Major, "validation tightened: %s", condition)
+		default:
+			add(Major, "validation changed (review): %s", condition)
+		}
+	}
+	for i := len(added); i < len(removed); i++ {
+		add(Minor, "validation removed: %s", removed[i])
+	}
+	if len(changes) == 0 && strings.Join(before.ErrorMessages, "\n") != strings.Join(after.ErrorMessages, "\n") {
+		add(Patch, "validation error message changed")
+	}
+
+	if len(changes) == 0 && (before.Description != after.Description || before.Sensitive != after.Sensitive) {
+		add(Patch, "description or sensitivity changed")
+	}
+	return changes
+}
+
+// compareType returns the level of the change of a variable's type from
+// before to after: none if it is the same, minor if after accepts every
+// value before did, major otherwise.
+func compareType(before, after cty.Type) Level {
+	switch {
+	case before.Equals(after):
+		return None
+	case after == cty.DynamicPseudoType:
+		return Minor
+	case before.IsListType() && after.IsListType(),
+		before.IsSetType() && after.IsSetType(),
+		before.IsMapType() && after.IsMapType():
+		return compareType(before.ElementType(), after.ElementType())
+	case before.IsObjectType() && after.IsObjectType():
+		for name, attribute := range after.AttributeTypes() {
+			if !before.HasAttribute(name) {
+				if !after.AttributeOptional(name) {
+					return Major
+				}
+				continue
+			}
+			if before.AttributeOptional(name) && !after.AttributeOptional(name) {
+				return Major
+			}
+			if compareType(before.AttributeType(name), attribute) == Major {
+				return Major
+			}
+		}
+		for name := range before.AttributeTypes() {
+			if !after.HasAttribute(name) {
+				// Terraform drops the attribute when callers set it, so
+				// what they asked for is silently ignored.
+				return Major
+			}
+		}
+		return Minor
+	}
+	return Major
+}
+
+// typeString returns the type constraint t as written in variables.tf.
+func typeString(t cty.Type) string {
+	switch {
+	case t == cty.DynamicPseudoType:
+		return "any"
+	case t.IsPrimitiveType():
+		return t.FriendlyNameForConstraint()
+	case t.IsListType():
+		return "list(" + typeString(t.ElementType()) + ")"
+	case t.IsSetType():
+		return "set(" + typeString(t.ElementType()) + ")"
+	case t.IsMapType():
+		return "map(" + typeString(t.ElementType()) + ")"
+	case t.IsTupleType():
+		var elements []string
+		for _, element := range t.TupleElementTypes() {
+			elements = append(elements, typeString(element))
+		}
+		return "tuple([" + strings.Join(elements, ", ") + "])"
+	case t.IsObjectType():
+		var attributes []string
+		for _, name := range keys(t.AttributeTypes()) {
+			attribute := typeString(t.AttributeType(name))
+			if t.AttributeOptional(name) {
+				attribute = "optional(" + attribute + ")"
+			}
+			attributes = append(attributes, name+" = "+attribute)
+		}
+		return "object({" + strings.Join(attributes, ", ") + "})"
+	}
+	return t.FriendlyNameForConstraint()
+}
+
+// Next returns version bumped by level: the major, minor or patch number
+// incremented and those after it reset. A pre-release suffix is dropped.
+func Next(version string, level Level) (string, error) {
+	core := strings.SplitN(version, "-", 2)[0]
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("%q is not a semantic version", version)
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf("%q is not a semantic version", version)
+		}
+		numbers[i] = n
+	}
+	switch level {
+	case None:
+		return version, nil
+	case Major:
+		numbers = [3]int{numbers[0] + 1, 0, 0}
+	case Minor:
+		numbers = [3]int{numbers[0], numbers[1] + 1, 0}
+	case Patch:
+		numbers[2]++
+	}
+	return fmt.Sprintf("%d.%d.%d", numbers[0], numbers[1], numbers[2]), nil
+}
+
+// sameValue reports whether a and b are the same value, whatever their
+// types: a default of [] is the same as a list(string) or a list(object).
+func sameValue(a, b cty.Value) bool {
+	aJSON, aErr := ctyjson.SimpleJSONValue{Value: a}.MarshalJSON()
+	bJSON, bErr := ctyjson.SimpleJSONValue{Value: b}.MarshalJSON()
+	if aErr != nil || bErr != nil {
+		return a.RawEquals(b)
+	}
+	return bytes.Equal(aJSON, bJSON)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// keys returns the keys of m, sorted.
+func keys[V any](m map[string]V) []string {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// union returns the strings of two sorted lists, sorted.
+func union(a, b []string) []string {
+	all := append(append([]string(nil), a...), b...)
+	sort.Strings(all)
+	var unique []string
+	for i, s := range all {
+		if i == 0 || s != all[i-1] {
+			unique = append(unique, s)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,119 @@
+package bump
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	old, err := Load("testdata/old")
+	require.NoError(t, err)
+	new, err := Load("testdata/new")
+	require.NoError(t, err)
+
+	changes := Compare(old, new)
+	var lines []string
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+	assert.Equal(t, []string{
+		"major: variable access_policies: type changed from list(string) to list(object({object_id = string}))",
+		"minor: variable enable_rbac: added",
+		`major: variable environment: validation tightened: can(regex("^(dev|prod)$", var.environment))`,
+		"major: variable location: added without a default, so callers must set it",
+		"patch: variable name: validation error message changed",
+		"minor: variable network_acls: type changed from object({default_action = string, ip_rules = optional(list(string))}) to object({bypass = optional(string), default_action = string, ip_rules = optional(list(string))})",
+		"major: variable purge_protection: removed",
+		"major: variable retention_days: default removed, so callers must set it",
+		`minor: variable short_name: validation loosened: var.short_name == null || can(regex("^[a-z][a-z0-9]{1,3}$", var.short_name))`,
+		"minor: variable sku_name: default changed",
+		`major: variable sku_name: validation added: contains(["standard", "premium"], var.sku_name)`,
+		`major: variable subnet_id: validation changed (review): var.subnet_id == null || startswith(var.subnet_id, "/subscriptions/")`,
+		"patch: variable tags: description or sensitivity changed",
+		"patch: output name: value changed",
+		"minor: output resource_group_name: added",
+		"major: output tenant_id: removed",
+		"major: output uri: made sensitive",
+	}, lines)
+	assert.Equal(t, Major, Highest(changes))
+}
+
+func TestComparePatch(t *testing.T) {
+	t.Parallel()
+
+	old, err := Load("testdata/old")
+	require.NoError(t, err)
+	new, err := Load("testdata/old")
+	require.NoError(t, err)
+	assert.Empty(t, Compare(old, new))
+
+	new.Files["main.tf"] = append(new.Files["main.tf"], "\n# Trailing comment\n"...)
+	changes := Compare(old, new)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "patch: file main.tf: changed", changes[0].String())
+}
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		version string
+		level   Level
+		want    string
+	}{
+		{"1.0.0", None, "1.0.0"},
+		{"1.0.0", Patch, "1.0.1"},
+		{"1.2.3", Minor, "1.3.0"},
+		{"1.2.3", Major, "2.0.0"},
+		{"1.2.3-rc.1", Patch, "1.2.4"},
+	} {
+		got, err := Next(tc.version, tc.level)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, "%s bumped by %s", tc.version, tc.level)
+	}
+
+	_, err := Next("1.0", Patch)
+	assert.ErrorContains(t, err, `"1.0" is not a semantic version`)
+}
+
+func TestLoadRevision(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	module := filepath.Join(root, "azure", "security", "key-vault")
+	require.NoError(t, os.MkdirAll(module, 0o755))
+	run := func(args ...string) {
+		t.Helper()
+		_, err := git(root, args...)
+		require.NoError(t, err)
+	}
+	run("init", "-q")
+	for _, name := range []string{"main.tf", "variables.tf", "outputs.tf"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "old", name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(module, name), data, 0o644))
+	}
+	run("add", "-A")
+	run("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "key vault")
+
+	old, err := LoadRevision(root, "HEAD", "azure/security/key-vault")
+	require.NoError(t, err)
+	require.NotNil(t, old)
+	want, err := Load("testdata/old")
+	require.NoError(t, err)
+	assert.Empty(t, Compare(want, old))
+
+	missing, err := LoadRevision(root, "HEAD", "azure/security/gone")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
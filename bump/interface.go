@@ -0,0 +1,193 @@
+package bump
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+// Interface is what a module offers its callers: its variables and outputs.
+// Files holds the module's .tf files, so that changes to the rest of the
+// module are noticed too.
+type Interface struct {
+	Variables map[string]Variable
+	Outputs   map[string]Output
+	Files     map[string][]byte
+}
+
+// Variable is an input variable of a module.
+type Variable struct {
+	registry.Variable
+
+	// Conditions are the source of the conditions of its validation blocks,
+	// and ErrorMessages their messages, in order.
+	Conditions    []string
+	ErrorMessages []string
+}
+
+// Output is an output of a module.
+type Output struct {
+	Name        string
+	Description string
+	Sensitive   bool
+
+	// Value is the source of the value expression.
+	Value string
+}
+
+var outputSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "output", LabelNames: []string{"name"}}},
+}
+
+var outputBodySchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "value", Required: true}, {Name: "description"}, {Name: "sensitive"},
+		{Name: "depends_on"}, {Name: "ephemeral"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{{Type: "precondition"}},
+}
+
+// Load reads the interface of the module in dir.
+func Load(dir string) (*Interface, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		files[filepath.Base(name)] = data
+	}
+	return parse(dir, files)
+}
+
+// LoadRevision reads the interface of the module at modulePath, relative to
+// the root of the git repository at root, as it was at revision. It returns
+// nil if the module did not exist then.
+func LoadRevision(root, revision, modulePath string) (*Interface, error) {
+	modulePath = path.Clean(filepath.ToSlash(modulePath))
+	list, err := git(root, "ls-tree", "--name-only", "--full-name", revision, modulePath+"/")
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, name := range strings.Fields(string(list)) {
+		if path.Ext(name) != ".tf" {
+			continue
+		}
+		data, err := Show(root, revision, name)
+		if err != nil {
+			return nil, err
+		}
+		files[path.Base(name)] = data
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	// The variables are read by registry.LoadVariables, from a directory.
+	dir, err := os.MkdirTemp("", "zrr-bump-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return parse(dir, files)
+}
+
+// Show returns the file at name, relative to the root of the git
+// repository at root, as it was at revision.
+func Show(root, revision, name string) ([]byte, error) {
+	return git(root, "show", revision+":"+path.Clean(filepath.ToSlash(name)))
+}
+
+// git runs git in dir and returns its output.
+func git(dir string, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// parse reads the interface of the module in dir, whose .tf files are files.
+func parse(dir string, files map[string][]byte) (*Interface, error) {
+	variables, err := registry.LoadVariables(dir)
+	if err != nil {
+		return nil, err
+	}
+	source := func(r hcl.Range) string {
+		return strings.TrimSpace(string(r.SliceBytes(files[filepath.Base(r.Filename)])))
+	}
+
+	module := &Interface{Variables: map[string]Variable{}, Outputs: map[string]Output{}, Files: files}
+	for _, v := range variables {
+		variable := Variable{Variable: v}
+		for _, validation := range v.Validations {
+			variable.Conditions = append(variable.Conditions, source(validation.Condition.Range()))
+			variable.ErrorMessages = append(variable.ErrorMessages, validation.ErrorMessage)
+		}
+		module.Variables[v.Name] = variable
+	}
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parser := hclparse.NewParser()
+	var diags hcl.Diagnostics
+	for _, name := range names {
+		file, fileDiags := parser.ParseHCL(files[name], name)
+		diags = append(diags, fileDiags...)
+		if fileDiags.HasErrors() {
+			continue
+		}
+		content, _, contentDiags := file.Body.PartialContent(outputSchema)
+		diags = append(diags, contentDiags...)
+		for _, block := range content.Blocks {
+			body, bodyDiags := block.Body.Content(outputBodySchema)
+			diags = append(diags, bodyDiags...)
+			if bodyDiags.HasErrors() {
+				continue
+			}
+			output := Output{Name: block.Labels[0], Value: source(body.Attributes["value"].Expr.Range())}
+			if attr, ok := body.Attributes["description"]; ok {
+				if value, valueDiags := attr.Expr.Value(nil); !valueDiags.HasErrors() && value.Type() == cty.String && !value.IsNull() {
+					output.Description = value.AsString()
+				}
+			}
+			if attr, ok := body.Attributes["sensitive"]; ok {
+				if value, valueDiags := attr.Expr.Value(nil); !valueDiags.HasErrors() && value.Type() == cty.Bool && !value.IsNull() {
+					output.Sensitive = value.True()
+				}
+			}
+			module.Outputs[output.Name] = output
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return module, nil
+}
@@ -0,0 +1,367 @@
+package bump
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Relations of the conditions of a validation changed in place.
+const (
+	unrelated = iota
+	loosened
+	tightened
+)
+
+// compareConditions tells whether the condition after accepts every value
+// the condition before did (loosened), only some of them (tightened), or
+// whether that cannot be told. It can only for conditions that are the same
+// but for the patterns they pass to regex, where each pattern either matches
+// in more strings or in fewer than the one it replaces.
+func compareConditions(before, after string) int {
+	beforePatterns, beforeRest, ok := conditionPatterns(before)
+	if !ok {
+		return unrelated
+	}
+	afterPatterns, afterRest, ok := conditionPatterns(after)
+	if !ok || beforeRest != afterRest || len(beforePatterns) != len(afterPatterns) {
+		return unrelated
+	}
+
+	wider, narrower := true, true
+	for i := range beforePatterns {
+		if beforePatterns[i] == afterPatterns[i] {
+			continue
+		}
+		widens, err := patternWithin(beforePatterns[i], afterPatterns[i])
+		if err != nil {
+			return unrelated
+		}
+		narrows, err := patternWithin(afterPatterns[i], beforePatterns[i])
+		if err != nil {
+			return unrelated
+		}
+		wider = wider && widens
+		narrower = narrower && narrows
+	}
+	switch {
+	case wider:
+		return loosened
+	case narrower:
+		return tightened
+	}
+	return unrelated
+}
+
+// conditionPatterns returns the patterns condition passes to regex where a
+// pattern matching in more strings makes the condition true for more values,
+// and the source of condition with those patterns left out. Patterns
+// elsewhere, e.g. under a !, stay in the source.
+func conditionPatterns(condition string) ([]string, string, bool) {
+	expr, diags := hclsyntax.ParseExpression([]byte(condition), "condition.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, "", false
+	}
+
+	var ranges []hcl.Range
+	var patterns []string
+	var collect func(expr hclsyntax.Expression)
+	collect = func(expr hclsyntax.Expression) {
+		switch e := expr.(type) {
+		case *hclsyntax.ParenthesesExpr:
+			collect(e.Expression)
+		case *hclsyntax.BinaryOpExpr:
+			if e.Op == hclsyntax.OpLogicalAnd || e.Op == hclsyntax.OpLogicalOr {
+				collect(e.LHS)
+				collect(e.RHS)
+			}
+		case *hclsyntax.ConditionalExpr:
+			collect(e.TrueResult)
+			collect(e.FalseResult)
+		case *hclsyntax.TupleConsExpr:
+			for _, item := range e.Exprs {
+				collect(item)
+			}
+		case *hclsyntax.ForExpr:
+			collect(e.ValExpr)
+		case *hclsyntax.FunctionCallExpr:
+			switch {
+			case (e.Name == "can" || e.Name == "alltrue" || e.Name == "anytrue") && len(e.Args) == 1:
+				collect(e.Args[0])
+			case e.Name == "regex" && len(e.Args) == 2:
+				value, diags := e.Args[0].Value(nil)
+				if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+					return
+				}
+				ranges = append(ranges, e.Args[0].Range())
+				patterns = append(patterns, value.AsString())
+			}
+		}
+	}
+	collect(expr)
+
+	var rest strings.Builder
+	offset := 0
+	for _, r := range ranges {
+		rest.WriteString(condition[offset:r.Start.Byte])
+		rest.WriteString("<pattern>")
+		offset = r.End.Byte
+	}
+	rest.WriteString(condition[offset:])
+	return patterns, rest.String(), true
+}
+
+// maxStates bounds the automata patternWithin explores.
+const maxStates = 10000
+
+var errUndecided = errors.New("pattern too complex to compare")
+
+// patternWithin reports whether every string the RE2 pattern inner matches
+// in, as Terraform's regex does, is matched in by outer too. Anchors other
+// than a leading ^ and a trailing $, and word boundaries, are not supported.
+func patternWithin(inner, outer string) (bool, error) {
+	a, err := compile(inner)
+	if err != nil {
+		return false, err
+	}
+	b, err := compile(outer)
+	if err != nil {
+		return false, err
+	}
+
+	// Every interval between two bounds of the ranges of either automaton
+	// is one symbol: its runes all take the same transitions.
+	bounds := map[rune]bool{0: true}
+	for _, n := range []*automaton{a, b} {
+		for _, edges := range n.edges {
+			for _, e := range edges {
+				bounds[e.lo] = true
+				bounds[e.hi+1] = true
+			}
+		}
+	}
+	var symbols []rune
+	for bound := range bounds {
+		if bound <= unicode.MaxRune {
+			symbols = append(symbols, bound)
+		}
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i] < symbols[j] })
+
+	// Walk both automata determinized in lockstep, looking for a string
+	// inner matches and outer does not.
+	type pair struct{ a, b []int }
+	start := pair{a.closure([]int{a.start}), b.closure([]int{b.start})}
+	seen := map[string]bool{}
+	queue := []pair{start}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		key := fmt.Sprint(p.a, p.b)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if len(seen) > maxStates {
+			return false, errUndecided
+		}
+		if contains(p.a, a.accept) && !contains(p.b, b.accept) {
+			return false, nil
+		}
+		for _, symbol := range symbols {
+			next := a.step(p.a, symbol)
+			if len(next) > 0 {
+				queue = append(queue, pair{next, b.step(p.b, symbol)})
+			}
+		}
+	}
+	return true, nil
+}
+
+// automaton is a nondeterministic finite automaton whose transitions consume
+// a rune within a range.
+type automaton struct {
+	edges  [][]edge
+	empty  [][]int
+	start  int
+	accept int
+}
+
+type edge struct {
+	lo, hi rune
+	to     int
+}
+
+// compile builds the automaton of the strings pattern matches in.
+func compile(pattern string) (*automaton, error) {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	re = re.Simplify()
+
+	// A pattern not anchored at an end matches with anything on that side.
+	subs := []*syntax.Regexp{re}
+	if re.Op == syntax.OpConcat {
+		subs = re.Sub
+	}
+	anything := &syntax.Regexp{Op: syntax.OpStar, Sub: []*syntax.Regexp{{Op: syntax.OpAnyChar}}}
+	if len(subs) > 0 && subs[0].Op == syntax.OpBeginText {
+		subs = subs[1:]
+	} else {
+		subs = append([]*syntax.Regexp{anything}, subs...)
+	}
+	if len(subs) > 0 && subs[len(subs)-1].Op == syntax.OpEndText {
+		subs = subs[:len(subs)-1]
+	} else {
+		subs = append(subs, anything)
+	}
+
+	n := &automaton{}
+	n.start, n.accept = n.state(), n.state()
+	if err := n.build(&syntax.Regexp{Op: syntax.OpConcat, Sub: subs}, n.start, n.accept); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *automaton) state() int {
+	n.edges = append(n.edges, nil)
+	n.empty = append(n.empty, nil)
+	return len(n.edges) - 1
+}
+
+// build adds the states and transitions matching re from state from to
+// state to.
+func (n *automaton) build(re *syntax.Regexp, from, to int) error {
+	if len(n.edges) > maxStates {
+		return errUndecided
+	}
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		n.empty[from] = append(n.empty[from], to)
+	case syntax.OpLiteral:
+		for i, r := range re.Rune {
+			next := to
+			if i < len(re.Rune)-1 {
+				next = n.state()
+			}
+			ranges := []rune{r, r}
+			if re.Flags&syntax.FoldCase != 0 {
+				for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+					ranges = append(ranges, f, f)
+				}
+			}
+			n.ranges(from, next, ranges)
+			from = next
+		}
+		if len(re.Rune) == 0 {
+			n.empty[from] = append(n.empty[from], to)
+		}
+	case syntax.OpCharClass:
+		n.ranges(from, to, re.Rune)
+	case syntax.OpAnyChar:
+		n.ranges(from, to, []rune{0, unicode.MaxRune})
+	case syntax.OpAnyCharNotNL:
+		n.ranges(from, to, []rune{0, '\n' - 1, '\n' + 1, unicode.MaxRune})
+	case syntax.OpCapture:
+		return n.build(re.Sub[0], from, to)
+	case syntax.OpConcat:
+		for i, sub := range re.Sub {
+			next := to
+			if i < len(re.Sub)-1 {
+				next = n.state()
+			}
+			if err := n.build(sub, from, next); err != nil {
+				return err
+			}
+			from = next
+		}
+		if len(re.Sub) == 0 {
+			n.empty[from] = append(n.empty[from], to)
+		}
+	case syntax.OpAlternate:
+		for _, sub := range re.Sub {
+			if err := n.build(sub, from, to); err != nil {
+				return err
+			}
+		}
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest:
+		loop := n.state()
+		n.empty[from] = append(n.empty[from], loop)
+		if re.Op != syntax.OpPlus {
+			n.empty[loop] = append(n.empty[loop], to)
+		}
+		body := n.state()
+		if err := n.build(re.Sub[0], loop, body); err != nil {
+			return err
+		}
+		if re.Op != syntax.OpQuest {
+			n.empty[body] = append(n.empty[body], loop)
+		}
+		if re.Op != syntax.OpStar {
+			n.empty[body] = append(n.empty[body], to)
+		}
+	default:
+		return fmt.Errorf("%s in %s: %w", re.Op, re, errUndecided)
+	}
+	return nil
+}
+
+// ranges adds transitions from state from to state to on each of the rune
+// ranges, given as pairs of bounds.
+func (n *automaton) ranges(from, to int, ranges []rune) {
+	for i := 0; i+1 < len(ranges); i += 2 {
+		n.edges[from] = append(n.edges[from], edge{lo: ranges[i], hi: ranges[i+1], to: to})
+	}
+}
+
+// closure returns the sorted states reachable from states without consuming
+// a rune.
+func (n *automaton) closure(states []int) []int {
+	reached := map[int]bool{}
+	stack := append([]int(nil), states...)
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if reached[s] {
+			continue
+		}
+		reached[s] = true
+		stack = append(stack, n.empty[s]...)
+	}
+	closed := make([]int, 0, len(reached))
+	for s := range reached {
+		closed = append(closed, s)
+	}
+	sort.Ints(closed)
+	return closed
+}
+
+// step returns the closure of the states reached from states on r.
+func (n *automaton) step(states []int, r rune) []int {
+	var next []int
+	for _, s := range states {
+		for _, e := range n.edges[s] {
+			if e.lo <= r && r <= e.hi {
+				next = append(next, e.to)
+			}
+		}
+	}
+	if len(next) == 0 {
+		return nil
+	}
+	return n.closure(next)
+}
+
+func contains(states []int, state int) bool {
+	i := sort.SearchInts(states, state)
+	return i < len(states) && states[i] == state
+}
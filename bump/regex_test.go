@@ -0,0 +1,57 @@
+package bump
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatternWithin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		inner, outer string
+		within       bool
+	}{
+		{`^[a-z]{2,4}$`, `^[a-z][a-z0-9]{1,3}$`, true},
+		{`^[a-z][a-z0-9]{1,3}$`, `^[a-z]{2,4}$`, false},
+		{`^[a-z]{2,4}$`, `^[a-z]{1,10}$`, true},
+		{`^[a-z]{2,4}$`, `^[a-z]{3,4}$`, false},
+		{`^[a-z0-9-]+$`, `^[a-zA-Z0-9-]+$`, true},
+		{`^(dev|prod)$`, `^(dev|test|prod)$`, true},
+		{`^(dev|test|prod)$`, `^(dev|prod)$`, false},
+		{`^dev$`, `(?i)^DEV$`, true},
+		{`^sa[a-z]+$`, `sa`, true},
+		{`sa`, `^sa[a-z]+$`, false},
+		{`/subnets/`, `/subnets/.*`, true},
+	}
+	for _, test := range tests {
+		within, err := patternWithin(test.inner, test.outer)
+		require.NoError(t, err, "%s within %s", test.inner, test.outer)
+		assert.Equal(t, test.within, within, "%s within %s", test.inner, test.outer)
+	}
+
+	_, err := patternWithin(`\bdev\b`, `dev`)
+	assert.ErrorIs(t, err, errUndecided)
+}
+
+func TestCompareConditions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, loosened, compareConditions(
+		`alltrue([for email in var.emails : can(regex("^[a-z]+@example\\.com$", email))])`,
+		`alltrue([for email in var.emails : can(regex("^[a-z.]+@example\\.com$", email))])`))
+	assert.Equal(t, tightened, compareConditions(
+		`var.id == null || can(regex("/subnets/", var.id))`,
+		`var.id == null || can(regex("^/subscriptions/.*/subnets/", var.id))`))
+
+	// Under a !, a pattern matching more makes the condition accept less.
+	assert.Equal(t, unrelated, compareConditions(
+		`!can(regex("^[a-z]$", var.name))`,
+		`!can(regex("^[a-z0-9]$", var.name))`))
+	// Anything else changed besides the pattern.
+	assert.Equal(t, unrelated, compareConditions(
+		`can(regex("^[a-z]+$", var.name))`,
+		`length(var.name) < 10 && can(regex("^[a-z0-9]+$", var.name))`))
+}
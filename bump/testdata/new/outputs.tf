@@ -0,0 +1,20 @@
+output "id" {
+  description = "ID of the key vault"
+  value       = azurerm_key_vault.main.id
+}
+
+output "uri" {
+  description = "URI of the key vault"
+  value       = azurerm_key_vault.main.vault_uri
+  sensitive   = true
+}
+
+output "name" {
+  description = "Name of the key vault"
+  value       = lower(azurerm_key_vault.main.name)
+}
+
+output "resource_group_name" {
+  description = "Resource group of the key vault"
+  value       = azurerm_key_vault.main.resource_group_name
+}
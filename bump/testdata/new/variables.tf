@@ -0,0 +1,91 @@
+variable "name" {
+  description = "Name of the key vault"
+  type        = string
+
+  validation {
+    condition     = length(var.name) <= 24
+    error_message = "The name must be at most 24 characters long."
+  }
+}
+
+variable "sku_name" {
+  description = "SKU of the key vault"
+  type        = string
+  default     = "premium"
+
+  validation {
+    condition     = contains(["standard", "premium"], var.sku_name)
+    error_message = "The SKU must be standard or premium."
+  }
+}
+
+variable "retention_days" {
+  description = "Days soft-deleted vaults are retained"
+  type        = number
+}
+
+variable "network_acls" {
+  description = "Network rules of the key vault"
+  type = object({
+    default_action = string
+    ip_rules       = optional(list(string))
+    bypass         = optional(string)
+  })
+  default = null
+}
+
+variable "access_policies" {
+  description = "Access policies of the key vault"
+  type        = list(object({ object_id = string }))
+  default     = []
+}
+
+variable "tags" {
+  description = "Tags to add to the key vault"
+  type        = map(string)
+  default     = {}
+}
+
+variable "location" {
+  description = "Azure region of the key vault"
+  type        = string
+}
+
+variable "enable_rbac" {
+  description = "Whether RBAC authorization is enabled"
+  type        = bool
+  default     = false
+}
+
+variable "environment" {
+  description = "Environment of the key vault"
+  type        = string
+  default     = "dev"
+
+  validation {
+    condition     = can(regex("^(dev|prod)$", var.environment))
+    error_message = "The environment must be dev, test or prod."
+  }
+}
+
+variable "short_name" {
+  description = "Short name used in generated names"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.short_name == null || can(regex("^[a-z][a-z0-9]{1,3}$", var.short_name))
+    error_message = "The short name must be 2 to 4 lowercase letters."
+  }
+}
+
+variable "subnet_id" {
+  description = "Subnet of the private endpoint"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.subnet_id == null || startswith(var.subnet_id, "/subscriptions/")
+    error_message = "The subnet ID must be the ID of a subnet."
+  }
+}
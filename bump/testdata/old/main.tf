@@ -0,0 +1,8 @@
+# azure-security-key-vault module
+# Description: Manages a key vault for the bump tests
+
+resource "azurerm_key_vault" "main" {
+  name     = var.name
+  sku_name = var.sku_name
+  tags     = var.tags
+}
@@ -0,0 +1,19 @@
+output "id" {
+  description = "ID of the key vault"
+  value       = azurerm_key_vault.main.id
+}
+
+output "uri" {
+  description = "URI of the key vault"
+  value       = azurerm_key_vault.main.vault_uri
+}
+
+output "tenant_id" {
+  description = "Tenant of the key vault"
+  value       = azurerm_key_vault.main.tenant_id
+}
+
+output "name" {
+  description = "Name of the key vault"
+  value       = azurerm_key_vault.main.name
+}
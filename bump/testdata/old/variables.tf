@@ -0,0 +1,81 @@
+variable "name" {
+  description = "Name of the key vault"
+  type        = string
+
+  validation {
+    condition     = length(var.name) <= 24
+    error_message = "The name must be at most 24 characters."
+  }
+}
+
+variable "sku_name" {
+  description = "SKU of the key vault"
+  type        = string
+  default     = "standard"
+}
+
+variable "retention_days" {
+  description = "Days soft-deleted vaults are retained"
+  type        = number
+  default     = 7
+}
+
+variable "network_acls" {
+  description = "Network rules of the key vault"
+  type = object({
+    default_action = string
+    ip_rules       = optional(list(string))
+  })
+  default = null
+}
+
+variable "access_policies" {
+  description = "Access policies of the key vault"
+  type        = list(string)
+  default     = []
+}
+
+variable "purge_protection" {
+  description = "Whether purge protection is enabled"
+  type        = bool
+  default     = false
+}
+
+variable "tags" {
+  description = "Tags of the key vault"
+  type        = map(string)
+  default     = {}
+}
+
+variable "environment" {
+  description = "Environment of the key vault"
+  type        = string
+  default     = "dev"
+
+  validation {
+    condition     = can(regex("^(dev|test|prod)$", var.environment))
+    error_message = "The environment must be dev, test or prod."
+  }
+}
+
+variable "short_name" {
+  description = "Short name used in generated names"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.short_name == null || can(regex("^[a-z]{2,4}$", var.short_name))
+    error_message = "The short name must be 2 to 4 lowercase letters."
+  }
+}
+
+variable "subnet_id" {
+  description = "Subnet of the private endpoint"
+  type        = string
+  default     = null
+
+  validation {
+    condition     = var.subnet_id == null || can(regex("/subnets/", var.subnet_id))
+    error_message = "The subnet ID must be the ID of a subnet."
+  }
+}
@@ -0,0 +1,103 @@
+// Command zrr-coverage reports which resources of each module its tests
+// exercise.
+//
+// Usage:
+//
+//	zrr-coverage [-root dir] [-json] [-min percent] [module ...]
+//
+// It cross-references the resources of the main.tf of the given modules, or
+// of every module on disk, with the addresses their tests refer to, as the
+// coverage package describes, and prints each module's coverage with the
+// resources no test covers, then the coverage of all of them. With -json it
+// prints the modules as JSON instead. With -min it exits 1 if a module's
+// coverage is below percent.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/coverage"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/suite"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("zrr-coverage", flag.ContinueOnError)
+	root := flags.String("root", "", "repository root (default: the directory above holding "+suite.RegistryFile+")")
+	asJSON := flags.Bool("json", false, "print the coverage as JSON")
+	min := flags.Float64("min", 0, "exit 1 if a module's coverage is below this percentage")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *root == "" {
+		dir, err := suite.FindRoot(".")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-coverage:", err)
+			return 2
+		}
+		*root = dir
+	}
+
+	modules, err := coverage.Measure(*root, flags.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-coverage:", err)
+		return 1
+	}
+
+	if *asJSON {
+		type module struct {
+			*coverage.Module
+			Percent   float64  `json:"percent"`
+			Uncovered []string `json:"uncovered"`
+		}
+		out := []module{}
+		for _, m := range modules {
+			uncovered := m.Uncovered()
+			if uncovered == nil {
+				uncovered = []string{}
+			}
+			out = append(out, module{Module: m, Percent: m.Percent(), Uncovered: uncovered})
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(out); err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-coverage:", err)
+			return 1
+		}
+	} else {
+		total := &coverage.Module{Path: "total", Covered: map[string][]string{}}
+		for _, m := range modules {
+			fmt.Println(m)
+			for _, address := range m.Uncovered() {
+				fmt.Println("\t" + address)
+			}
+			for _, address := range m.Resources {
+				total.Resources = append(total.Resources, m.Path+"/"+address)
+			}
+			for address, files := range m.Covered {
+				total.Covered[m.Path+"/"+address] = files
+			}
+		}
+		if len(modules) > 1 {
+			fmt.Println(total)
+		}
+	}
+
+	below := 0
+	for _, m := range modules {
+		if m.Percent() < *min {
+			below++
+		}
+	}
+	if below > 0 {
+		fmt.Fprintf(os.Stderr, "zrr-coverage: %d modules are below %.1f%% coverage\n", below, *min)
+		return 1
+	}
+	return 0
+}
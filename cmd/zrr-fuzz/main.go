@@ -0,0 +1,124 @@
+// Command zrr-fuzz lists the invalid inputs generated from the variable
+// validations of every module, and the validations none of them exercise.
+//
+// Usage:
+//
+//	zrr-fuzz [-root dir] [-module glob]... [-v]
+//
+// Values are generated from the literals and regular expressions of each
+// validation condition and kept if the condition rejects them, checked the
+// way Terraform would. The suites plan those values with fuzz.Validations;
+// this command only generates them, so it needs neither Terraform nor Azure.
+// With -v every generated value is listed.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/suite"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fuzz"
+)
+
+// list collects repeated, comma-separated flag values.
+type list []string
+
+func (l *list) String() string { return strings.Join(*l, ",") }
+func (l *list) Set(v string) error {
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("zrr-fuzz", flag.ContinueOnError)
+	var modules list
+	flags.Var(&modules, "module", "modules to check, e.g. mysql-*; repeatable")
+	root := flags.String("root", "", "repository root (default: the directory above holding "+suite.RegistryFile+")")
+	verbose := flags.Bool("v", false, "list every generated value")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	for _, pattern := range modules {
+		if _, err := path.Match(pattern, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "zrr-fuzz: invalid pattern %q: %v\n", pattern, err)
+			return 2
+		}
+	}
+
+	if *root == "" {
+		dir, err := suite.FindRoot(".")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-fuzz:", err)
+			return 2
+		}
+		*root = dir
+	}
+	dirs, err := filepath.Glob(filepath.Join(*root, "azure", "*", "*", "variables.tf"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-fuzz:", err)
+		return 2
+	}
+
+	status := 0
+	var total, exercised, generated int
+	for _, file := range dirs {
+		dir := filepath.Dir(file)
+		name := filepath.Base(dir)
+		if !selected(modules, name) {
+			continue
+		}
+		relative, _ := filepath.Rel(*root, dir)
+		variables, err := registry.LoadVariables(dir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "zrr-fuzz: %s: %v\n", relative, err)
+			status = 1
+			continue
+		}
+		cases, gaps := fuzz.Generate(variables, nil)
+
+		validations := 0
+		for _, variable := range variables {
+			validations += len(variable.Validations)
+		}
+		total += validations
+		exercised += validations - len(gaps)
+		generated += len(cases)
+		fmt.Printf("%-50s %3d validations, %3d exercised by %3d values\n",
+			filepath.ToSlash(relative), validations, validations-len(gaps), len(cases))
+		if *verbose {
+			for _, c := range cases {
+				fmt.Printf("    var.%s = %s\n", c.Variable, c.Name())
+			}
+		}
+		for _, gap := range gaps {
+			fmt.Printf("    not exercised: %s\n", gap)
+		}
+	}
+	fmt.Printf("%d of %d validations exercised by %d values\n", exercised, total, generated)
+	return status
+}
+
+func selected(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+// Command zrr-gen generates the typed Go inputs and outputs of every module.
+//
+// Usage:
+//
+//	zrr-gen [-root dir] [-check]
+//
+// It writes zrrtest/modules/<package>/inputs_gen.go for every module from
+// its variables.tf and outputs_gen.go from its outputs.tf, and removes the
+// generated files of modules that no longer exist. With -check it writes
+// nothing, lists the generated files that are out of date and exits 1 if
+// there are any.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/codegen"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/suite"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("zrr-gen", flag.ContinueOnError)
+	root := flags.String("root", "", "repository root (default: the directory above holding "+suite.RegistryFile+")")
+	check := flags.Bool("check", false, "list the out-of-date generated files instead of writing them")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *root == "" {
+		dir, err := suite.FindRoot(".")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-gen:", err)
+			return 2
+		}
+		*root = dir
+	}
+
+	if *check {
+		stale, err := codegen.Stale(*root)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-gen:", err)
+			return 1
+		}
+		for _, file := range stale {
+			fmt.Println(file)
+		}
+		if len(stale) > 0 {
+			fmt.Printf("%d generated files are out of date; run zrr-gen\n", len(stale))
+			return 1
+		}
+		return 0
+	}
+
+	written, err := codegen.Write(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-gen:", err)
+		return 1
+	}
+	for _, file := range written {
+		fmt.Println(file)
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+// Command zrr-lint checks the modules of the library against its conventions.
+//
+// Usage:
+//
+//	zrr-lint [-root dir] [-format text|sarif] [-o file] [module ...]
+//
+// It lints the given modules, or every module on disk, with the rules of the
+// lint package, and exits 1 if any module breaks one. The text format prints
+// each finding as file:line: message (rule); the sarif format writes a SARIF
+// 2.1.0 log for code scanning. Without -o the findings are written to
+// standard output.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/lint"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/suite"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("zrr-lint", flag.ContinueOnError)
+	root := flags.String("root", "", "repository root (default: the directory above holding "+suite.RegistryFile+")")
+	format := flags.String("format", "text", "output format: text or sarif")
+	output := flags.String("o", "", "write the findings to this file instead of standard output")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format != "text" && *format != "sarif" {
+		fmt.Fprintf(os.Stderr, "zrr-lint: unknown format %q\n", *format)
+		return 2
+	}
+	if *root == "" {
+		dir, err := suite.FindRoot(".")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-lint:", err)
+			return 2
+		}
+		*root = dir
+	}
+
+	findings, err := lint.Lint(*root, flags.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-lint:", err)
+		return 1
+	}
+
+	var out bytes.Buffer
+	if *format == "sarif" {
+		data, err := lint.SARIF(findings)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-lint:", err)
+			return 1
+		}
+		out.Write(data)
+	} else {
+		for _, f := range findings {
+			fmt.Fprintln(&out, f)
+		}
+		if len(findings) > 0 {
+			fmt.Fprintf(&out, "%d findings\n", len(findings))
+		}
+	}
+	if *output == "" {
+		os.Stdout.Write(out.Bytes())
+	} else if err := os.WriteFile(*output, out.Bytes(), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-lint:", err)
+		return 1
+	}
+
+	if len(findings) > 0 {
+		return 1
+	}
+	return 0
+}
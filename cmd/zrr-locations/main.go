@@ -0,0 +1,32 @@
+// Command zrr-locations writes the Azure region tables of the location
+// package as Terraform locals.
+//
+// Usage:
+//
+//	zrr-locations [-o azure/shared/locations/locations.tf]
+//
+// Without -o the file is written to standard output.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/location"
+)
+
+func main() {
+	output := flag.String("o", "", "write the locals to this file instead of standard output")
+	flag.Parse()
+
+	locals := location.Locals()
+	if *output == "" {
+		os.Stdout.Write(locals)
+		return
+	}
+	if err := os.WriteFile(*output, locals, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-locations:", err)
+		os.Exit(1)
+	}
+}
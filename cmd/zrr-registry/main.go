@@ -0,0 +1,270 @@
+// Command zrr-registry maintains module-registry.json.
+//
+// Usage:
+//
+//	zrr-registry validate [-root dir]
+//	zrr-registry sync [-root dir] [-n] [module ...]
+//	zrr-registry bump [-root dir] [-from rev] [-to rev] [-w] [module ...]
+//
+// validate checks every entry of the registry against the format in
+// module-registry.schema.json and against the module's files: its examples,
+// the required_providers and required_version of its terraform block, and
+// that every module on disk is listed. It prints each problem and exits 1
+// if there are any.
+//
+// sync rewrites the entries of the given modules, or of every module on
+// disk, from their files: the description from the header of main.tf, the
+// examples, required_providers and terraform_version. Modules not listed
+// yet are added. Features, tags and the other hand-curated fields are kept,
+// and the updated dates change only for entries that did. It prints each
+// change; with -n it does not write the registry.
+//
+// bump compares the variables, outputs and .tf files of the given modules,
+// or of every module on disk, between two git revisions: -from, HEAD by
+// default, and -to, the working tree by default. It prints each change as
+// major, minor or patch and proposes the module's next version from the one
+// the registry gave it at -from. With -w it writes the proposed versions
+// into the registry.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/bump"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/suite"
+)
+
+const usage = `usage: zrr-registry validate [-root dir]
+       zrr-registry sync [-root dir] [-n] [module ...]
+       zrr-registry bump [-root dir] [-from rev] [-to rev] [-w] [module ...]`
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "validate":
+		return validate(args[1:])
+	case "sync":
+		return sync(args[1:])
+	case "bump":
+		return bumpVersions(args[1:])
+	default:
+		fmt.Fprintf(os.Stderr, "zrr-registry: unknown command %q\n%s\n", args[0], usage)
+		return 2
+	}
+}
+
+// rootFlag adds -root to flags.
+func rootFlag(flags *flag.FlagSet) *string {
+	return flags.String("root", "", "repository root (default: the directory above holding "+registry.File+")")
+}
+
+// findRoot returns root, or the repository root above the working directory
+// if it is empty.
+func findRoot(root string) (string, error) {
+	if root != "" {
+		return root, nil
+	}
+	return suite.FindRoot(".")
+}
+
+func validate(args []string) int {
+	flags := flag.NewFlagSet("zrr-registry validate", flag.ContinueOnError)
+	root := rootFlag(flags)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	dir, err := findRoot(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 2
+	}
+
+	r, err := registry.Load(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 1
+	}
+	problems, err := registry.Validate(dir, r)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 1
+	}
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("%d problems in %s\n", len(problems), registry.File)
+		return 1
+	}
+	fmt.Printf("%s lists all %d modules\n", registry.File, len(r.Modules))
+	return 0
+}
+
+func sync(args []string) int {
+	flags := flag.NewFlagSet("zrr-registry sync", flag.ContinueOnError)
+	root := rootFlag(flags)
+	dryRun := flags.Bool("n", false, "print the changes without writing "+registry.File)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	dir, err := findRoot(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 2
+	}
+
+	r, err := registry.Load(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 1
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		if paths, err = registry.Discover(dir); err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+			return 1
+		}
+	}
+	changes, err := registry.Sync(dir, r, paths, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 1
+	}
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("%s is up to date\n", registry.File)
+		return 0
+	}
+	if *dryRun {
+		return 0
+	}
+
+	data, err := r.Marshal()
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, registry.File), data, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 1
+	}
+	fmt.Printf("%d changes written to %s\n", len(changes), registry.File)
+	return 0
+}
+
+func bumpVersions(args []string) int {
+	flags := flag.NewFlagSet("zrr-registry bump", flag.ContinueOnError)
+	root := rootFlag(flags)
+	from := flags.String("from", "HEAD", "git revision to compare from")
+	to := flags.String("to", "", "git revision to compare to (default: the working tree)")
+	write := flags.Bool("w", false, "write the proposed versions to "+registry.File)
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	dir, err := findRoot(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 2
+	}
+	fail := func(err error) int {
+		fmt.Fprintln(os.Stderr, "zrr-registry:", err)
+		return 1
+	}
+
+	r, err := registry.Load(dir)
+	if err != nil {
+		return fail(err)
+	}
+	// The versions are bumped from those of the registry at -from, so that
+	// running bump twice does not bump twice.
+	base := r
+	if data, err := bump.Show(dir, *from, registry.File); err == nil {
+		if base, err = registry.Parse(data); err != nil {
+			return fail(fmt.Errorf("%s at %s: %w", registry.File, *from, err))
+		}
+	}
+	paths := flags.Args()
+	if len(paths) == 0 {
+		if paths, err = registry.Discover(dir); err != nil {
+			return fail(err)
+		}
+	}
+
+	date := time.Now().Format(registry.DateLayout)
+	bumped := 0
+	for _, modulePath := range paths {
+		old, err := bump.LoadRevision(dir, *from, modulePath)
+		if err != nil {
+			return fail(err)
+		}
+		var new *bump.Interface
+		if *to == "" {
+			new, err = bump.Load(filepath.Join(dir, filepath.FromSlash(modulePath)))
+		} else {
+			new, err = bump.LoadRevision(dir, *to, modulePath)
+		}
+		if err != nil {
+			return fail(err)
+		}
+		entry, baseEntry := r.Module(modulePath), base.Module(modulePath)
+		switch {
+		case old == nil:
+			fmt.Printf("%s: new module\n", modulePath)
+			continue
+		case new == nil || len(new.Files) == 0:
+			fmt.Printf("%s: removed\n", modulePath)
+			continue
+		case entry == nil:
+			fmt.Printf("%s: not in %s\n", modulePath, registry.File)
+			continue
+		case baseEntry == nil:
+			baseEntry = entry
+		}
+
+		changes := bump.Compare(old, new)
+		if len(changes) == 0 {
+			continue
+		}
+		level := bump.Highest(changes)
+		version, err := bump.Next(baseEntry.Version, level)
+		if err != nil {
+			return fail(fmt.Errorf("%s: %w", modulePath, err))
+		}
+		fmt.Printf("%s: %s -> %s (%s)\n", modulePath, baseEntry.Version, version, level)
+		for _, change := range changes {
+			fmt.Printf("\t%s\n", change)
+		}
+		if entry.Version != version {
+			entry.Version = version
+			entry.Updated = date
+			r.Updated = date
+			bumped++
+		}
+	}
+	if !*write || bumped == 0 {
+		return 0
+	}
+
+	data, err := r.Marshal()
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, registry.File), data, 0o644)
+	}
+	if err != nil {
+		return fail(err)
+	}
+	fmt.Printf("%d versions written to %s\n", bumped, registry.File)
+	return 0
+}
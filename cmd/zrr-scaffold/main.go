@@ -0,0 +1,82 @@
+// Command zrr-scaffold creates a new module of the library.
+//
+// Usage:
+//
+//	zrr-scaffold [-root dir] -description text [-resource type] [-prefix p] <cloud> <layer> <name>
+//
+// It writes <cloud>/<layer>/<name> from the templates of the scaffold
+// package: main.tf, variables.tf, outputs.tf, versions.tf,
+// .terraform-docs.yml, README.md, the basic and advanced examples and a
+// tests/ module using the shared test kit. It then adds the module to
+// module-registry.json and generates its typed inputs and outputs under
+// zrrtest/modules. The main resource is azurerm_<name> unless -resource says
+// otherwise, and the naming convention prefixes names with the initials of
+// the name unless -prefix does.
+//
+// The plan snapshots of the examples are left to be recorded with
+// `ZRR_UPDATE_SNAPSHOTS=1 go test ./zrrtest/snapshot/examples -args
+// -module=<path>` from the repository root.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/scaffold"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/suite"
+)
+
+const usage = "usage: zrr-scaffold [-root dir] -description text [-resource type] [-prefix p] <cloud> <layer> <name>"
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("zrr-scaffold", flag.ContinueOnError)
+	root := flags.String("root", "", "repository root (default: the directory above holding "+suite.RegistryFile+")")
+	description := flags.String("description", "", "description of the module, for main.tf and the registry")
+	resource := flags.String("resource", "", "Terraform type of the main resource (default: azurerm_<name>)")
+	prefix := flags.String("prefix", "", "prefix of the names the naming convention builds (default: the initials of the name)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 3 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	module := scaffold.Module{
+		Cloud:       flags.Arg(0),
+		Layer:       flags.Arg(1),
+		Name:        flags.Arg(2),
+		Description: *description,
+		Resource:    *resource,
+		Prefix:      *prefix,
+	}
+	if err := module.Check(); err != nil {
+		fmt.Fprintf(os.Stderr, "zrr-scaffold: %v\n%s\n", err, usage)
+		return 2
+	}
+
+	if *root == "" {
+		dir, err := suite.FindRoot(".")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-scaffold:", err)
+			return 2
+		}
+		*root = dir
+	}
+	written, err := scaffold.Generate(*root, module, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-scaffold:", err)
+		return 1
+	}
+	for _, file := range written {
+		fmt.Println(file)
+	}
+	fmt.Printf("added %s to %s\n", module.Path(), suite.RegistryFile)
+	fmt.Printf("record the plan snapshots with: ZRR_UPDATE_SNAPSHOTS=1 go test ./zrrtest/snapshot/examples -args -module=%s\n", module.Path())
+	return 0
+}
@@ -0,0 +1,107 @@
+// Command zrr-sweep deletes resource groups leaked by integration tests.
+//
+// Usage:
+//
+//	zrr-sweep [-subscription id] [-pattern glob]... [-tag TestID]
+//	          [-min-age 6h] [-concurrency 5] [-dry-run]
+//
+// A resource group is swept when its name matches one of the patterns
+// (test-rg-*, test-mysql-ha-* and test-perf-nsg-* by default), it carries
+// the tag and it is older than the minimum age. The subscription defaults to
+// ARM_SUBSCRIPTION_ID and credentials are taken from the environment or the
+// Azure CLI, like the rest of the test suite.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/sweep"
+	"github.com/gruntwork-io/terratest/modules/azure"
+)
+
+// patterns collects repeated -pattern flags.
+type patterns []string
+
+func (p *patterns) String() string     { return strings.Join(*p, ",") }
+func (p *patterns) Set(v string) error { *p = append(*p, v); return nil }
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("zrr-sweep", flag.ContinueOnError)
+	var namePatterns patterns
+	flags.Var(&namePatterns, "pattern", "resource group name pattern to sweep; repeatable (default "+strings.Join(sweep.DefaultPatterns, ", ")+")")
+	subscription := flags.String("subscription", "", "subscription to sweep (default $ARM_SUBSCRIPTION_ID)")
+	tag := flags.String("tag", sweep.DefaultTag, "tag key a resource group must carry to be swept")
+	minAge := flags.Duration("min-age", 6*time.Hour, "only sweep resource groups older than this")
+	concurrency := flags.Int("concurrency", sweep.DefaultConcurrency, "number of resource groups deleted at once")
+	dryRun := flags.Bool("dry-run", false, "list the resource groups that would be deleted without deleting them")
+	endpoint := flags.String("endpoint", sweep.DefaultEndpoint, "Azure Resource Manager endpoint")
+	noAuth := flags.Bool("no-auth", false, "send unauthenticated requests, for a local fake endpoint")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	subscriptionID, err := azure.GetTargetAzureSubscription(*subscription)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-sweep:", err)
+		return 2
+	}
+
+	client := &sweep.ARMClient{Endpoint: *endpoint, SubscriptionID: subscriptionID}
+	if !*noAuth {
+		authorizer, err := azure.NewAuthorizer()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-sweep:", err)
+			return 2
+		}
+		client.Authorizer = *authorizer
+	} else {
+		client.Authorizer = autorest.NullAuthorizer{}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	opts := sweep.Options{
+		Patterns:    namePatterns,
+		Tag:         *tag,
+		MinAge:      *minAge,
+		DryRun:      *dryRun,
+		Concurrency: *concurrency,
+		OnDelete: func(group sweep.ResourceGroup, err error) {
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed  %s: %v\n", group.Name, err)
+				return
+			}
+			fmt.Printf("deleted %s\n", group.Name)
+		},
+	}
+	result, err := sweep.Sweep(ctx, client, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-sweep:", err)
+		return 1
+	}
+
+	if *dryRun {
+		now := time.Now()
+		for _, group := range result.Matched {
+			fmt.Printf("would delete %s (%s, %s old, %s=%s)\n",
+				group.Name, group.Location, group.Age(now).Round(time.Minute), *tag, group.Tags[*tag])
+		}
+	}
+	fmt.Printf("%d matched, %d deleted, %d failed\n", len(result.Matched), len(result.Deleted), len(result.Failed))
+	if len(result.Failed) > 0 {
+		return 1
+	}
+	return 0
+}
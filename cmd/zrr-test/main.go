@@ -0,0 +1,222 @@
+// Command zrr-test runs the test suites of every module in the library.
+//
+// Usage:
+//
+//	zrr-test [-layer glob]... [-module glob]... [-tier unit|plan|integration]...
+//	         [-tag glob]... [-concurrency 4] [-timeout d] [-report dir] [-list]
+//	         [-v] [-- go test flags]
+//
+// The suites are found by walking the repository for the tests modules
+// under each module's tests directory. Filters are repeatable and take
+// comma-separated lists; a suite must match every filter given, and carry
+// every -tag in module-registry.json. Without -tier only the unit tier runs,
+// since the others need an Azure subscription. Output of failed suites is
+// printed as they finish, followed by one summary of all of them.
+//
+// With -report the suites run with go test -json, and a JUnit XML and a JSON
+// report of every module is written to dir as <layer>-<module>.xml and
+// <layer>-<module>.json, with the time each test spent in every Terraform
+// command and the IDs of the Azure resources it created.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/report"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/suite"
+)
+
+// list collects repeated, comma-separated flag values.
+type list []string
+
+func (l *list) String() string { return strings.Join(*l, ",") }
+func (l *list) Set(v string) error {
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	flags := flag.NewFlagSet("zrr-test", flag.ContinueOnError)
+	var filter suite.Filter
+	flags.Var((*list)(&filter.Layers), "layer", "layers to test, e.g. infrastructure; repeatable")
+	flags.Var((*list)(&filter.Modules), "module", "modules to test, e.g. mysql-*; repeatable")
+	flags.Var((*list)(&filter.Tiers), "tier", "tiers to test: unit, plan or integration; repeatable (default unit)")
+	flags.Var((*list)(&filter.Tags), "tag", "registry tags the module must carry; repeatable")
+	root := flags.String("root", "", "repository root (default: the directory above holding "+suite.RegistryFile+")")
+	concurrency := flags.Int("concurrency", suite.DefaultConcurrency, "number of suites run at once")
+	timeout := flags.Duration("timeout", 0, "go test timeout of every suite (default per tier)")
+	reportDir := flags.String("report", "", "directory to write JUnit XML and JSON reports of every module to")
+	listOnly := flags.Bool("list", false, "list the selected suites without running them")
+	verbose := flags.Bool("v", false, "print the output of every suite, not only of failed ones")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if len(filter.Tiers) == 0 {
+		filter.Tiers = []string{string(suite.TierUnit)}
+	}
+	if err := filter.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-test:", err)
+		return 2
+	}
+
+	if *root == "" {
+		dir, err := suite.FindRoot(".")
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-test:", err)
+			return 2
+		}
+		*root = dir
+	}
+	suites, err := suite.Discover(*root)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "zrr-test:", err)
+		return 2
+	}
+	suites = filter.Select(suites)
+	if len(suites) == 0 {
+		fmt.Fprintln(os.Stderr, "zrr-test: no suites match")
+		return 2
+	}
+
+	if *listOnly {
+		for _, s := range suites {
+			fmt.Printf("%-60s %s %s\n", s.Name(), s.Dir, s.Package)
+		}
+		return 0
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var mu sync.Mutex
+	reports := map[string]*report.Suite{}
+	results := suite.Run(ctx, suites, suite.Options{
+		Root:        *root,
+		Concurrency: *concurrency,
+		Timeout:     *timeout,
+		Args:        flags.Args(),
+		JSON:        *reportDir != "",
+		OnStart: func(s suite.Suite) {
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Printf("=== %s\n", s.Name())
+		},
+		OnDone: func(r suite.Result) {
+			mu.Lock()
+			defer mu.Unlock()
+			output := r.Output
+			if *reportDir != "" {
+				parsed, err := report.Parse(bytes.NewReader(r.Output))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "zrr-test: %s: %v\n", r.Suite.Name(), err)
+				} else {
+					reports[r.Suite.Name()] = parsed
+					output = []byte(parsed.Text())
+				}
+			}
+			if !r.Passed || *verbose {
+				fmt.Printf("--- output of %s (go %s)\n", r.Suite.Name(), strings.Join(r.Args, " "))
+				os.Stdout.Write(output)
+			}
+			fmt.Printf("%s %s (%s)\n", status(r), r.Suite.Name(), r.Duration.Round(time.Second/10))
+		},
+	})
+
+	counts := map[string]int{}
+	fmt.Println()
+	for _, r := range results {
+		counts[status(r)]++
+		fmt.Printf("%-6s %-60s %8s\n", status(r), r.Suite.Name(), r.Duration.Round(time.Second/10))
+	}
+	fmt.Printf("%d passed, %d failed, %d not run\n", counts["ok"], counts["FAIL"], counts["notrun"])
+
+	if *reportDir != "" {
+		if err := writeReports(*reportDir, results, reports); err != nil {
+			fmt.Fprintln(os.Stderr, "zrr-test:", err)
+			return 1
+		}
+	}
+	if counts["ok"] < len(results) {
+		return 1
+	}
+	return 0
+}
+
+// status is the summary column of a result. Suites left waiting when the
+// run was interrupted never started.
+func status(r suite.Result) string {
+	switch {
+	case r.Passed:
+		return "ok"
+	case r.Output == nil && r.Duration == 0:
+		return "notrun"
+	default:
+		return "FAIL"
+	}
+}
+
+// writeReports writes the reports of the suites that ran to dir, one JUnit
+// XML and one JSON file per module.
+func writeReports(dir string, results []suite.Result, reports map[string]*report.Suite) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	var modules []*report.Module
+	byName := map[string]*report.Module{}
+	for _, r := range results {
+		parsed := reports[r.Suite.Name()]
+		if parsed == nil {
+			continue
+		}
+		parsed.Name, parsed.Tier = r.Suite.Name(), string(r.Suite.Tier)
+		name := r.Suite.Layer + "-" + r.Suite.Module
+		module := byName[name]
+		if module == nil {
+			module = &report.Module{Layer: r.Suite.Layer, Module: r.Suite.Module}
+			byName[name] = module
+			modules = append(modules, module)
+		}
+		module.Suites = append(module.Suites, parsed)
+	}
+
+	for _, module := range modules {
+		base := filepath.Join(dir, module.Layer+"-"+module.Module)
+		if err := writeFile(base+".xml", module.WriteJUnit); err != nil {
+			return err
+		}
+		if err := writeFile(base+".json", module.WriteJSON); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeFile(name string, write func(io.Writer) error) error {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
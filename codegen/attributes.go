@@ -0,0 +1,74 @@
+package codegen
+
+import "strings"
+
+// resourceAttributes are the types of the attributes of azurerm resources the
+// modules' outputs refer to, where they are not strings. The provider's
+// schema is not at hand when generating, and the same attribute name has the
+// same type across its resources.
+var resourceAttributes = map[string]*valueType{
+	// Bools.
+	"disable_ip_masking":                  scalar(boolean),
+	"enabled":                             scalar(boolean),
+	"force_customer_storage_for_profiler": scalar(boolean),
+	"read_scale":                          scalar(boolean),
+	"zone_redundant":                      scalar(boolean),
+
+	// Numbers.
+	"amount":                       scalar(float),
+	"daily_data_cap_in_gb":         scalar(float),
+	"daily_quota_gb":               scalar(float),
+	"iops":                         scalar(integer),
+	"max_size_gb":                  scalar(float),
+	"maximum_elastic_worker_count": scalar(integer),
+	"min_capacity":                 scalar(float),
+	"priority":                     scalar(integer),
+	"quota":                        scalar(integer),
+	"read_replica_count":           scalar(integer),
+	"replica_capacity":             scalar(integer),
+	"sampling_percentage":          scalar(float),
+	"size_gb":                      scalar(integer),
+	"worker_count":                 scalar(integer),
+
+	// Lists of strings.
+	"address_space":                listOf(scalar(str)),
+	"destination_address_prefixes": listOf(scalar(str)),
+	"destination_port_ranges":      listOf(scalar(str)),
+	"dns_servers":                  listOf(scalar(str)),
+	"name_servers":                 listOf(scalar(str)),
+	"outbound_ip_addresses":        listOf(scalar(str)),
+	"source_address_prefixes":      listOf(scalar(str)),
+	"source_port_ranges":           listOf(scalar(str)),
+	"subnet_ids":                   listOf(scalar(str)),
+	"zones":                        listOf(scalar(str)),
+
+	// Maps of strings.
+	"metadata": mapOfType(scalar(str)),
+	"tags":     mapOfType(scalar(str)),
+
+	// Nested blocks, which are lists of objects whose attributes are looked
+	// up here too.
+	"high_availability":           listOf(scalar(resource)),
+	"identity":                    listOf(scalar(resource)),
+	"maintenance_window":          listOf(scalar(resource)),
+	"network_interface":           listOf(scalar(resource)),
+	"private_service_connection":  listOf(scalar(resource)),
+	"short_term_retention_policy": listOf(scalar(resource)),
+	"storage":                     listOf(scalar(resource)),
+}
+
+// resourceAttribute returns the type of the attribute name of a resource.
+// Attributes not in resourceAttributes are taken for bools if their name
+// says so, and for strings otherwise: ids, names, endpoints, keys and SKUs.
+func resourceAttribute(name string) *valueType {
+	if t, ok := resourceAttributes[name]; ok {
+		return t
+	}
+	switch {
+	case strings.HasSuffix(name, "_enabled"), strings.HasSuffix(name, "_disabled"), strings.HasPrefix(name, "has_"):
+		return scalar(boolean)
+	case strings.HasSuffix(name, "_days"), strings.HasSuffix(name, "_in_hours"), strings.HasSuffix(name, "_in_minutes"), strings.HasSuffix(name, "_count"):
+		return scalar(integer)
+	}
+	return scalar(str)
+}
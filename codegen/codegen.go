@@ -0,0 +1,182 @@
+// Package codegen generates typed Go code from the Terraform of each
+// module, so that tests refer to its variables and outputs through Go
+// identifiers the compiler checks instead of string keys.
+//
+// For every module it writes a package under zrrtest/modules named after
+// the module, e.g. zrrtest/modules/containerinstance, holding an Inputs
+// struct with a field per variable of variables.tf and a struct per object
+// type:
+//
+//	vars := containerinstance.Inputs{
+//		Name:       "aci-test",
+//		Containers: []containerinstance.Container{{Name: "app", Image: "nginx", CPU: 0.5, Memory: 1.5}},
+//	}.Vars()
+//
+// Required variables and object attributes are plain values; optional ones
+// are pointers, slices, maps or interfaces, left out of Vars when nil so the
+// module's defaults apply. Numbers are float64, as Terraform's are.
+//
+// The package also holds an Outputs type with a method per output of
+// outputs.tf, returning its value decoded into the type of its value
+// expression:
+//
+//	outputs := resourcegroup.NewOutputs(t, terraformOptions)
+//	assert.True(t, outputs.IsLocked())
+//	backend := aztfinit.NewOutputs(t, terraformOptions).TerraformBackendConfig()
+//	assert.Equal(t, "terraform.tfstate", backend.Key)
+//
+// The generated files are checked in. Stale reports those that no longer
+// match the modules, and a test of this package fails on them, as does
+// zrr-gen -check.
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+const (
+	// PackageDir holds the generated packages, relative to the repository
+	// root.
+	PackageDir = "zrrtest/modules"
+
+	// InputsFile is the file of a generated package holding Inputs.
+	InputsFile = "inputs_gen.go"
+
+	// OutputsFile is the file of a generated package holding Outputs.
+	OutputsFile = "outputs_gen.go"
+)
+
+// generatedMarker starts the first line of every generated file.
+const generatedMarker = "// Code generated by zrr-gen"
+
+// PackageName returns the name of the package generated for the module at
+// modulePath: its name without hyphens, e.g. containerinstance.
+func PackageName(modulePath string) string {
+	return strings.ReplaceAll(path.Base(modulePath), "-", "")
+}
+
+// PackagePath returns the directory of the package generated for the module
+// at modulePath, relative to the repository root.
+func PackagePath(modulePath string) string {
+	return path.Join(PackageDir, PackageName(modulePath))
+}
+
+// Files returns the generated files of every module under root, by path
+// relative to root.
+func Files(root string) (map[string][]byte, error) {
+	paths, err := registry.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, modulePath := range paths {
+		dir := filepath.Join(root, filepath.FromSlash(modulePath))
+		variables, err := registry.LoadVariables(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", modulePath, err)
+		}
+		inputs, err := Inputs(modulePath, variables)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", modulePath, err)
+		}
+		outputs, err := Outputs(modulePath, dir, variables)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", modulePath, err)
+		}
+		files[path.Join(PackagePath(modulePath), InputsFile)] = inputs
+		files[path.Join(PackagePath(modulePath), OutputsFile)] = outputs
+	}
+	return files, nil
+}
+
+// Write writes the generated files of every module under root and removes
+// the generated files of modules that no longer exist. It returns the paths
+// of the files it changed, sorted.
+func Write(root string) ([]string, error) {
+	stale, err := Stale(root)
+	if err != nil {
+		return nil, err
+	}
+	files, err := Files(root)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range stale {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		content, ok := files[name]
+		if !ok {
+			if err := os.Remove(file); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(file, content, 0o644); err != nil {
+			return nil, err
+		}
+	}
+	return stale, nil
+}
+
+// Stale returns the paths, relative to root, of the generated files that are
+// missing, out of date, or left from a module that no longer exists, sorted.
+func Stale(root string) ([]string, error) {
+	files, err := Files(root)
+	if err != nil {
+		return nil, err
+	}
+	var stale []string
+	for name, content := range files {
+		existing, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if !bytes.Equal(existing, content) {
+			stale = append(stale, name)
+		}
+	}
+
+	onDisk, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(PackageDir), "*", "*_gen.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range onDisk {
+		name, err := filepath.Rel(root, file)
+		if err != nil {
+			return nil, err
+		}
+		name = filepath.ToSlash(name)
+		if _, ok := files[name]; ok {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.HasPrefix(content, []byte(generatedMarker)) {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
+// formatSource gofmts the source of a generated file.
+func formatSource(source []byte) ([]byte, error) {
+	formatted, err := format.Source(source)
+	if err != nil {
+		return nil, fmt.Errorf("generated code does not parse: %w\n%s", err, source)
+	}
+	return formatted, nil
+}
@@ -0,0 +1,118 @@
+package codegen
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files under testdata instead of comparing against them")
+
+// TestInputsGolden compares the Inputs generated from testdata/variables.tf
+// with testdata/inputs_gen.go.golden. Run with -update to accept a change.
+func TestInputsGolden(t *testing.T) {
+	variables, err := registry.LoadVariables("testdata")
+	require.NoError(t, err)
+	source, err := Inputs("azure/application/container-instance", variables)
+	require.NoError(t, err)
+
+	const golden = "testdata/inputs_gen.go.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, source, 0o644))
+		return
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(source), "the generated Inputs differ from %s; run the test with -update to accept the change", golden)
+}
+
+// TestOutputsGolden compares the Outputs generated from testdata/outputs.tf
+// with testdata/outputs_gen.go.golden. Run with -update to accept a change.
+func TestOutputsGolden(t *testing.T) {
+	variables, err := registry.LoadVariables("testdata")
+	require.NoError(t, err)
+	source, err := Outputs("azure/application/container-instance", "testdata", variables)
+	require.NoError(t, err)
+
+	const golden = "testdata/outputs_gen.go.golden"
+	if *update {
+		require.NoError(t, os.WriteFile(golden, source, 0o644))
+		return
+	}
+	expected, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(source), "the generated Outputs differ from %s; run the test with -update to accept the change", golden)
+}
+
+func TestResourceAttribute(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]kind{
+		"id":                            str,
+		"primary_blob_endpoint":         str,
+		"public_network_access_enabled": boolean,
+		"has_legal_hold":                boolean,
+		"retention_in_days":             integer,
+		"sampling_percentage":           float,
+		"address_space":                 list,
+		"tags":                          mapOf,
+	} {
+		assert.Equal(t, want, resourceAttribute(name).kind, name)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]string{
+		"name":                       "Name",
+		"dns_name_label":             "DNSNameLabel",
+		"virtual_network_subnet_ids": "VirtualNetworkSubnetIDs",
+		"mysql_version":              "MySQLVersion",
+		"ip_rules":                   "IPRules",
+		"2fa":                        "V2fa",
+	} {
+		assert.Equal(t, want, goName(name), name)
+	}
+}
+
+func TestSingular(t *testing.T) {
+	t.Parallel()
+
+	for name, want := range map[string]string{
+		"Containers":      "Container",
+		"AccessPolicies":  "AccessPolicy",
+		"NetworkACLs":     "NetworkACL",
+		"Addresses":       "Address",
+		"Boxes":           "Box",
+		"NetworkAccess":   "NetworkAccess",
+		"SecuritySetting": "SecuritySetting",
+	} {
+		assert.Equal(t, want, singular(name), name)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "containerinstance", PackageName("azure/application/container-instance"))
+	assert.Equal(t, "zrrtest/modules/containerinstance", PackagePath("azure/application/container-instance"))
+}
+
+// TestRepositoryGenerated checks the generated packages of this repository
+// match its modules, so a variable changed without running zrr-gen fails go
+// test at the root as well as zrr-gen -check.
+func TestRepositoryGenerated(t *testing.T) {
+	t.Parallel()
+
+	stale, err := Stale("..")
+	require.NoError(t, err)
+	for _, name := range stale {
+		t.Errorf("%s is out of date; run go run ./cmd/zrr-gen", name)
+	}
+}
@@ -0,0 +1,527 @@
+package codegen
+
+import (
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// kind is the kind of value an expression evaluates to.
+type kind int
+
+const (
+	unknown kind = iota
+	str
+	boolean
+	integer
+	float
+	list
+	mapOf
+	object
+	// resource is an instance of a resource, whose attributes are looked up
+	// in resourceAttributes.
+	resource
+	// null is the type of the null literal, which unifies with anything.
+	null
+	// nothing is the type of the elements of an empty collection, which
+	// unifies with anything too.
+	nothing
+)
+
+// valueType is the type inferred for an expression. Terraform does not
+// declare the types of outputs, so they are worked out from their value
+// expressions.
+type valueType struct {
+	kind kind
+
+	// elem is the type of the elements of a list or map.
+	elem *valueType
+
+	// attributes are the attributes of an object.
+	attributes map[string]*valueType
+
+	// nullable reports whether the value may be null.
+	nullable bool
+}
+
+var unknownType = &valueType{kind: unknown}
+
+func scalar(k kind) *valueType { return &valueType{kind: k} }
+
+func listOf(elem *valueType) *valueType { return &valueType{kind: list, elem: elem} }
+
+func mapOfType(elem *valueType) *valueType { return &valueType{kind: mapOf, elem: elem} }
+
+// orNull returns t as a nullable type.
+func (t *valueType) orNull() *valueType {
+	if t.kind == unknown || t.nullable {
+		return t
+	}
+	nullable := *t
+	nullable.nullable = true
+	return &nullable
+}
+
+// element returns the type of the elements of t.
+func (t *valueType) element() *valueType {
+	switch t.kind {
+	case list, mapOf:
+		return t.elem
+	case object:
+		var elem *valueType
+		for _, attribute := range t.attributes {
+			elem = unify(elem, attribute)
+		}
+		if elem == nil {
+			return scalar(nothing)
+		}
+		return elem
+	case resource:
+		return t
+	}
+	return unknownType
+}
+
+// attribute returns the type of the attribute name of values of t.
+func (t *valueType) attribute(name string) *valueType {
+	switch t.kind {
+	case object:
+		if attribute, ok := t.attributes[name]; ok {
+			return attribute
+		}
+	case mapOf:
+		return t.elem
+	case resource:
+		return resourceAttribute(name)
+	}
+	return unknownType
+}
+
+// unify returns the type of a value that is either of type a or of type b,
+// unknown if they do not agree. A nil type is no type yet.
+func unify(a, b *valueType) *valueType {
+	switch {
+	case a == nil || a.kind == nothing:
+		return b
+	case b == nil || b.kind == nothing:
+		return a
+	case a.kind == null:
+		return b.orNull()
+	case b.kind == null:
+		return a.orNull()
+	}
+	nullable := a.nullable || b.nullable
+	withNull := func(t *valueType) *valueType {
+		if nullable {
+			return t.orNull()
+		}
+		return t
+	}
+	switch {
+	case a.kind == unknown || b.kind == unknown:
+		return unknownType
+	case a.kind == b.kind && (a.kind == list || a.kind == mapOf):
+		return withNull(&valueType{kind: a.kind, elem: unify(a.elem, b.elem)})
+	case a.kind == object && b.kind == object:
+		attributes := map[string]*valueType{}
+		for name, attribute := range a.attributes {
+			attributes[name] = attribute
+		}
+		for name, attribute := range b.attributes {
+			attributes[name] = unify(attributes[name], attribute)
+		}
+		return withNull(&valueType{kind: object, attributes: attributes})
+	case a.kind == mapOf && b.kind == object, a.kind == object && b.kind == mapOf:
+		return withNull(mapOfType(unify(a.element(), b.element())))
+	case a.kind == b.kind:
+		return withNull(a)
+	case (a.kind == integer || a.kind == float) && (b.kind == integer || b.kind == float):
+		return withNull(scalar(float))
+	}
+	return unknownType
+}
+
+// fromCty returns the type of values of the cty type t. Optional attributes
+// of objects are nullable.
+func fromCty(t cty.Type) *valueType {
+	switch {
+	case t == cty.String:
+		return scalar(str)
+	case t == cty.Bool:
+		return scalar(boolean)
+	case t == cty.Number:
+		return scalar(float)
+	case t.IsListType(), t.IsSetType():
+		return listOf(fromCty(t.ElementType()))
+	case t.IsMapType():
+		return mapOfType(fromCty(t.ElementType()))
+	case t.IsObjectType():
+		attributes := map[string]*valueType{}
+		for name, attribute := range t.AttributeTypes() {
+			attributes[name] = fromCty(attribute)
+			if t.AttributeOptional(name) {
+				attributes[name] = attributes[name].orNull()
+			}
+		}
+		return &valueType{kind: object, attributes: attributes}
+	}
+	return unknownType
+}
+
+// inferrer works out the types of the expressions of a module.
+type inferrer struct {
+	variables map[string]*valueType
+	locals    map[string]hclsyntax.Expression
+
+	// scope holds the types of the symbols of the for and splat expressions
+	// being inferred.
+	scope map[string]*valueType
+	items map[*hclsyntax.AnonSymbolExpr]*valueType
+
+	// visiting holds the locals being inferred, to stop at cycles.
+	visiting map[string]bool
+}
+
+func newInferrer(variables map[string]*valueType, locals map[string]hclsyntax.Expression) *inferrer {
+	return &inferrer{
+		variables: variables,
+		locals:    locals,
+		scope:     map[string]*valueType{},
+		items:     map[*hclsyntax.AnonSymbolExpr]*valueType{},
+		visiting:  map[string]bool{},
+	}
+}
+
+// infer returns the type of the value of expr.
+func (in *inferrer) infer(expr hclsyntax.Expression) *valueType {
+	switch e := expr.(type) {
+	case *hclsyntax.LiteralValueExpr:
+		return literal(e.Val)
+	case *hclsyntax.TemplateExpr:
+		return scalar(str)
+	case *hclsyntax.TemplateWrapExpr:
+		return in.infer(e.Wrapped)
+	case *hclsyntax.ParenthesesExpr:
+		return in.infer(e.Expression)
+	case *hclsyntax.ScopeTraversalExpr:
+		return in.traversal(e.Traversal)
+	case *hclsyntax.RelativeTraversalExpr:
+		return traverse(in.infer(e.Source), e.Traversal)
+	case *hclsyntax.IndexExpr:
+		return in.infer(e.Collection).element()
+	case *hclsyntax.SplatExpr:
+		in.items[e.Item] = in.infer(e.Source).element()
+		defer delete(in.items, e.Item)
+		return listOf(in.infer(e.Each))
+	case *hclsyntax.AnonSymbolExpr:
+		if item, ok := in.items[e]; ok {
+			return item
+		}
+		return unknownType
+	case *hclsyntax.ConditionalExpr:
+		return unify(in.infer(e.TrueResult), in.infer(e.FalseResult))
+	case *hclsyntax.UnaryOpExpr:
+		if e.Op == hclsyntax.OpLogicalNot {
+			return scalar(boolean)
+		}
+		return in.infer(e.Val)
+	case *hclsyntax.BinaryOpExpr:
+		return in.binary(e)
+	case *hclsyntax.TupleConsExpr:
+		var elem *valueType
+		for _, item := range e.Exprs {
+			elem = unify(elem, in.infer(item))
+		}
+		if elem == nil {
+			elem = scalar(nothing)
+		}
+		return listOf(elem)
+	case *hclsyntax.ObjectConsExpr:
+		return in.object(e)
+	case *hclsyntax.ForExpr:
+		return in.forExpr(e)
+	case *hclsyntax.FunctionCallExpr:
+		return in.call(e)
+	}
+	return unknownType
+}
+
+func literal(value cty.Value) *valueType {
+	switch {
+	case value.IsNull():
+		return scalar(null)
+	case value.Type() == cty.String:
+		return scalar(str)
+	case value.Type() == cty.Bool:
+		return scalar(boolean)
+	case value.Type() == cty.Number:
+		if value.AsBigFloat().IsInt() {
+			return scalar(integer)
+		}
+		return scalar(float)
+	}
+	return unknownType
+}
+
+// traversal returns the type of the value a reference refers to.
+func (in *inferrer) traversal(traversal hcl.Traversal) *valueType {
+	root := traversal.RootName()
+	rest := traversal[1:]
+	if symbol, ok := in.scope[root]; ok {
+		return traverse(symbol, rest)
+	}
+	attribute := func() (string, hcl.Traversal) {
+		if len(rest) == 0 {
+			return "", nil
+		}
+		if step, ok := rest[0].(hcl.TraverseAttr); ok {
+			return step.Name, rest[1:]
+		}
+		return "", nil
+	}
+	switch root {
+	case "var":
+		name, rest := attribute()
+		if variable, ok := in.variables[name]; ok {
+			return traverse(variable, rest)
+		}
+		return unknownType
+	case "local":
+		name, rest := attribute()
+		expr, ok := in.locals[name]
+		if !ok || in.visiting[name] {
+			return unknownType
+		}
+		in.visiting[name] = true
+		defer delete(in.visiting, name)
+		return traverse(in.infer(expr), rest)
+	case "each":
+		name, _ := attribute()
+		if name == "key" {
+			return scalar(str)
+		}
+		return unknownType
+	case "count":
+		return scalar(integer)
+	case "path", "terraform":
+		return scalar(str)
+	case "data":
+		// data.<type>.<name> is read like a resource.
+		if len(rest) < 1 {
+			return unknownType
+		}
+		rest = rest[1:]
+	case "module":
+		return unknownType
+	}
+
+	// <type>.<name>, then an index for a resource with count or for_each,
+	// then the attribute.
+	if len(rest) == 0 {
+		return unknownType
+	}
+	rest = rest[1:]
+	instances := &valueType{kind: resource}
+	if len(rest) == 0 {
+		// All of the instances, or the one of a resource without count.
+		return instances
+	}
+	return traverse(instances, rest)
+}
+
+// traverse returns the type of the value steps lead to from a value of type
+// t.
+func traverse(t *valueType, steps hcl.Traversal) *valueType {
+	for _, step := range steps {
+		switch s := step.(type) {
+		case hcl.TraverseAttr:
+			t = t.attribute(s.Name)
+		case hcl.TraverseIndex:
+			if s.Key.Type() == cty.String && t.kind == object {
+				t = t.attribute(s.Key.AsString())
+				continue
+			}
+			t = t.element()
+		case hcl.TraverseSplat:
+			return unknownType
+		}
+	}
+	return t
+}
+
+func (in *inferrer) binary(e *hclsyntax.BinaryOpExpr) *valueType {
+	switch e.Op {
+	case hclsyntax.OpEqual, hclsyntax.OpNotEqual, hclsyntax.OpGreaterThan, hclsyntax.OpGreaterThanOrEqual,
+		hclsyntax.OpLessThan, hclsyntax.OpLessThanOrEqual, hclsyntax.OpLogicalAnd, hclsyntax.OpLogicalOr:
+		return scalar(boolean)
+	case hclsyntax.OpDivide:
+		return scalar(float)
+	}
+	if in.infer(e.LHS).kind == integer && in.infer(e.RHS).kind == integer {
+		return scalar(integer)
+	}
+	return scalar(float)
+}
+
+func (in *inferrer) object(e *hclsyntax.ObjectConsExpr) *valueType {
+	attributes := map[string]*valueType{}
+	for _, item := range e.Items {
+		name := hcl.ExprAsKeyword(item.KeyExpr)
+		if name == "" {
+			key, diags := item.KeyExpr.Value(nil)
+			if diags.HasErrors() || key.Type() != cty.String || key.IsNull() {
+				// Keys worked out at apply time make a map.
+				var elem *valueType
+				for _, item := range e.Items {
+					elem = unify(elem, in.infer(item.ValueExpr))
+				}
+				return mapOfType(elem)
+			}
+			name = key.AsString()
+		}
+		attributes[name] = in.infer(item.ValueExpr)
+	}
+	return &valueType{kind: object, attributes: attributes}
+}
+
+func (in *inferrer) forExpr(e *hclsyntax.ForExpr) *valueType {
+	collection := in.infer(e.CollExpr)
+	key := unknownType
+	switch collection.kind {
+	case list:
+		key = scalar(integer)
+	case mapOf, object:
+		key = scalar(str)
+	}
+	saved := map[string]*valueType{}
+	for _, name := range []string{e.KeyVar, e.ValVar} {
+		if symbol, ok := in.scope[name]; ok {
+			saved[name] = symbol
+		}
+	}
+	defer func() {
+		delete(in.scope, e.KeyVar)
+		delete(in.scope, e.ValVar)
+		for name, symbol := range saved {
+			in.scope[name] = symbol
+		}
+	}()
+	if e.KeyVar != "" {
+		in.scope[e.KeyVar] = key
+	}
+	in.scope[e.ValVar] = collection.element()
+
+	value := in.infer(e.ValExpr)
+	if e.Group {
+		value = listOf(value)
+	}
+	if e.KeyExpr != nil {
+		return mapOfType(value)
+	}
+	return listOf(value)
+}
+
+// stringFunctions return strings, whatever their arguments.
+var stringFunctions = stringSet(
+	"base64decode", "base64encode", "base64sha256", "chomp", "format", "formatdate", "indent", "join",
+	"jsonencode", "lower", "md5", "replace", "sha1", "sha256", "substr", "timeadd", "timestamp", "title",
+	"tostring", "trim", "trimprefix", "trimspace", "trimsuffix", "upper", "urlencode", "uuid", "yamlencode",
+)
+
+// boolFunctions return bools.
+var boolFunctions = stringSet("alltrue", "anytrue", "can", "contains", "endswith", "startswith", "tobool")
+
+// elementFunctions return the type of their first argument.
+var elementFunctions = stringSet("coalesce", "try")
+
+// listFunctions return a list of the elements of their first argument.
+var listFunctions = stringSet("compact", "concat", "distinct", "reverse", "setintersection", "setunion", "slice", "sort", "tolist", "toset")
+
+func (in *inferrer) call(e *hclsyntax.FunctionCallExpr) *valueType {
+	argument := func(i int) *valueType {
+		if i >= len(e.Args) {
+			return unknownType
+		}
+		return in.infer(e.Args[i])
+	}
+	switch {
+	case e.Name == "length":
+		return scalar(integer)
+	case stringFunctions[e.Name]:
+		return scalar(str)
+	case boolFunctions[e.Name]:
+		return scalar(boolean)
+	case elementFunctions[e.Name]:
+		var t *valueType
+		for i := range e.Args {
+			t = unify(t, argument(i))
+		}
+		if t == nil {
+			return unknownType
+		}
+		return t
+	case listFunctions[e.Name]:
+		t := argument(0)
+		if t.kind == list {
+			return listOf(t.elem)
+		}
+		return listOf(unknownType)
+	case e.Name == "split", e.Name == "keys":
+		return listOf(scalar(str))
+	case e.Name == "values":
+		return listOf(argument(0).element())
+	case e.Name == "lookup":
+		return argument(0).element()
+	case e.Name == "element":
+		return argument(0).element()
+	case e.Name == "one":
+		return argument(0).element().orNull()
+	case e.Name == "tonumber":
+		return scalar(float)
+	case e.Name == "abs", e.Name == "ceil", e.Name == "floor", e.Name == "max", e.Name == "min", e.Name == "sum":
+		if e.Name == "ceil" || e.Name == "floor" {
+			return scalar(integer)
+		}
+		var t *valueType
+		for i := range e.Args {
+			t = unify(t, argument(i))
+		}
+		if t != nil && t.kind == integer {
+			return scalar(integer)
+		}
+		return scalar(float)
+	case e.Name == "tomap", e.Name == "merge":
+		var t *valueType
+		for i := range e.Args {
+			t = unify(t, argument(i))
+		}
+		if t == nil || t.kind == unknown {
+			return mapOfType(unknownType)
+		}
+		return t
+	case e.Name == "zipmap":
+		return mapOfType(argument(1).element())
+	}
+	return unknownType
+}
+
+func stringSet(names ...string) map[string]bool {
+	set := map[string]bool{}
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// sortedAttributes returns the names of the attributes of an object type,
+// sorted.
+func (t *valueType) sortedAttributes() []string {
+	var names []string
+	for name := range t.attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
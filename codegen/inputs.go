@@ -0,0 +1,216 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+)
+
+// Inputs returns the source of the Inputs file of the module at modulePath,
+// whose variables are variables.
+func Inputs(modulePath string, variables []registry.Variable) ([]byte, error) {
+	g := &generator{names: map[string]bool{"Inputs": true}}
+
+	inputs := structType{
+		name: "Inputs",
+		doc: fmt.Sprintf("Inputs are the variables of %s. Required variables are values; optional\n"+
+			"// ones are nil unless set, so that the module's defaults apply.", modulePath),
+	}
+	for _, variable := range variables {
+		optional := variable.Default != cty.NilVal
+		inputs.fields = append(inputs.fields, field{
+			name: goName(variable.Name),
+			tag:  variable.Name,
+			doc:  sentence(variable.Description),
+			typ:  g.goType(variable.Type, goName(variable.Name), optional),
+		})
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s from %s/variables.tf. DO NOT EDIT.\n\n", generatedMarker, modulePath)
+	fmt.Fprintf(&buf, "// Package %s holds the typed inputs and outputs of %s, for its tests.\n", PackageName(modulePath), modulePath)
+	fmt.Fprintf(&buf, "package %s\n\n", PackageName(modulePath))
+	fmt.Fprintf(&buf, "import \"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars\"\n\n")
+	inputs.write(&buf)
+	fmt.Fprintf(&buf, "// Vars returns the inputs as terraform.Options.Vars.\n")
+	fmt.Fprintf(&buf, "func (in Inputs) Vars() map[string]interface{} {\n\treturn tfvars.Vars(in)\n}\n")
+	for _, s := range g.types {
+		buf.WriteString("\n")
+		s.write(&buf)
+	}
+	return formatSource(buf.Bytes())
+}
+
+// generator collects the struct types of the object types it meets.
+type generator struct {
+	types []structType
+	names map[string]bool
+}
+
+type structType struct {
+	name   string
+	doc    string
+	fields []field
+
+	// tag is the key of the struct tags of the fields, tfvars.Tag unless
+	// set.
+	tag string
+}
+
+type field struct {
+	name, tag, doc, typ string
+}
+
+func (s structType) write(buf *bytes.Buffer) {
+	tag := s.tag
+	if tag == "" {
+		tag = tfvars.Tag
+	}
+	fmt.Fprintf(buf, "// %s\n", s.doc)
+	fmt.Fprintf(buf, "type %s struct {\n", s.name)
+	for i, f := range s.fields {
+		if f.doc != "" {
+			if i > 0 {
+				buf.WriteString("\n")
+			}
+			fmt.Fprintf(buf, "\t// %s\n", f.doc)
+		}
+		fmt.Fprintf(buf, "\t%s %s `%s:%q`\n", f.name, f.typ, tag, f.tag)
+	}
+	buf.WriteString("}\n")
+}
+
+// goType returns the Go type of values of t. Object types become struct
+// types called name, or name with a number if it is taken. Optional
+// strings, numbers, bools and objects are pointers.
+func (g *generator) goType(t cty.Type, name string, optional bool) string {
+	pointer := ""
+	if optional {
+		pointer = "*"
+	}
+	switch {
+	case t == cty.DynamicPseudoType:
+		return "interface{}"
+	case t == cty.String:
+		return pointer + "string"
+	case t == cty.Number:
+		return pointer + "float64"
+	case t == cty.Bool:
+		return pointer + "bool"
+	case t.IsListType(), t.IsSetType():
+		return "[]" + g.goType(t.ElementType(), singular(name), false)
+	case t.IsMapType():
+		return "map[string]" + g.goType(t.ElementType(), singular(name), false)
+	case t.IsObjectType():
+		return pointer + g.object(t, name)
+	}
+	// Tuples hold values of different types.
+	return "[]interface{}"
+}
+
+// object adds the struct type of the object type t and returns its name.
+func (g *generator) object(t cty.Type, name string) string {
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	g.names[unique] = true
+
+	// The struct is added before those of its attributes, so the types read
+	// from the outside in.
+	index := len(g.types)
+	g.types = append(g.types, structType{})
+	s := structType{name: unique, doc: fmt.Sprintf("%s is an object of the module's inputs.", unique)}
+	attributes := t.AttributeTypes()
+	var names []string
+	for attribute := range attributes {
+		names = append(names, attribute)
+	}
+	sort.Strings(names)
+	for _, attribute := range names {
+		s.fields = append(s.fields, field{
+			name: goName(attribute),
+			tag:  attribute,
+			typ:  g.goType(attributes[attribute], unique+goName(attribute), t.AttributeOptional(attribute)),
+		})
+	}
+	g.types[index] = s
+	return unique
+}
+
+// initialisms are the words of Terraform names Go spells in capitals.
+var initialisms = map[string]string{
+	"acl": "ACL", "acls": "ACLs", "api": "API", "cidr": "CIDR", "cidrs": "CIDRs", "cpu": "CPU",
+	"db": "DB", "dns": "DNS", "fqdn": "FQDN", "gb": "GB", "gpu": "GPU", "hcl": "HCL", "http": "HTTP",
+	"https": "HTTPS", "id": "ID", "ids": "IDs", "iops": "IOPS", "ip": "IP", "ips": "IPs",
+	"json": "JSON", "mysql": "MySQL", "os": "OS", "rbac": "RBAC", "sku": "SKU", "sql": "SQL",
+	"ssh": "SSH", "ssl": "SSL", "tcp": "TCP", "tls": "TLS", "ttl": "TTL", "udp": "UDP",
+	"uri": "URI", "urn": "URN", "url": "URL", "uuid": "UUID", "vm": "VM",
+}
+
+// goName returns the exported Go name of a Terraform name, e.g.
+// DNSNameLabel for dns_name_label.
+func goName(name string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' }) {
+		if initialism, ok := initialisms[word]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	if b.Len() == 0 || !isLetter(b.String()[0]) {
+		return "V" + b.String()
+	}
+	return b.String()
+}
+
+func isLetter(c byte) bool {
+	return c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z'
+}
+
+// singular returns the Go name of an element of the collection called
+// name: Containers holds Container values.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ss"):
+		return name
+	case strings.HasSuffix(name, "s"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
+// comment returns text as a // comment, wrapped at 80 columns.
+func comment(text string) string {
+	var b strings.Builder
+	line := "//"
+	for _, word := range strings.Fields(text) {
+		if len(line)+1+len(word) > 80 && line != "//" {
+			b.WriteString(line + "\n")
+			line = "//"
+		}
+		line += " " + word
+	}
+	b.WriteString(line + "\n")
+	return b.String()
+}
+
+// sentence returns a description as a sentence for a doc comment.
+func sentence(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" || strings.HasSuffix(description, ".") {
+		return description
+	}
+	return description + "."
+}
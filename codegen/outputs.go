@@ -0,0 +1,201 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+// output is an output block of a module.
+type output struct {
+	name        string
+	description string
+	sensitive   bool
+	value       hclsyntax.Expression
+}
+
+var moduleSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "output", LabelNames: []string{"name"}},
+		{Type: "locals"},
+	},
+}
+
+var outputBodySchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "value", Required: true}, {Name: "description"}, {Name: "sensitive"},
+		{Name: "depends_on"}, {Name: "ephemeral"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{{Type: "precondition"}},
+}
+
+// loadOutputs parses the outputs and locals of the .tf files of the module in
+// dir. Outputs are sorted by name.
+func loadOutputs(dir string) ([]output, map[string]hclsyntax.Expression, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(files)
+	parser := hclparse.NewParser()
+	var (
+		outputs []output
+		locals  = map[string]hclsyntax.Expression{}
+		diags   hcl.Diagnostics
+	)
+	for _, name := range files {
+		file, fileDiags := parser.ParseHCLFile(name)
+		diags = append(diags, fileDiags...)
+		if fileDiags.HasErrors() {
+			continue
+		}
+		content, _, contentDiags := file.Body.PartialContent(moduleSchema)
+		diags = append(diags, contentDiags...)
+		for _, block := range content.Blocks {
+			if block.Type == "locals" {
+				attributes, attributeDiags := block.Body.JustAttributes()
+				diags = append(diags, attributeDiags...)
+				for name, attribute := range attributes {
+					locals[name] = attribute.Expr.(hclsyntax.Expression)
+				}
+				continue
+			}
+			body, bodyDiags := block.Body.Content(outputBodySchema)
+			diags = append(diags, bodyDiags...)
+			if bodyDiags.HasErrors() {
+				continue
+			}
+			o := output{name: block.Labels[0], value: body.Attributes["value"].Expr.(hclsyntax.Expression)}
+			if attribute, ok := body.Attributes["description"]; ok {
+				if value, valueDiags := attribute.Expr.Value(nil); !valueDiags.HasErrors() && value.Type() == cty.String && !value.IsNull() {
+					o.description = value.AsString()
+				}
+			}
+			if attribute, ok := body.Attributes["sensitive"]; ok {
+				if value, valueDiags := attribute.Expr.Value(nil); !valueDiags.HasErrors() && value.Type() == cty.Bool && !value.IsNull() {
+					o.sensitive = value.True()
+				}
+			}
+			outputs = append(outputs, o)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, nil, diags
+	}
+	sort.Slice(outputs, func(i, j int) bool { return outputs[i].name < outputs[j].name })
+	return outputs, locals, nil
+}
+
+// Outputs returns the source of the Outputs file of the module at
+// modulePath, in dir, whose variables are variables.
+//
+// Outputs have no declared type, so each is given the type of its value
+// expression: literals, variables and locals have theirs, functions and
+// operators give theirs, and resource attributes have the types in
+// resourceAttributes. Numbers are int when they are whole, as counts and
+// lengths are. Where the type cannot be worked out the value is an
+// interface{}.
+func Outputs(modulePath, dir string, variables []registry.Variable) ([]byte, error) {
+	outputs, locals, err := loadOutputs(dir)
+	if err != nil {
+		return nil, err
+	}
+	variableTypes := map[string]*valueType{}
+	for _, variable := range variables {
+		t := fromCty(variable.Type)
+		if variable.Default != cty.NilVal && variable.Default.IsNull() {
+			t = t.orNull()
+		}
+		variableTypes[variable.Name] = t
+	}
+	in := newInferrer(variableTypes, locals)
+	g := &generator{names: map[string]bool{}}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s from %s/outputs.tf. DO NOT EDIT.\n\n", generatedMarker, modulePath)
+	fmt.Fprintf(&buf, "package %s\n\n", PackageName(modulePath))
+	fmt.Fprintf(&buf, "import (\n\t\"github.com/gruntwork-io/terratest/modules/terraform\"\n\t\"github.com/gruntwork-io/terratest/modules/testing\"\n)\n\n")
+	fmt.Fprintf(&buf, "// Outputs reads the outputs of %s once applied, by name.\n", modulePath)
+	fmt.Fprintf(&buf, "type Outputs struct {\n\tt       testing.TestingT\n\toptions *terraform.Options\n}\n\n")
+	fmt.Fprintf(&buf, "// NewOutputs returns the outputs of the module applied with options.\n")
+	fmt.Fprintf(&buf, "func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {\n\treturn Outputs{t: t, options: options}\n}\n")
+	for _, o := range outputs {
+		method := goName(o.name)
+		typ := g.outputType(in.infer(o.value), method)
+		buf.WriteString("\n")
+		doc := fmt.Sprintf("%s returns the %s output", method, o.name)
+		if description := sentence(o.description); description != "" {
+			doc += ": " + description
+		} else {
+			doc += "."
+		}
+		fmt.Fprintf(&buf, "%s", comment(doc))
+		if o.sensitive {
+			fmt.Fprintf(&buf, "//\n// The output is sensitive.\n")
+		}
+		fmt.Fprintf(&buf, "func (o Outputs) %s() %s {\n", method, typ)
+		fmt.Fprintf(&buf, "\tvar value %s\n", typ)
+		fmt.Fprintf(&buf, "\tterraform.OutputStruct(o.t, o.options, %q, &value)\n", o.name)
+		fmt.Fprintf(&buf, "\treturn value\n}\n")
+	}
+	for _, s := range g.types {
+		buf.WriteString("\n")
+		s.write(&buf)
+	}
+	return formatSource(buf.Bytes())
+}
+
+// outputType returns the Go type of values of t. Objects become struct types
+// called name followed by Output, whose fields are tagged for encoding/json.
+func (g *generator) outputType(t *valueType, name string) string {
+	pointer := ""
+	if t.nullable {
+		pointer = "*"
+	}
+	switch t.kind {
+	case str:
+		return pointer + "string"
+	case boolean:
+		return pointer + "bool"
+	case integer:
+		return pointer + "int"
+	case float:
+		return pointer + "float64"
+	case list:
+		return "[]" + g.outputType(t.elem, singular(name))
+	case mapOf:
+		return "map[string]" + g.outputType(t.elem, singular(name))
+	case object:
+		return pointer + g.outputObject(t, name)
+	}
+	return "interface{}"
+}
+
+func (g *generator) outputObject(t *valueType, name string) string {
+	unique := name + "Output"
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s%dOutput", name, i)
+	}
+	g.names[unique] = true
+
+	index := len(g.types)
+	g.types = append(g.types, structType{})
+	s := structType{name: unique, doc: fmt.Sprintf("%s is an object of the module's outputs.", unique), tag: "json"}
+	for _, attribute := range t.sortedAttributes() {
+		s.fields = append(s.fields, field{
+			name: goName(attribute),
+			tag:  attribute,
+			typ:  g.outputType(t.attributes[attribute], name+goName(attribute)),
+		})
+	}
+	g.types[index] = s
+	return unique
+}
@@ -0,0 +1,16 @@
+locals {
+  exposed_ports = var.dns_name_label != null ? [for c in var.containers : c.ports] : []
+  summary = {
+    enabled   = var.enabled
+    threshold = var.cpu_threshold
+  }
+}
+
+resource "azurerm_container_group" "main" {
+  name = var.name
+}
+
+resource "azurerm_container_group" "replica" {
+  count = 2
+  name  = "${var.name}-${count.index}"
+}
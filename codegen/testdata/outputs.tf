@@ -0,0 +1,67 @@
+output "id" {
+  description = "ID of the container group"
+  value       = azurerm_container_group.main.id
+}
+
+output "ip_address" {
+  value = var.dns_name_label != null ? azurerm_container_group.main.ip_address : null
+}
+
+output "fqdn" {
+  description = "FQDN of the container group"
+  value       = try(azurerm_container_group.main.fqdn, null)
+  sensitive   = true
+}
+
+output "tags" {
+  description = "Tags of the container group"
+  value       = azurerm_container_group.main.tags
+}
+
+output "replica_count" {
+  description = "Number of replicas"
+  value       = length(azurerm_container_group.replica)
+}
+
+output "replica_ids" {
+  description = "IDs of the replicas"
+  value       = azurerm_container_group.replica[*].id
+}
+
+output "has_identity" {
+  description = "Whether the group has an identity"
+  value       = var.identity != null
+}
+
+output "identity_type" {
+  description = "Type of the identity"
+  value       = var.identity != null ? var.identity.type : null
+}
+
+output "container_images" {
+  description = "Images of the containers, by name"
+  value       = { for c in var.containers : c.name => c.image }
+}
+
+output "exposed_ports" {
+  description = "Ports exposed to the public"
+  value       = local.exposed_ports
+}
+
+output "summary" {
+  description = "Summary of the configuration"
+  value = merge(local.summary, {
+    name     = var.name
+    replicas = 2
+  })
+}
+
+output "half_threshold" {
+  description = "Half the CPU threshold"
+  value       = var.cpu_threshold / 2
+}
+
+output "settings" {
+  description = "Arbitrary settings"
+  value       = var.settings
+}
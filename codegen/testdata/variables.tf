@@ -0,0 +1,66 @@
+variable "name" {
+  description = "Name of the container group"
+  type        = string
+}
+
+variable "dns_name_label" {
+  description = "DNS name label of the container group."
+  type        = string
+  default     = null
+}
+
+variable "cpu_threshold" {
+  description = "CPU alert threshold"
+  type        = number
+  default     = 80
+}
+
+variable "enabled" {
+  description = "Enable the container group"
+  type        = bool
+  default     = true
+}
+
+variable "containers" {
+  description = "Containers of the group"
+  type = list(object({
+    name   = string
+    image  = string
+    cpu    = number
+    memory = number
+    ports = optional(list(object({
+      port     = number
+      protocol = optional(string, "TCP")
+    })), [])
+    liveness_probe = optional(object({
+      exec = optional(list(string))
+    }))
+  }))
+}
+
+variable "identity" {
+  description = "Managed identity"
+  type = object({
+    type         = string
+    identity_ids = optional(list(string))
+  })
+  default = null
+}
+
+variable "tags" {
+  description = "Tags to apply"
+  type        = map(string)
+  default     = {}
+}
+
+variable "settings" {
+  description = "Arbitrary settings"
+  type        = any
+  default     = {}
+}
+
+variable "pair" {
+  description = "A string and a number"
+  type        = tuple([string, number])
+  default     = ["a", 1]
+}
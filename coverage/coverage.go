@@ -0,0 +1,174 @@
+// Package coverage reports which resources of each module its tests exercise.
+//
+// A module's resources are the resource blocks of its main.tf, by address,
+// e.g. azurerm_container_registry_task.import_task. A resource is covered
+// when the files under the module's tests directory refer to it:
+//
+//   - a string literal of a Go test is its address, alone or followed by an
+//     index or attribute, as in "azurerm_dns_zone.main" or
+//     "azurerm_mysql_user.users[\"app\"]";
+//   - an expression of a .tftest.hcl or .tf file refers to it, as the
+//     condition of an assert block does;
+//   - a plan snapshot, tests/testdata/<example>.plan.json, plans it in the
+//     module an example calls, at module.<name>.<address>.
+//
+// Instances count for their resource, so covering azurerm_dns_zone.main[0]
+// covers azurerm_dns_zone.main. The references are read, not run: a test
+// that builds an address at run time, with fmt.Sprintf, covers nothing.
+package coverage
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+// TestsDir is the directory of a module holding its tests.
+const TestsDir = "tests"
+
+// Module is the coverage of a module's resources by its tests.
+type Module struct {
+	// Path is the module's directory relative to the repository root.
+	Path string `json:"path"`
+
+	// Resources are the addresses of the resources of main.tf, sorted.
+	Resources []string `json:"resources"`
+
+	// Covered are the addresses of the resources the tests refer to, with
+	// the files referring to each, relative to the module.
+	Covered map[string][]string `json:"covered"`
+}
+
+// Uncovered returns the addresses of the resources no test refers to, sorted.
+func (m *Module) Uncovered() []string {
+	var uncovered []string
+	for _, address := range m.Resources {
+		if _, ok := m.Covered[address]; !ok {
+			uncovered = append(uncovered, address)
+		}
+	}
+	return uncovered
+}
+
+// Percent returns the share of the module's resources its tests cover. A
+// module without resources is fully covered.
+func (m *Module) Percent() float64 {
+	if len(m.Resources) == 0 {
+		return 100
+	}
+	return 100 * float64(len(m.Covered)) / float64(len(m.Resources))
+}
+
+// String returns the coverage as path: covered/resources (percent).
+func (m *Module) String() string {
+	return fmt.Sprintf("%s: %d/%d resources covered (%.1f%%)", m.Path, len(m.Covered), len(m.Resources), m.Percent())
+}
+
+// Measure returns the coverage of the modules at paths under root, or of
+// every module under root if there are none.
+func Measure(root string, paths []string) ([]*Module, error) {
+	if len(paths) == 0 {
+		var err error
+		if paths, err = registry.Discover(root); err != nil {
+			return nil, err
+		}
+	}
+	var modules []*Module
+	for _, modulePath := range paths {
+		m, err := MeasureModule(root, modulePath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", modulePath, err)
+		}
+		modules = append(modules, m)
+	}
+	return modules, nil
+}
+
+// MeasureModule returns the coverage of the module at modulePath under root.
+func MeasureModule(root, modulePath string) (*Module, error) {
+	dir := filepath.Join(root, filepath.FromSlash(modulePath))
+	resources, err := Resources(filepath.Join(dir, "main.tf"))
+	if err != nil {
+		return nil, err
+	}
+	m := &Module{Path: modulePath, Resources: resources, Covered: map[string][]string{}}
+	declared := map[string]bool{}
+	for _, address := range resources {
+		declared[address] = true
+	}
+
+	references, err := testReferences(filepath.Join(dir, TestsDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, ref := range references {
+		if !declared[ref.address] {
+			continue
+		}
+		file := path.Join(TestsDir, ref.file)
+		if files := m.Covered[ref.address]; len(files) == 0 || files[len(files)-1] != file {
+			m.Covered[ref.address] = append(files, file)
+		}
+	}
+	return m, nil
+}
+
+// Resources returns the addresses of the resource blocks of the .tf file at
+// name, sorted.
+func Resources(name string) ([]string, error) {
+	file, diags := hclparse.NewParser().ParseHCLFile(name)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "resource", LabelNames: []string{"type", "name"}}},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	addresses := []string{}
+	for _, block := range content.Blocks {
+		addresses = append(addresses, block.Labels[0]+"."+block.Labels[1])
+	}
+	sort.Strings(addresses)
+	return addresses, nil
+}
+
+// resourceAddress returns the address of the resource an address or
+// reference names, without its index or attributes, e.g.
+// azurerm_dns_zone.main for azurerm_dns_zone.main[0].name, or "" if it names
+// no managed resource.
+func resourceAddress(s string) string {
+	typ, rest, ok := strings.Cut(s, ".")
+	if !ok || !isName(typ) || !strings.Contains(typ, "_") {
+		return ""
+	}
+	end := strings.IndexAny(rest, ".[")
+	if end < 0 {
+		end = len(rest)
+	}
+	if name := rest[:end]; isName(name) {
+		return typ + "." + name
+	}
+	return ""
+}
+
+// isName reports whether s is a Terraform identifier.
+func isName(s string) bool {
+	if s == "" || !(s[0] == '_' || s[0] >= 'a' && s[0] <= 'z' || s[0] >= 'A' && s[0] <= 'Z') {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r == '-' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package coverage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasure(t *testing.T) {
+	t.Parallel()
+
+	modules, err := Measure("testdata", nil)
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	storage := modules[0]
+	assert.Equal(t, "azure/infrastructure/storage", storage.Path)
+	assert.Equal(t, []string{
+		"azurerm_monitor_metric_alert.quota",
+		"azurerm_private_endpoint.blob",
+		"azurerm_storage_account.main",
+		"azurerm_storage_container.containers",
+		"azurerm_storage_queue.main",
+	}, storage.Resources)
+	assert.Equal(t, map[string][]string{
+		"azurerm_private_endpoint.blob":        {"tests/testdata/basic.plan.json"},
+		"azurerm_storage_account.main":         {"tests/unit/plan_test.go", "tests/unit/variables.tftest.hcl"},
+		"azurerm_storage_container.containers": {"tests/unit/plan_test.go"},
+	}, storage.Covered)
+	assert.Equal(t, []string{"azurerm_monitor_metric_alert.quota", "azurerm_storage_queue.main"}, storage.Uncovered())
+	assert.InDelta(t, 60, storage.Percent(), 0.01)
+	assert.Equal(t, "azure/infrastructure/storage: 3/5 resources covered (60.0%)", storage.String())
+
+	naming := modules[1]
+	assert.Equal(t, "azure/shared/naming", naming.Path)
+	assert.Empty(t, naming.Covered)
+	assert.Equal(t, []string{"random_string.suffix"}, naming.Uncovered())
+	assert.Zero(t, naming.Percent())
+}
+
+func TestPercentWithoutResources(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 100.0, (&Module{Path: "azure/shared/locations"}).Percent())
+}
+
+func TestResourceAddress(t *testing.T) {
+	t.Parallel()
+
+	for s, want := range map[string]string{
+		"azurerm_dns_zone.main":                 "azurerm_dns_zone.main",
+		"azurerm_dns_zone.main[0]":              "azurerm_dns_zone.main",
+		"azurerm_dns_zone.main[0].name":         "azurerm_dns_zone.main",
+		`azurerm_mysql_user.users["app"]`:       "azurerm_mysql_user.users",
+		"azurerm_storage_account.main.tags.Env": "azurerm_storage_account.main",
+		"data.azurerm_client_config.current":    "",
+		"var.name":                              "",
+		"azurerm_dns_zone.":                     "",
+		"azurerm_dns_zone.%s":                   "",
+		"example.com":                           "",
+		"rg-test.main":                          "",
+	} {
+		assert.Equal(t, want, resourceAddress(s), s)
+	}
+}
+
+func TestModuleResource(t *testing.T) {
+	t.Parallel()
+
+	for address, want := range map[string]string{
+		"module.dns.azurerm_dns_zone.main":                 "azurerm_dns_zone.main",
+		`module.dns["primary"].azurerm_dns_zone.main[0]`:   "azurerm_dns_zone.main",
+		"module.dns.module.records.azurerm_dns_a_record.a": "",
+		"module.dns.data.azurerm_client_config.current":    "",
+		"module.dns":            "",
+		"azurerm_dns_zone.main": "",
+	} {
+		assert.Equal(t, want, moduleResource(address), address)
+	}
+}
@@ -0,0 +1,172 @@
+package coverage
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// SnapshotSuffix ends the names of plan snapshots; see the snapshot package.
+const SnapshotSuffix = ".plan.json"
+
+// reference is a resource a test file refers to.
+type reference struct {
+	// address is the resource's address, without index.
+	address string
+
+	// file is the test file, relative to the tests directory.
+	file string
+}
+
+// testReferences returns the resources the files under dir refer to, file by
+// file in lexical order. A module without tests refers to none.
+func testReferences(dir string) ([]reference, error) {
+	var references []reference
+	err := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if os.IsNotExist(err) && name == dir {
+			return filepath.SkipDir
+		}
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		var addresses []string
+		switch base := entry.Name(); {
+		case strings.HasSuffix(base, ".go"):
+			addresses, err = goReferences(name)
+		case strings.HasSuffix(base, ".tftest.hcl"), strings.HasSuffix(base, ".tf"):
+			addresses, err = hclReferences(name)
+		case strings.HasSuffix(base, SnapshotSuffix):
+			addresses, err = snapshotReferences(name)
+		default:
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		file, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+		for _, address := range addresses {
+			references = append(references, reference{address: address, file: filepath.ToSlash(file)})
+		}
+		return nil
+	})
+	return references, err
+}
+
+// goReferences returns the resources the string literals of the Go file name
+// refer to.
+func goReferences(name string) ([]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), name, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	var addresses []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		literal, ok := node.(*ast.BasicLit)
+		if !ok || literal.Kind != token.STRING {
+			return true
+		}
+		value, err := strconv.Unquote(literal.Value)
+		if err != nil {
+			return true
+		}
+		if address := resourceAddress(value); address != "" {
+			addresses = append(addresses, address)
+		}
+		return true
+	})
+	return addresses, nil
+}
+
+// hclReferences returns the resources the expressions of the HCL file name
+// refer to.
+func hclReferences(name string) ([]string, error) {
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	file, diags := hclsyntax.ParseConfig(src, name, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	var addresses []string
+	hclsyntax.VisitAll(file.Body.(*hclsyntax.Body), func(node hclsyntax.Node) hcl.Diagnostics {
+		expr, ok := node.(*hclsyntax.ScopeTraversalExpr)
+		if !ok || len(expr.Traversal) < 2 {
+			return nil
+		}
+		if attribute, ok := expr.Traversal[1].(hcl.TraverseAttr); ok {
+			if address := resourceAddress(expr.Traversal.RootName() + "." + attribute.Name); address != "" {
+				addresses = append(addresses, address)
+			}
+		}
+		return nil
+	})
+	return addresses, nil
+}
+
+// snapshotReferences returns the resources of the module an example calls
+// that the plan snapshot name plans. The resources of the example itself
+// are at the root of the plan and do not count.
+func snapshotReferences(name string) ([]string, error) {
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var plan interface{}
+	if err := json.Unmarshal(src, &plan); err != nil {
+		return nil, err
+	}
+	var addresses []string
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if address, ok := v["address"].(string); ok {
+				if address = moduleResource(address); address != "" {
+					addresses = append(addresses, address)
+				}
+			}
+			for _, item := range v {
+				walk(item)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		}
+	}
+	walk(plan)
+	return addresses, nil
+}
+
+// moduleResource returns the address, within the module, of a resource a
+// plan holds in a module the root calls, e.g. azurerm_dns_zone.main for
+// module.dns.azurerm_dns_zone.main[0], or "" if address is not one.
+func moduleResource(address string) string {
+	rest := strings.TrimPrefix(address, "module.")
+	if rest == address {
+		return ""
+	}
+	end := strings.IndexByte(rest, '.')
+	if end < 0 {
+		return ""
+	}
+	// Skip the name of the module call and any index, as in
+	// module.dns["primary"].
+	if rest = rest[end+1:]; strings.HasPrefix(rest, "module.") {
+		return ""
+	}
+	return resourceAddress(rest)
+}
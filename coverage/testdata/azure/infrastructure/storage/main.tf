@@ -0,0 +1,52 @@
+# azure-infrastructure-storage module
+# Description: Storage account fixture for the coverage tests
+
+resource "azurerm_storage_account" "main" {
+  name                     = var.name
+  resource_group_name      = var.resource_group_name
+  location                 = var.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "containers" {
+  for_each = toset(var.containers)
+
+  name                 = each.value
+  storage_account_name = azurerm_storage_account.main.name
+}
+
+resource "azurerm_private_endpoint" "blob" {
+  count = var.subnet_id == null ? 0 : 1
+
+  name                = "${var.name}-blob"
+  resource_group_name = var.resource_group_name
+  location            = var.location
+  subnet_id           = var.subnet_id
+
+  private_service_connection {
+    name                           = "${var.name}-blob"
+    private_connection_resource_id = azurerm_storage_account.main.id
+    subresource_names              = ["blob"]
+    is_manual_connection           = false
+  }
+}
+
+resource "azurerm_storage_queue" "main" {
+  name                 = "${var.name}-queue"
+  storage_account_name = azurerm_storage_account.main.name
+}
+
+resource "azurerm_monitor_metric_alert" "quota" {
+  name                = "${var.name}-quota"
+  resource_group_name = var.resource_group_name
+  scopes              = [azurerm_storage_account.main.id]
+
+  criteria {
+    metric_namespace = "Microsoft.Storage/storageAccounts"
+    metric_name      = "UsedCapacity"
+    aggregation      = "Average"
+    operator         = "GreaterThan"
+    threshold        = 1000000000
+  }
+}
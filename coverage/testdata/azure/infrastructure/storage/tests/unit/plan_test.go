@@ -0,0 +1,22 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlan(t *testing.T) {
+	options := &terraform.Options{TerraformDir: "../.."}
+	plan := terraform.InitAndPlanAndShowWithStruct(t, options)
+
+	terraform.AssertPlannedValuesMapKeyExists(t, plan, "azurerm_storage_account.main")
+	logs := plan.ResourcePlannedValuesMap["azurerm_storage_container.containers[\"logs\"]"]
+	assert.Equal(t, "logs", logs.AttributeValues["name"])
+
+	// Neither a built address nor a data source covers a resource.
+	terraform.AssertPlannedValuesMapKeyExists(t, plan, fmt.Sprintf("azurerm_storage_queue.%s", "main"))
+	assert.NotContains(t, plan.ResourcePlannedValuesMap, "data.azurerm_monitor_metric_alert.quota")
+}
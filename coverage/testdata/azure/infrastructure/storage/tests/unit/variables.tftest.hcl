@@ -0,0 +1,8 @@
+run "account_tier" {
+  command = plan
+
+  assert {
+    condition     = azurerm_storage_account.main.account_tier == "Standard"
+    error_message = "The account should be Standard"
+  }
+}
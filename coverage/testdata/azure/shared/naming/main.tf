@@ -0,0 +1,7 @@
+# azure-shared-naming module
+# Description: Naming fixture for the coverage tests, which has no tests
+
+resource "random_string" "suffix" {
+  length  = 6
+  special = false
+}
@@ -0,0 +1,160 @@
+// Package lint checks the modules of the library against its conventions by
+// reading their Terraform, without running it:
+//
+//   - versions.tf exists and pins the azurerm provider to a major version;
+//   - main.tf opens with the header naming the module after its path;
+//   - the module takes a common_tags variable, and every resource that can be
+//     tagged is tagged with local.common_tags;
+//   - every variable has a description and a type, and every output a
+//     description.
+//
+// Rules lists the rules. Lint returns the findings of every module under a
+// root, which zrr-lint prints as text or SARIF, for code scanning.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+// Finding is a place where a module breaks a rule.
+type Finding struct {
+	// Rule is the ID of the rule broken.
+	Rule string
+
+	// Module is the path of the module, relative to the repository root.
+	Module string
+
+	// Range is where in the module the rule is broken, its Filename relative
+	// to the repository root. It is empty for a file that is missing.
+	Range hcl.Range
+
+	Message string
+}
+
+// File returns the file of the finding, relative to the repository root.
+func (f Finding) File() string {
+	if f.Range.Filename == "" {
+		return f.Module
+	}
+	return f.Range.Filename
+}
+
+// String returns the finding as file:line: message (rule).
+func (f Finding) String() string {
+	location := f.File()
+	if f.Range.Start.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, f.Range.Start.Line)
+	}
+	return fmt.Sprintf("%s: %s (%s)", location, f.Message, f.Rule)
+}
+
+// Module is the parsed Terraform of a module.
+type Module struct {
+	// Path is the module's directory relative to the repository root, e.g.
+	// azure/infrastructure/virtual-network.
+	Path string
+
+	// Files are the module's .tf files by name, e.g. main.tf.
+	Files map[string]*hcl.File
+}
+
+// body returns the body of the file name, or nil if the module has no such
+// file.
+func (m *Module) body(name string) *hclsyntax.Body {
+	file, ok := m.Files[name]
+	if !ok {
+		return nil
+	}
+	return file.Body.(*hclsyntax.Body)
+}
+
+// blocks returns the blocks of type typ of all of the module's files, in the
+// order of the files' names.
+func (m *Module) blocks(typ string) []*hclsyntax.Block {
+	var names []string
+	for name := range m.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var blocks []*hclsyntax.Block
+	for _, name := range names {
+		for _, block := range m.body(name).Blocks {
+			if block.Type == typ {
+				blocks = append(blocks, block)
+			}
+		}
+	}
+	return blocks
+}
+
+// finding returns a finding of rule on m at r.
+func (m *Module) finding(rule string, r hcl.Range, format string, args ...interface{}) Finding {
+	return Finding{Rule: rule, Module: m.Path, Range: r, Message: fmt.Sprintf(format, args...)}
+}
+
+// Load parses the .tf files of the module at modulePath under root.
+func Load(root, modulePath string) (*Module, error) {
+	names, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(modulePath), "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	m := &Module{Path: modulePath, Files: map[string]*hcl.File{}}
+	for _, name := range names {
+		src, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		file, diags := hclsyntax.ParseConfig(src, path.Join(modulePath, filepath.Base(name)), hcl.Pos{Line: 1, Column: 1})
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		m.Files[filepath.Base(name)] = file
+	}
+	return m, nil
+}
+
+// Lint checks the modules at paths under root, or every module under root if
+// there are none, against Rules. It returns the findings module by module,
+// each module's sorted by file and line.
+func Lint(root string, paths []string) ([]Finding, error) {
+	if len(paths) == 0 {
+		var err error
+		if paths, err = registry.Discover(root); err != nil {
+			return nil, err
+		}
+	}
+	var findings []Finding
+	for _, modulePath := range paths {
+		m, err := Load(root, modulePath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", modulePath, err)
+		}
+		findings = append(findings, Check(m)...)
+	}
+	return findings, nil
+}
+
+// Check returns the findings of every rule on m, sorted by file and line.
+func Check(m *Module) []Finding {
+	var findings []Finding
+	for _, rule := range Rules {
+		findings = append(findings, rule.check(m)...)
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File() != b.File() {
+			return a.File() < b.File()
+		}
+		return a.Range.Start.Line < b.Range.Start.Line
+	})
+	return findings
+}
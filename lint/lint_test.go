@@ -0,0 +1,137 @@
+package lint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		module string
+		want   []string
+	}{
+		{"compliant", nil},
+		{"versions-tf", []string{
+			`azure/infrastructure/versions-tf: versions.tf is missing (versions-tf)`,
+			`azure/infrastructure/versions-tf/main.tf:4: the terraform block belongs in versions.tf (versions-tf)`,
+		}},
+		{"azurerm-pinned", []string{
+			`azure/infrastructure/azurerm-pinned/versions.tf:5: azurerm version ">= 3.0" does not bound the major version; use ~> 3.0 (azurerm-pinned)`,
+		}},
+		{"main-header", []string{
+			`azure/infrastructure/main-header/main.tf:1: the header is "# storage module", not "# azure-infrastructure-main-header module" (main-header)`,
+			`azure/infrastructure/main-header/main.tf:2: the second line is not a "# Description:" of the module (main-header)`,
+		}},
+		{"common-tags-variable", []string{
+			`azure/infrastructure/common-tags-variable/variables.tf:6: common_tags is not of type map(string) (common-tags-variable)`,
+		}},
+		{"common-tags", []string{
+			`azure/infrastructure/common-tags/main.tf:16: the tags of azurerm_resource_group.main do not include local.common_tags (common-tags)`,
+			`azure/infrastructure/common-tags/main.tf:25: azurerm_key_vault.main sets no tags; set tags = local.common_tags (common-tags)`,
+		}},
+		{"variable-description", []string{
+			`azure/infrastructure/variable-description/variables.tf:1: variable "name" has no description (variable-description)`,
+		}},
+		{"variable-type", []string{
+			`azure/infrastructure/variable-type/variables.tf:1: variable "name" has no type (variable-type)`,
+		}},
+		{"output-description", []string{
+			`azure/infrastructure/output-description/outputs.tf:6: output "name" has no description (output-description)`,
+		}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.module, func(t *testing.T) {
+			t.Parallel()
+
+			findings, err := Lint("testdata", []string{"azure/infrastructure/" + tt.module})
+			require.NoError(t, err)
+			var lines []string
+			for _, f := range findings {
+				lines = append(lines, f.String())
+			}
+			assert.Equal(t, tt.want, lines)
+		})
+	}
+}
+
+func TestLintEveryModule(t *testing.T) {
+	t.Parallel()
+
+	findings, err := Lint("testdata", nil)
+	require.NoError(t, err)
+	modules := map[string]bool{}
+	for _, f := range findings {
+		modules[f.Module] = true
+	}
+	// Every fixture but compliant breaks a rule.
+	assert.Len(t, modules, len(Rules))
+	assert.NotContains(t, modules, "azure/infrastructure/compliant")
+}
+
+func TestSARIF(t *testing.T) {
+	t.Parallel()
+
+	findings, err := Lint("testdata", []string{"azure/infrastructure/versions-tf"})
+	require.NoError(t, err)
+	data, err := SARIF(findings)
+	require.NoError(t, err)
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(data, &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, ToolName, run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, len(Rules))
+	for i, rule := range Rules {
+		assert.Equal(t, rule.ID, run.Tool.Driver.Rules[i].ID)
+	}
+
+	require.Len(t, run.Results, 2)
+	missing := run.Results[0]
+	assert.Equal(t, "versions-tf", missing.RuleID)
+	assert.Equal(t, 0, missing.RuleIndex)
+	assert.Equal(t, "error", missing.Level)
+	assert.Equal(t, "azure/infrastructure/versions-tf", missing.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Nil(t, missing.Locations[0].PhysicalLocation.Region)
+
+	misplaced := run.Results[1]
+	assert.Equal(t, "azure/infrastructure/versions-tf/main.tf", misplaced.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	require.NotNil(t, misplaced.Locations[0].PhysicalLocation.Region)
+	assert.Equal(t, 4, misplaced.Locations[0].PhysicalLocation.Region.StartLine)
+
+	data, err = SARIF(nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"results": []`)
+}
+
+func TestBoundsMajor(t *testing.T) {
+	t.Parallel()
+
+	for constraint, want := range map[string]bool{
+		"~> 3.0":        true,
+		"3.117.0":       true,
+		"= 3.117.0":     true,
+		">= 3.0, < 4.0": true,
+		">= 3.0":        false,
+		"":              false,
+	} {
+		assert.Equal(t, want, boundsMajor(constraint), constraint)
+	}
+}
+
+func TestRepositoryLint(t *testing.T) {
+	t.Parallel()
+
+	findings, err := Lint("..", nil)
+	require.NoError(t, err)
+	for _, f := range findings {
+		t.Error(f)
+	}
+}
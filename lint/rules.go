@@ -0,0 +1,319 @@
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Rule is a convention every module of the library follows.
+type Rule struct {
+	// ID names the rule in findings, e.g. versions-tf.
+	ID string
+
+	// Description says what the rule asks of a module, in a sentence.
+	Description string
+
+	check func(m *Module) []Finding
+}
+
+// Rules are the rules Check applies, in order.
+var Rules = []Rule{
+	{
+		ID:          "versions-tf",
+		Description: "The module's terraform block is in versions.tf.",
+		check:       checkVersionsFile,
+	},
+	{
+		ID:          "azurerm-pinned",
+		Description: "A module using azurerm requires hashicorp/azurerm in versions.tf with a constraint bounding its major version, such as ~> 3.0.",
+		check:       checkAzurermPinned,
+	},
+	{
+		ID:          "main-header",
+		Description: "main.tf opens with a \"# <cloud>-<layer>-<name> module\" line naming the module after its path, then a \"# Description:\" line.",
+		check:       checkMainHeader,
+	},
+	{
+		ID:          "common-tags-variable",
+		Description: "A module with taggable resources takes a common_tags variable of type map(string).",
+		check:       checkCommonTagsVariable,
+	},
+	{
+		ID:          "common-tags",
+		Description: "Every taggable resource sets tags from local.common_tags.",
+		check:       checkCommonTags,
+	},
+	{
+		ID:          "variable-description",
+		Description: "Every variable has a description.",
+		check:       checkVariableDescriptions,
+	},
+	{
+		ID:          "variable-type",
+		Description: "Every variable has a type.",
+		check:       checkVariableTypes,
+	},
+	{
+		ID:          "output-description",
+		Description: "Every output has a description.",
+		check:       checkOutputDescriptions,
+	},
+}
+
+// VersionsFile is the file holding a module's terraform block.
+const VersionsFile = "versions.tf"
+
+func checkVersionsFile(m *Module) []Finding {
+	var findings []Finding
+	inVersions := false
+	for _, block := range m.blocks("terraform") {
+		if block.Range().Filename == m.Path+"/"+VersionsFile {
+			inVersions = true
+			continue
+		}
+		findings = append(findings, m.finding("versions-tf", block.DefRange(), "the terraform block belongs in %s", VersionsFile))
+	}
+	switch {
+	case m.body(VersionsFile) == nil:
+		findings = append(findings, m.finding("versions-tf", hcl.Range{}, "%s is missing", VersionsFile))
+	case !inVersions:
+		findings = append(findings, m.finding("versions-tf", hcl.Range{Filename: m.Path + "/" + VersionsFile}, "%s has no terraform block", VersionsFile))
+	}
+	return findings
+}
+
+func checkAzurermPinned(m *Module) []Finding {
+	if !m.usesAzurerm() {
+		return nil
+	}
+	versions := m.body(VersionsFile)
+	if versions == nil {
+		// versions-tf reports the missing file.
+		return nil
+	}
+	for _, block := range versions.Blocks {
+		if block.Type != "terraform" {
+			continue
+		}
+		for _, providers := range block.Body.Blocks {
+			if providers.Type != "required_providers" {
+				continue
+			}
+			attribute, ok := providers.Body.Attributes["azurerm"]
+			if !ok {
+				break
+			}
+			source, version := providerRequirement(attribute.Expr)
+			switch {
+			case source != "hashicorp/azurerm":
+				return []Finding{m.finding("azurerm-pinned", attribute.SrcRange, "azurerm has source %q, not hashicorp/azurerm", source)}
+			case version == "":
+				return []Finding{m.finding("azurerm-pinned", attribute.SrcRange, "azurerm has no version constraint")}
+			case !boundsMajor(version):
+				return []Finding{m.finding("azurerm-pinned", attribute.SrcRange, "azurerm version %q does not bound the major version; use ~> 3.0", version)}
+			}
+			return nil
+		}
+	}
+	return []Finding{m.finding("azurerm-pinned", hcl.Range{Filename: m.Path + "/" + VersionsFile}, "the module uses azurerm, which is not in the required_providers of %s", VersionsFile)}
+}
+
+// usesAzurerm reports whether m has azurerm resources or data sources.
+func (m *Module) usesAzurerm() bool {
+	for _, typ := range []string{"resource", "data"} {
+		for _, block := range m.blocks(typ) {
+			if strings.HasPrefix(block.Labels[0], "azurerm_") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// providerRequirement returns the source and version of a required_providers
+// entry.
+func providerRequirement(expr hclsyntax.Expression) (source, version string) {
+	object, ok := expr.(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		return "", ""
+	}
+	for _, item := range object.Items {
+		value, diags := item.ValueExpr.Value(nil)
+		if diags.HasErrors() || value.Type() != cty.String || value.IsNull() {
+			continue
+		}
+		switch hcl.ExprAsKeyword(item.KeyExpr) {
+		case "source":
+			source = value.AsString()
+		case "version":
+			version = value.AsString()
+		}
+	}
+	return source, version
+}
+
+// boundsMajor reports whether the version constraint keeps to one major
+// version: it is pessimistic, has an upper bound or names an exact version.
+func boundsMajor(constraint string) bool {
+	for _, part := range strings.Split(constraint, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case strings.HasPrefix(part, "~>"), strings.HasPrefix(part, "<"), strings.HasPrefix(part, "="):
+			return true
+		case part != "" && part[0] >= '0' && part[0] <= '9':
+			return true
+		}
+	}
+	return false
+}
+
+func checkMainHeader(m *Module) []Finding {
+	file, ok := m.Files["main.tf"]
+	if !ok {
+		return []Finding{m.finding("main-header", hcl.Range{}, "main.tf is missing")}
+	}
+	want := "# " + strings.ReplaceAll(m.Path, "/", "-") + " module"
+	at := func(line int) hcl.Range {
+		return hcl.Range{
+			Filename: m.Path + "/main.tf",
+			Start:    hcl.Pos{Line: line, Column: 1},
+			End:      hcl.Pos{Line: line, Column: 1},
+		}
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(file.Bytes))
+	var lines []string
+	for len(lines) < 2 && scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), " \t\r"))
+	}
+	for len(lines) < 2 {
+		lines = append(lines, "")
+	}
+	var findings []Finding
+	if lines[0] != want {
+		findings = append(findings, m.finding("main-header", at(1), "the header is %q, not %q", lines[0], want))
+	}
+	if description := strings.TrimPrefix(lines[1], "# Description:"); description == lines[1] || strings.TrimSpace(description) == "" {
+		findings = append(findings, m.finding("main-header", at(2), "the second line is not a \"# Description:\" of the module"))
+	}
+	return findings
+}
+
+func checkCommonTagsVariable(m *Module) []Finding {
+	if len(m.taggableResources()) == 0 {
+		return nil
+	}
+	for _, block := range m.blocks("variable") {
+		if block.Labels[0] != "common_tags" {
+			continue
+		}
+		attribute, ok := block.Body.Attributes["type"]
+		if !ok || typeSource(attribute.Expr) != "map(string)" {
+			return []Finding{m.finding("common-tags-variable", block.DefRange(), "common_tags is not of type map(string)")}
+		}
+		return nil
+	}
+	return []Finding{m.finding("common-tags-variable", hcl.Range{}, "the module has taggable resources but no common_tags variable")}
+}
+
+// typeSource returns a type constraint as written, without spaces.
+func typeSource(expr hclsyntax.Expression) string {
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		return e.Traversal.RootName()
+	case *hclsyntax.FunctionCallExpr:
+		var args []string
+		for _, arg := range e.Args {
+			args = append(args, typeSource(arg))
+		}
+		return e.Name + "(" + strings.Join(args, ",") + ")"
+	}
+	return ""
+}
+
+func checkCommonTags(m *Module) []Finding {
+	var findings []Finding
+	for _, block := range m.blocks("resource") {
+		attribute, ok := block.Body.Attributes["tags"]
+		if !ok {
+			if Taggable[block.Labels[0]] {
+				findings = append(findings, m.finding("common-tags", block.DefRange(), "%s.%s sets no tags; set tags = local.common_tags", block.Labels[0], block.Labels[1]))
+			}
+			continue
+		}
+		if !referencesCommonTags(attribute.Expr) {
+			findings = append(findings, m.finding("common-tags", attribute.SrcRange, "the tags of %s.%s do not include local.common_tags", block.Labels[0], block.Labels[1]))
+		}
+	}
+	return findings
+}
+
+// taggableResources returns the resources of m whose type is Taggable or
+// which set tags.
+func (m *Module) taggableResources() []*hclsyntax.Block {
+	var blocks []*hclsyntax.Block
+	for _, block := range m.blocks("resource") {
+		if _, ok := block.Body.Attributes["tags"]; ok || Taggable[block.Labels[0]] {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// referencesCommonTags reports whether expr refers to local.common_tags.
+func referencesCommonTags(expr hclsyntax.Expression) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			continue
+		}
+		if attribute, ok := traversal[1].(hcl.TraverseAttr); ok && attribute.Name == "common_tags" {
+			return true
+		}
+	}
+	return false
+}
+
+func checkVariableDescriptions(m *Module) []Finding {
+	var findings []Finding
+	for _, block := range m.blocks("variable") {
+		if !hasDescription(block) {
+			findings = append(findings, m.finding("variable-description", block.DefRange(), "variable %q has no description", block.Labels[0]))
+		}
+	}
+	return findings
+}
+
+func checkVariableTypes(m *Module) []Finding {
+	var findings []Finding
+	for _, block := range m.blocks("variable") {
+		if _, ok := block.Body.Attributes["type"]; !ok {
+			findings = append(findings, m.finding("variable-type", block.DefRange(), "variable %q has no type", block.Labels[0]))
+		}
+	}
+	return findings
+}
+
+func checkOutputDescriptions(m *Module) []Finding {
+	var findings []Finding
+	for _, block := range m.blocks("output") {
+		if !hasDescription(block) {
+			findings = append(findings, m.finding("output-description", block.DefRange(), "output %q has no description", block.Labels[0]))
+		}
+	}
+	return findings
+}
+
+// hasDescription reports whether block has a description that is not empty.
+func hasDescription(block *hclsyntax.Block) bool {
+	attribute, ok := block.Body.Attributes["description"]
+	if !ok {
+		return false
+	}
+	value, diags := attribute.Expr.Value(nil)
+	return !diags.HasErrors() && value.Type() == cty.String && !value.IsNull() && strings.TrimSpace(value.AsString()) != ""
+}
@@ -0,0 +1,113 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// ToolName is the name of the linter in SARIF logs.
+const ToolName = "zrr-lint"
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+
+	// srcRoot is the base of the files of findings, which are relative to
+	// the repository root.
+	srcRoot = "%SRCROOT%"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// SARIF returns findings as a SARIF 2.1.0 log listing every rule, for code
+// scanning. Every finding is an error.
+func SARIF(findings []Finding) ([]byte, error) {
+	run := sarifRun{Tool: sarifTool{Driver: sarifDriver{Name: ToolName}}, Results: []sarifResult{}}
+	index := map[string]int{}
+	for i, rule := range Rules {
+		index[rule.ID] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: rule.ID, ShortDescription: sarifMessage{Text: rule.Description}})
+	}
+	for _, f := range findings {
+		location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: f.File(), URIBaseID: srcRoot}}
+		if f.Range.Start.Line > 0 {
+			location.Region = &sarifRegion{
+				StartLine:   f.Range.Start.Line,
+				StartColumn: f.Range.Start.Column,
+				EndLine:     f.Range.End.Line,
+				EndColumn:   f.Range.End.Column,
+			}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    f.Rule,
+			RuleIndex: index[f.Rule],
+			Level:     "error",
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,53 @@
+package lint
+
+// Taggable are the azurerm resource types with a tags argument that the
+// library uses. A resource of one of these types must set tags, and any
+// resource that sets tags, whatever its type, must include
+// local.common_tags. Add a type here when a module starts using it.
+var Taggable = map[string]bool{
+	"azurerm_application_insights":                   true,
+	"azurerm_application_insights_web_test":          true,
+	"azurerm_application_insights_workbook_template": true,
+	"azurerm_container_app":                          true,
+	"azurerm_container_app_environment":              true,
+	"azurerm_container_group":                        true,
+	"azurerm_container_registry":                     true,
+	"azurerm_container_registry_task":                true,
+	"azurerm_dns_a_record":                           true,
+	"azurerm_dns_aaaa_record":                        true,
+	"azurerm_dns_cname_record":                       true,
+	"azurerm_dns_mx_record":                          true,
+	"azurerm_dns_ns_record":                          true,
+	"azurerm_dns_ptr_record":                         true,
+	"azurerm_dns_srv_record":                         true,
+	"azurerm_dns_txt_record":                         true,
+	"azurerm_dns_zone":                               true,
+	"azurerm_key_vault":                              true,
+	"azurerm_key_vault_key":                          true,
+	"azurerm_key_vault_secret":                       true,
+	"azurerm_log_analytics_solution":                 true,
+	"azurerm_log_analytics_workspace":                true,
+	"azurerm_monitor_action_group":                   true,
+	"azurerm_monitor_autoscale_setting":              true,
+	"azurerm_monitor_data_collection_rule":           true,
+	"azurerm_monitor_metric_alert":                   true,
+	"azurerm_mssql_database":                         true,
+	"azurerm_mysql_flexible_server":                  true,
+	"azurerm_network_security_group":                 true,
+	"azurerm_network_watcher_flow_log":               true,
+	"azurerm_private_dns_a_record":                   true,
+	"azurerm_private_dns_aaaa_record":                true,
+	"azurerm_private_dns_cname_record":               true,
+	"azurerm_private_dns_mx_record":                  true,
+	"azurerm_private_dns_srv_record":                 true,
+	"azurerm_private_dns_txt_record":                 true,
+	"azurerm_private_dns_zone":                       true,
+	"azurerm_private_dns_zone_virtual_network_link":  true,
+	"azurerm_private_endpoint":                       true,
+	"azurerm_recovery_services_vault":                true,
+	"azurerm_resource_group":                         true,
+	"azurerm_route_table":                            true,
+	"azurerm_service_plan":                           true,
+	"azurerm_storage_account":                        true,
+	"azurerm_virtual_network":                        true,
+}
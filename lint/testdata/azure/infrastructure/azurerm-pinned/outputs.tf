@@ -0,0 +1,4 @@
+output "id" {
+  description = "ID of the resource group"
+  value       = azurerm_resource_group.main.id
+}
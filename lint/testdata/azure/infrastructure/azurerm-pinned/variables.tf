@@ -0,0 +1,10 @@
+variable "name" {
+  description = "Name of the resource group"
+  type        = string
+}
+
+variable "common_tags" {
+  description = "Tags applied to every resource"
+  type        = map(string)
+  default     = {}
+}
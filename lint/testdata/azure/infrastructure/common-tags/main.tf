@@ -0,0 +1,31 @@
+# azure-infrastructure-common-tags module
+# Description: Fixture module for the common-tags rule
+
+locals {
+  common_tags = merge(var.common_tags, {
+    "ManagedBy" = "Terraform"
+    "Module"    = "zrr-tf-module-lib/azure/infrastructure/common-tags"
+    "Layer"     = "infrastructure"
+  })
+}
+
+resource "azurerm_resource_group" "main" {
+  name     = var.name
+  location = "eastus"
+
+  tags = var.common_tags
+}
+
+resource "azurerm_management_lock" "main" {
+  name       = "lock"
+  scope      = azurerm_resource_group.main.id
+  lock_level = "CanNotDelete"
+}
+
+resource "azurerm_key_vault" "main" {
+  name                = "kv"
+  location            = "eastus"
+  resource_group_name = azurerm_resource_group.main.name
+  tenant_id           = "00000000-0000-0000-0000-000000000000"
+  sku_name            = "standard"
+}
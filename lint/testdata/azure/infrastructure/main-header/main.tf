@@ -0,0 +1,22 @@
+# storage module
+
+locals {
+  common_tags = merge(var.common_tags, {
+    "ManagedBy" = "Terraform"
+    "Module"    = "zrr-tf-module-lib/azure/infrastructure/main-header"
+    "Layer"     = "infrastructure"
+  })
+}
+
+resource "azurerm_resource_group" "main" {
+  name     = var.name
+  location = "eastus"
+
+  tags = local.common_tags
+}
+
+resource "azurerm_management_lock" "main" {
+  name       = "lock"
+  scope      = azurerm_resource_group.main.id
+  lock_level = "CanNotDelete"
+}
@@ -0,0 +1,8 @@
+output "id" {
+  description = "ID of the resource group"
+  value       = azurerm_resource_group.main.id
+}
+
+output "name" {
+  value = azurerm_resource_group.main.name
+}
@@ -0,0 +1,9 @@
+variable "name" {
+  type        = string
+}
+
+variable "common_tags" {
+  description = "Tags applied to every resource"
+  type        = map(string)
+  default     = {}
+}
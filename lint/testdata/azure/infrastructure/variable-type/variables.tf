@@ -0,0 +1,9 @@
+variable "name" {
+  description = "Name of the resource group"
+}
+
+variable "common_tags" {
+  description = "Tags applied to every resource"
+  type        = map(string)
+  default     = {}
+}
@@ -0,0 +1,59 @@
+package location
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// LocalsHeader starts every generated locals file.
+const LocalsHeader = "# Code generated by `go generate ./location`. DO NOT EDIT.\n"
+
+// Locals renders the region tables as a Terraform file declaring two locals:
+//
+//	location_short_codes     - canonical name to short code
+//	location_canonical_names - display name to canonical name
+//
+// The output is already formatted the way `terraform fmt` would.
+func Locals() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(LocalsHeader)
+	buf.WriteString("# Source: location/regions.go\n\n")
+	buf.WriteString("locals {\n")
+	buf.WriteString("  # Canonical Azure region name to naming-convention short code\n")
+	writeMap(&buf, "location_short_codes", ShortCodes(), false)
+	buf.WriteString("\n  # Portal display name to canonical Azure region name\n")
+	writeMap(&buf, "location_canonical_names", CanonicalNames(), true)
+	buf.WriteString("}\n")
+	return buf.Bytes()
+}
+
+// writeMap writes an aligned object constructor. Canonical names are valid
+// identifiers and are written bare; display names contain spaces and are
+// quoted.
+func writeMap(buf *bytes.Buffer, name string, values map[string]string, quoteKeys bool) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rendered := make([]string, len(keys))
+	width := 0
+	for i, key := range keys {
+		rendered[i] = key
+		if quoteKeys {
+			rendered[i] = fmt.Sprintf("%q", key)
+		}
+		if len(rendered[i]) > width {
+			width = len(rendered[i])
+		}
+	}
+
+	fmt.Fprintf(buf, "  %s = {\n", name)
+	for i, key := range keys {
+		fmt.Fprintf(buf, "    %s%s = %q\n", rendered[i], strings.Repeat(" ", width-len(rendered[i])), values[key])
+	}
+	buf.WriteString("  }\n")
+}
@@ -0,0 +1,100 @@
+// Package location maps Azure regions between their canonical names
+// ("eastus"), display names ("East US") and the short codes the naming
+// convention uses for location_short ("eus").
+//
+// The same tables are generated as Terraform locals in
+// azure/shared/locations/locations.tf, so modules can derive location_short
+// from location. Run `go generate ./location` after changing regions.go.
+package location
+
+//go:generate go run ../cmd/zrr-locations -o ../azure/shared/locations/locations.tf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Region is an Azure public cloud region.
+type Region struct {
+	// Name is the canonical name the Azure APIs use, e.g. eastus.
+	Name string
+
+	// DisplayName is the name shown in the portal, e.g. East US.
+	DisplayName string
+
+	// Short is the naming-convention code, e.g. eus.
+	Short string
+}
+
+// Regions returns every known region, sorted by canonical name.
+func Regions() []Region {
+	sorted := make([]Region, len(regions))
+	copy(sorted, regions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+// Lookup finds a region by canonical name or display name. Case and spaces
+// are ignored, so "East US", "eastus" and "EASTUS" are the same region.
+func Lookup(location string) (Region, bool) {
+	key := normalize(location)
+	for _, region := range regions {
+		if region.Name == key {
+			return region, true
+		}
+	}
+	return Region{}, false
+}
+
+// Canonical returns the canonical name of a location given by canonical name
+// or display name.
+func Canonical(location string) (string, error) {
+	region, ok := Lookup(location)
+	if !ok {
+		return "", fmt.Errorf("unknown Azure location %q", location)
+	}
+	return region.Name, nil
+}
+
+// Short returns the short code of a location given by canonical name or
+// display name.
+func Short(location string) (string, error) {
+	region, ok := Lookup(location)
+	if !ok {
+		return "", fmt.Errorf("unknown Azure location %q", location)
+	}
+	return region.Short, nil
+}
+
+// MustShort is like Short but panics on an unknown location. It is meant for
+// tests whose locations are constants.
+func MustShort(location string) string {
+	short, err := Short(location)
+	if err != nil {
+		panic(err)
+	}
+	return short
+}
+
+// ShortCodes returns the region to short-code table, keyed by canonical name.
+func ShortCodes() map[string]string {
+	codes := make(map[string]string, len(regions))
+	for _, region := range regions {
+		codes[region.Name] = region.Short
+	}
+	return codes
+}
+
+// CanonicalNames returns the display-name to canonical-name table.
+func CanonicalNames() map[string]string {
+	names := make(map[string]string, len(regions))
+	for _, region := range regions {
+		names[region.DisplayName] = region.Name
+	}
+	return names
+}
+
+func normalize(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
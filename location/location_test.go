@@ -0,0 +1,279 @@
+package location
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// repoRoot is the repository root as seen from this package.
+const repoRoot = ".."
+
+// localsFile is the generated copy of the tables.
+var localsFile = filepath.Join(repoRoot, "azure", "shared", "locations", "locations.tf")
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	for _, location := range []string{"eastus", "East US", "EASTUS", "east us"} {
+		name, err := Canonical(location)
+		require.NoError(t, err, location)
+		assert.Equal(t, "eastus", name, location)
+	}
+
+	short, err := Short("West Europe")
+	require.NoError(t, err)
+	assert.Equal(t, "weu", short)
+	assert.Equal(t, "eus2", MustShort("eastus2"))
+
+	_, err = Short("Atlantis")
+	assert.EqualError(t, err, `unknown Azure location "Atlantis"`)
+}
+
+func TestRegionTable(t *testing.T) {
+	t.Parallel()
+
+	shortPattern := regexp.MustCompile(`^[a-z][a-z0-9]{1,3}$`)
+	names := map[string]bool{}
+	shorts := map[string]string{}
+	for _, region := range Regions() {
+		assert.False(t, names[region.Name], "duplicate region %s", region.Name)
+		names[region.Name] = true
+
+		if other, ok := shorts[region.Short]; ok {
+			t.Errorf("%s and %s share the short code %s", other, region.Name, region.Short)
+		}
+		shorts[region.Short] = region.Name
+
+		assert.Regexp(t, shortPattern, region.Short, region.Name)
+		// Lookup relies on the canonical name being the display name in
+		// lowercase without spaces.
+		assert.Equal(t, region.Name, normalize(region.DisplayName), region.DisplayName)
+	}
+	assert.Len(t, CanonicalNames(), len(regions))
+}
+
+// TestLocalsInSync fails when locations.tf was not regenerated after a change
+// to regions.go.
+func TestLocalsInSync(t *testing.T) {
+	t.Parallel()
+
+	generated, err := os.ReadFile(localsFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(Locals()), string(generated), "%s is out of date; run go generate ./location", localsFile)
+
+	// The file must also mean the same to Terraform.
+	file, diags := hclsyntax.ParseConfig(generated, localsFile, hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	locals := map[string]cty.Value{}
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "locals" {
+			continue
+		}
+		for name, attr := range block.Body.Attributes {
+			value, diags := attr.Expr.Value(nil)
+			require.False(t, diags.HasErrors(), diags.Error())
+			locals[name] = value
+		}
+	}
+	assert.Equal(t, ShortCodes(), stringMap(t, locals["location_short_codes"]))
+	assert.Equal(t, CanonicalNames(), stringMap(t, locals["location_canonical_names"]))
+}
+
+// TestModuleMatchesLookup evaluates the outputs of the locations module, and
+// the precondition of its name, for every spelling Lookup accepts and checks
+// they agree with it.
+func TestModuleMatchesLookup(t *testing.T) {
+	t.Parallel()
+
+	moduleDir := filepath.Join(repoRoot, "azure", "shared", "locations")
+	outputs := map[string]hcl.Expression{}
+	locals := map[string]hcl.Expression{}
+	for _, name := range []string{"main.tf", "locations.tf", "outputs.tf"} {
+		path := filepath.Join(moduleDir, name)
+		src, err := os.ReadFile(path)
+		require.NoError(t, err)
+		file, diags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+		require.False(t, diags.HasErrors(), diags.Error())
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			switch block.Type {
+			case "locals":
+				for name, attr := range block.Body.Attributes {
+					locals[name] = attr.Expr
+				}
+			case "output":
+				outputs[block.Labels[0]] = block.Body.Attributes["value"].Expr
+				for _, precondition := range block.Body.Blocks {
+					outputs[block.Labels[0]+" precondition"] = precondition.Body.Attributes["condition"].Expr
+				}
+			}
+		}
+	}
+
+	var locations []string
+	for _, region := range Regions() {
+		locations = append(locations, region.Name, region.DisplayName, strings.ToUpper(region.Name), strings.ToLower(region.DisplayName))
+	}
+	for _, location := range append(locations, "Atlantis", "") {
+		values := moduleOutputs(t, locals, outputs, location)
+		region, ok := Lookup(location)
+		assert.Equal(t, cty.BoolVal(ok), values["name precondition"], location)
+		if !ok {
+			assert.True(t, values["display_name"].IsNull(), location)
+			assert.True(t, values["short"].IsNull(), location)
+			continue
+		}
+		assert.Equal(t, cty.StringVal(region.Name), values["name"], location)
+		assert.Equal(t, cty.StringVal(region.DisplayName), values["display_name"], location)
+		assert.Equal(t, cty.StringVal(region.Short), values["short"], location)
+	}
+}
+
+// moduleOutputs evaluates the outputs of a module without resources for the
+// given location, resolving its locals in the order they refer to each other.
+func moduleOutputs(t *testing.T, locals, outputs map[string]hcl.Expression, location string) map[string]cty.Value {
+	t.Helper()
+
+	ctx := &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"contains": stdlib.ContainsFunc,
+			"keys":     stdlib.KeysFunc,
+			"lookup":   stdlib.LookupFunc,
+			"lower":    stdlib.LowerFunc,
+			"replace":  stdlib.ReplaceFunc,
+			"try":      tryfunc.TryFunc,
+		},
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(map[string]cty.Value{"location": cty.StringVal(location)}),
+		},
+	}
+	resolved := map[string]cty.Value{}
+	for len(resolved) < len(locals) {
+		progress := false
+		for name, expr := range locals {
+			if _, ok := resolved[name]; ok || !refersTo(expr, resolved) {
+				continue
+			}
+			ctx.Variables["local"] = cty.ObjectVal(resolved)
+			value, diags := expr.Value(ctx)
+			require.False(t, diags.HasErrors(), "local.%s: %s", name, diags.Error())
+			resolved[name] = value
+			progress = true
+		}
+		require.True(t, progress, "the locals refer to each other in a cycle or to unknown locals")
+	}
+
+	ctx.Variables["local"] = cty.ObjectVal(resolved)
+	values := map[string]cty.Value{}
+	for name, expr := range outputs {
+		value, diags := expr.Value(ctx)
+		require.False(t, diags.HasErrors(), "output %s: %s", name, diags.Error())
+		values[name] = value
+	}
+	return values
+}
+
+// refersTo reports whether every local expr refers to is in resolved.
+func refersTo(expr hcl.Expression, resolved map[string]cty.Value) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "local" || len(traversal) < 2 {
+			continue
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+			if _, ok := resolved[attr.Name]; !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// TestModulesAcceptShortCodes evaluates the location_short validations of
+// every module against every short code.
+func TestModulesAcceptShortCodes(t *testing.T) {
+	t.Parallel()
+
+	paths, err := filepath.Glob(filepath.Join(repoRoot, "azure", "*", "*", "variables.tf"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	ctx := &hcl.EvalContext{
+		Functions: map[string]function.Function{
+			"can":    tryfunc.CanFunc,
+			"regex":  stdlib.RegexFunc,
+			"length": lengthFunc,
+		},
+	}
+
+	for _, path := range paths {
+		for _, condition := range locationShortConditions(t, path) {
+			for _, region := range Regions() {
+				ctx.Variables = map[string]cty.Value{
+					"var": cty.ObjectVal(map[string]cty.Value{"location_short": cty.StringVal(region.Short)}),
+				}
+				value, diags := condition.Value(ctx)
+				require.False(t, diags.HasErrors(), "%s: %s", path, diags.Error())
+				assert.True(t, value.True(), "%s rejects the short code %s of %s", path, region.Short, region.Name)
+			}
+		}
+	}
+}
+
+// lengthFunc is Terraform's length, which unlike cty's also accepts strings.
+var lengthFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "value", Type: cty.DynamicPseudoType}},
+	Type:   function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if args[0].Type() == cty.String {
+			return stdlib.Strlen(args[0])
+		}
+		return stdlib.Length(args[0])
+	},
+})
+
+// locationShortConditions returns the validation conditions of the
+// location_short variable in a variables.tf, if it declares one.
+func locationShortConditions(t *testing.T, path string) []hcl.Expression {
+	t.Helper()
+
+	src, err := os.ReadFile(path)
+	require.NoError(t, err)
+	file, diags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	var conditions []hcl.Expression
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "variable" || len(block.Labels) != 1 || block.Labels[0] != "location_short" {
+			continue
+		}
+		for _, validation := range block.Body.Blocks {
+			if attr, ok := validation.Body.Attributes["condition"]; validation.Type == "validation" && ok {
+				conditions = append(conditions, attr.Expr)
+			}
+		}
+	}
+	return conditions
+}
+
+func stringMap(t *testing.T, value cty.Value) map[string]string {
+	t.Helper()
+
+	require.True(t, value.Type().IsObjectType(), "expected an object, got %s", value.Type().FriendlyName())
+	out := map[string]string{}
+	for key, element := range value.AsValueMap() {
+		out[key] = element.AsString()
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package location
+
+// regions is the table every other view in this package is derived from.
+// Short codes are at most four characters, lowercase, and start with a
+// letter, so they fit the location_short validation of every module and
+// leave room in length-limited names such as storage accounts.
+var regions = []Region{
+	// Americas
+	{Name: "eastus", DisplayName: "East US", Short: "eus"},
+	{Name: "eastus2", DisplayName: "East US 2", Short: "eus2"},
+	{Name: "centralus", DisplayName: "Central US", Short: "cus"},
+	{Name: "northcentralus", DisplayName: "North Central US", Short: "ncus"},
+	{Name: "southcentralus", DisplayName: "South Central US", Short: "scus"},
+	{Name: "westcentralus", DisplayName: "West Central US", Short: "wcus"},
+	{Name: "westus", DisplayName: "West US", Short: "wus"},
+	{Name: "westus2", DisplayName: "West US 2", Short: "wus2"},
+	{Name: "westus3", DisplayName: "West US 3", Short: "wus3"},
+	{Name: "canadacentral", DisplayName: "Canada Central", Short: "cac"},
+	{Name: "canadaeast", DisplayName: "Canada East", Short: "cae"},
+	{Name: "mexicocentral", DisplayName: "Mexico Central", Short: "mxc"},
+	{Name: "brazilsouth", DisplayName: "Brazil South", Short: "brs"},
+	{Name: "brazilsoutheast", DisplayName: "Brazil Southeast", Short: "brse"},
+
+	// Europe
+	{Name: "northeurope", DisplayName: "North Europe", Short: "neu"},
+	{Name: "westeurope", DisplayName: "West Europe", Short: "weu"},
+	{Name: "uksouth", DisplayName: "UK South", Short: "uks"},
+	{Name: "ukwest", DisplayName: "UK West", Short: "ukw"},
+	{Name: "francecentral", DisplayName: "France Central", Short: "frc"},
+	{Name: "francesouth", DisplayName: "France South", Short: "frs"},
+	{Name: "germanywestcentral", DisplayName: "Germany West Central", Short: "gwc"},
+	{Name: "germanynorth", DisplayName: "Germany North", Short: "gn"},
+	{Name: "switzerlandnorth", DisplayName: "Switzerland North", Short: "szn"},
+	{Name: "switzerlandwest", DisplayName: "Switzerland West", Short: "szw"},
+	{Name: "norwayeast", DisplayName: "Norway East", Short: "nwe"},
+	{Name: "norwaywest", DisplayName: "Norway West", Short: "nww"},
+	{Name: "swedencentral", DisplayName: "Sweden Central", Short: "sdc"},
+	{Name: "swedensouth", DisplayName: "Sweden South", Short: "sds"},
+	{Name: "polandcentral", DisplayName: "Poland Central", Short: "plc"},
+	{Name: "italynorth", DisplayName: "Italy North", Short: "itn"},
+	{Name: "spaincentral", DisplayName: "Spain Central", Short: "spc"},
+
+	// Asia Pacific
+	{Name: "australiaeast", DisplayName: "Australia East", Short: "ae"},
+	{Name: "australiasoutheast", DisplayName: "Australia Southeast", Short: "ase"},
+	{Name: "australiacentral", DisplayName: "Australia Central", Short: "acl"},
+	{Name: "australiacentral2", DisplayName: "Australia Central 2", Short: "acl2"},
+	{Name: "newzealandnorth", DisplayName: "New Zealand North", Short: "nzn"},
+	{Name: "eastasia", DisplayName: "East Asia", Short: "ea"},
+	{Name: "southeastasia", DisplayName: "Southeast Asia", Short: "sea"},
+	{Name: "japaneast", DisplayName: "Japan East", Short: "jpe"},
+	{Name: "japanwest", DisplayName: "Japan West", Short: "jpw"},
+	{Name: "koreacentral", DisplayName: "Korea Central", Short: "krc"},
+	{Name: "koreasouth", DisplayName: "Korea South", Short: "krs"},
+	{Name: "centralindia", DisplayName: "Central India", Short: "inc"},
+	{Name: "southindia", DisplayName: "South India", Short: "ins"},
+	{Name: "westindia", DisplayName: "West India", Short: "inw"},
+	{Name: "jioindiacentral", DisplayName: "Jio India Central", Short: "jic"},
+	{Name: "jioindiawest", DisplayName: "Jio India West", Short: "jiw"},
+
+	// Middle East and Africa
+	{Name: "uaenorth", DisplayName: "UAE North", Short: "uan"},
+	{Name: "uaecentral", DisplayName: "UAE Central", Short: "uac"},
+	{Name: "qatarcentral", DisplayName: "Qatar Central", Short: "qac"},
+	{Name: "israelcentral", DisplayName: "Israel Central", Short: "ilc"},
+	{Name: "southafricanorth", DisplayName: "South Africa North", Short: "san"},
+	{Name: "southafricawest", DisplayName: "South Africa West", Short: "saw"},
+}
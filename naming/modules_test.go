@@ -0,0 +1,144 @@
+package naming
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// repoRoot is the repository root as seen from this package.
+const repoRoot = ".."
+
+// TestModuleLocalsMatchRules evaluates the naming local of every module that
+// supports use_naming_convention and compares it with the rule in this
+// package, so the two cannot drift apart.
+func TestModuleLocalsMatchRules(t *testing.T) {
+	t.Parallel()
+
+	parts := Parts{
+		Name:          "app-data",
+		Environment:   "dev",
+		LocationShort: "eus",
+		Project:       "zrr",
+		DomainSuffix:  "example.com",
+		Suffix:        "x1y2",
+	}
+
+	testCases := []struct {
+		module string
+		local  string
+		kind   Kind
+	}{
+		{module: "azure/infrastructure/resource-group", local: "resource_group_name", kind: ResourceGroup},
+		{module: "azure/infrastructure/virtual-network", local: "vnet_name", kind: VirtualNetwork},
+		{module: "azure/application/container-instance", local: "container_group_name", kind: ContainerGroup},
+		{module: "azure/infrastructure/dns-zone", local: "dns_zone_name", kind: DNSZone},
+		{module: "azure/infrastructure/mysql-flexible-server", local: "server_name", kind: MySQLFlexibleServer},
+		{module: "azure/infrastructure/mysql-database", local: "database_name", kind: MySQLDatabase},
+		{module: "azure/infrastructure/storage-account", local: "storage_account_name", kind: StorageAccount},
+		{module: "azure/state/az-tf-init", local: "resource_group_name", kind: StateResourceGroup},
+		{module: "azure/state/az-tf-init", local: "storage_account_name", kind: StateStorageAccount},
+		{module: "azure/state/az-tf-init", local: "key_vault_name", kind: StateKeyVault},
+	}
+
+	covered := map[Kind]bool{}
+	for _, tc := range testCases {
+		tc := tc
+		covered[tc.kind] = true
+		t.Run(string(tc.kind), func(t *testing.T) {
+			t.Parallel()
+
+			rule, ok := Lookup(tc.kind)
+			require.True(t, ok)
+
+			expr := findLocal(t, filepath.Join(repoRoot, tc.module, "main.tf"), tc.local)
+			assert.Equal(t, rule.Format(parts), evalNamingLocal(t, expr, parts, true),
+				"%s: local.%s differs from the %s rule", tc.module, tc.local, tc.kind)
+			assert.Equal(t, "custom-name", evalNamingLocal(t, expr, parts, false),
+				"%s: local.%s must use the given name when use_naming_convention is false", tc.module, tc.local)
+		})
+	}
+
+	for _, rule := range Rules() {
+		assert.True(t, covered[rule.Kind], "rule %s is not compared with any module", rule.Kind)
+	}
+}
+
+// findLocal returns the expression of the named local in a Terraform file.
+func findLocal(t *testing.T, path, name string) hcl.Expression {
+	t.Helper()
+
+	src, err := os.ReadFile(path)
+	require.NoError(t, err)
+	file, diags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+	require.False(t, diags.HasErrors(), diags.Error())
+
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type != "locals" {
+			continue
+		}
+		if attr, ok := block.Body.Attributes[name]; ok {
+			return attr.Expr
+		}
+	}
+	t.Fatalf("%s has no local %q", path, name)
+	return nil
+}
+
+// evalNamingLocal evaluates a naming local with the variables set from parts.
+// Every variable a local falls back to when the convention is off is set to
+// "custom-name".
+func evalNamingLocal(t *testing.T, expr hcl.Expression, parts Parts, useNamingConvention bool) string {
+	t.Helper()
+
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"var": cty.ObjectVal(map[string]cty.Value{
+				"use_naming_convention": cty.BoolVal(useNamingConvention),
+				"name":                  cty.StringVal(parts.Name),
+				"environment":           cty.StringVal(parts.Environment),
+				"location_short":        cty.StringVal(parts.LocationShort),
+				"project_name":          cty.StringVal(parts.Project),
+				"domain_suffix":         cty.StringVal(parts.DomainSuffix),
+				"resource_group_name":   cty.StringVal("custom-name"),
+				"storage_account_name":  cty.StringVal("custom-name"),
+				"key_vault_name":        cty.StringVal("custom-name"),
+			}),
+			// Modules deriving location_short from location keep it in a
+			// local of the same name.
+			"local": cty.ObjectVal(map[string]cty.Value{
+				"location_short": cty.StringVal(parts.LocationShort),
+			}),
+			"random_string": cty.ObjectVal(map[string]cty.Value{
+				"suffix": cty.ObjectVal(map[string]cty.Value{
+					"result": cty.StringVal(parts.Suffix),
+				}),
+			}),
+		},
+		Functions: map[string]function.Function{
+			"replace": stdlib.ReplaceFunc,
+			"lower":   stdlib.LowerFunc,
+			"substr":  stdlib.SubstrFunc,
+		},
+	}
+
+	if !useNamingConvention {
+		// The fallback is var.name in most modules; make it distinguishable.
+		values := ctx.Variables["var"].AsValueMap()
+		values["name"] = cty.StringVal("custom-name")
+		ctx.Variables["var"] = cty.ObjectVal(values)
+	}
+
+	value, diags := expr.Value(ctx)
+	require.False(t, diags.HasErrors(), diags.Error())
+	require.True(t, value.Type() == cty.String, "naming local is not a string")
+	return value.AsString()
+}
@@ -0,0 +1,182 @@
+// Package naming encodes the ZRR naming convention the modules apply when
+// use_naming_convention is true, together with the Azure length and charset
+// limits of each resource type. Tests and tools build expected names here
+// instead of repeating the module locals with fmt.Sprintf:
+//
+//	name, err := naming.Build(naming.ResourceGroup, naming.Parts{
+//		Name:          "app",
+//		Environment:   "dev",
+//		LocationShort: "eus",
+//	})
+//	// name == "rg-dev-app-eus"
+package naming
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kind identifies one naming rule. Most kinds name a resource type; the
+// az-tf-init module has its own rules for the state backend resources.
+type Kind string
+
+// Naming rules of the library's modules.
+const (
+	ResourceGroup       Kind = "resource_group"
+	VirtualNetwork      Kind = "virtual_network"
+	ContainerGroup      Kind = "container_group"
+	DNSZone             Kind = "dns_zone"
+	MySQLFlexibleServer Kind = "mysql_flexible_server"
+	MySQLDatabase       Kind = "mysql_database"
+	StorageAccount      Kind = "storage_account"
+	StateResourceGroup  Kind = "state_resource_group"
+	StateStorageAccount Kind = "state_storage_account"
+	StateKeyVault       Kind = "state_key_vault"
+)
+
+// Parts are the inputs of a generated name. They correspond to the module
+// variables of the same name; Suffix is the result of the module's
+// random_string.suffix.
+type Parts struct {
+	Name          string
+	Environment   string
+	LocationShort string
+	Project       string
+	DomainSuffix  string
+	Suffix        string
+}
+
+// Rule describes how a kind of resource is named and which names Azure
+// accepts for it.
+type Rule struct {
+	Kind Kind
+
+	// Pattern is the name template. Placeholders are written in braces:
+	// {name}, {name_compact} (the name without hyphens), {environment},
+	// {location_short}, {project}, {domain_suffix} and {suffix}.
+	Pattern string
+
+	// MinLength and MaxLength bound the length of the name in characters.
+	MinLength, MaxLength int
+
+	// Allowed matches the whole of every name Azure accepts, apart from
+	// the length.
+	Allowed *regexp.Regexp
+
+	// Charset describes Allowed for error messages.
+	Charset string
+}
+
+var placeholderPattern = regexp.MustCompile(`\{[a-z_]+\}`)
+
+// Placeholders returns the placeholders used by the rule's pattern, in order.
+func (r Rule) Placeholders() []string {
+	var placeholders []string
+	for _, match := range placeholderPattern.FindAllString(r.Pattern, -1) {
+		placeholders = append(placeholders, strings.Trim(match, "{}"))
+	}
+	return placeholders
+}
+
+// Format fills in the rule's pattern without validating the result.
+func (r Rule) Format(parts Parts) string {
+	return placeholderPattern.ReplaceAllStringFunc(r.Pattern, func(match string) string {
+		value, _ := parts.value(strings.Trim(match, "{}"))
+		return value
+	})
+}
+
+// Build fills in the rule's pattern and validates the result. Every
+// placeholder of the pattern must have a value.
+func (r Rule) Build(parts Parts) (string, error) {
+	for _, placeholder := range r.Placeholders() {
+		if value, _ := parts.value(placeholder); value == "" {
+			return "", fmt.Errorf("%s name: %s is required", r.Kind, placeholder)
+		}
+	}
+	name := r.Format(parts)
+	if err := r.Validate(name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// Validate reports whether Azure accepts name for the rule's resource type.
+func (r Rule) Validate(name string) error {
+	length := len([]rune(name))
+	if length < r.MinLength || length > r.MaxLength {
+		return fmt.Errorf("%s name %q is %d characters long; it must be %d-%d", r.Kind, name, length, r.MinLength, r.MaxLength)
+	}
+	if !r.Allowed.MatchString(name) {
+		return fmt.Errorf("%s name %q is invalid; it may only contain %s", r.Kind, name, r.Charset)
+	}
+	return nil
+}
+
+func (p Parts) value(placeholder string) (string, bool) {
+	switch placeholder {
+	case "name":
+		return p.Name, true
+	case "name_compact":
+		return strings.ReplaceAll(p.Name, "-", ""), true
+	case "environment":
+		return p.Environment, true
+	case "location_short":
+		return p.LocationShort, true
+	case "project":
+		return p.Project, true
+	case "domain_suffix":
+		return p.DomainSuffix, true
+	case "suffix":
+		return p.Suffix, true
+	default:
+		return "", false
+	}
+}
+
+// Lookup returns the rule for kind.
+func Lookup(kind Kind) (Rule, bool) {
+	rule, ok := rules[kind]
+	return rule, ok
+}
+
+// Rules returns every rule, sorted by kind.
+func Rules() []Rule {
+	all := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		all = append(all, rule)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Kind < all[j].Kind })
+	return all
+}
+
+// Build builds and validates the name of a resource of the given kind.
+func Build(kind Kind, parts Parts) (string, error) {
+	rule, ok := Lookup(kind)
+	if !ok {
+		return "", fmt.Errorf("unknown naming rule %q", kind)
+	}
+	return rule.Build(parts)
+}
+
+// MustBuild is like Build but panics on an error. It is meant for tests whose
+// inputs are constants.
+func MustBuild(kind Kind, parts Parts) string {
+	name, err := Build(kind, parts)
+	if err != nil {
+		panic(err)
+	}
+	return name
+}
+
+// Validate reports whether Azure accepts name for a resource of the given
+// kind.
+func Validate(kind Kind, name string) error {
+	rule, ok := Lookup(kind)
+	if !ok {
+		return fmt.Errorf("unknown naming rule %q", kind)
+	}
+	return rule.Validate(name)
+}
@@ -0,0 +1,148 @@
+package naming
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		kind   Kind
+		parts  Parts
+		expect string
+		err    string
+	}{
+		{
+			kind:   ResourceGroup,
+			parts:  Parts{Name: "app", Environment: "dev", LocationShort: "eus"},
+			expect: "rg-dev-app-eus",
+		},
+		{
+			kind:   VirtualNetwork,
+			parts:  Parts{Name: "hub", Environment: "prod", LocationShort: "weu"},
+			expect: "vnet-prod-hub-weu",
+		},
+		{
+			kind:   ContainerGroup,
+			parts:  Parts{Name: "web", Environment: "test", LocationShort: "eus2"},
+			expect: "aci-web-test-eus2",
+		},
+		{
+			kind:  ContainerGroup,
+			parts: Parts{Name: "Web", Environment: "test", LocationShort: "eus2"},
+			err:   "may only contain lowercase letters",
+		},
+		{
+			kind:   DNSZone,
+			parts:  Parts{Name: "app", Environment: "dev", DomainSuffix: "internal"},
+			expect: "app.dev.internal",
+		},
+		{
+			kind:  DNSZone,
+			parts: Parts{Name: "-app", Environment: "dev", DomainSuffix: "internal"},
+			err:   "dot-separated labels",
+		},
+		{
+			kind:   MySQLFlexibleServer,
+			parts:  Parts{Name: "orders", Environment: "prod", LocationShort: "eus"},
+			expect: "orders-mysql-prod-eus",
+		},
+		{
+			kind:   MySQLDatabase,
+			parts:  Parts{Name: "orders", Environment: "prod", LocationShort: "eus"},
+			expect: "orders-db-prod-eus",
+		},
+		{
+			kind:   StorageAccount,
+			parts:  Parts{Name: "app-data", Environment: "dev", LocationShort: "eus", Suffix: "a1b2c3"},
+			expect: "sadevappdataeusa1b2c3",
+		},
+		{
+			kind:  StorageAccount,
+			parts: Parts{Name: "application-data", Environment: "dev", LocationShort: "eus", Suffix: "a1b2c3"},
+			err:   "is 29 characters long; it must be 3-24",
+		},
+		{
+			kind:  StorageAccount,
+			parts: Parts{Name: "app_data", Environment: "dev", LocationShort: "eus", Suffix: "a1b2c3"},
+			err:   "lowercase letters and digits",
+		},
+		{
+			kind:   StateResourceGroup,
+			parts:  Parts{Project: "zrr", Environment: "dev", LocationShort: "eus"},
+			expect: "rg-zrr-dev-tfstate-eus",
+		},
+		{
+			kind:   StateStorageAccount,
+			parts:  Parts{Project: "zr", Environment: "dev", LocationShort: "eu", Suffix: "a1b2c3d4"},
+			expect: "sazrdevtfstateeua1b2c3d4",
+		},
+		{
+			// The 8 character suffix leaves 7 characters for project,
+			// environment and location together.
+			kind:  StateStorageAccount,
+			parts: Parts{Project: "zrr", Environment: "dev", LocationShort: "eus", Suffix: "a1b2c3d4"},
+			err:   "is 26 characters long",
+		},
+		{
+			kind:   StateKeyVault,
+			parts:  Parts{Project: "z", Environment: "d", Suffix: "a1b2c3d4"},
+			expect: "kv-z-d-tfstate-a1b2c3d4",
+		},
+		{
+			// Key Vault names are capped at 24 characters, so only very
+			// short project and environment names fit.
+			kind:  StateKeyVault,
+			parts: Parts{Project: "zrr", Environment: "dev", Suffix: "a1b2c3d4"},
+			err:   "is 27 characters long",
+		},
+		{
+			kind:  ResourceGroup,
+			parts: Parts{Name: "app", Environment: "dev"},
+			err:   "location_short is required",
+		},
+	}
+
+	for _, tc := range testCases {
+		name, err := Build(tc.kind, tc.parts)
+		if tc.err != "" {
+			require.Error(t, err, "%s %+v", tc.kind, tc.parts)
+			assert.Contains(t, err.Error(), tc.err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.expect, name)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Validate(ResourceGroup, "rg-dev-app(1)-eus"))
+	assert.Error(t, Validate(ResourceGroup, "rg-dev-app."))
+	assert.Error(t, Validate(ResourceGroup, strings.Repeat("a", 91)))
+	assert.NoError(t, Validate(VirtualNetwork, "vnet_"))
+	assert.Error(t, Validate(VirtualNetwork, "v"))
+	assert.Error(t, Validate(StateKeyVault, "kv--double"))
+	assert.Error(t, Validate(StateKeyVault, "1kv"))
+	assert.Error(t, Validate(MySQLFlexibleServer, "ab"))
+	assert.Error(t, Validate(Kind("unknown"), "x"))
+}
+
+func TestRules(t *testing.T) {
+	t.Parallel()
+
+	for _, rule := range Rules() {
+		assert.NotEmpty(t, rule.Placeholders(), rule.Kind)
+		for _, placeholder := range rule.Placeholders() {
+			_, known := Parts{}.value(placeholder)
+			assert.True(t, known, "%s: unknown placeholder %s", rule.Kind, placeholder)
+		}
+		assert.LessOrEqual(t, rule.MinLength, rule.MaxLength, rule.Kind)
+	}
+}
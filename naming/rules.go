@@ -0,0 +1,108 @@
+package naming
+
+import "regexp"
+
+// Charsets shared by several rules.
+const (
+	lowerAlphanumeric       = "lowercase letters and digits"
+	lowerAlphanumericHyphen = "lowercase letters, digits and hyphens, starting and ending with a letter or digit"
+)
+
+var (
+	lowerAlphanumericPattern       = regexp.MustCompile(`^[a-z0-9]+$`)
+	lowerAlphanumericHyphenPattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+	resourceGroupPattern           = regexp.MustCompile(`^[-a-zA-Z0-9_.()]*[-a-zA-Z0-9_()]$`)
+)
+
+// rules mirror the naming locals of the modules. A change to a module's
+// locals must be made here too; modules_test.go compares the two.
+var rules = map[Kind]Rule{
+	// azure/infrastructure/resource-group
+	ResourceGroup: {
+		Kind:      ResourceGroup,
+		Pattern:   "rg-{environment}-{name}-{location_short}",
+		MinLength: 1,
+		MaxLength: 90,
+		Allowed:   resourceGroupPattern,
+		Charset:   "letters, digits, underscores, hyphens, periods and parentheses, not ending with a period",
+	},
+	// azure/infrastructure/virtual-network
+	VirtualNetwork: {
+		Kind:      VirtualNetwork,
+		Pattern:   "vnet-{environment}-{name}-{location_short}",
+		MinLength: 2,
+		MaxLength: 64,
+		Allowed:   regexp.MustCompile(`^[a-zA-Z0-9][-a-zA-Z0-9_.]*[a-zA-Z0-9_]$`),
+		Charset:   "letters, digits, underscores, hyphens and periods, starting with a letter or digit and ending with a letter, digit or underscore",
+	},
+	// azure/application/container-instance
+	ContainerGroup: {
+		Kind:      ContainerGroup,
+		Pattern:   "aci-{name}-{environment}-{location_short}",
+		MinLength: 1,
+		MaxLength: 63,
+		Allowed:   lowerAlphanumericHyphenPattern,
+		Charset:   lowerAlphanumericHyphen,
+	},
+	// azure/infrastructure/dns-zone
+	DNSZone: {
+		Kind:      DNSZone,
+		Pattern:   "{name}.{environment}.{domain_suffix}",
+		MinLength: 1,
+		MaxLength: 253,
+		Allowed:   regexp.MustCompile(`^([a-zA-Z0-9]([-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z0-9]([-a-zA-Z0-9]{0,61}[a-zA-Z0-9])?$`),
+		Charset:   "at least two dot-separated labels of up to 63 letters, digits and hyphens",
+	},
+	// azure/infrastructure/mysql-flexible-server
+	MySQLFlexibleServer: {
+		Kind:      MySQLFlexibleServer,
+		Pattern:   "{name}-mysql-{environment}-{location_short}",
+		MinLength: 3,
+		MaxLength: 63,
+		Allowed:   lowerAlphanumericHyphenPattern,
+		Charset:   lowerAlphanumericHyphen,
+	},
+	// azure/infrastructure/mysql-database
+	MySQLDatabase: {
+		Kind:      MySQLDatabase,
+		Pattern:   "{name}-db-{environment}-{location_short}",
+		MinLength: 1,
+		MaxLength: 64,
+		Allowed:   regexp.MustCompile(`^[-a-zA-Z0-9_]+$`),
+		Charset:   "letters, digits, underscores and hyphens",
+	},
+	// azure/infrastructure/storage-account
+	StorageAccount: {
+		Kind:      StorageAccount,
+		Pattern:   "sa{environment}{name_compact}{location_short}{suffix}",
+		MinLength: 3,
+		MaxLength: 24,
+		Allowed:   lowerAlphanumericPattern,
+		Charset:   lowerAlphanumeric,
+	},
+	// azure/state/az-tf-init
+	StateResourceGroup: {
+		Kind:      StateResourceGroup,
+		Pattern:   "rg-{project}-{environment}-tfstate-{location_short}",
+		MinLength: 1,
+		MaxLength: 90,
+		Allowed:   resourceGroupPattern,
+		Charset:   "letters, digits, underscores, hyphens, periods and parentheses, not ending with a period",
+	},
+	StateStorageAccount: {
+		Kind:      StateStorageAccount,
+		Pattern:   "sa{project}{environment}tfstate{location_short}{suffix}",
+		MinLength: 3,
+		MaxLength: 24,
+		Allowed:   lowerAlphanumericPattern,
+		Charset:   lowerAlphanumeric,
+	},
+	StateKeyVault: {
+		Kind:      StateKeyVault,
+		Pattern:   "kv-{project}-{environment}-tfstate-{suffix}",
+		MinLength: 3,
+		MaxLength: 24,
+		Allowed:   regexp.MustCompile(`^[a-zA-Z]([a-zA-Z0-9]|-[a-zA-Z0-9])*$`),
+		Charset:   "letters, digits and single hyphens, starting with a letter and ending with a letter or digit",
+	},
+}
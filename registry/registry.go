@@ -0,0 +1,133 @@
+// Package registry reads and checks module-registry.json, the catalogue of
+// the library's modules at the repository root.
+//
+// Most fields of an entry restate what the module's own files say: its path,
+// its examples, the providers and Terraform version of its terraform block.
+// Validate compares them, so the registry cannot drift from the modules it
+// describes. The remaining fields, such as features and tags, are curated by
+// hand. The JSON Schema in module-registry.schema.json describes the same
+// format for editors.
+//
+// LoadVariables reads the input variables of a module, for the tools that
+// work from its interface: fuzzing its validations, generating typed inputs
+// and proposing version bumps.
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+const (
+	// File is the registry at the repository root.
+	File = "module-registry.json"
+
+	// SchemaFile is the JSON Schema of File, next to it.
+	SchemaFile = "module-registry.schema.json"
+)
+
+// Registry is the content of File.
+type Registry struct {
+	// Schema refers editors to SchemaFile.
+	Schema string `json:"$schema,omitempty"`
+
+	// Version is the version of the library as a whole.
+	Version string `json:"version"`
+
+	// Updated is the date, as YYYY-MM-DD, of the latest change to any
+	// entry.
+	Updated string `json:"updated"`
+
+	Modules []Module `json:"modules"`
+}
+
+// Module is the registry entry of a module.
+type Module struct {
+	// Name is the module's directory name, e.g. key-vault.
+	Name string `json:"name"`
+
+	// Cloud and Layer are the directories above it, e.g. azure and
+	// security.
+	Cloud string `json:"cloud"`
+	Layer string `json:"layer"`
+
+	// Path is the module's directory relative to the repository root, e.g.
+	// azure/security/key-vault.
+	Path string `json:"path"`
+
+	// Version is the module's semantic version.
+	Version     string   `json:"version"`
+	Description string   `json:"description"`
+	Features    []string `json:"features"`
+
+	// Examples are the directories under the module's examples/.
+	Examples []string `json:"examples"`
+
+	// RequiredProviders are the version constraints of the providers the
+	// module's terraform block requires, by local name.
+	RequiredProviders map[string]string `json:"required_providers"`
+
+	// TerraformVersion is the required_version of the terraform block.
+	TerraformVersion string `json:"terraform_version"`
+
+	// Created and Updated are dates as YYYY-MM-DD.
+	Created string `json:"created"`
+	Updated string `json:"updated"`
+
+	Author string   `json:"author"`
+	Tags   []string `json:"tags"`
+}
+
+// Load reads the registry of the repository at root.
+func Load(root string) (*Registry, error) {
+	data, err := os.ReadFile(filepath.Join(root, File))
+	if err != nil {
+		return nil, err
+	}
+	registry, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", File, err)
+	}
+	return registry, nil
+}
+
+// Parse parses a registry. Fields it does not know are an error, so a typo
+// in a field name does not go unnoticed.
+func Parse(data []byte) (*Registry, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var registry Registry
+	if err := decoder.Decode(&registry); err != nil {
+		return nil, err
+	}
+	return &registry, nil
+}
+
+// Marshal returns the registry as File is formatted: indented by two spaces,
+// with constraints such as ">= 1.0" left unescaped.
+func (r *Registry) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(r); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Module returns the entry of the module at path, relative to the
+// repository root, or nil.
+func (r *Registry) Module(modulePath string) *Module {
+	modulePath = path.Clean(filepath.ToSlash(modulePath))
+	for i := range r.Modules {
+		if path.Clean(r.Modules[i].Path) == modulePath {
+			return &r.Modules[i]
+		}
+	}
+	return nil
+}
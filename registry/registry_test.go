@@ -0,0 +1,95 @@
+package registry
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	r, err := Load("testdata")
+	require.NoError(t, err)
+	assert.Equal(t, "./"+SchemaFile, r.Schema)
+	require.Len(t, r.Modules, 2)
+	storage := r.Module("azure/infrastructure/storage/")
+	require.NotNil(t, storage)
+	assert.Equal(t, "storage", storage.Name)
+	assert.Equal(t, map[string]string{"azurerm": "~> 3.0", "random": "~> 3.0"}, storage.RequiredProviders)
+	assert.Nil(t, r.Module("azure/infrastructure/missing"))
+
+	// Marshal writes the file back as it was.
+	data, err := os.ReadFile("testdata/" + File)
+	require.NoError(t, err)
+	marshaled, err := r.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, string(data), string(marshaled))
+}
+
+func TestParseUnknownField(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte(`{"version": "1.0.0", "modules": [{"name": "storage", "tag": ["azure"]}]}`))
+	assert.ErrorContains(t, err, `unknown field "tag"`)
+}
+
+// TestSchema checks that the JSON Schema and the Go types describe the same
+// fields.
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("../" + SchemaFile)
+	require.NoError(t, err)
+	var schema struct {
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       struct {
+			Module struct {
+				Required   []string                   `json:"required"`
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"module"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	fields := func(v interface{}) (all, required []string) {
+		ty := reflect.TypeOf(v)
+		for i := 0; i < ty.NumField(); i++ {
+			tag := strings.Split(ty.Field(i).Tag.Get("json"), ",")
+			all = append(all, tag[0])
+			if len(tag) == 1 {
+				required = append(required, tag[0])
+			}
+		}
+		sort.Strings(all)
+		sort.Strings(required)
+		return all, required
+	}
+	keys := func(properties map[string]json.RawMessage) []string {
+		var names []string
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	sorted := func(list []string) []string {
+		list = append([]string(nil), list...)
+		sort.Strings(list)
+		return list
+	}
+
+	all, required := fields(Registry{})
+	assert.Equal(t, all, keys(schema.Properties))
+	assert.Equal(t, required, sorted(schema.Required))
+	all, required = fields(Module{})
+	assert.Equal(t, all, keys(schema.Defs.Module.Properties))
+	assert.Equal(t, required, sorted(schema.Defs.Module.Required))
+}
@@ -0,0 +1,236 @@
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Clouds are the directories at the repository root holding modules, as
+// <cloud>/<layer>/<name>.
+var Clouds = []string{"azure"}
+
+// Source is what a module's files say about the fields of its entry.
+type Source struct {
+	// Path is the module's directory relative to the repository root.
+	Path string
+
+	// Examples are the directories under examples/ holding a .tf file,
+	// sorted.
+	Examples []string
+
+	// RequiredProviders and TerraformVersion are read from the module's
+	// terraform blocks, in whichever .tf files they are.
+	RequiredProviders map[string]string
+	TerraformVersion  string
+
+	// Description is the "# Description:" line of the comment heading
+	// main.tf, or empty if there is none.
+	Description string
+}
+
+// descriptionPrefix starts the line of the main.tf header holding the
+// module's description:
+//
+//	# azure-security-key-vault module
+//	# Description: Manages Azure Key Vault with ...
+const descriptionPrefix = "# Description:"
+
+// Discover returns the paths, relative to root, of the modules under root:
+// the <cloud>/<layer>/<name> directories holding a .tf file. They are
+// sorted.
+func Discover(root string) ([]string, error) {
+	seen := map[string]bool{}
+	var paths []string
+	for _, cloud := range Clouds {
+		files, err := filepath.Glob(filepath.Join(root, cloud, "*", "*", "*.tf"))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			relative, err := filepath.Rel(root, filepath.Dir(file))
+			if err != nil {
+				return nil, err
+			}
+			if relative = filepath.ToSlash(relative); !seen[relative] {
+				seen[relative] = true
+				paths = append(paths, relative)
+			}
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// ReadSource reads the module at modulePath, relative to root.
+func ReadSource(root, modulePath string) (*Source, error) {
+	dir := filepath.Join(root, filepath.FromSlash(modulePath))
+	source := &Source{Path: path.Clean(filepath.ToSlash(modulePath)), RequiredProviders: map[string]string{}}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%s: no .tf files", source.Path)
+	}
+	parser := hclparse.NewParser()
+	var versions []string
+	for _, file := range files {
+		f, diags := parser.ParseHCLFile(file)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		content, _, diags := f.Body.PartialContent(terraformSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for _, block := range content.Blocks {
+			version, err := readTerraformBlock(block, source.RequiredProviders)
+			if err != nil {
+				return nil, err
+			}
+			if version != "" && !contains(versions, version) {
+				versions = append(versions, version)
+			}
+		}
+	}
+	// Terraform requires every required_version of the module at once.
+	source.TerraformVersion = strings.Join(versions, ", ")
+
+	if source.Description, err = readDescription(filepath.Join(dir, "main.tf")); err != nil {
+		return nil, err
+	}
+
+	examples, err := os.ReadDir(filepath.Join(dir, "examples"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	source.Examples = []string{}
+	for _, example := range examples {
+		if !example.IsDir() {
+			continue
+		}
+		tf, err := filepath.Glob(filepath.Join(dir, "examples", example.Name(), "*.tf"))
+		if err != nil {
+			return nil, err
+		}
+		if len(tf) > 0 {
+			source.Examples = append(source.Examples, example.Name())
+		}
+	}
+	sort.Strings(source.Examples)
+	return source, nil
+}
+
+// readDescription returns the description in the comment heading file.
+func readDescription(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		if strings.HasPrefix(line, descriptionPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, descriptionPrefix)), nil
+		}
+	}
+	return "", nil
+}
+
+var terraformSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+}
+
+var terraformBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "required_version"}},
+	Blocks:     []hcl.BlockHeaderSchema{{Type: "required_providers"}},
+}
+
+// readTerraformBlock adds the provider constraints of a terraform block to
+// providers and returns its required_version.
+func readTerraformBlock(block *hcl.Block, providers map[string]string) (string, error) {
+	content, _, diags := block.Body.PartialContent(terraformBlockSchema)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	version := ""
+	if attr, ok := content.Attributes["required_version"]; ok {
+		if version, diags = stringValue(attr.Expr); diags.HasErrors() {
+			return "", diags
+		}
+	}
+
+	for _, block := range content.Blocks {
+		attrs, diags := block.Body.JustAttributes()
+		if diags.HasErrors() {
+			return "", diags
+		}
+		for name, attr := range attrs {
+			constraint, diags := providerVersion(attr.Expr)
+			if diags.HasErrors() {
+				return "", diags
+			}
+			providers[name] = constraint
+		}
+	}
+	return version, nil
+}
+
+// providerVersion returns the version of a required_providers entry, either
+// an object with source and version or, in the older form, the constraint
+// alone. The object is not evaluated as a whole, since
+// configuration_aliases refers to providers.
+func providerVersion(expr hcl.Expression) (string, hcl.Diagnostics) {
+	pairs, diags := hcl.ExprMap(expr)
+	if diags.HasErrors() {
+		return stringValue(expr)
+	}
+	for _, pair := range pairs {
+		key := hcl.ExprAsKeyword(pair.Key)
+		if key == "" {
+			key, _ = stringValue(pair.Key)
+		}
+		if key == "version" {
+			return stringValue(pair.Value)
+		}
+	}
+	return "", nil
+}
+
+func stringValue(expr hcl.Expression) (string, hcl.Diagnostics) {
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return "", diags
+	}
+	if value.Type() != cty.String || value.IsNull() || !value.IsKnown() {
+		return "", hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Expected a string",
+			Subject:  expr.Range().Ptr(),
+		}}
+	}
+	return value.AsString(), nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
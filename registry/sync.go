@@ -0,0 +1,174 @@
+package registry
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultAuthor is the author of the entries Sync adds.
+const DefaultAuthor = "ZRR Platform Team"
+
+// Change is a change Sync made to the registry.
+type Change struct {
+	// Module is the path of the module whose entry changed, or empty for
+	// a field of the registry itself.
+	Module string
+
+	// Field is the JSON name of the field that changed, or empty for an
+	// entry that was added.
+	Field string
+
+	Old, New string
+}
+
+// String returns the change as module: field: old -> new.
+func (c Change) String() string {
+	if c.Field == "" {
+		return c.Module + ": added"
+	}
+	change := fmt.Sprintf("%s: %s -> %s", c.Field, c.Old, c.New)
+	if c.Module == "" {
+		return change
+	}
+	return c.Module + ": " + change
+}
+
+// Sync updates the entries of the modules in paths, relative to root, from
+// their files, and adds an entry for each module not in the registry yet.
+// The fields Validate compares are rewritten; hand-curated fields such as
+// features and tags are kept. The updated date of an entry, and of the
+// registry, is set to today only if the entry changed. Examples already
+// listed keep their order.
+//
+// Added entries start at version 1.0.0 with the module's name, cloud and
+// layer as tags and no features, which are left to be written by hand.
+func Sync(root string, r *Registry, paths []string, today time.Time) ([]Change, error) {
+	date := today.Format(DateLayout)
+	var changes []Change
+	for _, modulePath := range paths {
+		modulePath = path.Clean(modulePath)
+		source, err := ReadSource(root, modulePath)
+		if err != nil {
+			return nil, err
+		}
+
+		module := r.Module(modulePath)
+		added := module == nil
+		if added {
+			parts := strings.Split(modulePath, "/")
+			if len(parts) != 3 {
+				return nil, fmt.Errorf("%s is not <cloud>/<layer>/<name>", modulePath)
+			}
+			r.Modules = append(r.Modules, Module{
+				Name:     parts[2],
+				Cloud:    parts[0],
+				Layer:    parts[1],
+				Path:     modulePath,
+				Version:  "1.0.0",
+				Features: []string{},
+				Created:  date,
+				Author:   DefaultAuthor,
+				Tags:     []string{parts[0], parts[2], parts[1]},
+			})
+			module = &r.Modules[len(r.Modules)-1]
+		}
+
+		moduleChanges := module.sync(source)
+		if added {
+			module.Updated = date
+			changes = append(changes, Change{Module: modulePath})
+			continue
+		}
+		for i := range moduleChanges {
+			moduleChanges[i].Module = modulePath
+		}
+		if len(moduleChanges) > 0 && module.Updated != date {
+			moduleChanges = append(moduleChanges, Change{Module: modulePath, Field: "updated", Old: module.Updated, New: date})
+			module.Updated = date
+		}
+		changes = append(changes, moduleChanges...)
+	}
+	if len(changes) > 0 && r.Updated != date {
+		changes = append(changes, Change{Field: "updated", Old: r.Updated, New: date})
+		r.Updated = date
+	}
+	return changes, nil
+}
+
+// sync sets the fields of the entry read from the module's files and
+// returns those that changed.
+func (m *Module) sync(source *Source) []Change {
+	var changes []Change
+	set := func(field string, target *string, value string) {
+		if *target != value {
+			changes = append(changes, Change{Field: field, Old: quote(*target), New: quote(value)})
+			*target = value
+		}
+	}
+
+	if source.Description != "" {
+		set("description", &m.Description, source.Description)
+	}
+	set("terraform_version", &m.TerraformVersion, source.TerraformVersion)
+
+	examples := syncExamples(m.Examples, source.Examples)
+	if !equal(examples, m.Examples) {
+		changes = append(changes, Change{Field: "examples", Old: describe(m.Examples), New: describe(examples)})
+		m.Examples = examples
+	}
+
+	if before, after := describeProviders(m.RequiredProviders), describeProviders(source.RequiredProviders); before != after {
+		changes = append(changes, Change{Field: "required_providers", Old: before, New: after})
+	}
+	m.RequiredProviders = source.RequiredProviders
+	return changes
+}
+
+// syncExamples returns the examples of the module: those listed that still
+// exist, in their order, followed by the new ones.
+func syncExamples(listed, found []string) []string {
+	examples := []string{}
+	for _, example := range listed {
+		if contains(found, example) && !contains(examples, example) {
+			examples = append(examples, example)
+		}
+	}
+	for _, example := range found {
+		if !contains(examples, example) {
+			examples = append(examples, example)
+		}
+	}
+	return examples
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func describeProviders(providers map[string]string) string {
+	var names []string
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var parts []string
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s %q", name, providers[name]))
+	}
+	return describe(parts)
+}
+
+func quote(s string) string {
+	return fmt.Sprintf("%q", s)
+}
@@ -0,0 +1,76 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var syncDay = time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestSyncUnchanged(t *testing.T) {
+	t.Parallel()
+
+	r, err := Load("testdata")
+	require.NoError(t, err)
+	paths, err := Discover("testdata")
+	require.NoError(t, err)
+	changes, err := Sync("testdata", r, paths, syncDay)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, "2025-09-12", r.Updated)
+	assert.Equal(t, "2025-09-12", r.Module("azure/infrastructure/storage").Updated)
+	// The examples keep the order they are listed in.
+	assert.Equal(t, []string{"basic", "advanced"}, r.Module("azure/infrastructure/storage").Examples)
+}
+
+func TestSyncDrift(t *testing.T) {
+	t.Parallel()
+
+	r, err := Load("testdata")
+	require.NoError(t, err)
+	storage := r.Module("azure/infrastructure/storage")
+	storage.Description = "Old description"
+	storage.Examples = []string{"complete", "advanced"}
+	storage.RequiredProviders = map[string]string{"azurerm": "~> 4.0"}
+	storage.TerraformVersion = ">= 1.0"
+	r.Modules = r.Modules[:1]
+
+	changes, err := Sync("testdata", r, []string{"azure/infrastructure/storage", "azure/shared/naming/"}, syncDay)
+	require.NoError(t, err)
+	var lines []string
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+	assert.Equal(t, []string{
+		`azure/infrastructure/storage: description: "Old description" -> "Manages a storage account for the registry tests"`,
+		`azure/infrastructure/storage: terraform_version: ">= 1.0" -> ">= 1.3"`,
+		`azure/infrastructure/storage: examples: complete, advanced -> advanced, basic`,
+		`azure/infrastructure/storage: required_providers: azurerm "~> 4.0" -> azurerm "~> 3.0", random "~> 3.0"`,
+		`azure/infrastructure/storage: updated: 2025-09-12 -> 2025-10-01`,
+		`azure/shared/naming: added`,
+		`updated: 2025-09-12 -> 2025-10-01`,
+	}, lines)
+
+	// Hand-curated fields are kept.
+	storage = r.Module("azure/infrastructure/storage")
+	assert.Equal(t, []string{"Storage accounts"}, storage.Features)
+	assert.Equal(t, []string{"azure", "storage"}, storage.Tags)
+	assert.Equal(t, "1.2.0", storage.Version)
+	assert.Equal(t, "2025-10-01", r.Updated)
+
+	naming := r.Module("azure/shared/naming")
+	require.NotNil(t, naming)
+	assert.Equal(t, "Builds resource names for the registry tests", naming.Description)
+	assert.Equal(t, "2025-10-01", naming.Created)
+	assert.Equal(t, "2025-10-01", naming.Updated)
+
+	// The synced registry validates, but for the features of the new entry,
+	// which are written by hand.
+	problems, err := Validate("testdata", r)
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, "azure/shared/naming: features: must not be empty", problems[0].String())
+}
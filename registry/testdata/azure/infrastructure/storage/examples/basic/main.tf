@@ -0,0 +1 @@
+module "storage" { source = "../.." }
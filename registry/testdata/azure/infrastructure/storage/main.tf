@@ -0,0 +1,10 @@
+# azure-infrastructure-storage module
+# Description: Manages a storage account for the registry tests
+
+terraform {
+  required_version = ">= 1.3"
+}
+
+resource "azurerm_storage_account" "main" {
+  name = var.name
+}
@@ -0,0 +1,13 @@
+terraform {
+  required_version = ">= 1.3"
+
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "~> 3.0"
+
+      configuration_aliases = [azurerm.secondary]
+    }
+    random = "~> 3.0"
+  }
+}
@@ -0,0 +1,10 @@
+# azure-shared-naming module
+# Description: Builds resource names for the registry tests
+
+terraform {
+  required_version = ">= 1.0"
+}
+
+output "name" {
+  value = "example"
+}
@@ -0,0 +1,274 @@
+package registry
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of the dates in the registry.
+const DateLayout = "2006-01-02"
+
+var (
+	semver = regexp.MustCompile(`^(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z.-]+)?$`)
+
+	// kebab matches names, directory names and tags: lowercase words joined
+	// by hyphens.
+	kebab = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// Problem is a way the registry is malformed or disagrees with the modules.
+type Problem struct {
+	// Module is the path of the module concerned, or empty for the
+	// registry as a whole.
+	Module string
+
+	// Field is the JSON name of the field concerned, if any.
+	Field string
+
+	Message string
+}
+
+// String returns the problem as module: field: message.
+func (p Problem) String() string {
+	var parts []string
+	if p.Module != "" {
+		parts = append(parts, p.Module)
+	}
+	if p.Field != "" {
+		parts = append(parts, p.Field)
+	}
+	return strings.Join(append(parts, p.Message), ": ")
+}
+
+// Validate checks the registry of the repository at root on its own, as the
+// schema does, and against the modules under root: every module must have
+// an entry, and the path, examples, required_providers and
+// terraform_version of every entry must match the module's files, as must
+// the description if main.tf states one. It returns the problems found, in
+// the order of the entries; the error is for a module that cannot be read
+// at all.
+func Validate(root string, r *Registry) ([]Problem, error) {
+	var problems []Problem
+	add := func(module, field, format string, args ...interface{}) {
+		problems = append(problems, Problem{Module: module, Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if !semver.MatchString(r.Version) {
+		add("", "version", "%q is not a semantic version", r.Version)
+	}
+	updated, err := time.Parse(DateLayout, r.Updated)
+	if err != nil {
+		add("", "updated", "%q is not a YYYY-MM-DD date", r.Updated)
+	}
+
+	paths, err := Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	onDisk := map[string]bool{}
+	for _, modulePath := range paths {
+		onDisk[modulePath] = true
+	}
+
+	names := map[string]string{}
+	entries := map[string]bool{}
+	for _, module := range r.Modules {
+		id := module.Path
+		if id == "" {
+			id = module.Name
+		}
+		for _, problem := range module.check() {
+			add(id, problem.Field, "%s", problem.Message)
+		}
+		if other, ok := names[module.Name]; ok && module.Name != "" {
+			add(id, "name", "%q is also the name of %s", module.Name, other)
+		}
+		names[module.Name] = id
+
+		modulePath := path.Clean(module.Path)
+		if module.Path == "" {
+			continue
+		}
+		if entries[modulePath] {
+			add(id, "path", "listed more than once")
+			continue
+		}
+		entries[modulePath] = true
+		if moduleUpdated, err := time.Parse(DateLayout, module.Updated); err == nil && !updated.IsZero() && moduleUpdated.After(updated) {
+			add("", "updated", "%s is before %s, when %s was updated", r.Updated, module.Updated, module.Path)
+		}
+		if !onDisk[modulePath] {
+			add(id, "path", "no module at %s", module.Path)
+			continue
+		}
+
+		source, err := ReadSource(root, modulePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, problem := range module.compare(source) {
+			add(id, problem.Field, "%s", problem.Message)
+		}
+	}
+
+	for _, modulePath := range paths {
+		if !entries[modulePath] {
+			add(modulePath, "", "the module is not in %s", File)
+		}
+	}
+	return problems, nil
+}
+
+// check returns the problems of the entry on its own.
+func (m *Module) check() []Problem {
+	var problems []Problem
+	add := func(field, format string, args ...interface{}) {
+		problems = append(problems, Problem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for field, value := range map[string]string{
+		"name": m.Name, "cloud": m.Cloud, "layer": m.Layer, "path": m.Path,
+		"description": m.Description, "terraform_version": m.TerraformVersion, "author": m.Author,
+	} {
+		if strings.TrimSpace(value) == "" {
+			add(field, "must not be empty")
+		}
+	}
+	for field, value := range map[string]string{"name": m.Name, "cloud": m.Cloud, "layer": m.Layer} {
+		if value != "" && !kebab.MatchString(value) {
+			add(field, "%q is not lowercase words joined by hyphens", value)
+		}
+	}
+	for field, list := range map[string][]string{"examples": m.Examples, "tags": m.Tags} {
+		for _, value := range list {
+			if !kebab.MatchString(value) {
+				add(field, "%q is not lowercase words joined by hyphens", value)
+			}
+		}
+	}
+	if m.Path != "" {
+		if parts := strings.Split(path.Clean(m.Path), "/"); len(parts) != 3 {
+			add("path", "%q is not <cloud>/<layer>/<name>", m.Path)
+		} else if want := path.Join(m.Cloud, m.Layer, m.Name); path.Clean(m.Path) != want {
+			add("path", "%q does not match cloud, layer and name, %q", m.Path, want)
+		}
+	}
+	if !semver.MatchString(m.Version) {
+		add("version", "%q is not a semantic version", m.Version)
+	}
+	created, createdErr := time.Parse(DateLayout, m.Created)
+	if createdErr != nil {
+		add("created", "%q is not a YYYY-MM-DD date", m.Created)
+	}
+	updated, updatedErr := time.Parse(DateLayout, m.Updated)
+	if updatedErr != nil {
+		add("updated", "%q is not a YYYY-MM-DD date", m.Updated)
+	}
+	if createdErr == nil && updatedErr == nil && updated.Before(created) {
+		add("updated", "%s is before created, %s", m.Updated, m.Created)
+	}
+	if len(m.Features) == 0 {
+		add("features", "must not be empty")
+	}
+	if len(m.Tags) == 0 {
+		add("tags", "must not be empty")
+	}
+	for field, list := range map[string][]string{"features": m.Features, "examples": m.Examples, "tags": m.Tags} {
+		if duplicate := firstDuplicate(list); duplicate != "" {
+			add(field, "%q is listed more than once", duplicate)
+		}
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Field < problems[j].Field })
+	return problems
+}
+
+// compare returns the fields of the entry that disagree with the module's
+// files.
+func (m *Module) compare(source *Source) []Problem {
+	var problems []Problem
+	add := func(field, format string, args ...interface{}) {
+		problems = append(problems, Problem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	examples := append([]string(nil), m.Examples...)
+	sort.Strings(examples)
+	if missing, extra := difference(source.Examples, examples); len(missing)+len(extra) > 0 {
+		add("examples", "%s, but examples/ holds %s", describe(examples), describe(source.Examples))
+	}
+
+	var providers []string
+	for name := range source.RequiredProviders {
+		providers = append(providers, name)
+	}
+	for name := range m.RequiredProviders {
+		if _, ok := source.RequiredProviders[name]; !ok {
+			providers = append(providers, name)
+		}
+	}
+	sort.Strings(providers)
+	for _, name := range providers {
+		want, required := source.RequiredProviders[name]
+		got, listed := m.RequiredProviders[name]
+		switch {
+		case !listed:
+			add("required_providers", "%s %q is required by the module but not listed", name, want)
+		case !required:
+			add("required_providers", "%s %q is listed but the module does not require it", name, got)
+		case got != want:
+			add("required_providers", "%s is %q, but the module requires %q", name, got, want)
+		}
+	}
+
+	if source.Description != "" && m.Description != source.Description {
+		add("description", "%q, but main.tf says %q", m.Description, source.Description)
+	}
+
+	if m.TerraformVersion != source.TerraformVersion {
+		want := source.TerraformVersion
+		if want == "" {
+			want = "no version"
+		} else {
+			want = fmt.Sprintf("%q", want)
+		}
+		add("terraform_version", "%q, but the module requires %s", m.TerraformVersion, want)
+	}
+	return problems
+}
+
+// difference returns the strings of want missing from got and those of got
+// missing from want.
+func difference(want, got []string) (missing, extra []string) {
+	for _, s := range want {
+		if !contains(got, s) {
+			missing = append(missing, s)
+		}
+	}
+	for _, s := range got {
+		if !contains(want, s) {
+			extra = append(extra, s)
+		}
+	}
+	return missing, extra
+}
+
+func describe(list []string) string {
+	if len(list) == 0 {
+		return "none"
+	}
+	return strings.Join(list, ", ")
+}
+
+func firstDuplicate(list []string) string {
+	seen := map[string]bool{}
+	for _, s := range list {
+		if seen[s] {
+			return s
+		}
+		seen[s] = true
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSource(t *testing.T) {
+	t.Parallel()
+
+	paths, err := Discover("testdata")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"azure/infrastructure/storage", "azure/shared/naming"}, paths)
+
+	source, err := ReadSource("testdata", "azure/infrastructure/storage")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"advanced", "basic"}, source.Examples)
+	assert.Equal(t, map[string]string{"azurerm": "~> 3.0", "random": "~> 3.0"}, source.RequiredProviders)
+	// Both terraform blocks say the same.
+	assert.Equal(t, ">= 1.3", source.TerraformVersion)
+
+	source, err = ReadSource("testdata", "azure/shared/naming")
+	require.NoError(t, err)
+	assert.Empty(t, source.Examples)
+	assert.Empty(t, source.RequiredProviders)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	r, err := Load("testdata")
+	require.NoError(t, err)
+	problems, err := Validate("testdata", r)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestValidateDrift(t *testing.T) {
+	t.Parallel()
+
+	r, err := Load("testdata")
+	require.NoError(t, err)
+	storage := r.Module("azure/infrastructure/storage")
+	storage.Examples = []string{"basic", "complete"}
+	storage.RequiredProviders = map[string]string{"azurerm": "~> 4.0", "null": "~> 3.0"}
+	storage.TerraformVersion = ">= 1.0"
+	storage.Tags = append(storage.Tags, "azure")
+	storage.Updated = "2025-09-10"
+	r.Modules[1].Name = "storage"
+	r.Modules = append(r.Modules, Module{
+		Name: "gone", Cloud: "azure", Layer: "shared", Path: "azure/shared/gone",
+		Version: "1.0", Description: "Removed", Features: []string{"None"}, TerraformVersion: ">= 1.0",
+		Created: "2025-09-11", Updated: "2025-09-13", Author: "ZRR Platform Team", Tags: []string{"Gone"},
+	})
+
+	problems, err := Validate("testdata", r)
+	require.NoError(t, err)
+	var lines []string
+	for _, problem := range problems {
+		lines = append(lines, problem.String())
+	}
+	assert.Equal(t, []string{
+		`azure/infrastructure/storage: tags: "azure" is listed more than once`,
+		`azure/infrastructure/storage: updated: 2025-09-10 is before created, 2025-09-11`,
+		`azure/infrastructure/storage: examples: basic, complete, but examples/ holds advanced, basic`,
+		`azure/infrastructure/storage: required_providers: azurerm is "~> 4.0", but the module requires "~> 3.0"`,
+		`azure/infrastructure/storage: required_providers: null "~> 3.0" is listed but the module does not require it`,
+		`azure/infrastructure/storage: required_providers: random "~> 3.0" is required by the module but not listed`,
+		`azure/infrastructure/storage: terraform_version: ">= 1.0", but the module requires ">= 1.3"`,
+		`azure/shared/naming: path: "azure/shared/naming" does not match cloud, layer and name, "azure/shared/storage"`,
+		`azure/shared/naming: name: "storage" is also the name of azure/infrastructure/storage`,
+		`azure/shared/gone: tags: "Gone" is not lowercase words joined by hyphens`,
+		`azure/shared/gone: version: "1.0" is not a semantic version`,
+		`updated: 2025-09-12 is before 2025-09-13, when azure/shared/gone was updated`,
+		`azure/shared/gone: path: no module at azure/shared/gone`,
+	}, lines)
+}
+
+// TestRepositoryRegistry checks the registry of this repository, so drift
+// fails go test at the root as well as zrr-registry validate.
+func TestRepositoryRegistry(t *testing.T) {
+	t.Parallel()
+
+	r, err := Load("..")
+	require.NoError(t, err)
+	problems, err := Validate("..", r)
+	require.NoError(t, err)
+	for _, problem := range problems {
+		t.Error(problem)
+	}
+}
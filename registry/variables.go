@@ -0,0 +1,278 @@
+package registry
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+)
+
+// Variable is an input variable of a module and its validation blocks.
+type Variable struct {
+	Name string
+
+	// Type is the variable's type constraint, cty.DynamicPseudoType for any
+	// or when it could not be read.
+	Type cty.Type
+
+	// Default is the default value, or cty.NilVal if the variable is
+	// required.
+	Default cty.Value
+
+	// Nullable reports whether the variable accepts null.
+	Nullable bool
+
+	Description string
+	Sensitive   bool
+
+	Validations []Validation
+
+	// Range is where the variable is declared.
+	Range hcl.Range
+}
+
+// Validation is a validation block of a variable.
+type Validation struct {
+	Condition hcl.Expression
+
+	// ErrorMessage is the block's error_message. For a message built from
+	// a template, it is the longest literal part of the template, which the
+	// rendered message contains.
+	ErrorMessage string
+
+	Range hcl.Range
+}
+
+// String returns the file and line of the validation.
+func (v Validation) String() string {
+	return fmt.Sprintf("%s:%d", filepath.Base(v.Range.Filename), v.Range.Start.Line)
+}
+
+var variableSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{{Type: "variable", LabelNames: []string{"name"}}},
+}
+
+var variableBodySchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "type"}, {Name: "default"}, {Name: "nullable"},
+		{Name: "description"}, {Name: "sensitive"}, {Name: "ephemeral"},
+	},
+	Blocks: []hcl.BlockHeaderSchema{{Type: "validation"}},
+}
+
+var validationSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "condition", Required: true},
+		{Name: "error_message", Required: true},
+	},
+}
+
+// LoadVariables parses the variables declared in the .tf files of the module
+// in dir, sorted by name.
+func LoadVariables(dir string) ([]Variable, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	var variables []Variable
+	var diags hcl.Diagnostics
+	for _, name := range files {
+		file, fileDiags := parser.ParseHCLFile(name)
+		diags = append(diags, fileDiags...)
+		if fileDiags.HasErrors() {
+			continue
+		}
+		content, _, contentDiags := file.Body.PartialContent(variableSchema)
+		diags = append(diags, contentDiags...)
+		for _, block := range content.Blocks {
+			variable, blockDiags := decodeVariable(block)
+			diags = append(diags, blockDiags...)
+			variables = append(variables, variable)
+		}
+	}
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i].Name < variables[j].Name })
+	return variables, nil
+}
+
+func decodeVariable(block *hcl.Block) (Variable, hcl.Diagnostics) {
+	variable := Variable{
+		Name:     block.Labels[0],
+		Type:     cty.DynamicPseudoType,
+		Default:  cty.NilVal,
+		Nullable: true,
+		Range:    block.DefRange,
+	}
+	content, diags := block.Body.Content(variableBodySchema)
+
+	if attr, ok := content.Attributes["type"]; ok {
+		if ty, err := typeConstraint(attr.Expr); err == nil {
+			variable.Type = ty
+		}
+	}
+	if attr, ok := content.Attributes["nullable"]; ok {
+		if value, valueDiags := attr.Expr.Value(nil); !valueDiags.HasErrors() && value.Type() == cty.Bool && value.IsKnown() && !value.IsNull() {
+			variable.Nullable = value.True()
+		}
+	}
+	if attr, ok := content.Attributes["description"]; ok {
+		if value, valueDiags := attr.Expr.Value(nil); !valueDiags.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() {
+			variable.Description = value.AsString()
+		}
+	}
+	if attr, ok := content.Attributes["sensitive"]; ok {
+		if value, valueDiags := attr.Expr.Value(nil); !valueDiags.HasErrors() && value.Type() == cty.Bool && value.IsKnown() && !value.IsNull() {
+			variable.Sensitive = value.True()
+		}
+	}
+	if attr, ok := content.Attributes["default"]; ok {
+		value, valueDiags := attr.Expr.Value(nil)
+		diags = append(diags, valueDiags...)
+		if !valueDiags.HasErrors() {
+			if converted, err := convert.Convert(value, variable.Type); err == nil {
+				value = converted
+			}
+			variable.Default = value
+		}
+	}
+
+	for _, validationBlock := range content.Blocks {
+		validationContent, validationDiags := validationBlock.Body.Content(validationSchema)
+		diags = append(diags, validationDiags...)
+		if validationDiags.HasErrors() {
+			continue
+		}
+		variable.Validations = append(variable.Validations, Validation{
+			Condition:    validationContent.Attributes["condition"].Expr,
+			ErrorMessage: errorMessage(validationContent.Attributes["error_message"].Expr),
+			Range:        validationBlock.DefRange,
+		})
+	}
+	return variable, diags
+}
+
+// errorMessage returns the message of an error_message expression, or the
+// longest literal part of it if it is a template.
+func errorMessage(expr hcl.Expression) string {
+	if value, diags := expr.Value(nil); !diags.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() {
+		return value.AsString()
+	}
+	template, ok := expr.(*hclsyntax.TemplateExpr)
+	if !ok {
+		return ""
+	}
+	longest := ""
+	for _, part := range template.Parts {
+		literal, ok := part.(*hclsyntax.LiteralValueExpr)
+		if !ok || literal.Val.Type() != cty.String {
+			continue
+		}
+		if text := strings.TrimSpace(literal.Val.AsString()); len(text) > len(longest) {
+			longest = text
+		}
+	}
+	return longest
+}
+
+// typeConstraint reads a type constraint, including the optional attribute
+// modifiers of object types, which hcl's typeexpr predates. Defaults given
+// to optional attributes are ignored.
+func typeConstraint(expr hcl.Expression) (cty.Type, error) {
+	switch hcl.ExprAsKeyword(expr) {
+	case "string":
+		return cty.String, nil
+	case "number":
+		return cty.Number, nil
+	case "bool":
+		return cty.Bool, nil
+	case "any":
+		return cty.DynamicPseudoType, nil
+	case "":
+	default:
+		return cty.NilType, fmt.Errorf("unknown type %s", hcl.ExprAsKeyword(expr))
+	}
+
+	call, diags := hcl.ExprCall(expr)
+	if diags.HasErrors() {
+		return cty.NilType, diags
+	}
+	argument := func(i int) (cty.Type, error) {
+		if len(call.Arguments) <= i {
+			return cty.NilType, fmt.Errorf("%s needs an element type", call.Name)
+		}
+		return typeConstraint(call.Arguments[i])
+	}
+
+	switch call.Name {
+	case "list", "set", "map":
+		element, err := argument(0)
+		if err != nil {
+			return cty.NilType, err
+		}
+		switch call.Name {
+		case "list":
+			return cty.List(element), nil
+		case "set":
+			return cty.Set(element), nil
+		}
+		return cty.Map(element), nil
+	case "tuple":
+		if len(call.Arguments) != 1 {
+			return cty.NilType, fmt.Errorf("tuple needs a list of element types")
+		}
+		items, diags := hcl.ExprList(call.Arguments[0])
+		if diags.HasErrors() {
+			return cty.NilType, diags
+		}
+		elements := make([]cty.Type, len(items))
+		for i, item := range items {
+			element, err := typeConstraint(item)
+			if err != nil {
+				return cty.NilType, err
+			}
+			elements[i] = element
+		}
+		return cty.Tuple(elements), nil
+	case "object":
+		if len(call.Arguments) != 1 {
+			return cty.NilType, fmt.Errorf("object needs a map of attribute types")
+		}
+		pairs, diags := hcl.ExprMap(call.Arguments[0])
+		if diags.HasErrors() {
+			return cty.NilType, diags
+		}
+		attributes := map[string]cty.Type{}
+		var optional []string
+		for _, pair := range pairs {
+			name := hcl.ExprAsKeyword(pair.Key)
+			if name == "" {
+				return cty.NilType, fmt.Errorf("object attribute names must be identifiers")
+			}
+			valueExpr := pair.Value
+			if inner, diags := hcl.ExprCall(valueExpr); !diags.HasErrors() && inner.Name == "optional" && len(inner.Arguments) > 0 {
+				optional = append(optional, name)
+				valueExpr = inner.Arguments[0]
+			}
+			attribute, err := typeConstraint(valueExpr)
+			if err != nil {
+				return cty.NilType, err
+			}
+			attributes[name] = attribute
+		}
+		if len(optional) > 0 {
+			return cty.ObjectWithOptionalAttrs(attributes, optional), nil
+		}
+		return cty.Object(attributes), nil
+	}
+	return cty.NilType, fmt.Errorf("unknown type constructor %s", call.Name)
+}
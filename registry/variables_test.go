@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestLoadVariables(t *testing.T) {
+	t.Parallel()
+
+	variables, err := LoadVariables("testdata/variables")
+	require.NoError(t, err)
+	var names []string
+	for _, variable := range variables {
+		names = append(names, variable.Name)
+	}
+	assert.Equal(t, []string{"address_space", "name", "network_rules", "retention_days", "sku", "tags"}, names)
+
+	byName := map[string]Variable{}
+	for _, variable := range variables {
+		byName[variable.Name] = variable
+	}
+	name := byName["name"]
+	assert.Equal(t, cty.String, name.Type)
+	assert.Equal(t, cty.NilVal, name.Default)
+	assert.True(t, name.Nullable)
+	require.Len(t, name.Validations, 1)
+	assert.Equal(t, "Name must be 3-24 lowercase letters and numbers.", name.Validations[0].ErrorMessage)
+	assert.Equal(t, "variables.tf:5", name.Validations[0].String())
+
+	assert.True(t, byName["retention_days"].Default.Equals(cty.NumberIntVal(7)).True())
+	assert.Equal(t, "is not between 1 and 365.", byName["retention_days"].Validations[0].ErrorMessage)
+
+	rules := byName["network_rules"].Type
+	require.True(t, rules.IsObjectType())
+	assert.True(t, rules.AttributeOptional("ip_rules"))
+	assert.False(t, rules.AttributeOptional("default_action"))
+	assert.Equal(t, cty.List(cty.String), rules.AttributeType("ip_rules"))
+	assert.True(t, byName["network_rules"].Default.IsNull())
+}
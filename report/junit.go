@@ -0,0 +1,154 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// The JUnit XML format, as read by CI systems.
+type (
+	junitSuites struct {
+		XMLName xml.Name     `xml:"testsuites"`
+		Name    string       `xml:"name,attr"`
+		Suites  []junitSuite `xml:"testsuite"`
+	}
+
+	junitSuite struct {
+		Name       string           `xml:"name,attr"`
+		Tests      int              `xml:"tests,attr"`
+		Failures   int              `xml:"failures,attr"`
+		Errors     int              `xml:"errors,attr"`
+		Skipped    int              `xml:"skipped,attr"`
+		Time       string           `xml:"time,attr"`
+		Properties *junitProperties `xml:"properties"`
+		Cases      []junitCase      `xml:"testcase"`
+		SystemOut  string           `xml:"system-out,omitempty"`
+	}
+
+	junitCase struct {
+		Classname  string           `xml:"classname,attr"`
+		Name       string           `xml:"name,attr"`
+		Time       string           `xml:"time,attr"`
+		Properties *junitProperties `xml:"properties"`
+		Failure    *junitMessage    `xml:"failure"`
+		Error      *junitMessage    `xml:"error"`
+		Skipped    *junitMessage    `xml:"skipped"`
+	}
+
+	junitProperties struct {
+		Properties []junitProperty `xml:"property"`
+	}
+
+	junitProperty struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:"value,attr"`
+	}
+
+	junitMessage struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// WriteJUnit writes the report as JUnit XML, one testsuite per suite. The
+// Terraform timings and resource IDs of a test are its properties, named
+// terraform.<subcommand>.seconds and azure.resource_id.
+func (m *Module) WriteJUnit(w io.Writer) error {
+	doc := junitSuites{Name: m.Layer + "/" + m.Module}
+	for _, suite := range m.Suites {
+		doc.Suites = append(doc.Suites, suite.junit())
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteJSON writes the report as indented JSON.
+func (m *Module) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(m)
+}
+
+func (s *Suite) junit() junitSuite {
+	suite := junitSuite{
+		Name:       s.Name,
+		Time:       seconds(s.Seconds),
+		Properties: terraformProperties(s.Terraform()),
+	}
+	for _, test := range s.Tests {
+		c := junitCase{
+			Classname:  test.Package,
+			Name:       test.Name,
+			Time:       seconds(test.Seconds),
+			Properties: terraformProperties(test.Terraform),
+		}
+		for _, id := range test.ResourceIDs {
+			if c.Properties == nil {
+				c.Properties = &junitProperties{}
+			}
+			c.Properties.Properties = append(c.Properties.Properties, junitProperty{Name: "azure.resource_id", Value: id})
+		}
+		switch test.Result {
+		case Fail:
+			suite.Failures++
+			c.Failure = &junitMessage{Message: "Failed", Text: test.Output}
+		case Skip:
+			suite.Skipped++
+			c.Skipped = &junitMessage{Message: "Skipped"}
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	// A suite failing without a failed test did not build, or its binary
+	// failed outside of any test; report it as an error so it is not lost.
+	if s.Result == Fail && suite.Failures == 0 {
+		suite.Tests++
+		suite.Errors++
+		suite.Cases = append(suite.Cases, junitCase{
+			Classname: s.Name,
+			Name:      "suite",
+			Time:      seconds(s.Seconds),
+			Error:     &junitMessage{Message: "Suite failed", Text: s.Output},
+		})
+	} else if s.Output != "" {
+		suite.SystemOut = s.Output
+	}
+	return suite
+}
+
+// terraformProperties returns the properties of the time spent in each
+// Terraform subcommand, sorted by subcommand, or nil without any.
+func terraformProperties(times map[string]float64) *junitProperties {
+	if len(times) == 0 {
+		return nil
+	}
+	subcommands := make([]string, 0, len(times))
+	for subcommand := range times {
+		subcommands = append(subcommands, subcommand)
+	}
+	sort.Strings(subcommands)
+	properties := &junitProperties{}
+	for _, subcommand := range subcommands {
+		properties.Properties = append(properties.Properties, junitProperty{
+			Name:  "terraform." + subcommand + ".seconds",
+			Value: seconds(times[subcommand]),
+		})
+	}
+	return properties
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
@@ -0,0 +1,274 @@
+// Package report turns the `go test -json` streams of the module suites into
+// JUnit XML for CI and a JSON report of its own.
+//
+// Besides each test's result, a report breaks down the time a test spent in
+// each Terraform command and lists the Azure resources it created. Both come
+// from the lines terratest logs: every command starts with
+//
+//	TestName 2024-03-20T12:00:00Z command.go:100: Running command tofu with args [apply -input=false ...]
+//
+// and is followed by the command's output, logged line by line, in which
+// Terraform reports each resource it creates with its ID. The streams are
+// plain files, so reports can be built from a recorded stream as well as from
+// a live run.
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Results of a test or suite.
+const (
+	Pass = "pass"
+	Fail = "fail"
+	Skip = "skip"
+)
+
+// Event is an event of a `go test -json` stream, as emitted by test2json.
+// Since Go 1.24 the stream also carries build-output events, with the
+// compiler's errors, which have no Package.
+type Event struct {
+	Time    time.Time
+	Action  string
+	Package string
+	Test    string
+	Elapsed float64
+	Output  string
+}
+
+// Test is the report of a single test or subtest.
+type Test struct {
+	Name    string  `json:"name"`
+	Package string  `json:"package"`
+	Result  string  `json:"result"`
+	Seconds float64 `json:"seconds"`
+
+	// Terraform is the time spent in each Terraform command, in seconds, by
+	// subcommand: init, plan, apply, destroy, output and so on.
+	Terraform map[string]float64 `json:"terraform_seconds,omitempty"`
+
+	// ResourceIDs are the IDs of the Azure resources the test created, in
+	// the order they were created.
+	ResourceIDs []string `json:"resource_ids,omitempty"`
+
+	// Output is the test's output, kept for failed tests only.
+	Output string `json:"output,omitempty"`
+
+	output  strings.Builder
+	command *command
+}
+
+// command is the Terraform command a test is running.
+type command struct {
+	subcommand string
+	start, end time.Time
+}
+
+// Suite is the report of a suite: one tier of a module's tests.
+type Suite struct {
+	Name    string  `json:"name"`
+	Tier    string  `json:"tier"`
+	Result  string  `json:"result"`
+	Seconds float64 `json:"seconds"`
+	Tests   []*Test `json:"tests"`
+
+	// Output is the output not attributed to a test, such as build errors,
+	// kept for failed suites only.
+	Output string `json:"output,omitempty"`
+
+	text    strings.Builder
+	pending strings.Builder
+	tests   map[string]*Test
+}
+
+// Module is the report of the suites of a module.
+type Module struct {
+	Layer  string   `json:"layer"`
+	Module string   `json:"module"`
+	Suites []*Suite `json:"suites"`
+}
+
+var (
+	logLine       = regexp.MustCompile(`^(\S+) (\d{4}-\d\d-\d\dT\S+) (\S+?):\d+: (.*)$`)
+	runCommand    = regexp.MustCompile(`^Running command (\S+) with args \[(\S*)`)
+	createdLine   = regexp.MustCompile(`: Creation complete after \S+ \[id=(/subscriptions/[^\]\s]+)\]`)
+	terraformBins = map[string]bool{"terraform": true, "tofu": true}
+)
+
+// Parse reads a `go test -json` stream into the report of a suite. Lines that
+// are not JSON, such as build errors go prints before the stream starts, are
+// kept as the suite's output.
+func Parse(r io.Reader) (*Suite, error) {
+	suite := &Suite{tests: map[string]*Test{}}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var event Event
+		if !bytes.HasPrefix(line, []byte("{")) || json.Unmarshal(line, &event) != nil {
+			suite.text.Write(line)
+			suite.text.WriteByte('\n')
+			suite.pending.Write(line)
+			suite.pending.WriteByte('\n')
+			continue
+		}
+		suite.add(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	suite.finish()
+	return suite, nil
+}
+
+// Text returns the stream as go test would have printed it without -json.
+func (s *Suite) Text() string {
+	return s.text.String()
+}
+
+// Failed returns the tests that failed.
+func (s *Suite) Failed() []*Test {
+	var failed []*Test
+	for _, test := range s.Tests {
+		if test.Result == Fail {
+			failed = append(failed, test)
+		}
+	}
+	return failed
+}
+
+// Terraform returns the time the suite's tests spent in each Terraform
+// subcommand, in seconds. Subtests are left out, as their time is part of
+// their parent's.
+func (s *Suite) Terraform() map[string]float64 {
+	total := map[string]float64{}
+	for _, test := range s.Tests {
+		if strings.Contains(test.Name, "/") {
+			continue
+		}
+		for subcommand, seconds := range test.Terraform {
+			total[subcommand] += seconds
+		}
+	}
+	return total
+}
+
+func (s *Suite) add(event Event) {
+	if event.Output != "" {
+		s.text.WriteString(event.Output)
+	}
+	if event.Test == "" {
+		switch event.Action {
+		case "output", "build-output":
+			s.pending.WriteString(event.Output)
+		case "pass", "fail", "skip":
+			s.Result = event.Action
+			s.Seconds = event.Elapsed
+		}
+		return
+	}
+
+	test := s.tests[event.Test]
+	if test == nil {
+		test = &Test{Name: event.Test, Package: event.Package}
+		s.tests[event.Test] = test
+		s.Tests = append(s.Tests, test)
+	}
+	switch event.Action {
+	case "output":
+		test.output.WriteString(event.Output)
+		test.log(event)
+	case "pass", "fail", "skip":
+		test.endCommand(event.Time)
+		test.Result = event.Action
+		test.Seconds = event.Elapsed
+	}
+}
+
+// log follows the Terraform commands the test runs and the resources they
+// create.
+func (t *Test) log(event Event) {
+	match := logLine.FindStringSubmatch(strings.TrimRight(event.Output, "\n"))
+	if match == nil {
+		return
+	}
+	at := event.Time
+	if at.IsZero() {
+		at, _ = time.Parse(time.RFC3339, match[2])
+	}
+	caller, message := match[3], match[4]
+
+	if run := runCommand.FindStringSubmatch(message); run != nil {
+		t.endCommand(at)
+		binary := strings.TrimSuffix(filepath.Base(run[1]), ".exe")
+		if terraformBins[binary] && run[2] != "" {
+			t.command = &command{subcommand: run[2], start: at}
+		}
+		return
+	}
+	if t.command == nil {
+		return
+	}
+	if caller != "command.go" {
+		// The test moved on, so the command has ended.
+		t.endCommand(at)
+		return
+	}
+	t.command.end = at
+	if created := createdLine.FindStringSubmatch(message); created != nil {
+		t.ResourceIDs = append(t.ResourceIDs, created[1])
+	}
+}
+
+// endCommand adds the running command's time to the test. The command ended
+// with its last line of output, or, if it printed none, by next, the time of
+// the test's next line.
+func (t *Test) endCommand(next time.Time) {
+	if t.command == nil {
+		return
+	}
+	end := t.command.end
+	if end.IsZero() {
+		end = next
+	}
+	if !end.IsZero() {
+		if t.Terraform == nil {
+			t.Terraform = map[string]float64{}
+		}
+		t.Terraform[t.command.subcommand] += end.Sub(t.command.start).Seconds()
+	}
+	t.command = nil
+}
+
+func (s *Suite) finish() {
+	for _, test := range s.Tests {
+		test.endCommand(time.Time{})
+		if test.Result == "" {
+			// The test never finished: the binary panicked or timed out.
+			test.Result = Fail
+		}
+		if test.Result == Fail {
+			test.Output = test.output.String()
+		}
+	}
+	if s.Result == "" {
+		// go test reports every package it ran; a stream without the report
+		// was cut short.
+		s.Result = Fail
+	}
+	if s.Result == Fail {
+		s.Output = s.pending.String()
+	}
+	if s.Tests == nil {
+		s.Tests = []*Test{}
+	}
+	sort.SliceStable(s.Tests, func(i, j int) bool { return s.Tests[i].Name < s.Tests[j].Name })
+}
@@ -0,0 +1,165 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const resourceGroup = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-zrr-t1"
+
+// parseFile parses a recorded stream from testdata.
+func parseFile(t *testing.T, name string) *Suite {
+	t.Helper()
+
+	file, err := os.Open("testdata/" + name)
+	require.NoError(t, err)
+	defer file.Close()
+	suite, err := Parse(file)
+	require.NoError(t, err)
+	return suite
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	suite := parseFile(t, "integration.jsonl")
+	assert.Equal(t, Fail, suite.Result)
+	assert.Equal(t, 480.123, suite.Seconds)
+	require.Len(t, suite.Tests, 3)
+
+	basic, validate, firewall := suite.Tests[0], suite.Tests[1], suite.Tests[2]
+	assert.Equal(t, "TestBasic", basic.Name)
+	assert.Equal(t, Pass, basic.Result)
+	assert.Equal(t, 480.0, basic.Seconds)
+	assert.Equal(t, map[string]float64{"init": 10, "apply": 300, "output": 1, "destroy": 120}, basic.Terraform)
+	assert.Equal(t, []string{
+		resourceGroup,
+		resourceGroup + "/providers/Microsoft.DBforMySQL/flexibleServers/mysql-zrr-t1",
+	}, basic.ResourceIDs)
+	assert.Empty(t, basic.Output)
+
+	assert.Equal(t, "TestBasic/Validate", validate.Name)
+	assert.Equal(t, Pass, validate.Result)
+	assert.Empty(t, validate.Terraform)
+
+	// The parallel tests' lines interleave; each keeps its own commands.
+	assert.Equal(t, "TestFirewall", firewall.Name)
+	assert.Equal(t, Fail, firewall.Result)
+	assert.Equal(t, map[string]float64{"init": 7, "apply": 48, "destroy": 34}, firewall.Terraform)
+	assert.Equal(t, []string{
+		strings.Replace(resourceGroup, "t1", "t2", 1) + "/providers/Microsoft.DBforMySQL/flexibleServers/mysql-zrr-t2/firewallRules/office",
+	}, firewall.ResourceIDs)
+	assert.Contains(t, firewall.Output, "Not equal")
+
+	assert.Equal(t, []*Test{firewall}, suite.Failed())
+	assert.Equal(t, map[string]float64{"init": 17, "apply": 348, "output": 1, "destroy": 154}, suite.Terraform())
+	assert.Contains(t, suite.Text(), "--- FAIL: TestFirewall (100.00s)\n")
+	assert.Contains(t, suite.Output, "FAIL\tgithub.com/zrr-hq/")
+}
+
+func TestParseBuildFailure(t *testing.T) {
+	t.Parallel()
+
+	suite := parseFile(t, "build.jsonl")
+	assert.Equal(t, Fail, suite.Result)
+	assert.Empty(t, suite.Tests)
+	assert.Contains(t, suite.Output, "unknown field PlanOnly")
+	assert.Contains(t, suite.Output, "[build failed]")
+
+	// Before Go 1.24 build errors were printed as plain text instead.
+	suite, err := Parse(strings.NewReader("# example.com/tests\n./x_test.go:5:28: undefined: undefined\n" +
+		`{"Action":"output","Package":"example.com/tests","Output":"FAIL\texample.com/tests [build failed]\n"}` + "\n" +
+		`{"Action":"fail","Package":"example.com/tests","Elapsed":0}` + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Fail, suite.Result)
+	assert.Equal(t, "# example.com/tests\n./x_test.go:5:28: undefined: undefined\nFAIL\texample.com/tests [build failed]\n", suite.Output)
+	assert.Equal(t, suite.Output, suite.Text())
+}
+
+func TestParseUnfinished(t *testing.T) {
+	t.Parallel()
+
+	// A test binary killed by its timeout never reports the running tests.
+	suite, err := Parse(strings.NewReader(
+		`{"Time":"2024-03-20T12:00:00Z","Action":"run","Package":"p","Test":"TestSlow"}` + "\n" +
+			`{"Time":"2024-03-20T12:00:01Z","Action":"output","Package":"p","Test":"TestSlow","Output":"TestSlow 2024-03-20T12:00:01Z command.go:100: Running command terraform with args [apply -input=false]\n"}` + "\n" +
+			`{"Time":"2024-03-20T12:00:31Z","Action":"output","Package":"p","Test":"TestSlow","Output":"TestSlow 2024-03-20T12:00:31Z command.go:185: module.this.azurerm_key_vault.this: Still creating... [30s elapsed]\n"}` + "\n"))
+	require.NoError(t, err)
+	assert.Equal(t, Fail, suite.Result)
+	require.Len(t, suite.Tests, 1)
+	assert.Equal(t, Fail, suite.Tests[0].Result)
+	assert.Equal(t, map[string]float64{"apply": 30}, suite.Tests[0].Terraform)
+	assert.Contains(t, suite.Tests[0].Output, "Still creating")
+}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	integration := parseFile(t, "integration.jsonl")
+	integration.Name, integration.Tier = "infrastructure/mysql-flexible-server/integration", "integration"
+	build := parseFile(t, "build.jsonl")
+	build.Name, build.Tier = "infrastructure/mysql-flexible-server/plan", "plan"
+	module := &Module{Layer: "infrastructure", Module: "mysql-flexible-server", Suites: []*Suite{integration, build}}
+
+	var buf bytes.Buffer
+	require.NoError(t, module.WriteJUnit(&buf))
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var doc junitSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "infrastructure/mysql-flexible-server", doc.Name)
+	require.Len(t, doc.Suites, 2)
+
+	suite := doc.Suites[0]
+	assert.Equal(t, integration.Name, suite.Name)
+	assert.Equal(t, 3, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, "480.123", suite.Time)
+	assert.Contains(t, suite.Properties.Properties, junitProperty{Name: "terraform.apply.seconds", Value: "348.000"})
+	basic := suite.Cases[0]
+	assert.Equal(t, "TestBasic", basic.Name)
+	assert.Nil(t, basic.Failure)
+	assert.Equal(t, []junitProperty{
+		{Name: "terraform.apply.seconds", Value: "300.000"},
+		{Name: "terraform.destroy.seconds", Value: "120.000"},
+		{Name: "terraform.init.seconds", Value: "10.000"},
+		{Name: "terraform.output.seconds", Value: "1.000"},
+		{Name: "azure.resource_id", Value: resourceGroup},
+		{Name: "azure.resource_id", Value: resourceGroup + "/providers/Microsoft.DBforMySQL/flexibleServers/mysql-zrr-t1"},
+	}, basic.Properties.Properties)
+	assert.Nil(t, suite.Cases[1].Properties)
+	require.NotNil(t, suite.Cases[2].Failure)
+	assert.Contains(t, suite.Cases[2].Failure.Text, "Not equal")
+
+	// A suite that did not build has no tests, so it reports an error.
+	failed := doc.Suites[1]
+	assert.Equal(t, 1, failed.Tests)
+	assert.Equal(t, 1, failed.Errors)
+	require.NotNil(t, failed.Cases[0].Error)
+	assert.Contains(t, failed.Cases[0].Error.Text, "unknown field PlanOnly")
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	suite := parseFile(t, "integration.jsonl")
+	suite.Name, suite.Tier = "infrastructure/mysql-flexible-server/integration", "integration"
+	module := &Module{Layer: "infrastructure", Module: "mysql-flexible-server", Suites: []*Suite{suite}}
+
+	var buf bytes.Buffer
+	require.NoError(t, module.WriteJSON(&buf))
+	var decoded Module
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded.Suites, 1)
+	assert.Equal(t, "integration", decoded.Suites[0].Tier)
+	assert.Equal(t, suite.Tests[0].Terraform, decoded.Suites[0].Tests[0].Terraform)
+	assert.Equal(t, suite.Tests[0].ResourceIDs, decoded.Suites[0].Tests[0].ResourceIDs)
+	assert.Contains(t, buf.String(), `"terraform_seconds": {`)
+}
@@ -0,0 +1,286 @@
+// Package scaffold creates a new module of the library from the templates
+// under templates/, which are embedded in the binary.
+//
+// Every module starts from the same layout: main.tf, variables.tf,
+// outputs.tf and versions.tf with the library's header, tags and naming
+// convention, a .terraform-docs.yml and README.md, basic and advanced
+// examples, and a tests/ module holding its go.mod with the unit suites of
+// the shared test kit and a staged integration test. Generate writes them
+// and adds the module's entry to module-registry.json and its typed
+// inputs and outputs to zrrtest/modules. The plan snapshots of the examples
+// come from the shared suite in zrrtest/snapshot/examples, so the tests/
+// module has none of its own.
+//
+// The templates use [[ and ]] as delimiters, so that the {{ }} of
+// terraform-docs pass through untouched.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/codegen"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+// templateSuffix ends the name of every template; it is dropped from the
+// name of the file written.
+const templateSuffix = ".tmpl"
+
+var kebab = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Module describes the module to create.
+type Module struct {
+	// Cloud, Layer and Name place the module at <cloud>/<layer>/<name>.
+	Cloud string
+	Layer string
+	Name  string
+
+	// Description is the module's description, for the header of main.tf
+	// and its registry entry.
+	Description string
+
+	// Resource is the Terraform type of the module's main resource. It
+	// defaults to azurerm_ followed by the name, e.g. azurerm_key_vault.
+	Resource string
+
+	// Prefix starts the names the naming convention builds. It defaults to
+	// the initials of the name, e.g. kv for key-vault.
+	Prefix string
+}
+
+// Path returns the module's directory relative to the repository root.
+func (m Module) Path() string {
+	return path.Join(m.Cloud, m.Layer, m.Name)
+}
+
+// Check returns an error if the module cannot be created as described.
+func (m Module) Check() error {
+	known := false
+	for _, cloud := range registry.Clouds {
+		known = known || cloud == m.Cloud
+	}
+	if !known {
+		return fmt.Errorf("unknown cloud %q, want one of %s", m.Cloud, strings.Join(registry.Clouds, ", "))
+	}
+	for field, value := range map[string]string{"layer": m.Layer, "name": m.Name} {
+		if !kebab.MatchString(value) {
+			return fmt.Errorf("%s %q is not lowercase words joined by hyphens", field, value)
+		}
+	}
+	if strings.TrimSpace(m.Description) == "" {
+		return fmt.Errorf("the description must not be empty")
+	}
+	return nil
+}
+
+// data is what the templates see.
+type data struct {
+	Module
+
+	// ID is <cloud>-<layer>-<name>, as in the header of main.tf.
+	ID string
+
+	// Snake is the name with underscores, e.g. key_vault, Title the name
+	// as words, e.g. Key Vault, and GoName as a Go identifier, KeyVault.
+	Snake  string
+	Title  string
+	GoName string
+
+	// GoModule is the module path of tests/go.mod; Require and Indirect
+	// are its requirements, copied from the go.mod at the repository root.
+	GoModule string
+	Require  []string
+	Indirect []string
+}
+
+// Render returns the module's files, by path relative to the module's
+// directory. goMod is the go.mod at the repository root, whose
+// requirements the module's tests/go.mod shares.
+func Render(m Module, goMod []byte) (map[string][]byte, error) {
+	if err := m.Check(); err != nil {
+		return nil, err
+	}
+	// The header of main.tf states the description without a period.
+	m.Description = strings.TrimSuffix(strings.TrimSpace(m.Description), ".")
+	words := strings.Split(m.Name, "-")
+	if m.Resource == "" {
+		m.Resource = "azurerm_" + strings.Join(words, "_")
+	}
+	if m.Prefix == "" {
+		for _, word := range words {
+			m.Prefix += word[:1]
+		}
+	}
+	d := data{
+		Module:   m,
+		ID:       strings.Join([]string{m.Cloud, m.Layer, m.Name}, "-"),
+		Snake:    strings.Join(words, "_"),
+		Title:    title(m.Name),
+		GoName:   strings.ReplaceAll(title(m.Name), " ", ""),
+		GoModule: "github.com/ZealousRockResearch/zrr-tf-module-lib/" + m.Path() + "/tests",
+	}
+	d.Require, d.Indirect = requirements(goMod)
+
+	files := map[string][]byte{}
+	err := fs.WalkDir(templates, "templates", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		text, err := templates.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(name).Delims("[[", "]]").Funcs(template.FuncMap{"title": title}).Parse(string(text))
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, d); err != nil {
+			return err
+		}
+		files[strings.TrimSuffix(strings.TrimPrefix(name, "templates/"), templateSuffix)] = buf.Bytes()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// title returns a kebab-case name as capitalized words, e.g. Key Vault.
+func title(name string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// direct are the requirements of tests/go.mod that its tests import; the
+// others of the root go.mod are indirect.
+var direct = map[string]bool{
+	"github.com/gruntwork-io/terratest": true,
+	"github.com/stretchr/testify":       true,
+}
+
+// requirements returns the requirements of goMod, as "path version", split
+// into those the tests import and the others.
+func requirements(goMod []byte) (require, indirect []string) {
+	inBlock := false
+	for _, line := range strings.Split(string(goMod), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "require (":
+			inBlock = true
+			continue
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimPrefix(line, "require ")
+		case !inBlock:
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		requirement := fields[0] + " " + fields[1]
+		if direct[fields[0]] {
+			require = append(require, requirement)
+		} else {
+			indirect = append(indirect, requirement)
+		}
+	}
+	sort.Strings(require)
+	sort.Strings(indirect)
+	return require, indirect
+}
+
+// Generate creates the module under the repository at root and adds its
+// entry to the registry, dated today. It returns the paths of the files
+// written, relative to root and sorted. The module's directory must not
+// exist yet.
+func Generate(root string, m Module, today time.Time) ([]string, error) {
+	dir := filepath.Join(root, filepath.FromSlash(m.Path()))
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", m.Path())
+	}
+	goMod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	goSum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		return nil, err
+	}
+	files, err := Render(m, goMod)
+	if err != nil {
+		return nil, err
+	}
+	// The tests module resolves the same versions as the root one.
+	files["tests/go.sum"] = goSum
+
+	r, err := registry.Load(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(file, content, 0o644); err != nil {
+			return nil, err
+		}
+		written = append(written, path.Join(m.Path(), name))
+	}
+	sort.Strings(written)
+
+	if _, err := registry.Sync(root, r, []string{m.Path()}, today); err != nil {
+		return nil, err
+	}
+	entry := r.Module(m.Path())
+	entry.Features = Features
+	data, err := r.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(root, registry.File), data, 0o644); err != nil {
+		return nil, err
+	}
+
+	// Tests of the module can use its typed inputs and outputs straight
+	// away.
+	generated, err := codegen.Write(root)
+	if err != nil {
+		return nil, err
+	}
+	written = append(written, generated...)
+	sort.Strings(written)
+	return written, nil
+}
+
+// Features are the features of a generated module, for its registry entry
+// until the module grows its own.
+var Features = []string{
+	"Standardized naming convention support",
+	"Comprehensive tagging strategy",
+	"Input validation for all variables",
+}
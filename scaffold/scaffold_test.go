@@ -0,0 +1,145 @@
+package scaffold
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files under testdata/golden instead of comparing against them")
+
+// goldenDir holds the files Render returns for keyVault.
+const goldenDir = "testdata/golden"
+
+var keyVault = Module{
+	Cloud:       "azure",
+	Layer:       "security",
+	Name:        "key-vault",
+	Description: "Manages Azure Key Vaults.",
+}
+
+// TestRenderGolden compares every file rendered from the templates with its
+// golden file. Run with -update to accept a change to the templates.
+func TestRenderGolden(t *testing.T) {
+	goMod, err := os.ReadFile("testdata/go.mod")
+	require.NoError(t, err)
+	files, err := Render(keyVault, goMod)
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.RemoveAll(goldenDir))
+		for name, content := range files {
+			path := filepath.Join(goldenDir, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, content, 0o644))
+		}
+		return
+	}
+
+	var golden []string
+	err = filepath.Walk(goldenDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(goldenDir, path)
+		golden = append(golden, filepath.ToSlash(name))
+		return err
+	})
+	require.NoError(t, err)
+	var rendered []string
+	for name := range files {
+		rendered = append(rendered, name)
+	}
+	sort.Strings(rendered)
+	require.Equal(t, golden, rendered, "the templates render other files than %s holds; run the test with -update to accept the change", goldenDir)
+
+	for _, name := range rendered {
+		expected, err := os.ReadFile(filepath.Join(goldenDir, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(files[name]), "%s differs from its golden file; run the test with -update to accept the change", name)
+	}
+}
+
+func TestRenderDefaults(t *testing.T) {
+	t.Parallel()
+
+	files, err := Render(Module{
+		Cloud: "azure", Layer: "security", Name: "network-security-group",
+		Description: "Manages network security groups",
+	}, nil)
+	require.NoError(t, err)
+	main := string(files["main.tf"])
+	assert.Contains(t, main, `resource "azurerm_network_security_group" "main" {`)
+	assert.Contains(t, main, `"nsg-${var.environment}-${var.name}-${var.location_short}"`)
+	assert.Contains(t, string(files["tests/integration/main_test.go"]), "func TestNetworkSecurityGroupBasic(t *testing.T) {")
+
+	files, err = Render(Module{
+		Cloud: "azure", Layer: "security", Name: "network-security-group",
+		Description: "Manages network security groups", Resource: "azurerm_application_security_group", Prefix: "asg",
+	}, nil)
+	require.NoError(t, err)
+	main = string(files["main.tf"])
+	assert.Contains(t, main, `resource "azurerm_application_security_group" "main" {`)
+	assert.Contains(t, main, `"asg-${var.environment}`)
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	for module, want := range map[Module]string{
+		{Cloud: "aws", Layer: "security", Name: "key-vault", Description: "x"}:   `unknown cloud "aws"`,
+		{Cloud: "azure", Layer: "Security", Name: "key-vault", Description: "x"}: `layer "Security" is not lowercase words joined by hyphens`,
+		{Cloud: "azure", Layer: "security", Name: "key_vault", Description: "x"}: `name "key_vault" is not lowercase words joined by hyphens`,
+		{Cloud: "azure", Layer: "security", Name: "key-vault"}:                   "the description must not be empty",
+	} {
+		assert.ErrorContains(t, module.Check(), want)
+	}
+}
+
+// TestGenerate creates a module in a copy of the registry tests' repository
+// and checks the registry validates with its new entry.
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, name := range []string{"go.mod", "go.sum"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte("module example\n"), 0o644))
+	}
+	data, err := os.ReadFile(filepath.Join("..", "registry", "testdata", registry.File))
+	require.NoError(t, err)
+	r, err := registry.Parse(data)
+	require.NoError(t, err)
+	r.Modules = []registry.Module{}
+	data, err = r.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(root, registry.File), data, 0o644))
+
+	written, err := Generate(root, keyVault, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Contains(t, written, "azure/security/key-vault/main.tf")
+	assert.Contains(t, written, "azure/security/key-vault/tests/go.sum")
+	assert.Contains(t, written, "zrrtest/modules/keyvault/inputs_gen.go")
+
+	r, err = registry.Load(root)
+	require.NoError(t, err)
+	entry := r.Module("azure/security/key-vault")
+	require.NotNil(t, entry)
+	assert.Equal(t, "Manages Azure Key Vaults", entry.Description)
+	assert.Equal(t, []string{"advanced", "basic"}, entry.Examples)
+	assert.Equal(t, map[string]string{"azurerm": "~> 3.0"}, entry.RequiredProviders)
+	assert.Equal(t, "2025-10-01", entry.Created)
+	problems, err := registry.Validate(root, r)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	_, err = Generate(root, keyVault, time.Now())
+	assert.ErrorContains(t, err, "azure/security/key-vault already exists")
+}
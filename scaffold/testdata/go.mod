@@ -0,0 +1,14 @@
+module github.com/ZealousRockResearch/zrr-tf-module-lib
+
+go 1.21
+
+require (
+	github.com/gruntwork-io/terratest v0.46.8
+	github.com/hashicorp/hcl/v2 v2.9.1
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
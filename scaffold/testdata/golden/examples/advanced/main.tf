@@ -0,0 +1,19 @@
+module "key_vault_example" {
+  source = "../../"
+
+  name                = "advanced"
+  resource_group_name = var.resource_group_name
+  location            = var.location
+
+  # Naming convention: kv-<environment>-<name>-<location_short>
+  environment           = var.environment
+  location_short        = var.location_short
+  use_naming_convention = true
+
+  common_tags = var.common_tags
+
+  key_vault_tags = {
+    Example = "advanced"
+    Module  = "key-vault"
+  }
+}
@@ -0,0 +1,33 @@
+variable "resource_group_name" {
+  description = "Name of the resource group for the example"
+  type        = string
+  default     = "rg-zrr-example"
+}
+
+variable "location" {
+  description = "Azure region for the example"
+  type        = string
+  default     = "eastus"
+}
+
+variable "common_tags" {
+  description = "Common tags for the example"
+  type        = map(string)
+  default = {
+    Environment = "dev"
+    Project     = "zrr-example"
+    Owner       = "terraform"
+  }
+}
+
+variable "environment" {
+  description = "Environment for the example"
+  type        = string
+  default     = "prod"
+}
+
+variable "location_short" {
+  description = "Short code for the Azure region"
+  type        = string
+  default     = "eus"
+}
@@ -0,0 +1,9 @@
+module "key_vault_example" {
+  source = "../../"
+
+  name                = "example-key-vault"
+  resource_group_name = var.resource_group_name
+  location            = var.location
+
+  common_tags = var.common_tags
+}
@@ -0,0 +1,14 @@
+output "key_vault_id" {
+  description = "ID of the created Key Vault"
+  value       = module.key_vault_example.id
+}
+
+output "key_vault_name" {
+  description = "Name of the created Key Vault"
+  value       = module.key_vault_example.name
+}
+
+output "key_vault_tags" {
+  description = "Tags applied to the Key Vault"
+  value       = module.key_vault_example.tags
+}
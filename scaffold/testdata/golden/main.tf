@@ -0,0 +1,27 @@
+# azure-security-key-vault module
+# Description: Manages Azure Key Vaults
+
+# Local values
+locals {
+  common_tags = merge(
+    var.common_tags,
+    var.key_vault_tags,
+    {
+      "ManagedBy" = "Terraform"
+      "Module"    = "zrr-tf-module-lib/azure/security/key-vault"
+      "Layer"     = "security"
+    }
+  )
+
+  # Construct the name with the naming convention
+  name = var.use_naming_convention ? "kv-${var.environment}-${var.name}-${var.location_short}" : var.name
+}
+
+# Resources
+resource "azurerm_key_vault" "main" {
+  name                = local.name
+  resource_group_name = var.resource_group_name
+  location            = var.location
+
+  tags = local.common_tags
+}
@@ -0,0 +1,26 @@
+# Primary outputs
+output "id" {
+  description = "ID of the Key Vault"
+  value       = azurerm_key_vault.main.id
+}
+
+output "name" {
+  description = "Name of the Key Vault"
+  value       = azurerm_key_vault.main.name
+}
+
+output "location" {
+  description = "Location of the Key Vault"
+  value       = azurerm_key_vault.main.location
+}
+
+output "resource_group_name" {
+  description = "Name of the resource group holding the Key Vault"
+  value       = azurerm_key_vault.main.resource_group_name
+}
+
+# Tags output
+output "tags" {
+  description = "Tags applied to the Key Vault"
+  value       = azurerm_key_vault.main.tags
+}
@@ -0,0 +1,36 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestKeyVaultBasic deploys the basic example and checks its
+// outputs.
+func TestKeyVaultBasic(t *testing.T) {
+	t.Parallel()
+
+	stages := zrrtest.NewStages(t)
+	uniqueID := stages.UniqueID()
+
+	terraformOptions := stages.Options(zrrtest.ExampleOptions(t, "basic", map[string]interface{}{
+		"location":    "eastus",
+		"common_tags": zrrtest.TestTags(uniqueID),
+	}))
+
+	defer stages.Teardown(terraformOptions)
+	stages.Deploy(terraformOptions)
+
+	stages.Validate(func() {
+		assert.NotEmpty(t, terraform.Output(t, terraformOptions, "key_vault_id"))
+		assert.NotEmpty(t, terraform.Output(t, terraformOptions, "key_vault_name"))
+
+		tags := terraform.OutputMap(t, terraformOptions, "key_vault_tags")
+		assert.Equal(t, "Terraform", tags["ManagedBy"])
+		assert.Equal(t, "security", tags["Layer"])
+		assert.Equal(t, uniqueID, tags["TestID"])
+	})
+}
@@ -0,0 +1,83 @@
+# Variable validation tests for Key Vault module
+
+variables {
+  name                = "test-key-vault"
+  resource_group_name = "rg-test"
+  location            = "eastus"
+}
+
+# Test valid name
+run "valid_name_test" {
+  command = plan
+
+  assert {
+    condition     = var.name == "test-key-vault"
+    error_message = "Name variable should accept valid names"
+  }
+}
+
+# Test invalid name with special characters
+run "invalid_name_test" {
+  command = plan
+
+  variables {
+    name = "invalid@name!"
+  }
+
+  expect_failures = [
+    var.name
+  ]
+}
+
+# Test invalid environment
+run "invalid_environment_test" {
+  command = plan
+
+  variables {
+    environment = "invalid"
+  }
+
+  expect_failures = [
+    var.environment
+  ]
+}
+
+# Test naming convention
+run "naming_convention_test" {
+  command = plan
+
+  variables {
+    environment    = "prod"
+    location_short = "weu"
+  }
+
+  assert {
+    condition     = azurerm_key_vault.main.name == "kv-prod-test-key-vault-weu"
+    error_message = "Name should follow the naming convention"
+  }
+}
+
+# Test required tags
+run "required_tags_test" {
+  command = plan
+
+  variables {
+    common_tags = {
+      Owner = "test"
+    }
+  }
+
+  expect_failures = [
+    var.common_tags
+  ]
+}
+
+# Test module tags
+run "module_tags_test" {
+  command = plan
+
+  assert {
+    condition     = azurerm_key_vault.main.tags["ManagedBy"] == "Terraform" && azurerm_key_vault.main.tags["Layer"] == "security"
+    error_message = "Resources should carry the ManagedBy and Layer tags"
+  }
+}
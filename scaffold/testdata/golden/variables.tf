@@ -0,0 +1,77 @@
+# Required variables
+variable "name" {
+  description = "Name of the Key Vault"
+  type        = string
+
+  validation {
+    condition     = can(regex("^[a-zA-Z0-9-]{1,60}$", var.name))
+    error_message = "Name must be 1-60 characters long and contain only alphanumeric characters and hyphens."
+  }
+}
+
+variable "resource_group_name" {
+  description = "Name of the resource group holding the Key Vault"
+  type        = string
+
+  validation {
+    condition     = can(regex("^[-a-zA-Z0-9_.()]{1,90}$", var.resource_group_name))
+    error_message = "Resource group name must be 1-90 characters long and contain only alphanumeric characters, underscores, hyphens, periods and parentheses."
+  }
+}
+
+variable "location" {
+  description = "Azure region where the Key Vault will be created"
+  type        = string
+}
+
+# Optional variables
+variable "environment" {
+  description = "Environment name (e.g., dev, staging, prod)"
+  type        = string
+  default     = "dev"
+
+  validation {
+    condition     = contains(["dev", "test", "staging", "prod", "dr"], var.environment)
+    error_message = "Environment must be one of: dev, test, staging, prod, dr."
+  }
+}
+
+variable "location_short" {
+  description = "Short location code for naming convention"
+  type        = string
+  default     = "eus"
+
+  validation {
+    condition     = can(regex("^[a-z][a-z0-9]{1,3}$", var.location_short))
+    error_message = "Location short must be 2-4 lowercase letters or digits, starting with a letter."
+  }
+}
+
+variable "use_naming_convention" {
+  description = "Use ZRR naming convention for the Key Vault name"
+  type        = bool
+  default     = true
+}
+
+# Common tags (required for all modules)
+variable "common_tags" {
+  description = "Common tags to be applied to all resources"
+  type        = map(string)
+  default = {
+    Environment = "dev"
+    Project     = "zrr"
+    ManagedBy   = "Terraform"
+  }
+
+  validation {
+    condition     = can(var.common_tags["Environment"]) && can(var.common_tags["Project"])
+    error_message = "Common tags must include 'Environment' and 'Project' keys."
+  }
+}
+
+# Resource-specific tags
+variable "key_vault_tags" {
+  description = "Additional tags specific to the Key Vault"
+  type        = map(string)
+  default     = {}
+}
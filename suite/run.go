@@ -0,0 +1,166 @@
+package suite
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency is the number of suites run at once.
+const DefaultConcurrency = 4
+
+// DefaultTimeouts are the go test timeouts of each tier. Integration suites
+// deploy several modules in parallel, which takes far longer than go test's
+// default of ten minutes.
+var DefaultTimeouts = map[Tier]time.Duration{
+	TierUnit:        15 * time.Minute,
+	TierPlan:        30 * time.Minute,
+	TierIntegration: 3 * time.Hour,
+}
+
+// interruptGrace is how long a suite gets to run its deferred destroys after
+// an interrupt before it is killed.
+const interruptGrace = 5 * time.Minute
+
+// Options configure Run.
+type Options struct {
+	// Root is the repository root the suites' directories are relative to.
+	Root string
+
+	// Concurrency is the number of suites run at once. Defaults to
+	// DefaultConcurrency.
+	Concurrency int
+
+	// Timeout overrides DefaultTimeouts for every suite when set.
+	Timeout time.Duration
+
+	// Args are passed to every go test invocation after the runner's own,
+	// e.g. -v or -parallel 8.
+	Args []string
+
+	// JSON runs go test with -json, so the output is a stream package report
+	// can parse.
+	JSON bool
+
+	// Go is the go command. Defaults to "go".
+	Go string
+
+	// OnStart and OnDone, if set, are called as each suite starts and
+	// finishes, from the goroutine running it.
+	OnStart func(s Suite)
+	OnDone  func(r Result)
+}
+
+// Result is the outcome of running a suite.
+type Result struct {
+	Suite Suite
+
+	// Args are the arguments go was run with.
+	Args []string
+
+	// Passed reports whether go test succeeded.
+	Passed bool
+
+	// Err is the reason go test failed: its exit status, or the error that
+	// kept it from starting.
+	Err error
+
+	// Output is the combined output of go test.
+	Output []byte
+
+	Duration time.Duration
+}
+
+// Run runs the suites, at most opts.Concurrency at a time, and returns their
+// results in the order of suites. Cancelling ctx interrupts the running
+// suites, so their deferred destroys still run, and skips the rest.
+func Run(ctx context.Context, suites []Suite, opts Options) []Result {
+	opts = opts.withDefaults()
+	results := make([]Result, len(suites))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, opts.Concurrency)
+	for i, s := range suites {
+		i, s := i, s
+		results[i] = Result{Suite: s, Args: opts.args(s)}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if opts.OnStart != nil {
+				opts.OnStart(s)
+			}
+			opts.run(ctx, &results[i])
+			if opts.OnDone != nil {
+				opts.OnDone(results[i])
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// args returns the go arguments that run s.
+func (o Options) args(s Suite) []string {
+	timeout := o.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeouts[s.Tier]
+	}
+	if timeout == 0 {
+		timeout = DefaultTimeouts[TierIntegration]
+	}
+
+	args := []string{"test", "-count=1", "-timeout", timeout.String()}
+	if o.JSON {
+		args = append(args, "-json")
+	}
+	if s.Run != "" {
+		args = append(args, "-run", s.Run)
+	}
+	if s.Skip != "" {
+		args = append(args, "-skip", s.Skip)
+	}
+	args = append(args, o.Args...)
+	args = append(args, s.Package)
+	if len(s.Args) > 0 {
+		args = append(append(args, "-args"), s.Args...)
+	}
+	return args
+}
+
+func (o Options) run(ctx context.Context, result *Result) {
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, o.Go, result.Args...)
+	cmd.Dir = filepath.Join(o.Root, filepath.FromSlash(result.Suite.Dir))
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = interruptGrace
+
+	start := time.Now()
+	result.Err = cmd.Run()
+	result.Duration = time.Since(start)
+	result.Output = output.Bytes()
+	result.Passed = result.Err == nil
+}
+
+func (o Options) withDefaults() Options {
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+	if o.Go == "" {
+		o.Go = "go"
+	}
+	return o
+}
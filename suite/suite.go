@@ -0,0 +1,403 @@
+// Package suite discovers and runs the test suites of the modules in the
+// library. Every module keeps its tests under tests/ in a Go module of its
+// own, with the go.mod either in tests/ or in tests/integration and a module
+// path that differs from module to module, so `go test ./...` at the root
+// reaches none of them. Discover finds them all by walking the tree instead.
+package suite
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+)
+
+// Tier is how much of Azure a suite needs.
+type Tier string
+
+const (
+	// TierUnit suites run against the Terraform configuration alone.
+	TierUnit Tier = "unit"
+
+	// TierPlan suites plan the module's examples against a subscription
+	// without deploying them: the plan snapshot and tag policy tests.
+	TierPlan Tier = "plan"
+
+	// TierIntegration suites deploy the module.
+	TierIntegration Tier = "integration"
+)
+
+// Tiers are the tiers in the order they are cheapest to run.
+var Tiers = []Tier{TierUnit, TierPlan, TierIntegration}
+
+// PlanTests matches the names of the tests of the plan tier. They live next
+// to the unit or integration tests of a module and are split off from them.
+const PlanTests = "^TestExamples(PlanSnapshot|TagPolicy)$"
+
+// SnapshotPackage is the package, in the repository's root module, of the
+// plan snapshot suite shared by the modules without one of their own. Its
+// suites pass the module with -module.
+const SnapshotPackage = "./zrrtest/snapshot/examples"
+
+// snapshotTest is the name of a plan snapshot suite.
+const snapshotTest = "TestExamplesPlanSnapshot"
+
+// RegistryFile is the module registry at the repository root.
+const RegistryFile = registry.File
+
+var planTests = regexp.MustCompile(PlanTests)
+
+// Suite is one tier of the tests of a module: a package of a tests module,
+// narrowed to the tests of the tier.
+type Suite struct {
+	// Layer and Module name the module, e.g. infrastructure and
+	// mysql-flexible-server.
+	Layer  string
+	Module string
+
+	// Tier is the tier of the tests.
+	Tier Tier
+
+	// Dir is the directory holding the tests module's go.mod, relative to
+	// the repository root, and Package the package within it, e.g. ./unit.
+	Dir     string
+	Package string
+
+	// Run and Skip are passed to go test as -run and -skip when set.
+	Run  string
+	Skip string
+
+	// Args are passed to the test binary, after -args.
+	Args []string
+
+	// Tags are the module's tags in the registry.
+	Tags []string
+}
+
+// Name returns layer/module/tier, with /snapshot appended for a suite of
+// SnapshotPackage so that it stays apart from the module's own plan suite.
+func (s Suite) Name() string {
+	name := s.Layer + "/" + s.Module + "/" + string(s.Tier)
+	if s.Package == SnapshotPackage {
+		name += "/snapshot"
+	}
+	return name
+}
+
+// FindRoot returns the repository root: dir or the closest directory above
+// it holding the module registry.
+func FindRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, RegistryFile)); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s in this directory or above it", RegistryFile)
+		}
+		dir = parent
+	}
+}
+
+// Discover returns the suites of every module under root, sorted by module
+// and tier. A package whose tests are split between the plan tier and its
+// own tier yields a suite for each.
+func Discover(root string) ([]Suite, error) {
+	tags, err := registryTags(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var suites []Suite
+	snapshots := map[string]bool{}
+	err = filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if file != root && (strings.HasPrefix(name, ".") || name == "testdata" || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != "go.mod" || filepath.Dir(file) == root {
+			return nil
+		}
+		found, err := discoverModule(root, filepath.Dir(file), tags, snapshots)
+		suites = append(suites, found...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	shared, err := sharedSnapshots(root, tags, snapshots)
+	if err != nil {
+		return nil, err
+	}
+	suites = append(suites, shared...)
+
+	sort.SliceStable(suites, func(i, j int) bool {
+		a, b := suites[i], suites[j]
+		if a.Layer+"/"+a.Module != b.Layer+"/"+b.Module {
+			return a.Layer+"/"+a.Module < b.Layer+"/"+b.Module
+		}
+		return tierIndex(a.Tier) < tierIndex(b.Tier)
+	})
+	return suites, nil
+}
+
+// discoverModule returns the suites of the tests module in dir, and records
+// in snapshots the path of its module if it has a plan snapshot suite.
+func discoverModule(root, dir string, tags map[string][]string, snapshots map[string]bool) ([]Suite, error) {
+	relative, err := filepath.Rel(root, dir)
+	if err != nil {
+		return nil, err
+	}
+	relative = filepath.ToSlash(relative)
+
+	// The module is the directory holding tests/, which sits in its layer.
+	elements := strings.Split(relative, "/")
+	testsAt := -1
+	for i, element := range elements {
+		if element == "tests" {
+			testsAt = i
+		}
+	}
+	if testsAt < 2 {
+		return nil, nil
+	}
+	modulePath := path.Join(elements[:testsAt]...)
+	base := Suite{
+		Layer:  elements[testsAt-2],
+		Module: elements[testsAt-1],
+		Dir:    relative,
+		Tags:   tags[modulePath],
+	}
+
+	var suites []Suite
+	err = filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		name := entry.Name()
+		if file != dir && (strings.HasPrefix(name, ".") || name == "testdata" || name == "fixtures") {
+			return filepath.SkipDir
+		}
+		if file != dir {
+			if _, err := os.Stat(filepath.Join(file, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+
+		tests, err := testNames(file)
+		if err != nil || len(tests) == 0 {
+			return err
+		}
+		pkg, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		below, err := filepath.Rel(filepath.Join(root, modulePath, "tests"), file)
+		if err != nil {
+			return err
+		}
+
+		suite := base
+		suite.Package = "./" + filepath.ToSlash(pkg)
+		if suite.Package == "./." {
+			suite.Package = "."
+		}
+		suite.Tier = Tier(strings.Split(filepath.ToSlash(below), "/")[0])
+		if suite.Tier == "." {
+			suite.Tier = TierUnit
+		}
+
+		plan, other := 0, 0
+		for _, test := range tests {
+			if test == snapshotTest {
+				snapshots[modulePath] = true
+			}
+			if planTests.MatchString(test) {
+				plan++
+			} else {
+				other++
+			}
+		}
+		if plan > 0 {
+			planSuite := suite
+			planSuite.Tier = TierPlan
+			planSuite.Run = PlanTests
+			suites = append(suites, planSuite)
+			suite.Skip = PlanTests
+		}
+		if other > 0 {
+			suites = append(suites, suite)
+		}
+		return nil
+	})
+	return suites, err
+}
+
+// sharedSnapshots returns a suite of SnapshotPackage for every module under
+// root with examples but without a plan snapshot suite, by snapshots.
+func sharedSnapshots(root string, tags map[string][]string, snapshots map[string]bool) ([]Suite, error) {
+	modules, err := registry.Discover(root)
+	if err != nil {
+		return nil, err
+	}
+	var suites []Suite
+	for _, modulePath := range modules {
+		if snapshots[modulePath] {
+			continue
+		}
+		examples, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(modulePath), "examples", "*", "*.tf"))
+		if err != nil {
+			return nil, err
+		}
+		if len(examples) == 0 {
+			continue
+		}
+		elements := strings.Split(modulePath, "/")
+		suites = append(suites, Suite{
+			Layer:   elements[len(elements)-2],
+			Module:  elements[len(elements)-1],
+			Tier:    TierPlan,
+			Dir:     ".",
+			Package: SnapshotPackage,
+			Run:     "^" + snapshotTest + "$",
+			Args:    []string{"-module=" + modulePath},
+			Tags:    tags[modulePath],
+		})
+	}
+	return suites, nil
+}
+
+var testFunc = regexp.MustCompile(`(?m)^func (Test\w*)\(\w+ \*testing\.T\)`)
+
+// testNames returns the names of the top-level tests in the _test.go files
+// of dir.
+func testNames(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		source, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range testFunc.FindAllSubmatch(source, -1) {
+			names = append(names, string(match[1]))
+		}
+	}
+	return names, nil
+}
+
+// registryTags returns the tags of every module in the registry by the
+// module's path.
+func registryTags(root string) (map[string][]string, error) {
+	r, err := registry.Load(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	tags := map[string][]string{}
+	for _, module := range r.Modules {
+		tags[path.Clean(module.Path)] = module.Tags
+	}
+	return tags, nil
+}
+
+func tierIndex(tier Tier) int {
+	for i, known := range Tiers {
+		if tier == known {
+			return i
+		}
+	}
+	return len(Tiers)
+}
+
+// Filter selects suites. Each field is a list of path.Match patterns; a
+// suite must match one pattern of every non-empty list, and one pattern of
+// Tags for every tag pattern given.
+type Filter struct {
+	Layers  []string
+	Modules []string
+	Tiers   []string
+
+	// Tags must all be carried by the module in the registry.
+	Tags []string
+}
+
+// Validate reports a malformed pattern.
+func (f Filter) Validate() error {
+	for _, list := range [][]string{f.Layers, f.Modules, f.Tiers, f.Tags} {
+		for _, pattern := range list {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Match reports whether the filter selects s.
+func (f Filter) Match(s Suite) bool {
+	if !matchAny(f.Layers, s.Layer) || !matchAny(f.Modules, s.Module) || !matchAny(f.Tiers, string(s.Tier)) {
+		return false
+	}
+	for _, pattern := range f.Tags {
+		carried := false
+		for _, tag := range s.Tags {
+			if ok, _ := path.Match(pattern, tag); ok {
+				carried = true
+				break
+			}
+		}
+		if !carried {
+			return false
+		}
+	}
+	return true
+}
+
+// Select returns the suites the filter matches.
+func (f Filter) Select(suites []Suite) []Suite {
+	var selected []Suite
+	for _, s := range suites {
+		if f.Match(s) {
+			selected = append(selected, s)
+		}
+	}
+	return selected
+}
+
+func matchAny(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+package suite
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const registryJSON = `{
+  "modules": [
+    {"name": "mysql-server", "layer": "infrastructure", "path": "azure/infrastructure/mysql-server", "tags": ["azure", "mysql", "database"]},
+    {"name": "dns-record", "layer": "infrastructure", "path": "azure/infrastructure/dns-record", "tags": ["azure", "dns"]}
+  ]
+}`
+
+const passingTest = `package test
+
+import "testing"
+
+func TestPasses(t *testing.T) {}
+`
+
+const planTest = `package test
+
+import "testing"
+
+func TestExamplesPlanSnapshot(t *testing.T) {}
+
+func TestExamplesTagPolicy(t *testing.T) {}
+`
+
+// sharedSnapshotTest stands in for the suite of SnapshotPackage.
+const sharedSnapshotTest = `package examples
+
+import (
+	"flag"
+	"testing"
+)
+
+var module = flag.String("module", "", "")
+
+func TestExamplesPlanSnapshot(t *testing.T) { t.Log("module", *module) }
+`
+
+const failingTest = `package test
+
+import "testing"
+
+func TestFails(t *testing.T) { t.Fatal("broken") }
+`
+
+// writeTree creates the files under root, creating directories as needed.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0o755))
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o644))
+	}
+}
+
+// newRepo lays out a repository with a module keeping its go.mod in tests/
+// and one keeping it in tests/integration, under different module paths, and
+// one whose examples are left to the shared snapshot suite.
+func newRepo(t *testing.T) string {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"go.mod":      "module example.com/lib\n\ngo 1.21\n",
+		"lib_test.go": passingTest,
+		RegistryFile:  registryJSON,
+		"azure/infrastructure/mysql-server/main.tf":                            "",
+		"azure/infrastructure/mysql-server/tests/go.mod":                       "module github.com/zrr-hq/lib/mysql-server/tests\n\ngo 1.21\n",
+		"azure/infrastructure/mysql-server/tests/unit/main_test.go":            passingTest,
+		"azure/infrastructure/mysql-server/tests/unit/snapshot_test.go":        planTest,
+		"azure/infrastructure/mysql-server/tests/integration/main_test.go":     failingTest,
+		"azure/infrastructure/mysql-server/tests/integration/.test-data/x.go":  "package broken",
+		"azure/infrastructure/dns-record/tests/unit/variables_test.tftest.hcl": "",
+		"azure/infrastructure/dns-record/tests/integration/go.mod":             "module github.com/zrr-org/lib/dns-record/tests/integration\n\ngo 1.21\n",
+		"azure/infrastructure/dns-record/tests/integration/snapshot_test.go":   planTest,
+		"azure/security/key-vault/main.tf":                                     "",
+		"azure/security/key-vault/examples/basic/main.tf":                      "",
+		"azure/security/key-vault/tests/go.mod":                                "module example.com/lib/key-vault/tests\n\ngo 1.21\n",
+		"azure/security/key-vault/tests/unit/helpers.go":                       "package test\n",
+		"zrrtest/snapshot/examples/examples_test.go":                           sharedSnapshotTest,
+	})
+	return root
+}
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+
+	suites, err := Discover(newRepo(t))
+	require.NoError(t, err)
+
+	mysqlTags := []string{"azure", "mysql", "database"}
+	assert.Equal(t, []Suite{
+		{Layer: "infrastructure", Module: "dns-record", Tier: TierPlan, Dir: "azure/infrastructure/dns-record/tests/integration", Package: ".", Run: PlanTests, Tags: []string{"azure", "dns"}},
+		{Layer: "infrastructure", Module: "mysql-server", Tier: TierUnit, Dir: "azure/infrastructure/mysql-server/tests", Package: "./unit", Skip: PlanTests, Tags: mysqlTags},
+		{Layer: "infrastructure", Module: "mysql-server", Tier: TierPlan, Dir: "azure/infrastructure/mysql-server/tests", Package: "./unit", Run: PlanTests, Tags: mysqlTags},
+		{Layer: "infrastructure", Module: "mysql-server", Tier: TierIntegration, Dir: "azure/infrastructure/mysql-server/tests", Package: "./integration", Tags: mysqlTags},
+		{Layer: "security", Module: "key-vault", Tier: TierPlan, Dir: ".", Package: SnapshotPackage, Run: "^TestExamplesPlanSnapshot$", Args: []string{"-module=azure/security/key-vault"}},
+	}, suites)
+	assert.Equal(t, "infrastructure/mysql-server/unit", suites[1].Name())
+}
+
+func TestFindRoot(t *testing.T) {
+	t.Parallel()
+
+	root := newRepo(t)
+	found, err := FindRoot(filepath.Join(root, "azure", "infrastructure", "mysql-server", "tests", "unit"))
+	require.NoError(t, err)
+	assert.Equal(t, root, found)
+
+	_, err = FindRoot(t.TempDir())
+	assert.ErrorContains(t, err, RegistryFile)
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	suites, err := Discover(newRepo(t))
+	require.NoError(t, err)
+	names := func(f Filter) []string {
+		var names []string
+		for _, s := range f.Select(suites) {
+			names = append(names, s.Name())
+		}
+		return names
+	}
+
+	assert.Len(t, names(Filter{}), 5)
+	assert.Equal(t, []string{"infrastructure/dns-record/plan", "infrastructure/mysql-server/plan", "security/key-vault/plan/snapshot"}, names(Filter{Tiers: []string{"plan"}}))
+	assert.Equal(t, []string{"infrastructure/mysql-server/unit", "infrastructure/mysql-server/plan"}, names(Filter{Modules: []string{"mysql-*"}, Tiers: []string{"unit", "plan"}}))
+	assert.Equal(t, []string{"infrastructure/dns-record/plan"}, names(Filter{Tags: []string{"azure", "dns"}}))
+	assert.Empty(t, names(Filter{Tags: []string{"dns", "mysql"}}))
+	assert.Empty(t, names(Filter{Layers: []string{"application"}}))
+
+	assert.NoError(t, Filter{Modules: []string{"mysql-*"}}.Validate())
+	assert.Error(t, Filter{Tags: []string{"[dns"}}.Validate())
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	root := newRepo(t)
+	suites, err := Discover(root)
+	require.NoError(t, err)
+
+	var started []string
+	results := Run(context.Background(), suites, Options{
+		Root:        root,
+		Concurrency: 1,
+		Args:        []string{"-v"},
+		OnStart:     func(s Suite) { started = append(started, s.Name()) },
+	})
+	require.Len(t, results, 5)
+	assert.Len(t, started, 5)
+
+	plan := results[0]
+	assert.True(t, plan.Passed, string(plan.Output))
+	assert.Equal(t, []string{"test", "-count=1", "-timeout", "30m0s", "-run", PlanTests, "-v", "."}, plan.Args)
+	assert.Contains(t, string(plan.Output), "--- PASS: TestExamplesTagPolicy")
+
+	unit := results[1]
+	assert.True(t, unit.Passed, string(unit.Output))
+	assert.Contains(t, string(unit.Output), "--- PASS: TestPasses")
+	assert.NotContains(t, string(unit.Output), "TestExamplesPlanSnapshot")
+	assert.Positive(t, unit.Duration)
+
+	integration := results[3]
+	assert.False(t, integration.Passed)
+	assert.Error(t, integration.Err)
+	assert.Contains(t, string(integration.Output), "broken")
+	assert.Equal(t, "3h0m0s", integration.Args[3])
+
+	shared := results[4]
+	assert.True(t, shared.Passed, string(shared.Output))
+	assert.Equal(t, []string{SnapshotPackage, "-args", "-module=azure/security/key-vault"}, shared.Args[len(shared.Args)-3:])
+	assert.Contains(t, string(shared.Output), "module azure/security/key-vault")
+}
+
+func TestRunCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	suites := []Suite{{Layer: "l", Module: "m", Tier: TierUnit, Dir: ".", Package: "."}}
+	results := Run(ctx, suites, Options{Root: t.TempDir(), Timeout: time.Minute})
+	require.Len(t, results, 1)
+	assert.False(t, results[0].Passed)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, "1m0s", results[0].Args[3])
+}
+
+func TestRunJSON(t *testing.T) {
+	t.Parallel()
+
+	suites := []Suite{{Layer: "l", Module: "m", Tier: TierUnit, Dir: ".", Package: "."}}
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"go.mod": "module example.com/m\n\ngo 1.21\n", "m_test.go": passingTest})
+	results := Run(context.Background(), suites, Options{Root: root, JSON: true})
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Passed, string(results[0].Output))
+	assert.Equal(t, []string{"test", "-count=1", "-timeout", "15m0s", "-json", "."}, results[0].Args)
+	assert.Contains(t, string(results[0].Output), `"Action":"pass","Package":"example.com/m","Test":"TestPasses"`)
+}
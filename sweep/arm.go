@@ -0,0 +1,214 @@
+package sweep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// DefaultEndpoint is the Azure Resource Manager endpoint of the public cloud.
+const DefaultEndpoint = "https://management.azure.com"
+
+// resourceGroupsAPIVersion is the first resources API version that returns
+// createdTime when asked to with $expand.
+const resourceGroupsAPIVersion = "2021-04-01"
+
+// defaultPollInterval is how often a deletion is polled when ARM does not
+// send a Retry-After header.
+const defaultPollInterval = 10 * time.Second
+
+// ARMClient lists and deletes resource groups through the ARM REST API. The
+// SDK clients the rest of the library uses do not expose createdTime, which
+// the minimum age check needs.
+type ARMClient struct {
+	// Endpoint is the ARM base URL. Defaults to DefaultEndpoint.
+	Endpoint string
+
+	// SubscriptionID is the subscription to sweep.
+	SubscriptionID string
+
+	// Authorizer signs every request, e.g. the result of terratest's
+	// azure.NewAuthorizer. Defaults to no authorization, which is only
+	// useful against a local fake.
+	Authorizer autorest.Authorizer
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// PollInterval overrides the Retry-After header ARM sends while a
+	// deletion is in progress.
+	PollInterval time.Duration
+}
+
+// ListResourceGroups returns every resource group of the subscription,
+// following nextLink across pages.
+func (c *ARMClient) ListResourceGroups(ctx context.Context) ([]ResourceGroup, error) {
+	query := url.Values{}
+	query.Set("api-version", resourceGroupsAPIVersion)
+	query.Set("$expand", "createdTime")
+	next := fmt.Sprintf("%s/subscriptions/%s/resourcegroups?%s", c.endpoint(), url.PathEscape(c.SubscriptionID), query.Encode())
+
+	var groups []ResourceGroup
+	for next != "" {
+		resp, err := c.do(ctx, http.MethodGet, next)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Value []struct {
+				ID          string            `json:"id"`
+				Name        string            `json:"name"`
+				Location    string            `json:"location"`
+				Tags        map[string]string `json:"tags"`
+				CreatedTime *time.Time        `json:"createdTime"`
+			} `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		err = decode(resp, http.StatusOK, &page)
+		if err != nil {
+			return nil, fmt.Errorf("listing resource groups: %w", err)
+		}
+		for _, value := range page.Value {
+			group := ResourceGroup{ID: value.ID, Name: value.Name, Location: value.Location, Tags: value.Tags}
+			if value.CreatedTime != nil {
+				group.CreatedTime = *value.CreatedTime
+			}
+			groups = append(groups, group)
+		}
+		next = page.NextLink
+	}
+	return groups, nil
+}
+
+// DeleteResourceGroup deletes a resource group and waits for ARM to finish.
+// A group that no longer exists counts as deleted.
+func (c *ARMClient) DeleteResourceGroup(ctx context.Context, name string) error {
+	target := fmt.Sprintf("%s/subscriptions/%s/resourcegroups/%s?api-version=%s",
+		c.endpoint(), url.PathEscape(c.SubscriptionID), url.PathEscape(name), resourceGroupsAPIVersion)
+
+	resp, err := c.do(ctx, http.MethodDelete, target)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		resp.Body.Close()
+		return nil
+	case http.StatusAccepted:
+	default:
+		return fmt.Errorf("deleting resource group %s: %w", name, errorFromResponse(resp))
+	}
+
+	// Poll the operation ARM pointed us to until it completes. A poll may
+	// point to a new one; one that does not keeps the last. Without any, poll
+	// the group itself, which ARM keeps returning while it is deleting, until
+	// it is gone.
+	var operation string
+	for {
+		if header, err := resp.Location(); err == nil {
+			operation = header.String()
+		}
+		resp.Body.Close()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.pollInterval(resp)):
+		}
+
+		if operation == "" {
+			if resp, err = c.do(ctx, http.MethodGet, target); err != nil {
+				return err
+			}
+			switch resp.StatusCode {
+			case http.StatusNotFound:
+				resp.Body.Close()
+				return nil
+			case http.StatusOK:
+				continue
+			}
+			return fmt.Errorf("deleting resource group %s: %w", name, errorFromResponse(resp))
+		}
+
+		if resp, err = c.do(ctx, http.MethodGet, operation); err != nil {
+			return err
+		}
+		switch resp.StatusCode {
+		case http.StatusOK, http.StatusNoContent:
+			resp.Body.Close()
+			return nil
+		case http.StatusAccepted:
+			continue
+		}
+		return fmt.Errorf("deleting resource group %s: %w", name, errorFromResponse(resp))
+	}
+}
+
+func (c *ARMClient) endpoint() string {
+	if c.Endpoint == "" {
+		return DefaultEndpoint
+	}
+	return strings.TrimSuffix(c.Endpoint, "/")
+}
+
+func (c *ARMClient) pollInterval(resp *http.Response) time.Duration {
+	if c.PollInterval > 0 {
+		return c.PollInterval
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultPollInterval
+}
+
+func (c *ARMClient) do(ctx context.Context, method, target string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.Authorizer != nil {
+		if req, err = autorest.Prepare(req, c.Authorizer.WithAuthorization()); err != nil {
+			return nil, err
+		}
+	}
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// decode reads a JSON response body into v, turning any status other than
+// expected into an error.
+func decode(resp *http.Response, expected int, v interface{}) error {
+	defer resp.Body.Close()
+	if resp.StatusCode != expected {
+		return errorFromResponse(resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// errorFromResponse builds an error from an ARM error response and closes
+// its body.
+func errorFromResponse(resp *http.Response) error {
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	var armError struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &armError) == nil && armError.Error.Code != "" {
+		return fmt.Errorf("%s: %s: %s", resp.Status, armError.Error.Code, armError.Error.Message)
+	}
+	return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
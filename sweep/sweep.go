@@ -0,0 +1,192 @@
+// Package sweep finds and deletes resource groups leaked by the integration
+// tests. A test that panics before its deferred terraform.Destroy runs leaves
+// its resource group behind; the group is recognised by its name, e.g.
+// test-rg-*, and by the TestID tag zrrtest.TestTags puts on everything a
+// test creates.
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultPatterns are the resource group names the integration tests use.
+var DefaultPatterns = []string{"test-rg-*", "test-mysql-ha-*", "test-perf-nsg-*"}
+
+// DefaultTag is the tag every test resource carries.
+const DefaultTag = "TestID"
+
+// DefaultConcurrency is the number of resource groups deleted at once.
+const DefaultConcurrency = 5
+
+// ResourceGroup is the part of an ARM resource group the sweeper looks at.
+type ResourceGroup struct {
+	ID          string
+	Name        string
+	Location    string
+	Tags        map[string]string
+	CreatedTime time.Time
+}
+
+// Age returns how long ago the group was created, or zero if ARM did not
+// report a creation time.
+func (g ResourceGroup) Age(now time.Time) time.Duration {
+	if g.CreatedTime.IsZero() {
+		return 0
+	}
+	return now.Sub(g.CreatedTime)
+}
+
+// Client is the subset of ARM the sweeper needs. ARMClient implements it.
+type Client interface {
+	ListResourceGroups(ctx context.Context) ([]ResourceGroup, error)
+	DeleteResourceGroup(ctx context.Context, name string) error
+}
+
+// Options select which resource groups are swept and how.
+type Options struct {
+	// Patterns are path.Match patterns; a group must match one of them.
+	// Defaults to DefaultPatterns.
+	Patterns []string
+
+	// Tag is a tag key the group must carry. Defaults to DefaultTag.
+	Tag string
+
+	// MinAge skips groups younger than this, so tests that are still
+	// running are left alone. Groups without a creation time are always
+	// skipped when MinAge is set.
+	MinAge time.Duration
+
+	// DryRun reports the groups that would be deleted without deleting
+	// them.
+	DryRun bool
+
+	// Concurrency is the number of deletions in flight at once. Defaults to
+	// DefaultConcurrency.
+	Concurrency int
+
+	// Now defaults to time.Now.
+	Now func() time.Time
+
+	// OnDelete, if set, is called after each deletion attempt, from the
+	// goroutine that made it.
+	OnDelete func(group ResourceGroup, err error)
+}
+
+// Result is the outcome of a sweep.
+type Result struct {
+	// Matched are the groups selected for deletion, sorted by name.
+	Matched []ResourceGroup
+
+	// Deleted are the names of the groups deleted, sorted.
+	Deleted []string
+
+	// Failed maps the names of groups that could not be deleted to the
+	// error.
+	Failed map[string]error
+}
+
+// Find returns the resource groups that match opts, sorted by name.
+func Find(ctx context.Context, client Client, opts Options) ([]ResourceGroup, error) {
+	opts = opts.withDefaults()
+	for _, pattern := range opts.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	groups, err := client.ListResourceGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := opts.Now()
+	var matched []ResourceGroup
+	for _, group := range groups {
+		if opts.matches(group, now) {
+			matched = append(matched, group)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched, nil
+}
+
+// Sweep deletes the resource groups that match opts, at most
+// opts.Concurrency at a time. The error is only set when the groups could
+// not be listed; failed deletions are reported in the result.
+func Sweep(ctx context.Context, client Client, opts Options) (*Result, error) {
+	opts = opts.withDefaults()
+	matched, err := Find(ctx, client, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Matched: matched, Failed: map[string]error{}}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, opts.Concurrency)
+	for _, group := range matched {
+		group := group
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			err := client.DeleteResourceGroup(ctx, group.Name)
+			mu.Lock()
+			if err != nil {
+				result.Failed[group.Name] = err
+			} else {
+				result.Deleted = append(result.Deleted, group.Name)
+			}
+			mu.Unlock()
+			if opts.OnDelete != nil {
+				opts.OnDelete(group, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Strings(result.Deleted)
+	return result, nil
+}
+
+func (o Options) withDefaults() Options {
+	if len(o.Patterns) == 0 {
+		o.Patterns = DefaultPatterns
+	}
+	if o.Tag == "" {
+		o.Tag = DefaultTag
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = DefaultConcurrency
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return o
+}
+
+func (o Options) matches(group ResourceGroup, now time.Time) bool {
+	if _, ok := group.Tags[o.Tag]; !ok {
+		return false
+	}
+	if o.MinAge > 0 && (group.CreatedTime.IsZero() || group.Age(now) < o.MinAge) {
+		return false
+	}
+	for _, pattern := range o.Patterns {
+		if ok, _ := path.Match(pattern, group.Name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,272 @@
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/fakearm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+
+// newFakeARM starts a fake ARM holding the given resource groups. Listing
+// is paged two groups at a time so nextLink is followed.
+func newFakeARM(t *testing.T, groups ...fakearm.ResourceGroup) (*fakearm.Server, *ARMClient) {
+	server := fakearm.New(t)
+	server.PageSize = 2
+	for _, group := range groups {
+		server.SeedResourceGroup(group)
+	}
+	return server, &ARMClient{Endpoint: server.URL, SubscriptionID: server.SubscriptionID, PollInterval: time.Millisecond}
+}
+
+func group(name string, age time.Duration, tags map[string]string) fakearm.ResourceGroup {
+	return fakearm.ResourceGroup{Name: name, Location: "eastus", Tags: tags, CreatedTime: now.Add(-age)}
+}
+
+// groupNames returns the names of the resource groups left on the server.
+func groupNames(server *fakearm.Server) []string {
+	var names []string
+	for _, id := range server.IDs() {
+		names = append(names, id[len(server.ResourceGroupID("")):])
+	}
+	return names
+}
+
+func testID() map[string]string { return map[string]string{"TestID": "abc123"} }
+
+func seed() []fakearm.ResourceGroup {
+	return []fakearm.ResourceGroup{
+		group("test-rg-old", 48*time.Hour, testID()),
+		group("test-mysql-ha-old", 10*time.Hour, testID()),
+		group("test-perf-nsg-old", 7*time.Hour, testID()),
+		group("test-rg-running", 30*time.Minute, testID()),
+		group("test-rg-untagged", 48*time.Hour, nil),
+		group("prod-rg-app", 48*time.Hour, testID()),
+	}
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	_, client := newFakeARM(t, seed()...)
+	opts := Options{MinAge: 6 * time.Hour, Now: func() time.Time { return now }}
+
+	matched, err := Find(context.Background(), client, opts)
+	require.NoError(t, err)
+
+	var names []string
+	for _, group := range matched {
+		names = append(names, group.Name)
+	}
+	assert.Equal(t, []string{"test-mysql-ha-old", "test-perf-nsg-old", "test-rg-old"}, names)
+	assert.Equal(t, "eastus", matched[0].Location)
+	assert.Equal(t, 10*time.Hour, matched[0].Age(now))
+}
+
+func TestFindWithCustomPatternAndNoMinAge(t *testing.T) {
+	t.Parallel()
+
+	_, client := newFakeARM(t, seed()...)
+	matched, err := Find(context.Background(), client, Options{Patterns: []string{"test-rg-*"}})
+	require.NoError(t, err)
+	require.Len(t, matched, 2)
+	assert.Equal(t, "test-rg-old", matched[0].Name)
+	assert.Equal(t, "test-rg-running", matched[1].Name)
+
+	_, err = Find(context.Background(), client, Options{Patterns: []string{"test-rg-["}})
+	assert.Error(t, err)
+}
+
+func TestSweepDryRunDeletesNothing(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeARM(t, seed()...)
+	result, err := Sweep(context.Background(), client, Options{MinAge: time.Hour, DryRun: true, Now: func() time.Time { return now }})
+	require.NoError(t, err)
+
+	assert.Len(t, result.Matched, 3)
+	assert.Empty(t, result.Deleted)
+	assert.Len(t, groupNames(server), 6)
+}
+
+func TestSweepDeletesMatchedGroups(t *testing.T) {
+	t.Parallel()
+
+	server, client := newFakeARM(t, seed()...)
+	server.Fail(http.MethodDelete, server.ResourceGroupID("test-perf-nsg-old"), http.StatusConflict, "ScopeLocked", "the resource group is locked")
+
+	var reported []string
+	var mu sync.Mutex
+	opts := Options{
+		MinAge: time.Hour,
+		Now:    func() time.Time { return now },
+		OnDelete: func(group ResourceGroup, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported = append(reported, group.Name)
+		},
+	}
+	result, err := Sweep(context.Background(), client, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"test-mysql-ha-old", "test-rg-old"}, result.Deleted)
+	require.Contains(t, result.Failed, "test-perf-nsg-old")
+	assert.Contains(t, result.Failed["test-perf-nsg-old"].Error(), "ScopeLocked: the resource group is locked")
+	assert.Len(t, reported, 3)
+	assert.Equal(t, []string{"prod-rg-app", "test-perf-nsg-old", "test-rg-running", "test-rg-untagged"}, groupNames(server))
+}
+
+func TestSweepRespectsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var groups []fakearm.ResourceGroup
+	for i := 0; i < 8; i++ {
+		groups = append(groups, group(fmt.Sprintf("test-rg-%d", i), 48*time.Hour, testID()))
+	}
+	server, client := newFakeARM(t, groups...)
+
+	// Hold every deletion until the test lets it through, counting how many
+	// are waiting at once.
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	release := make(chan struct{})
+	server.OnRequest = func(r *http.Request) {
+		if r.Method != http.MethodDelete {
+			return
+		}
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}
+
+	done := make(chan *Result)
+	go func() {
+		result, err := Sweep(context.Background(), client, Options{Concurrency: 3})
+		assert.NoError(t, err)
+		done <- result
+	}()
+
+	// Let the deletions through one at a time; no more than three may be
+	// waiting at once.
+	for i := 0; i < 8; i++ {
+		release <- struct{}{}
+	}
+	result := <-done
+
+	assert.Len(t, result.Deleted, 8)
+	mu.Lock()
+	assert.LessOrEqual(t, maxInFlight, 3)
+	mu.Unlock()
+	assert.Empty(t, groupNames(server))
+}
+
+func TestDeleteMissingGroupSucceeds(t *testing.T) {
+	t.Parallel()
+
+	_, client := newFakeARM(t)
+	assert.NoError(t, client.DeleteResourceGroup(context.Background(), "test-rg-gone"))
+}
+
+// scriptedARM answers each request with the next of responses for its
+// path, and records the paths polled.
+func scriptedARM(t *testing.T, responses map[string][]func(w http.ResponseWriter)) (*ARMClient, func() []string) {
+	var mu sync.Mutex
+	var polled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodGet {
+			polled = append(polled, r.URL.Path)
+		}
+		queue := responses[r.Method+" "+r.URL.Path]
+		if len(queue) == 0 {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		responses[r.Method+" "+r.URL.Path] = queue[1:]
+		queue[0](w)
+	}))
+	t.Cleanup(server.Close)
+	client := &ARMClient{Endpoint: server.URL, SubscriptionID: "sub", PollInterval: time.Millisecond}
+	return client, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return polled
+	}
+}
+
+func status(code int, location string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		if location != "" {
+			w.Header().Set("Location", location)
+		}
+		w.WriteHeader(code)
+	}
+}
+
+func TestDeleteKeepsPollingTheOperation(t *testing.T) {
+	t.Parallel()
+
+	const group, operation = "/subscriptions/sub/resourcegroups/test-rg", "/subscriptions/sub/operationresults/job-1"
+	responses := map[string][]func(w http.ResponseWriter){}
+	client, polled := scriptedARM(t, responses)
+	responses["DELETE "+group] = []func(w http.ResponseWriter){status(http.StatusAccepted, client.Endpoint+operation)}
+	// The group is still there while it is deleting; the operation's
+	// later answers carry no Location.
+	responses["GET "+group] = []func(w http.ResponseWriter){status(http.StatusOK, "")}
+	responses["GET "+operation] = []func(w http.ResponseWriter){
+		status(http.StatusAccepted, ""),
+		status(http.StatusAccepted, ""),
+		status(http.StatusOK, ""),
+	}
+
+	require.NoError(t, client.DeleteResourceGroup(context.Background(), "test-rg"))
+	assert.Equal(t, []string{operation, operation, operation}, polled())
+}
+
+func TestDeleteWithoutOperationWaitsForTheGroupToGo(t *testing.T) {
+	t.Parallel()
+
+	const group = "/subscriptions/sub/resourcegroups/test-rg"
+	responses := map[string][]func(w http.ResponseWriter){}
+	client, polled := scriptedARM(t, responses)
+	responses["DELETE "+group] = []func(w http.ResponseWriter){status(http.StatusAccepted, "")}
+	responses["GET "+group] = []func(w http.ResponseWriter){
+		status(http.StatusOK, ""),
+		status(http.StatusOK, ""),
+		status(http.StatusNotFound, ""),
+	}
+
+	require.NoError(t, client.DeleteResourceGroup(context.Background(), "test-rg"))
+	assert.Equal(t, []string{group, group, group}, polled())
+}
+
+func TestDeleteReportsAFailedOperation(t *testing.T) {
+	t.Parallel()
+
+	const group, operation = "/subscriptions/sub/resourcegroups/test-rg", "/subscriptions/sub/operationresults/job-1"
+	responses := map[string][]func(w http.ResponseWriter){}
+	client, _ := scriptedARM(t, responses)
+	responses["DELETE "+group] = []func(w http.ResponseWriter){status(http.StatusAccepted, client.Endpoint+operation)}
+	responses["GET "+operation] = []func(w http.ResponseWriter){status(http.StatusNotFound, "")}
+
+	err := client.DeleteResourceGroup(context.Background(), "test-rg")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
@@ -0,0 +1,327 @@
+package zrrtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-10-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/gruntwork-io/terratest/modules/azure"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// ResourceGroupExists reports whether the resource group exists, failing the
+// test on any error other than not-found.
+func ResourceGroupExists(t testing.TestingT, subscriptionID, resourceGroupName string) bool {
+	exists, err := ResourceGroupExistsE(subscriptionID, resourceGroupName)
+	require.NoError(t, err)
+	return exists
+}
+
+// ResourceGroupExistsE reports whether the resource group exists. ARM
+// reports a missing group as ResourceGroupNotFound, which terratest's
+// ResourceGroupExistsE treats as an error, so any 404 counts as not found.
+func ResourceGroupExistsE(subscriptionID, resourceGroupName string) (bool, error) {
+	if _, err := azure.GetAResourceGroupE(resourceGroupName, subscriptionID); err != nil {
+		if notFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateResourceGroup creates the resource group, or updates the one that
+// exists, with the given tags.
+func CreateResourceGroup(t testing.TestingT, subscriptionID, resourceGroupName, location string, tags map[string]string) {
+	require.NoError(t, CreateResourceGroupE(subscriptionID, resourceGroupName, location, tags))
+}
+
+// CreateResourceGroupE is like CreateResourceGroup but returns an error
+// instead of failing the test.
+func CreateResourceGroupE(subscriptionID, resourceGroupName, location string, tags map[string]string) error {
+	client, err := azure.CreateResourceGroupClientE(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	group := resources.Group{Location: &location, Tags: map[string]*string{}}
+	for key, value := range tags {
+		value := value
+		group.Tags[key] = &value
+	}
+	_, err = client.CreateOrUpdate(context.Background(), resourceGroupName, group)
+	return err
+}
+
+// DeleteResourceGroup deletes the resource group and everything in it and
+// waits for ARM to finish. A group that does not exist counts as deleted.
+func DeleteResourceGroup(t testing.TestingT, subscriptionID, resourceGroupName string) {
+	require.NoError(t, DeleteResourceGroupE(subscriptionID, resourceGroupName))
+}
+
+// DeleteResourceGroupE is like DeleteResourceGroup but returns an error
+// instead of failing the test.
+func DeleteResourceGroupE(subscriptionID, resourceGroupName string) error {
+	client, err := azure.CreateResourceGroupClientE(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	future, err := client.Delete(context.Background(), resourceGroupName)
+	if err != nil {
+		if notFound(err) {
+			return nil
+		}
+		return err
+	}
+	return future.WaitForCompletionRef(context.Background(), client.Client)
+}
+
+// ResourceID returns the ID of the resource of the given type, such as
+// Microsoft.Insights/components, named name in the resource group.
+func ResourceID(t testing.TestingT, subscriptionID, resourceGroupName, resourceType, name string) string {
+	subscriptionID, err := azure.GetTargetAzureSubscription(subscriptionID)
+	require.NoError(t, err)
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/%s/%s", subscriptionID, resourceGroupName, resourceType, name)
+}
+
+// Resource returns the resource with the given ID, read with the API version
+// of its resource provider. It covers resources terratest has no helper for,
+// such as Application Insights components and DNS record sets.
+func Resource(t testing.TestingT, subscriptionID, resourceID, apiVersion string) resources.GenericResource {
+	resource, err := ResourceE(subscriptionID, resourceID, apiVersion)
+	require.NoError(t, err)
+	return resource
+}
+
+// ResourceE is like Resource but returns an error instead of failing the
+// test.
+func ResourceE(subscriptionID, resourceID, apiVersion string) (resources.GenericResource, error) {
+	client, err := resourcesClient(subscriptionID)
+	if err != nil {
+		return resources.GenericResource{}, err
+	}
+	return client.GetByID(context.Background(), resourceID, apiVersion)
+}
+
+// ResourceProperties returns the properties of the resource with the given
+// ID, read with the API version of its resource provider. It covers
+// resources terratest has no helper for, such as MySQL Flexible Servers.
+func ResourceProperties(t testing.TestingT, subscriptionID, resourceID, apiVersion string) map[string]interface{} {
+	properties, err := ResourcePropertiesE(subscriptionID, resourceID, apiVersion)
+	require.NoError(t, err)
+	return properties
+}
+
+// ResourcePropertiesE is like ResourceProperties but returns an error
+// instead of failing the test.
+func ResourcePropertiesE(subscriptionID, resourceID, apiVersion string) (map[string]interface{}, error) {
+	resource, err := ResourceE(subscriptionID, resourceID, apiVersion)
+	if err != nil {
+		return nil, err
+	}
+	properties, _ := resource.Properties.(map[string]interface{})
+	return properties, nil
+}
+
+// CreateResource creates the resource with the given ID, or updates the one
+// that exists, with the API version of its resource provider, and waits for
+// ARM to finish. It creates the prerequisites of a suite terratest has no
+// helper for, such as Log Analytics workspaces and DNS zones, in a resource
+// group the suite deletes afterwards.
+func CreateResource(t testing.TestingT, subscriptionID, resourceID, apiVersion, location string, properties map[string]interface{}) {
+	require.NoError(t, CreateResourceE(subscriptionID, resourceID, apiVersion, location, properties))
+}
+
+// CreateResourceE is like CreateResource but returns an error instead of
+// failing the test.
+func CreateResourceE(subscriptionID, resourceID, apiVersion, location string, properties map[string]interface{}) error {
+	client, err := resourcesClient(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	resource := resources.GenericResource{Location: &location, Properties: properties}
+	future, err := client.CreateOrUpdateByID(context.Background(), resourceID, apiVersion, resource)
+	if err != nil {
+		return err
+	}
+	return future.WaitForCompletionRef(context.Background(), client.Client)
+}
+
+// resourcesClient returns a client of the generic resources API for the
+// subscription, or the one of ARM_SUBSCRIPTION_ID if it is empty.
+func resourcesClient(subscriptionID string) (*resources.Client, error) {
+	subscriptionID, err := azure.GetTargetAzureSubscription(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURI, err := resourceManagerEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := azure.NewAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	client := resources.NewClientWithBaseURI(baseURI, subscriptionID)
+	client.Authorizer = *authorizer
+	return &client, nil
+}
+
+// VNetExists reports whether the virtual network exists, failing the test on
+// any error other than not-found.
+func VNetExists(t testing.TestingT, subscriptionID, resourceGroupName, vnetName string) bool {
+	exists, err := azure.VirtualNetworkExistsE(vnetName, resourceGroupName, subscriptionID)
+	require.NoError(t, err)
+	return exists
+}
+
+// DNSZoneExists reports whether the public DNS zone exists, failing the test
+// on any error other than not-found.
+func DNSZoneExists(t testing.TestingT, subscriptionID, resourceGroupName, zoneName string) bool {
+	exists, err := DNSZoneExistsE(subscriptionID, resourceGroupName, zoneName)
+	require.NoError(t, err)
+	return exists
+}
+
+// DNSZoneExistsE reports whether the public DNS zone exists. Terratest has no
+// DNS helpers, so this talks to the DNS management API directly using the same
+// environment and authorizer chain as the rest of the azure package.
+func DNSZoneExistsE(subscriptionID, resourceGroupName, zoneName string) (bool, error) {
+	subscriptionID, err := azure.GetTargetAzureSubscription(subscriptionID)
+	if err != nil {
+		return false, err
+	}
+
+	baseURI, err := resourceManagerEndpoint()
+	if err != nil {
+		return false, err
+	}
+
+	authorizer, err := azure.NewAuthorizer()
+	if err != nil {
+		return false, err
+	}
+
+	client := dns.NewZonesClientWithBaseURI(baseURI, subscriptionID)
+	client.Authorizer = *authorizer
+
+	if _, err := client.Get(context.Background(), resourceGroupName, zoneName); err != nil {
+		if azure.ResourceNotFoundErrorExists(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// DNSRecordSet returns the record set of the given type, such as A or CNAME,
+// named name in the public DNS zone. Use "@" for the zone apex.
+func DNSRecordSet(t testing.TestingT, subscriptionID, resourceGroupName, zoneName, name, recordType string) *dns.RecordSet {
+	recordSet, err := DNSRecordSetE(subscriptionID, resourceGroupName, zoneName, name, recordType)
+	require.NoError(t, err)
+	return recordSet
+}
+
+// DNSRecordSetE is like DNSRecordSet but returns an error instead of failing
+// the test.
+func DNSRecordSetE(subscriptionID, resourceGroupName, zoneName, name, recordType string) (*dns.RecordSet, error) {
+	subscriptionID, err := azure.GetTargetAzureSubscription(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURI, err := resourceManagerEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := azure.NewAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	client := dns.NewRecordSetsClientWithBaseURI(baseURI, subscriptionID)
+	client.Authorizer = *authorizer
+
+	recordSet, err := client.Get(context.Background(), resourceGroupName, zoneName, name, dns.RecordType(recordType))
+	if err != nil {
+		return nil, err
+	}
+	return &recordSet, nil
+}
+
+// PrivateDNSRecordSet returns the record set of the given type named name in
+// the private DNS zone.
+func PrivateDNSRecordSet(t testing.TestingT, subscriptionID, resourceGroupName, zoneName, name, recordType string) *privatedns.RecordSet {
+	recordSet, err := PrivateDNSRecordSetE(subscriptionID, resourceGroupName, zoneName, name, recordType)
+	require.NoError(t, err)
+	return recordSet
+}
+
+// PrivateDNSRecordSetE is like PrivateDNSRecordSet but returns an error
+// instead of failing the test.
+func PrivateDNSRecordSetE(subscriptionID, resourceGroupName, zoneName, name, recordType string) (*privatedns.RecordSet, error) {
+	subscriptionID, err := azure.GetTargetAzureSubscription(subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURI, err := resourceManagerEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	authorizer, err := azure.NewAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	client := privatedns.NewRecordSetsClientWithBaseURI(baseURI, subscriptionID)
+	client.Authorizer = *authorizer
+
+	recordSet, err := client.Get(context.Background(), resourceGroupName, zoneName, privatedns.RecordType(recordType), name)
+	if err != nil {
+		return nil, err
+	}
+	return &recordSet, nil
+}
+
+// notFound reports whether err is ARM's answer for a missing resource or
+// resource group. Depending on the call, the SDK reports it with the status
+// code or, for long-running operations, only with the service error code.
+func notFound(err error) bool {
+	var detailed autorest.DetailedError
+	if errors.As(err, &detailed) && detailed.StatusCode == http.StatusNotFound {
+		return true
+	}
+	var service *autorestazure.ServiceError
+	return errors.As(err, &service) && (service.Code == "ResourceGroupNotFound" || service.Code == "ResourceNotFound")
+}
+
+// resourceManagerEndpoint returns the ARM endpoint of the Azure environment
+// named by AZURE_ENVIRONMENT, the public cloud by default, like terratest's
+// client factory does.
+func resourceManagerEndpoint() (string, error) {
+	name := os.Getenv(azure.AzureEnvironmentEnvName)
+	if name == "" {
+		name = autorestazure.PublicCloud.Name
+	}
+	environment, err := autorestazure.EnvironmentFromName(name)
+	if err != nil {
+		return "", err
+	}
+	return environment.ResourceManagerEndpoint, nil
+}
@@ -0,0 +1,188 @@
+package zrrtest
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// CopyModuleToTemp copies the Terraform configuration in dir to a new
+// temporary directory, together with the modules it calls by a relative path
+// outside of dir, such as ../../shared/locations. Those land at the same
+// relative place, so the copy initializes like the original. It returns the
+// copy of dir and the temporary directory holding everything copied, which
+// the caller removes when done. On error nothing is left behind.
+func CopyModuleToTemp(dir, prefix string) (string, string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+	root, err := os.MkdirTemp("", prefix)
+	if err != nil {
+		return "", "", err
+	}
+	base, err := copyModules(root, terraformFile, abs)
+	if err != nil {
+		os.RemoveAll(root)
+		return "", "", err
+	}
+	relative, err := filepath.Rel(base, abs)
+	if err != nil {
+		os.RemoveAll(root)
+		return "", "", err
+	}
+	return filepath.Join(root, relative), root, nil
+}
+
+// copyModules copies dirs, and the modules they call by a relative path
+// outside of them, below destination. They keep their layout below the
+// closest directory holding them all, which is returned. keep filters the
+// files by their path below the directory being copied, so a checkout under
+// a hidden directory is still copied.
+func copyModules(destination string, keep func(below string) bool, dirs ...string) (string, error) {
+	dirs, err := localModules(dirs...)
+	if err != nil {
+		return "", err
+	}
+	base := dirs[0]
+	for _, dir := range dirs {
+		for !within(dir, base) {
+			parent := filepath.Dir(base)
+			if parent == base {
+				return "", fmt.Errorf("%s and %s share no directory", dirs[0], dir)
+			}
+			base = parent
+		}
+	}
+
+	for _, source := range dirs {
+		relative, err := filepath.Rel(base, source)
+		if err != nil {
+			return "", err
+		}
+		target := filepath.Join(destination, relative)
+		if err := os.MkdirAll(target, 0o700); err != nil {
+			return "", err
+		}
+		source := source
+		err = files.CopyFolderContentsWithFilter(source, target, func(path string) bool {
+			below, err := filepath.Rel(source, path)
+			return err == nil && keep(below)
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+	return base, nil
+}
+
+// localModules returns dirs together with every directory they call as a
+// module by a relative path, transitively, from any *.tf file below them.
+// Directories within another one returned are left out.
+func localModules(dirs ...string) ([]string, error) {
+	var all []string
+	for _, dir := range dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, abs)
+	}
+	for i := 0; i < len(all); i++ {
+		sources, err := relativeSources(all[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range sources {
+			known := false
+			for _, dir := range all {
+				known = known || within(source, dir)
+			}
+			if !known {
+				all = append(all, source)
+			}
+		}
+	}
+
+	// The shortest paths first, so a directory is kept before any within it.
+	sorted := append([]string(nil), all...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i]) < len(sorted[j]) })
+	var kept []string
+	for _, dir := range sorted {
+		inside := false
+		for _, outer := range kept {
+			inside = inside || within(dir, outer)
+		}
+		if !inside {
+			kept = append(kept, dir)
+		}
+	}
+	return kept, nil
+}
+
+// relativeSources returns the directories the module blocks of the *.tf files
+// below dir call by a relative path.
+func relativeSources(dir string) ([]string, error) {
+	parser := hclparse.NewParser()
+	var sources []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".tf" {
+			return nil
+		}
+		file, diags := parser.ParseHCLFile(path)
+		if diags.HasErrors() {
+			return diags
+		}
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if block.Type != "module" {
+				continue
+			}
+			attribute, ok := block.Body.Attributes["source"]
+			if !ok {
+				continue
+			}
+			value, diags := attribute.Expr.Value(nil)
+			if diags.HasErrors() || value.Type() != cty.String {
+				continue
+			}
+			source := value.AsString()
+			if strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") {
+				sources = append(sources, filepath.Join(filepath.Dir(path), filepath.FromSlash(source)))
+			}
+		}
+		return nil
+	})
+	return sources, err
+}
+
+// within reports whether path is dir or lies below it.
+func within(path, dir string) bool {
+	relative, err := filepath.Rel(dir, path)
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator))
+}
+
+// terraformFile is the filter of files.CopyTerraformFolderToTemp: it skips
+// hidden files, state and variable files, but keeps the lock file.
+func terraformFile(below string) bool {
+	if files.PathIsTerraformVersionFile(below) || files.PathIsTerraformLockFile(below) {
+		return true
+	}
+	return !files.PathContainsHiddenFileOrFolder(below) && !files.PathContainsTerraformStateOrVars(below)
+}
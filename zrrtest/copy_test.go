@@ -0,0 +1,61 @@
+package zrrtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyModuleToTemp(t *testing.T) {
+	t.Parallel()
+
+	repository := t.TempDir()
+	writeTree(t, repository, map[string]string{
+		"azure/state/backend/main.tf":                 `module "region" { source = "../../shared/region" }`,
+		"azure/state/backend/examples/basic/main.tf":  `module "backend" { source = "../.." }`,
+		"azure/state/backend/terraform.tfstate":       "{}",
+		"azure/state/backend/.terraform/modules.json": "{}",
+		"azure/shared/region/main.tf":                 `module "names" { source = "./names" }`,
+		"azure/shared/region/names/main.tf":           "# names",
+		"azure/shared/other/main.tf":                  "# other",
+	})
+
+	copied, root, err := CopyModuleToTemp(filepath.Join(repository, "azure", "state", "backend"), "copy-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	assert.Equal(t, filepath.Join(root, "state", "backend"), copied)
+	for _, name := range []string{
+		"state/backend/main.tf",
+		"state/backend/examples/basic/main.tf",
+		"shared/region/main.tf",
+		"shared/region/names/main.tf",
+	} {
+		assert.FileExists(t, filepath.Join(root, filepath.FromSlash(name)))
+	}
+	for _, name := range []string{
+		"state/backend/terraform.tfstate",
+		"state/backend/.terraform",
+		"shared/other",
+	} {
+		assert.NoFileExists(t, filepath.Join(root, filepath.FromSlash(name)))
+		assert.NoDirExists(t, filepath.Join(root, filepath.FromSlash(name)))
+	}
+}
+
+func TestCopyModuleToTempWithoutCalls(t *testing.T) {
+	t.Parallel()
+
+	module := t.TempDir()
+	writeTree(t, module, map[string]string{"main.tf": "# module"})
+
+	copied, root, err := CopyModuleToTemp(module, "copy-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	assert.Equal(t, root, copied)
+	assert.FileExists(t, filepath.Join(copied, "main.tf"))
+}
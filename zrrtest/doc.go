@@ -0,0 +1,31 @@
+// Package zrrtest is the shared test kit for the ZRR Terraform module library.
+//
+// Every module keeps its Terratest suites under tests/ in a Go module of its
+// own. Those suites import zrrtest for the helpers that used to be copied from
+// module to module: random name generation, the standard terraform.Options
+// builders, Azure existence checks and the MySQL connectivity checks. A fix made
+// here lands in every suite at once.
+//
+// Suites never hard-code credentials. Password generates a fresh password per
+// test that satisfies the Azure complexity rules, the logger WithDefaults
+// installs masks it in Terraform's command logs, and KeepSecretOnFailure keeps
+// it in a Key Vault when the test fails so the leftover resources can be
+// inspected.
+//
+// Integration suites run as the stages setup, deploy, validate and teardown
+// of a Stages value. Setting SKIP_<stage> skips a stage, and the deployment's
+// state and generated values are kept under .test-data next to the suite, so
+// the validate stage can be rerun against a deployment that is still up.
+//
+// Validation tests kept in Terraform test files run from Go as well: package
+// tftest reports each run block of a .tftest.hcl file as a subtest, so they
+// show up in the same reports as the Terratest suites. Package fuzz goes
+// further and generates the invalid inputs itself from each variable's
+// validation blocks.
+//
+// Module suites depend on the kit through a replace directive pointing at the
+// repository root, so they always build against the checked-out version:
+//
+//	require github.com/ZealousRockResearch/zrr-tf-module-lib v0.0.0
+//	replace github.com/ZealousRockResearch/zrr-tf-module-lib => ../../../..
+package zrrtest
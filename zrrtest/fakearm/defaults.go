@@ -0,0 +1,99 @@
+package fakearm
+
+import "strings"
+
+// defaults fills in the read-only properties ARM adds to a resource of the
+// given type when it is created, and returns the child resources it
+// creates alongside it, keyed by ID.
+func defaults(typ, id string, body map[string]interface{}) map[string]map[string]interface{} {
+	properties, _ := body["properties"].(map[string]interface{})
+	if properties == nil {
+		properties = map[string]interface{}{}
+		body["properties"] = properties
+	}
+	if _, ok := properties["provisioningState"]; !ok {
+		properties["provisioningState"] = "Succeeded"
+	}
+	name, _ := body["name"].(string)
+
+	switch strings.ToLower(typ) {
+	case "microsoft.resources/resourcegroups":
+		return nil
+
+	case "microsoft.storage/storageaccounts":
+		if _, ok := properties["primaryEndpoints"]; !ok {
+			endpoints := map[string]interface{}{}
+			for _, service := range []string{"blob", "dfs", "file", "queue", "table", "web"} {
+				endpoints[service] = "https://" + name + "." + service + ".core.windows.net/"
+			}
+			properties["primaryEndpoints"] = endpoints
+		}
+
+	case "microsoft.network/dnszones":
+		if _, ok := properties["nameServers"]; !ok {
+			properties["nameServers"] = []interface{}{
+				"ns1-01.azure-dns.com.",
+				"ns2-01.azure-dns.net.",
+				"ns3-01.azure-dns.org.",
+				"ns4-01.azure-dns.info.",
+			}
+		}
+		if _, ok := properties["zoneType"]; !ok {
+			properties["zoneType"] = "Public"
+		}
+
+	case "microsoft.network/networksecuritygroups":
+		return securityRules(id, properties)
+	}
+	return nil
+}
+
+// securityRules returns the custom rules given inline in a network security
+// group body and the default rules Azure adds to every group, as child
+// resources.
+func securityRules(id string, properties map[string]interface{}) map[string]map[string]interface{} {
+	children := map[string]map[string]interface{}{}
+	rules, _ := properties["securityRules"].([]interface{})
+	for _, rule := range rules {
+		rule, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := rule["name"].(string)
+		if name == "" {
+			continue
+		}
+		children[id+"/securityRules/"+name] = rule
+	}
+
+	for _, rule := range defaultSecurityRules {
+		children[id+"/defaultSecurityRules/"+rule.name] = map[string]interface{}{
+			"properties": map[string]interface{}{
+				"description":              rule.description,
+				"protocol":                 "*",
+				"sourcePortRange":          "*",
+				"destinationPortRange":     "*",
+				"sourceAddressPrefix":      rule.source,
+				"destinationAddressPrefix": rule.destination,
+				"access":                   rule.access,
+				"priority":                 rule.priority,
+				"direction":                rule.direction,
+			},
+		}
+	}
+	return children
+}
+
+// defaultSecurityRules are the rules Azure puts in every network security
+// group.
+var defaultSecurityRules = []struct {
+	name, description, source, destination, access, direction string
+	priority                                                  int
+}{
+	{"AllowVnetInBound", "Allow inbound traffic from all VMs in VNET", "VirtualNetwork", "VirtualNetwork", "Allow", "Inbound", 65000},
+	{"AllowAzureLoadBalancerInBound", "Allow inbound traffic from azure load balancer", "AzureLoadBalancer", "*", "Allow", "Inbound", 65001},
+	{"DenyAllInBound", "Deny all inbound traffic", "*", "*", "Deny", "Inbound", 65500},
+	{"AllowVnetOutBound", "Allow outbound traffic from all VMs to all VMs in VNET", "VirtualNetwork", "VirtualNetwork", "Allow", "Outbound", 65000},
+	{"AllowInternetOutBound", "Allow outbound traffic from all VMs to Internet", "*", "Internet", "Allow", "Outbound", 65001},
+	{"DenyAllOutBound", "Deny all outbound traffic", "*", "*", "Deny", "Outbound", 65500},
+}
@@ -0,0 +1,27 @@
+// Package fakearm is an in-process stand-in for Azure Resource Manager.
+//
+// The Go helpers in zrrtest and the sweeper talk to ARM through terratest's
+// azure package or plain REST calls, so until now they could only be
+// exercised against a real subscription. A Server implements the small part
+// of the ARM REST API those helpers use, keeps its resources in memory and
+// can be seeded before the test runs:
+//
+//	server := fakearm.New(t)
+//	server.SeedResourceGroup(fakearm.ResourceGroup{Name: "test-rg-abc123", Location: "eastus"})
+//	server.Seed(server.ResourceID("test-rg-abc123", "Microsoft.Network/dnsZones/example.com"), nil)
+//
+//	server.Use(t)
+//	assert.True(t, zrrtest.DNSZoneExists(t, "", "test-rg-abc123", "example.com"))
+//
+// Use points terratest's azure package at the server for the rest of the
+// test: it selects a custom Azure environment whose Resource Manager and
+// Active Directory endpoints are the server, and sets client credentials the
+// server's token endpoint accepts. It changes the process environment, so
+// tests that call it cannot run in parallel.
+//
+// The server understands resource groups, including paged and filtered
+// listing and asynchronous deletion, and generic CRUD on any provider
+// resource below a resource group, e.g. storage accounts, MySQL servers, DNS
+// zones and network security groups with their security rules. Requests for
+// anything else get the error ARM would send for an unknown route.
+package fakearm
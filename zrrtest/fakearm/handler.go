@@ -0,0 +1,370 @@
+package fakearm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// armError is an ARM error response.
+type armError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// response is what a handler returns; ServeHTTP writes it.
+type response struct {
+	status int
+	header http.Header
+	body   interface{}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/oauth2/token") {
+		s.token(w, r)
+		return
+	}
+	if s.OnRequest != nil {
+		s.OnRequest(r)
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method:        r.Method,
+		Path:          r.URL.Path,
+		Query:         r.URL.RawQuery,
+		Authorization: r.Header.Get("Authorization"),
+	})
+	resp, apiErr := s.serve(r)
+	s.mu.Unlock()
+
+	if apiErr != nil {
+		resp = &response{
+			status: apiErr.Status,
+			body:   map[string]interface{}{"error": map[string]interface{}{"code": apiErr.Code, "message": apiErr.Message}},
+		}
+	}
+	for name, values := range resp.header {
+		w.Header()[name] = values
+	}
+	if resp.body == nil || r.Method == http.MethodHead {
+		w.WriteHeader(resp.status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(resp.status)
+	json.NewEncoder(w).Encode(resp.body)
+}
+
+func (s *Server) serve(r *http.Request) (*response, *armError) {
+	if r.URL.Query().Get("api-version") == "" {
+		return nil, &armError{http.StatusBadRequest, "MissingApiVersionParameter", "The api-version query parameter (?api-version=) is required for all requests."}
+	}
+	id, err := parseID(r.URL.Path)
+	if err != nil {
+		return nil, &armError{http.StatusNotFound, "InvalidResourceType", err.Error()}
+	}
+	if !strings.EqualFold(id.subscription, s.SubscriptionID) {
+		return nil, &armError{http.StatusNotFound, "SubscriptionNotFound", fmt.Sprintf("The subscription '%s' could not be found.", id.subscription)}
+	}
+	if apiErr, ok := s.failures[r.Method+" "+key(id.path)]; ok {
+		return nil, apiErr
+	}
+
+	switch {
+	case id.kind == kindGroups && r.Method == http.MethodGet:
+		return s.listGroups(r, id)
+	case id.kind == kindCollection && r.Method == http.MethodGet:
+		return s.listCollection(r, id)
+	case id.kind == kindProviderList && r.Method == http.MethodGet:
+		return s.list(r, func(body map[string]interface{}) bool {
+			return strings.EqualFold(body["type"].(string), id.typ)
+		})
+	case id.kind == kindOperation && r.Method == http.MethodGet:
+		return s.poll(id)
+	case id.kind == kindGroup || id.kind == kindResource:
+		return s.serveResource(r, id)
+	}
+	return nil, &armError{http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The requested method %s is not allowed on %s.", r.Method, id.path)}
+}
+
+func (s *Server) serveResource(r *http.Request, id resourceID) (*response, *armError) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		body, apiErr := s.lookup(id)
+		if apiErr != nil {
+			if r.Method == http.MethodHead {
+				return &response{status: apiErr.Status}, nil
+			}
+			return nil, apiErr
+		}
+		if r.Method == http.MethodHead {
+			return &response{status: http.StatusNoContent}, nil
+		}
+		return &response{status: http.StatusOK, body: view(body, r)}, nil
+
+	case http.MethodPut:
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, &armError{http.StatusBadRequest, "InvalidRequestContent", err.Error()}
+		}
+		_, exists := s.resources[key(id.path)]
+		if apiErr := s.put(id, body); apiErr != nil {
+			return nil, apiErr
+		}
+		status := http.StatusCreated
+		if exists {
+			status = http.StatusOK
+		}
+		return &response{status: status, body: view(s.resources[key(id.path)], r)}, nil
+
+	case http.MethodDelete:
+		if id.kind == kindGroup {
+			return s.deleteGroup(r, id)
+		}
+		if _, ok := s.resources[key(id.path)]; !ok {
+			return &response{status: http.StatusNoContent}, nil
+		}
+		s.remove(id.path)
+		return &response{status: http.StatusOK}, nil
+	}
+	return nil, &armError{http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("The requested method %s is not allowed on %s.", r.Method, id.path)}
+}
+
+// lookup returns the stored resource, or the error ARM returns for it.
+func (s *Server) lookup(id resourceID) (map[string]interface{}, *armError) {
+	if body, ok := s.resources[key(id.path)]; ok {
+		return body, nil
+	}
+	if apiErr := s.checkParent(id); apiErr != nil {
+		return nil, apiErr
+	}
+	if id.kind == kindGroup {
+		return nil, groupNotFound(id.group)
+	}
+	return nil, &armError{http.StatusNotFound, "ResourceNotFound",
+		fmt.Sprintf("The Resource '%s/%s' under resource group '%s' was not found.", id.typ, id.name, id.group)}
+}
+
+// checkParent returns an error when the group or parent resource of id does
+// not exist.
+func (s *Server) checkParent(id resourceID) *armError {
+	if id.kind == kindGroup {
+		return nil
+	}
+	if _, ok := s.resources[key(s.ResourceGroupID(id.group))]; !ok {
+		return groupNotFound(id.group)
+	}
+	if _, ok := s.resources[key(id.parent)]; !ok {
+		return &armError{http.StatusNotFound, "ParentResourceNotFound",
+			fmt.Sprintf("Can not perform requested operation on nested resource. Parent resource '%s' not found.", id.parent)}
+	}
+	return nil
+}
+
+func groupNotFound(name string) *armError {
+	return &armError{http.StatusNotFound, "ResourceGroupNotFound", fmt.Sprintf("Resource group '%s' could not be found.", name)}
+}
+
+// put stores body at id. The caller holds the lock.
+func (s *Server) put(id resourceID, body map[string]interface{}) *armError {
+	if id.kind != kindGroup && id.kind != kindResource {
+		return &armError{http.StatusBadRequest, "InvalidResourceType", fmt.Sprintf("%s is not a resource", id.path)}
+	}
+	if apiErr := s.checkParent(id); apiErr != nil {
+		return apiErr
+	}
+
+	// Round-trip through JSON so seeded bodies look like decoded requests.
+	stored := map[string]interface{}{}
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return &armError{http.StatusBadRequest, "InvalidRequestContent", err.Error()}
+		}
+		if err := json.Unmarshal(encoded, &stored); err != nil {
+			return &armError{http.StatusBadRequest, "InvalidRequestContent", err.Error()}
+		}
+	}
+	if id.kind == kindGroup {
+		if location, _ := stored["location"].(string); location == "" {
+			return &armError{http.StatusBadRequest, "LocationRequired", "The location property is required for this definition."}
+		}
+		if existing, ok := s.resources[key(id.path)]; ok {
+			stored["createdTime"] = existing["createdTime"]
+		} else if _, ok := stored["createdTime"]; !ok {
+			stored["createdTime"] = s.Now().UTC().Format(time.RFC3339)
+		}
+	}
+	stored["id"] = id.path
+	stored["name"] = id.name
+	stored["type"] = id.typ
+
+	children := defaults(id.typ, id.path, stored)
+	s.resources[key(id.path)] = stored
+	for childPath, child := range children {
+		childID, err := parseID(childPath)
+		if err != nil {
+			return &armError{http.StatusInternalServerError, "InternalServerError", err.Error()}
+		}
+		if apiErr := s.put(childID, child); apiErr != nil {
+			return apiErr
+		}
+	}
+	return nil
+}
+
+// remove deletes a resource and everything below it. The caller holds the
+// lock.
+func (s *Server) remove(path string) {
+	prefix := key(path)
+	for k := range s.resources {
+		if k == prefix || strings.HasPrefix(k, prefix+"/") {
+			delete(s.resources, k)
+		}
+	}
+}
+
+// deleteGroup starts an asynchronous deletion, like ARM: the group is
+// marked Deleting and removed when the operation is first polled.
+func (s *Server) deleteGroup(r *http.Request, id resourceID) (*response, *armError) {
+	body, ok := s.resources[key(id.path)]
+	if !ok {
+		return nil, groupNotFound(id.group)
+	}
+	body["properties"].(map[string]interface{})["provisioningState"] = "Deleting"
+
+	token := fmt.Sprintf("RESOURCEGROUPDELETIONJOB-%d", len(s.operations)+1)
+	s.operations[token] = id.path
+	location := fmt.Sprintf("%s/subscriptions/%s/operationresults/%s?api-version=%s",
+		s.URL, s.SubscriptionID, token, r.URL.Query().Get("api-version"))
+	header := http.Header{}
+	header.Set("Location", location)
+	header.Set("Retry-After", strconv.Itoa(int(s.RetryAfter/time.Second)))
+	return &response{status: http.StatusAccepted, header: header}, nil
+}
+
+func (s *Server) poll(id resourceID) (*response, *armError) {
+	path, ok := s.operations[id.name]
+	if !ok {
+		return nil, &armError{http.StatusNotFound, "OperationNotFound", fmt.Sprintf("The operation '%s' could not be found.", id.name)}
+	}
+	delete(s.operations, id.name)
+	s.remove(path)
+	return &response{status: http.StatusOK}, nil
+}
+
+// tagFilter matches the $filter expressions the resource group list
+// supports.
+var tagFilter = regexp.MustCompile(`^tagName eq '([^']*)'(?: and tagValue eq '([^']*)')?$`)
+
+func (s *Server) listGroups(r *http.Request, id resourceID) (*response, *armError) {
+	match := func(map[string]interface{}) bool { return true }
+	if filter := r.URL.Query().Get("$filter"); filter != "" {
+		parts := tagFilter.FindStringSubmatch(filter)
+		if parts == nil {
+			return nil, &armError{http.StatusBadRequest, "InvalidFilterInQueryString", fmt.Sprintf("Invalid $filter '%s' specified in the query string.", filter)}
+		}
+		match = func(body map[string]interface{}) bool {
+			tags, _ := body["tags"].(map[string]interface{})
+			value, ok := tags[parts[1]]
+			return ok && (parts[2] == "" || value == parts[2])
+		}
+	}
+	return s.list(r, func(body map[string]interface{}) bool {
+		return isChild(id.path, body["id"].(string)) && match(body)
+	})
+}
+
+func (s *Server) listCollection(r *http.Request, id resourceID) (*response, *armError) {
+	if apiErr := s.checkParent(id); apiErr != nil {
+		return nil, apiErr
+	}
+	return s.list(r, func(body map[string]interface{}) bool {
+		return isChild(id.path, body["id"].(string)) && strings.EqualFold(body["type"].(string), id.typ)
+	})
+}
+
+// list returns the resources match accepts, sorted by ID and paged by
+// PageSize with a $skiptoken.
+func (s *Server) list(r *http.Request, match func(map[string]interface{}) bool) (*response, *armError) {
+	var values []map[string]interface{}
+	for _, body := range s.resources {
+		if match(body) {
+			values = append(values, body)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return key(values[i]["id"].(string)) < key(values[j]["id"].(string)) })
+
+	start, _ := strconv.Atoi(r.URL.Query().Get("$skiptoken"))
+	if start > len(values) {
+		start = len(values)
+	}
+	end := len(values)
+	if s.PageSize > 0 && start+s.PageSize < end {
+		end = start + s.PageSize
+	}
+
+	page := map[string]interface{}{"value": []interface{}{}}
+	for _, body := range values[start:end] {
+		page["value"] = append(page["value"].([]interface{}), view(body, r))
+	}
+	if end < len(values) {
+		query := r.URL.Query()
+		query.Set("$skiptoken", strconv.Itoa(end))
+		page["nextLink"] = s.URL + r.URL.Path + "?" + query.Encode()
+	}
+	return &response{status: http.StatusOK, body: page}, nil
+}
+
+// view returns the representation of a stored resource for a response.
+// Like ARM, the creation time of a resource group is only included when
+// asked for with $expand=createdTime.
+func view(body map[string]interface{}, r *http.Request) map[string]interface{} {
+	out := clone(body).(map[string]interface{})
+	if !strings.Contains(r.URL.Query().Get("$expand"), "createdTime") {
+		delete(out, "createdTime")
+	}
+	return out
+}
+
+// token answers a client credentials request with an access token.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	now := time.Now()
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(map[string]string{
+		"token_type":   "Bearer",
+		"access_token": Token,
+		"expires_in":   "3600",
+		"expires_on":   strconv.FormatInt(now.Add(time.Hour).Unix(), 10),
+		"not_before":   strconv.FormatInt(now.Unix(), 10),
+		"resource":     r.PostForm.Get("resource"),
+	})
+}
+
+// clone deep-copies a decoded JSON value.
+func clone(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(value))
+		for k, v := range value {
+			out[k] = clone(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(value))
+		for i, v := range value {
+			out[i] = clone(v)
+		}
+		return out
+	}
+	return value
+}
@@ -0,0 +1,96 @@
+package fakearm
+
+import (
+	"fmt"
+	"strings"
+)
+
+// kind is what a request path addresses.
+type kind int
+
+const (
+	kindGroups       kind = iota // /subscriptions/{sub}/resourcegroups
+	kindGroup                    // .../resourcegroups/{group}
+	kindResource                 // .../providers/{namespace}/{type}/{name}[/{type}/{name}]...
+	kindCollection               // a resource path ending in a type
+	kindProviderList             // /subscriptions/{sub}/providers/{namespace}/{type}
+	kindOperation                // /subscriptions/{sub}/operationresults/{token}
+)
+
+// resourceID is a parsed ARM request path.
+type resourceID struct {
+	kind         kind
+	path         string // as given, with a leading slash and no trailing slash
+	subscription string
+	group        string
+	typ          string // e.g. Microsoft.Network/networkSecurityGroups/securityRules
+	name         string // last name segment, or the operation token
+	parent       string // the group or resource a resource or collection belongs to
+}
+
+// parseID parses the path of an ARM request.
+func parseID(path string) (resourceID, error) {
+	path = "/" + strings.Trim(path, "/")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	id := resourceID{path: path}
+	if len(segments) < 3 || !strings.EqualFold(segments[0], "subscriptions") || segments[1] == "" {
+		return id, fmt.Errorf("%s is not an ARM resource path", path)
+	}
+	id.subscription = segments[1]
+
+	switch {
+	case strings.EqualFold(segments[2], "operationresults") && len(segments) == 4:
+		id.kind, id.name = kindOperation, segments[3]
+		return id, nil
+	case strings.EqualFold(segments[2], "providers") && len(segments) == 5:
+		id.kind, id.typ = kindProviderList, segments[3]+"/"+segments[4]
+		return id, nil
+	case !strings.EqualFold(segments[2], "resourcegroups"):
+		return id, fmt.Errorf("%s is not an ARM resource path", path)
+	case len(segments) == 3:
+		id.kind = kindGroups
+		return id, nil
+	}
+
+	id.group = segments[3]
+	if len(segments) == 4 {
+		id.kind, id.name, id.typ = kindGroup, id.group, "Microsoft.Resources/resourceGroups"
+		return id, nil
+	}
+	if len(segments) < 7 || !strings.EqualFold(segments[4], "providers") {
+		return id, fmt.Errorf("%s is not an ARM resource path", path)
+	}
+
+	// After the namespace, types and names alternate.
+	rest := segments[6:]
+	types := []string{segments[5]}
+	for i, segment := range rest {
+		if segment == "" {
+			return id, fmt.Errorf("%s has an empty segment", path)
+		}
+		if i%2 == 0 {
+			types = append(types, segment)
+		}
+	}
+	id.typ = strings.Join(types, "/")
+	if len(rest)%2 == 1 {
+		id.kind = kindCollection
+		id.parent = strings.Join(segments[:len(segments)-1], "/")
+	} else {
+		id.kind = kindResource
+		id.name = rest[len(rest)-1]
+		id.parent = strings.Join(segments[:len(segments)-2], "/")
+	}
+	if len(segments) <= 8 {
+		// Top-level resources and their collections belong to the group.
+		id.parent = strings.Join(segments[:4], "/")
+	}
+	id.parent = "/" + id.parent
+	return id, nil
+}
+
+// isChild reports whether child is an ID directly below the collection path.
+func isChild(collection, child string) bool {
+	rest := strings.TrimPrefix(key(child), key(collection)+"/")
+	return rest != key(child) && rest != "" && !strings.Contains(rest, "/")
+}
@@ -0,0 +1,227 @@
+package fakearm
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// DefaultSubscriptionID is the subscription a Server serves unless told
+// otherwise.
+const DefaultSubscriptionID = "00000000-0000-0000-0000-000000000000"
+
+// DefaultTenantID is the tenant Use configures.
+const DefaultTenantID = "11111111-1111-1111-1111-111111111111"
+
+// Token is the access token the server hands out.
+const Token = "fakearm-token"
+
+// environmentName selects the custom environment file in go-autorest.
+const environmentName = "AZURESTACKCLOUD"
+
+// Server is a fake ARM endpoint. Create it with New.
+type Server struct {
+	// URL is the base URL of the server, without a trailing slash.
+	URL string
+
+	// SubscriptionID is the only subscription the server knows.
+	SubscriptionID string
+
+	// PageSize limits the number of items in each page of a list response.
+	// Zero returns everything in one page.
+	PageSize int
+
+	// Now stamps the createdTime of new resource groups. Defaults to
+	// time.Now.
+	Now func() time.Time
+
+	// RetryAfter is sent as the Retry-After header of asynchronous
+	// operations. It defaults to 15 seconds, like ARM; set it to zero to
+	// have SDK clients, which honour the header, poll at once.
+	RetryAfter time.Duration
+
+	// OnRequest, if set, is called at the start of every ARM request,
+	// outside the server's lock, so it may block to hold requests in
+	// flight. Set it before the first request.
+	OnRequest func(r *http.Request)
+
+	t          testing.TB
+	mu         sync.Mutex
+	resources  map[string]map[string]interface{}
+	operations map[string]string
+	failures   map[string]*armError
+	requests   []Request
+}
+
+// Request is a request the server received.
+type Request struct {
+	Method        string
+	Path          string
+	Query         string
+	Authorization string
+}
+
+// ResourceGroup describes a resource group to seed.
+type ResourceGroup struct {
+	Name     string
+	Location string
+	Tags     map[string]string
+
+	// CreatedTime defaults to the server's current time.
+	CreatedTime time.Time
+}
+
+// New starts a Server that is shut down when the test ends.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		SubscriptionID: DefaultSubscriptionID,
+		Now:            time.Now,
+		RetryAfter:     15 * time.Second,
+		t:              t,
+		resources:      map[string]map[string]interface{}{},
+		operations:     map[string]string{},
+		failures:       map[string]*armError{},
+	}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	s.URL = server.URL
+	return s
+}
+
+// Use points terratest's azure package, and anything else that reads the
+// standard Azure environment variables, at the server until the test ends.
+// It cannot be combined with t.Parallel.
+func (s *Server) Use(t *testing.T) {
+	t.Helper()
+
+	environment := map[string]interface{}{
+		"name":                    "FakeARM",
+		"resourceManagerEndpoint": s.URL + "/",
+		"activeDirectoryEndpoint": s.URL + "/",
+		"tokenAudience":           s.URL + "/",
+		"storageEndpointSuffix":   "core.windows.net",
+		"keyVaultDNSSuffix":       "vault.azure.net",
+	}
+	contents, err := json.Marshal(environment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "environment.json")
+	if err := os.WriteFile(path, contents, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("AZURE_ENVIRONMENT", environmentName)
+	t.Setenv("AZURE_ENVIRONMENT_FILEPATH", path)
+	t.Setenv("ARM_SUBSCRIPTION_ID", s.SubscriptionID)
+	t.Setenv("AZURE_SUBSCRIPTION_ID", s.SubscriptionID)
+	t.Setenv("AZURE_TENANT_ID", DefaultTenantID)
+	t.Setenv("AZURE_CLIENT_ID", "fakearm")
+	t.Setenv("AZURE_CLIENT_SECRET", "fakearm")
+}
+
+// ResourceGroupID returns the ID of a resource group in the server's
+// subscription.
+func (s *Server) ResourceGroupID(name string) string {
+	return "/subscriptions/" + s.SubscriptionID + "/resourceGroups/" + name
+}
+
+// ResourceID returns the ID of a resource below a resource group, e.g.
+// ResourceID("rg", "Microsoft.Network/networkSecurityGroups/nsg/securityRules/ssh").
+func (s *Server) ResourceID(resourceGroup, resource string) string {
+	return s.ResourceGroupID(resourceGroup) + "/providers/" + resource
+}
+
+// SeedResourceGroup adds a resource group and returns its ID.
+func (s *Server) SeedResourceGroup(group ResourceGroup) string {
+	s.t.Helper()
+
+	created := group.CreatedTime
+	if created.IsZero() {
+		created = s.Now()
+	}
+	id := s.ResourceGroupID(group.Name)
+	body := map[string]interface{}{
+		"location":    group.Location,
+		"createdTime": created.UTC().Format(time.RFC3339),
+	}
+	if group.Tags != nil {
+		tags := map[string]interface{}{}
+		for key, value := range group.Tags {
+			tags[key] = value
+		}
+		body["tags"] = tags
+	}
+	s.Seed(id, body)
+	return id
+}
+
+// Seed stores a resource as if it had been created with a PUT of body. The
+// id, name and type properties are filled in from id, and provider specific
+// defaults, e.g. the default security rules of a network security group, are
+// added. The resource group of id must have been seeded first.
+func (s *Server) Seed(id string, body map[string]interface{}) {
+	s.t.Helper()
+
+	parsed, err := parseID(id)
+	if err != nil {
+		s.t.Fatalf("fakearm: %v", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if apiErr := s.put(parsed, body); apiErr != nil {
+		s.t.Fatalf("fakearm: seeding %s: %s", id, apiErr.Message)
+	}
+}
+
+// Get returns a copy of the stored resource with the given ID.
+func (s *Server) Get(id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, ok := s.resources[key(id)]
+	if !ok {
+		return nil, false
+	}
+	return clone(body).(map[string]interface{}), true
+}
+
+// IDs returns the IDs of every stored resource, sorted.
+func (s *Server) IDs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids []string
+	for _, body := range s.resources {
+		ids = append(ids, body["id"].(string))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Fail makes every later request with the given method and path, e.g.
+// DELETE on a resource group ID, fail with an ARM error.
+func (s *Server) Fail(method, path string, status int, code, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[method+" "+key(path)] = &armError{Status: status, Code: code, Message: message}
+}
+
+// Requests returns the ARM requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// key is the map key of a resource ID; ARM IDs are case-insensitive.
+func key(id string) string {
+	return strings.TrimSuffix(strings.ToLower(id), "/")
+}
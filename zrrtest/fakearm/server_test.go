@@ -0,0 +1,316 @@
+package fakearm
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/gruntwork-io/terratest/modules/azure"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testGroup = "test-rg-fakearm"
+
+func seedGroup(s *Server) {
+	s.SeedResourceGroup(ResourceGroup{Name: testGroup, Location: "eastus", Tags: map[string]string{"TestID": "fakearm"}})
+	s.SeedResourceGroup(ResourceGroup{Name: "prod-rg-app", Location: "eastus"})
+}
+
+// TestTerratestHelpers runs the terratest and zrrtest helpers the module
+// suites use against the fake. It changes the environment, so it is not
+// parallel.
+func TestTerratestHelpers(t *testing.T) {
+	server := New(t)
+	server.RetryAfter = 0
+	seedGroup(server)
+	server.Seed(server.ResourceID(testGroup, "Microsoft.Storage/storageAccounts/satestfakearm"), map[string]interface{}{
+		"location": "eastus",
+		"kind":     "StorageV2",
+		"sku":      map[string]interface{}{"name": "Standard_LRS", "tier": "Standard"},
+	})
+	server.Seed(server.ResourceID(testGroup, "Microsoft.DBforMySQL/servers/mysql-fakearm"), map[string]interface{}{
+		"location":   "eastus",
+		"properties": map[string]interface{}{"version": "5.7", "sslEnforcement": "Enabled"},
+	})
+	server.Seed(server.ResourceID(testGroup, "Microsoft.Network/virtualNetworks/vnet-fakearm"), map[string]interface{}{"location": "eastus"})
+	server.Seed(server.ResourceID(testGroup, "Microsoft.Network/dnsZones/fakearm.example.com"), map[string]interface{}{"location": "global"})
+	server.Seed(server.ResourceID(testGroup, "Microsoft.Network/networkSecurityGroups/nsg-fakearm"), map[string]interface{}{
+		"location": "eastus",
+		"properties": map[string]interface{}{
+			"securityRules": []interface{}{map[string]interface{}{
+				"name": "allow-ssh",
+				"properties": map[string]interface{}{
+					"protocol":             "Tcp",
+					"sourcePortRange":      "*",
+					"destinationPortRange": "22",
+					"access":               "Allow",
+					"direction":            "Inbound",
+					"priority":             100,
+				},
+			}},
+		},
+	})
+	server.Use(t)
+
+	// Validate the zrrtest existence checks
+	assert.True(t, zrrtest.ResourceGroupExists(t, "", testGroup))
+	assert.False(t, zrrtest.ResourceGroupExists(t, "", "test-rg-missing"))
+	assert.True(t, zrrtest.VNetExists(t, "", testGroup, "vnet-fakearm"))
+	assert.False(t, zrrtest.VNetExists(t, "", testGroup, "vnet-missing"))
+	assert.True(t, zrrtest.DNSZoneExists(t, "", testGroup, "fakearm.example.com"))
+	assert.False(t, zrrtest.DNSZoneExists(t, "", testGroup, "missing.example.com"))
+
+	// Validate resource groups can be created and deleted
+	zrrtest.CreateResourceGroup(t, "", "test-rg-created", "eastus", map[string]string{"TestID": "fakearm"})
+	created, ok := server.Get(server.ResourceGroupID("test-rg-created"))
+	require.True(t, ok)
+	assert.Equal(t, "fakearm", created["tags"].(map[string]interface{})["TestID"])
+	zrrtest.DeleteResourceGroup(t, "", "test-rg-created")
+	assert.False(t, zrrtest.ResourceGroupExists(t, "", "test-rg-created"))
+	zrrtest.DeleteResourceGroup(t, "", "test-rg-created")
+
+	// Validate generic resource properties
+	properties := zrrtest.ResourceProperties(t, "", server.ResourceID(testGroup, "Microsoft.DBforMySQL/servers/mysql-fakearm"), "2017-12-01")
+	assert.Equal(t, "Succeeded", properties["provisioningState"])
+	assert.Equal(t, "5.7", properties["version"])
+
+	// Validate the storage account helpers
+	account, err := azure.GetStorageAccountE("satestfakearm", testGroup, "")
+	require.NoError(t, err)
+	assert.Equal(t, "StorageV2", string(account.Kind))
+	assert.Equal(t, "Standard", string(account.Sku.Tier))
+	endpoint, err := azure.GetStorageAccountPrimaryBlobEndpointE("satestfakearm", testGroup, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://satestfakearm.blob.core.windows.net/", endpoint)
+
+	// Validate the MySQL server lookup
+	mysqlServer, err := azure.GetMYSQLServerE(t, "", testGroup, "mysql-fakearm")
+	require.NoError(t, err)
+	assert.Equal(t, "Enabled", string(mysqlServer.SslEnforcement))
+
+	// Validate the NSG rule lookups see the custom and the default rules
+	rules, err := azure.GetAllNSGRulesE(testGroup, "nsg-fakearm", "")
+	require.NoError(t, err)
+	assert.Len(t, rules.SummarizedRules, 7)
+	ssh := rules.FindRuleByName("allow-ssh")
+	assert.True(t, ssh.AllowsDestinationPort(t, "22"))
+	assert.False(t, ssh.AllowsDestinationPort(t, "3306"))
+	assert.Equal(t, "Deny", rules.FindRuleByName("DenyAllInBound").Access)
+
+	// Validate tag filtering
+	groups, err := azure.ListResourceGroupsByTagE("TestID", "")
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	assert.Equal(t, testGroup, *groups[0].Name)
+
+	// Every ARM request carried the token the fake issued
+	for _, request := range server.Requests() {
+		assert.Equal(t, "Bearer "+Token, request.Authorization, request.Path)
+	}
+}
+
+func TestResourceLifecycle(t *testing.T) {
+	t.Parallel()
+
+	server := New(t)
+	groupID := server.ResourceGroupID(testGroup)
+	zoneID := server.ResourceID(testGroup, "Microsoft.Network/dnsZones/example.com")
+
+	// Validate a resource needs its group
+	status, body := call(t, server, http.MethodPut, zoneID, map[string]interface{}{"location": "global"})
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "ResourceGroupNotFound", errorCode(body))
+
+	status, _ = call(t, server, http.MethodPut, groupID, map[string]interface{}{"location": "eastus"})
+	assert.Equal(t, http.StatusCreated, status)
+
+	status, body = call(t, server, http.MethodPut, zoneID, map[string]interface{}{"location": "global"})
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, "Microsoft.Network/dnsZones", body["type"])
+	assert.Equal(t, "example.com", body["name"])
+	assert.Len(t, body["properties"].(map[string]interface{})["nameServers"], 4)
+
+	status, _ = call(t, server, http.MethodPut, zoneID, map[string]interface{}{"location": "global", "tags": map[string]interface{}{"a": "b"}})
+	assert.Equal(t, http.StatusOK, status)
+
+	// Validate IDs are case-insensitive
+	status, body = call(t, server, http.MethodGet, strings.ToLower(zoneID), nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, zoneID, body["id"])
+
+	status, body = call(t, server, http.MethodGet, server.ResourceID(testGroup, "Microsoft.Network/dnsZones"), nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, body["value"], 1)
+
+	status, body = call(t, server, http.MethodGet, server.ResourceID(testGroup, "Microsoft.Network/dnsZones/missing.com"), nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "ResourceNotFound", errorCode(body))
+
+	// Validate group deletion is asynchronous and takes the resources with it
+	resp := do(t, server, http.MethodDelete, groupID, nil)
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+	_, stillThere := server.Get(zoneID)
+	assert.True(t, stillThere)
+
+	location, err := resp.Location()
+	require.NoError(t, err)
+	poll, err := http.Get(location.String())
+	require.NoError(t, err)
+	poll.Body.Close()
+	assert.Equal(t, http.StatusOK, poll.StatusCode)
+	assert.Empty(t, server.IDs())
+}
+
+func TestRequestErrors(t *testing.T) {
+	t.Parallel()
+
+	server := New(t)
+	seedGroup(server)
+
+	resp, err := http.Get(server.URL + server.ResourceGroupID(testGroup))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	status, body := call(t, server, http.MethodGet, "/subscriptions/other/resourcegroups/"+testGroup, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "SubscriptionNotFound", errorCode(body))
+
+	status, body = call(t, server, http.MethodGet, server.ResourceID(testGroup, "Microsoft.Network/networkSecurityGroups/missing/securityRules"), nil)
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Equal(t, "ParentResourceNotFound", errorCode(body))
+
+	server.Fail(http.MethodDelete, server.ResourceGroupID(testGroup), http.StatusConflict, "ScopeLocked", "locked")
+	status, body = call(t, server, http.MethodDelete, server.ResourceGroupID(testGroup), nil)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "ScopeLocked", errorCode(body))
+
+	status, _ = call(t, server, http.MethodDelete, server.ResourceID(testGroup, "Microsoft.Storage/storageAccounts/missing"), nil)
+	assert.Equal(t, http.StatusNoContent, status)
+}
+
+func TestListGroups(t *testing.T) {
+	t.Parallel()
+
+	server := New(t)
+	server.PageSize = 2
+	created := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		server.SeedResourceGroup(ResourceGroup{Name: name, Location: "eastus", Tags: map[string]string{"letter": name}, CreatedTime: created})
+	}
+
+	var names []string
+	next := server.URL + "/subscriptions/" + server.SubscriptionID + "/resourcegroups?api-version=2021-04-01&$expand=createdTime"
+	for pages := 0; next != ""; pages++ {
+		require.Less(t, pages, 3)
+		resp, err := http.Get(next)
+		require.NoError(t, err)
+		var page struct {
+			Value []struct {
+				Name        string `json:"name"`
+				CreatedTime string `json:"createdTime"`
+			} `json:"value"`
+			NextLink string `json:"nextLink"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		resp.Body.Close()
+		for _, group := range page.Value {
+			names = append(names, group.Name)
+			assert.Equal(t, "2024-03-20T12:00:00Z", group.CreatedTime)
+		}
+		next = page.NextLink
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, names)
+
+	// Validate createdTime needs $expand and tags can be filtered on
+	status, body := call(t, server, http.MethodGet, "/subscriptions/"+server.SubscriptionID+"/resourcegroups?$filter=tagName eq 'letter' and tagValue eq 'c'", nil)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, body["value"], 1)
+	group := body["value"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "c", group["name"])
+	assert.NotContains(t, group, "createdTime")
+}
+
+func TestParseID(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		path   string
+		kind   kind
+		typ    string
+		name   string
+		parent string
+	}{
+		{"/subscriptions/s/resourcegroups", kindGroups, "", "", ""},
+		{"/subscriptions/s/resourceGroups/rg", kindGroup, "Microsoft.Resources/resourceGroups", "rg", ""},
+		{"/subscriptions/s/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts", kindCollection, "Microsoft.Storage/storageAccounts", "", "/subscriptions/s/resourceGroups/rg"},
+		{"/subscriptions/s/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/sa", kindResource, "Microsoft.Storage/storageAccounts", "sa", "/subscriptions/s/resourceGroups/rg"},
+		{"/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nsg/securityRules", kindCollection, "Microsoft.Network/networkSecurityGroups/securityRules", "", "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nsg"},
+		{"/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nsg/securityRules/ssh", kindResource, "Microsoft.Network/networkSecurityGroups/securityRules", "ssh", "/subscriptions/s/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/nsg"},
+		{"/subscriptions/s/providers/Microsoft.Storage/storageAccounts", kindProviderList, "Microsoft.Storage/storageAccounts", "", ""},
+		{"/subscriptions/s/operationresults/job-1", kindOperation, "", "job-1", ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			id, err := parseID(tc.path)
+			require.NoError(t, err)
+			assert.Equal(t, tc.kind, id.kind)
+			assert.Equal(t, tc.typ, id.typ)
+			assert.Equal(t, tc.name, id.name)
+			assert.Equal(t, tc.parent, id.parent)
+		})
+	}
+
+	for _, path := range []string{"/", "/subscriptions/s", "/subscriptions/s/resourceGroups/rg/deployments/d", "/tenants/t/resourcegroups"} {
+		_, err := parseID(path)
+		assert.Error(t, err, path)
+	}
+}
+
+// do sends an ARM request to the server, adding the api-version.
+func do(t *testing.T, s *Server, method, path string, body interface{}) *http.Response {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	req, err := http.NewRequest(method, s.URL+strings.ReplaceAll(path, " ", "%20")+separator+"api-version=2021-04-01", &payload)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+// call is do for requests whose response body is wanted.
+func call(t *testing.T, s *Server, method, path string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	resp := do(t, s, method, path, body)
+	defer resp.Body.Close()
+	var decoded map[string]interface{}
+	if resp.ContentLength != 0 {
+		json.NewDecoder(resp.Body).Decode(&decoded)
+	}
+	return resp.StatusCode, decoded
+}
+
+func errorCode(body map[string]interface{}) string {
+	apiErr, _ := body["error"].(map[string]interface{})
+	code, _ := apiErr["code"].(string)
+	return code
+}
@@ -0,0 +1,235 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// MaxCases is the number of cases generated for each validation block.
+var MaxCases = 3
+
+// Case is a value of a variable that fails some of its validations.
+type Case struct {
+	Variable string
+	Value    cty.Value
+
+	// Validations are the validations the value fails. The plan must report
+	// the error message of each.
+	Validations []registry.Validation
+}
+
+// HCL returns the value as an HCL expression.
+func (c Case) HCL() string {
+	return strings.TrimSpace(string(hclwrite.TokensForValue(c.Value).Bytes()))
+}
+
+// Name returns the value as an HCL expression, shortened to be readable as
+// the name of a subtest.
+func (c Case) Name() string {
+	name := strings.Join(strings.Fields(c.HCL()), " ")
+	if len(name) > 40 {
+		name = name[:37] + "..."
+	}
+	return name
+}
+
+// Gap is a validation no case exercises.
+type Gap struct {
+	Variable   string
+	Validation registry.Validation
+
+	// Reason says why no case exercises the validation.
+	Reason string
+}
+
+// Reasons of a Gap.
+const (
+	NoFailingValue  = "no generated value fails it"
+	NotEvaluable    = "its condition cannot be checked outside Terraform"
+	NoValueToChange = "the variable has no default or base value to change"
+)
+
+// String returns the gap as file:line: var.name: reason.
+func (g Gap) String() string {
+	return fmt.Sprintf("%s: var.%s: %s", g.Validation, g.Variable, g.Reason)
+}
+
+// Generate returns the cases of the variables and the validations none of
+// them exercise. base are valid values of the variables without a default,
+// and of any others the cases should start from, by name; variables with
+// neither a base value nor a default are unknown to the conditions, so
+// conditions that refer to other variables may not be checkable.
+func Generate(variables []registry.Variable, base map[string]cty.Value) ([]Case, []Gap) {
+	values := map[string]cty.Value{}
+	for _, variable := range variables {
+		switch {
+		case base[variable.Name] != cty.NilVal:
+			values[variable.Name] = base[variable.Name]
+		case variable.Default != cty.NilVal:
+			values[variable.Name] = variable.Default
+		default:
+			values[variable.Name] = cty.UnknownVal(variable.Type)
+		}
+	}
+
+	var cases []Case
+	var gaps []Gap
+	for _, variable := range variables {
+		if len(variable.Validations) == 0 {
+			continue
+		}
+		variableCases, variableGaps := generateVariable(variable, values)
+		cases = append(cases, variableCases...)
+		gaps = append(gaps, variableGaps...)
+	}
+	return cases, gaps
+}
+
+func generateVariable(variable registry.Variable, values map[string]cty.Value) ([]Case, []Gap) {
+	exprs := make([]hcl.Expression, len(variable.Validations))
+	for i, validation := range variable.Validations {
+		exprs[i] = validation.Condition
+	}
+	start := values[variable.Name]
+	if !start.IsKnown() {
+		start = cty.NullVal(variable.Type)
+	}
+	candidates := mine(exprs...).values(variable.Type, start)
+	if variable.Nullable {
+		candidates = append(candidates, cty.NullVal(variable.Type))
+	}
+
+	var cases []Case
+	counts := make([]int, len(variable.Validations))
+	checked := make([]bool, len(variable.Validations))
+	seen := map[string]bool{}
+	vars := make(map[string]cty.Value, len(values))
+	for name, value := range values {
+		vars[name] = value
+	}
+	for _, candidate := range candidates {
+		value, err := convert.Convert(candidate, variable.Type)
+		if err != nil {
+			continue
+		}
+		key := Case{Value: value}.HCL()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		vars[variable.Name] = value
+		var failed []registry.Validation
+		wanted := false
+		for i, validation := range variable.Validations {
+			switch Check(validation, vars) {
+			case Fails:
+				checked[i] = true
+				failed = append(failed, validation)
+				if counts[i] < MaxCases {
+					wanted = true
+				}
+			case Passes:
+				checked[i] = true
+			}
+		}
+		if !wanted {
+			continue
+		}
+		for i, validation := range variable.Validations {
+			for _, f := range failed {
+				if f.Range == validation.Range {
+					counts[i]++
+				}
+			}
+		}
+		cases = append(cases, Case{Variable: variable.Name, Value: value, Validations: failed})
+	}
+
+	var gaps []Gap
+	for i, validation := range variable.Validations {
+		if counts[i] > 0 {
+			continue
+		}
+		reason := NoFailingValue
+		switch {
+		case !checked[i]:
+			reason = NotEvaluable
+		case hasObjects(variable.Type) && !holdsObjects(start):
+			// Objects are only generated by changing a given one.
+			reason = NoValueToChange
+		}
+		gaps = append(gaps, Gap{Variable: variable.Name, Validation: validation, Reason: reason})
+	}
+	return cases, gaps
+}
+
+// Values converts Go values, as in terraform.Options.Vars, to values of the
+// variables' types.
+func Values(variables []registry.Variable, vars map[string]interface{}) (map[string]cty.Value, error) {
+	types := map[string]cty.Type{}
+	for _, variable := range variables {
+		types[variable.Name] = variable.Type
+	}
+
+	values := map[string]cty.Value{}
+	for name, v := range vars {
+		ty, ok := types[name]
+		if !ok {
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("var.%s: %w", name, err)
+		}
+		implied, err := ctyjson.ImpliedType(data)
+		if err != nil {
+			return nil, fmt.Errorf("var.%s: %w", name, err)
+		}
+		value, err := ctyjson.Unmarshal(data, implied)
+		if err != nil {
+			return nil, fmt.Errorf("var.%s: %w", name, err)
+		}
+		if value, err = convert.Convert(value, ty); err != nil {
+			return nil, fmt.Errorf("var.%s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// hasObjects reports whether values of ty may hold objects.
+func hasObjects(ty cty.Type) bool {
+	switch {
+	case ty.IsObjectType():
+		return true
+	case ty.IsCollectionType():
+		return hasObjects(ty.ElementType())
+	}
+	return false
+}
+
+// holdsObjects reports whether value holds an object.
+func holdsObjects(value cty.Value) bool {
+	switch {
+	case !value.IsKnown() || value.IsNull():
+		return false
+	case value.Type().IsObjectType():
+		return true
+	case value.CanIterateElements():
+		for it := value.ElementIterator(); it.Next(); {
+			if _, element := it.Element(); holdsObjects(element) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+// Package fuzz checks a module's variable validations with values made to
+// break them.
+//
+// Validation blocks are usually tested by hand, one invalid value each, and
+// a validation nobody wrote a case for goes unnoticed. This package reads
+// every validation block of the module's variables.tf and generates values
+// around the constants of its condition: numbers one either side of each
+// bound, strings one character shorter or longer than each length, and for
+// each regular expression, strings that break one part of it at a time.
+// Conditions are evaluated here as Terraform would, and the values a
+// condition rejects become the cases. Validations writes each case to its
+// own Terraform test file, plans it against mocked providers, and reports it
+// as a subtest that passes if the plan fails with the declared
+// error_message:
+//
+//	func TestVariableValidations(t *testing.T) {
+//		t.Parallel()
+//		fuzz.Validations(t, zrrtest.ModuleOptions(t, validVars),
+//			offline.LoadFixture(t, "resource_group"))
+//	}
+//
+//	--- FAIL: TestVariableValidations/name/"aaaaaaaaaaaaaaaaaaaaaaaaa"
+//	    the plan succeeded with var.name = "aaaaaaaaaaaaaaaaaaaaaaaaa"; ...
+//
+// Validations for which no value could be generated, or whose condition
+// calls a function this package does not know, are logged as not exercised.
+// The zrr-fuzz command lists the cases and gaps of every module without
+// running Terraform.
+package fuzz
@@ -0,0 +1,171 @@
+package fuzz
+
+import (
+	"strings"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/tryfunc"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// Outcome is the result of checking a validation condition.
+type Outcome int
+
+const (
+	// Unknown means the condition could not be evaluated here: it calls a
+	// function this package lacks, or depends on a value only known during
+	// the plan.
+	Unknown Outcome = iota
+	Passes
+	Fails
+)
+
+// functions are the Terraform functions validation conditions are checked
+// with. Conditions calling any other function have an Unknown outcome.
+var functions = map[string]function.Function{
+	"alltrue":    allTrueFunc,
+	"anytrue":    anyTrueFunc,
+	"can":        tryfunc.CanFunc,
+	"coalesce":   stdlib.CoalesceFunc,
+	"concat":     stdlib.ConcatFunc,
+	"contains":   stdlib.ContainsFunc,
+	"distinct":   stdlib.DistinctFunc,
+	"endswith":   endsWithFunc,
+	"flatten":    stdlib.FlattenFunc,
+	"formatdate": stdlib.FormatDateFunc,
+	"join":       stdlib.JoinFunc,
+	"keys":       stdlib.KeysFunc,
+	"length":     lengthFunc,
+	"lookup":     stdlib.LookupFunc,
+	"lower":      stdlib.LowerFunc,
+	"max":        stdlib.MaxFunc,
+	"min":        stdlib.MinFunc,
+	"regex":      stdlib.RegexFunc,
+	"regexall":   stdlib.RegexAllFunc,
+	"split":      stdlib.SplitFunc,
+	"startswith": startsWithFunc,
+	"strlen":     stdlib.StrlenFunc,
+	"substr":     stdlib.SubstrFunc,
+	"tonumber":   stdlib.MakeToFunc(cty.Number),
+	"tostring":   stdlib.MakeToFunc(cty.String),
+	"trimspace":  stdlib.TrimSpaceFunc,
+	"try":        tryfunc.TryFunc,
+	"upper":      stdlib.UpperFunc,
+	"values":     stdlib.ValuesFunc,
+}
+
+// Check evaluates the condition of validation with the variables set to vars,
+// by name.
+func Check(validation registry.Validation, vars map[string]cty.Value) Outcome {
+	// can and try would turn the error of a missing function into a result.
+	if callsUnknownFunction(validation.Condition) {
+		return Unknown
+	}
+	ctx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(vars)},
+		Functions: functions,
+	}
+	result, diags := validation.Condition.Value(ctx)
+	if diags.HasErrors() || !result.IsWhollyKnown() || result.IsNull() {
+		return Unknown
+	}
+	result, err := convertBool(result)
+	if err != nil {
+		return Unknown
+	}
+	if result.True() {
+		return Passes
+	}
+	return Fails
+}
+
+func callsUnknownFunction(expr hcl.Expression) bool {
+	node, ok := expr.(hclsyntax.Node)
+	if !ok {
+		return true
+	}
+	unknown := false
+	hclsyntax.VisitAll(node, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			if _, ok := functions[call.Name]; !ok {
+				unknown = true
+			}
+		}
+		return nil
+	})
+	return unknown
+}
+
+func convertBool(value cty.Value) (cty.Value, error) {
+	return stdlib.MakeToFunc(cty.Bool).Call([]cty.Value{value})
+}
+
+// lengthFunc is Terraform's length, which also counts the characters of a
+// string.
+var lengthFunc = function.New(&function.Spec{
+	Params: []function.Parameter{{Name: "value", Type: cty.DynamicPseudoType, AllowDynamicType: true}},
+	Type:   function.StaticReturnType(cty.Number),
+	Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+		if args[0].Type() == cty.String {
+			return stdlib.Strlen(args[0])
+		}
+		return stdlib.Length(args[0])
+	},
+})
+
+var allTrueFunc = boolListFunc(func(values []cty.Value) bool {
+	for _, value := range values {
+		if !value.True() {
+			return false
+		}
+	}
+	return true
+})
+
+var anyTrueFunc = boolListFunc(func(values []cty.Value) bool {
+	for _, value := range values {
+		if value.True() {
+			return true
+		}
+	}
+	return false
+})
+
+// boolListFunc returns a function of a list of bools, such as alltrue.
+func boolListFunc(reduce func([]cty.Value) bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "list", Type: cty.List(cty.Bool)}},
+		Type:   function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			var values []cty.Value
+			for it := args[0].ElementIterator(); it.Next(); {
+				_, value := it.Element()
+				if value.IsNull() {
+					return cty.False, nil
+				}
+				values = append(values, value)
+			}
+			return cty.BoolVal(reduce(values)), nil
+		},
+	})
+}
+
+var startsWithFunc = affixFunc(strings.HasPrefix)
+
+var endsWithFunc = affixFunc(strings.HasSuffix)
+
+// affixFunc returns a function of a string and an affix, such as
+// startswith.
+func affixFunc(has func(s, affix string) bool) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "string", Type: cty.String}, {Name: "affix", Type: cty.String}},
+		Type:   function.StaticReturnType(cty.Bool),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return cty.BoolVal(has(args[0].AsString(), args[1].AsString())), nil
+		},
+	})
+}
@@ -0,0 +1,136 @@
+package fuzz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func loadModule(t *testing.T) map[string]registry.Variable {
+	t.Helper()
+	variables, err := registry.LoadVariables("testdata/module")
+	require.NoError(t, err)
+	byName := map[string]registry.Variable{}
+	for _, variable := range variables {
+		byName[variable.Name] = variable
+	}
+	return byName
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	byName := loadModule(t)
+	validation := byName["retention_days"].Validations[0]
+	check := func(days cty.Value) Outcome {
+		return Check(validation, map[string]cty.Value{"retention_days": days})
+	}
+	assert.Equal(t, Passes, check(cty.NumberIntVal(1)))
+	assert.Equal(t, Passes, check(cty.NumberIntVal(365)))
+	assert.Equal(t, Fails, check(cty.NumberIntVal(0)))
+	assert.Equal(t, Fails, check(cty.NumberIntVal(366)))
+	assert.Equal(t, Unknown, check(cty.UnknownVal(cty.Number)))
+
+	// cidrhost is not one of the functions, and can must not hide that.
+	address := byName["address_space"].Validations[0]
+	assert.Equal(t, Unknown, Check(address, map[string]cty.Value{"address_space": cty.StringVal("")}))
+}
+
+func TestPatternStrings(t *testing.T) {
+	t.Parallel()
+
+	texts := patternStrings("^[a-z0-9]{3,24}$")
+	assert.Equal(t, "aaa", texts[0])
+	assert.Contains(t, texts, "aa")
+	assert.Contains(t, texts, strings.Repeat("a", 24))
+	assert.Contains(t, texts, strings.Repeat("a", 25))
+	assert.Contains(t, texts, "AAA")
+	assert.Contains(t, texts, "aaa!")
+	assert.Contains(t, texts, "!aaa")
+
+	assert.Equal(t, []string{"kv-a", "KV@a", "kv-", "kv-aa", "kv-0", "kv-a!", "!kv-a"}, patternStrings("^kv-[a-z]+$"))
+	assert.Nil(t, patternStrings("("))
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	variables, err := registry.LoadVariables("testdata/module")
+	require.NoError(t, err)
+	base := map[string]cty.Value{"name": cty.StringVal("valid")}
+	cases, gaps := Generate(variables, base)
+
+	values := map[string][]string{}
+	for _, c := range cases {
+		require.Len(t, c.Validations, 1)
+		values[c.Variable] = append(values[c.Variable], c.HCL())
+	}
+	assert.Equal(t, []string{`"aa"`, `"aaaaaaaaaaaaaaaaaaaaaaaaa"`, `"AAA"`}, values["name"])
+	assert.Equal(t, []string{"0", "366", "-1"}, values["retention_days"])
+	assert.Len(t, values["sku"], MaxCases)
+	assert.NotContains(t, values["sku"], `"Standard"`)
+
+	reasons := map[string]string{}
+	for _, gap := range gaps {
+		reasons[gap.Variable] = gap.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"address_space": NotEvaluable,
+		"network_rules": NoValueToChange,
+		"tags":          NotEvaluable,
+	}, reasons)
+
+	// Given an object to start from, its attributes are changed.
+	base["network_rules"] = cty.ObjectVal(map[string]cty.Value{
+		"default_action": cty.StringVal("Deny"),
+		"ip_rules":       cty.ListValEmpty(cty.String),
+	})
+	cases, _ = Generate(variables, base)
+	var rules []string
+	for _, c := range cases {
+		if c.Variable == "network_rules" {
+			rules = append(rules, c.Value.GetAttr("default_action").AsString())
+		}
+	}
+	assert.Len(t, rules, MaxCases)
+	assert.NotContains(t, rules, "Allow")
+	assert.NotContains(t, rules, "Deny")
+}
+
+func TestValues(t *testing.T) {
+	t.Parallel()
+
+	variables, err := registry.LoadVariables("testdata/module")
+	require.NoError(t, err)
+	values, err := Values(variables, map[string]interface{}{
+		"name":           "valid",
+		"retention_days": 30,
+		"network_rules":  map[string]interface{}{"default_action": "Deny"},
+		"unknown":        true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, cty.StringVal("valid"), values["name"])
+	assert.True(t, values["retention_days"].Equals(cty.NumberIntVal(30)).True())
+	assert.Equal(t, cty.StringVal("Deny"), values["network_rules"].GetAttr("default_action"))
+	assert.NotContains(t, values, "unknown")
+
+	_, err = Values(variables, map[string]interface{}{"retention_days": "thirty"})
+	assert.ErrorContains(t, err, "var.retention_days")
+}
+
+func TestCaseTestFile(t *testing.T) {
+	t.Parallel()
+
+	c := Case{Variable: "name", Value: cty.StringVal("aa")}
+	assert.Equal(t, "case_0007.tftest.hcl", caseFile(7))
+	assert.Contains(t, c.testFile("mock_provider \"azurerm\" {}\n"),
+		"mock_provider \"azurerm\" {}\n\nrun \"case\" {\n  command = plan\n\n  variables {\n    name = \"aa\"\n  }\n}\n")
+
+	long := Case{Variable: "name", Value: cty.StringVal(strings.Repeat("a", 50))}
+	assert.Len(t, long.Name(), 40)
+	assert.True(t, strings.HasSuffix(long.Name(), "..."))
+}
@@ -0,0 +1,350 @@
+package fuzz
+
+import (
+	"math/big"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// maxRepeat caps the repetitions and lengths values are generated with.
+const maxRepeat = 1024
+
+// genericStrings are tried for every string, whatever its validations.
+var genericStrings = []string{"", " ", "a", "-", "UPPERCASE", "invalid@name!", "not-valid"}
+
+// literals are the constants of the validation conditions of a variable,
+// around which its values are generated.
+type literals struct {
+	strings  []string
+	numbers  []*big.Float
+	patterns []string
+}
+
+// mine collects the string and number literals of the expressions and the
+// patterns passed to regex and regexall.
+func mine(exprs ...hcl.Expression) literals {
+	var found literals
+	for _, expr := range exprs {
+		node, ok := expr.(hclsyntax.Node)
+		if !ok {
+			continue
+		}
+		hclsyntax.VisitAll(node, func(node hclsyntax.Node) hcl.Diagnostics {
+			switch node := node.(type) {
+			case *hclsyntax.LiteralValueExpr:
+				switch node.Val.Type() {
+				case cty.String:
+					found.strings = append(found.strings, node.Val.AsString())
+				case cty.Number:
+					found.numbers = append(found.numbers, node.Val.AsBigFloat())
+				}
+			case *hclsyntax.FunctionCallExpr:
+				if (node.Name == "regex" || node.Name == "regexall") && len(node.Args) > 0 {
+					if pattern, diags := node.Args[0].Value(nil); !diags.HasErrors() && pattern.Type() == cty.String && pattern.IsKnown() && !pattern.IsNull() {
+						found.patterns = append(found.patterns, pattern.AsString())
+					}
+				}
+			}
+			return nil
+		})
+	}
+	return found
+}
+
+// primitives returns the values of a primitive type generated from the
+// literals: the literals themselves and their neighbours, so boundaries are
+// hit from both sides.
+func (l literals) primitives(ty cty.Type) []cty.Value {
+	var values []cty.Value
+	switch ty {
+	case cty.String:
+		var texts []string
+		for _, pattern := range l.patterns {
+			texts = append(texts, patternStrings(pattern)...)
+		}
+		for _, s := range l.strings {
+			texts = append(texts, s, strings.ToLower(s), strings.ToUpper(s), s+"x")
+		}
+		for _, n := range l.numbers {
+			if length, accuracy := n.Int64(); accuracy == big.Exact && length >= 0 && length <= maxRepeat {
+				for _, k := range []int64{length - 1, length, length + 1} {
+					if k >= 0 {
+						texts = append(texts, strings.Repeat("a", int(k)))
+					}
+				}
+			}
+		}
+		texts = append(texts, genericStrings...)
+		for _, text := range texts {
+			values = append(values, cty.StringVal(text))
+		}
+	case cty.Number:
+		one := big.NewFloat(1)
+		for _, n := range l.numbers {
+			values = append(values,
+				cty.NumberVal(new(big.Float).Sub(n, one)),
+				cty.NumberVal(n),
+				cty.NumberVal(new(big.Float).Add(n, one)))
+		}
+		values = append(values, cty.NumberIntVal(0), cty.NumberIntVal(-1))
+	case cty.Bool:
+		values = append(values, cty.True, cty.False)
+	}
+	return values
+}
+
+// values returns the values of type ty generated from the literals. Values of
+// collections hold one generated element; objects, which cannot be made up,
+// are generated by changing one attribute of base at a time, if there is a
+// base.
+func (l literals) values(ty cty.Type, base cty.Value) []cty.Value {
+	switch {
+	case ty.IsPrimitiveType():
+		return l.primitives(ty)
+	case ty.IsListType() || ty.IsSetType():
+		values := []cty.Value{emptyCollection(ty)}
+		for _, element := range l.values(ty.ElementType(), firstElement(base)) {
+			values = append(values, collection(ty, []cty.Value{element}))
+		}
+		return values
+	case ty.IsMapType():
+		values := []cty.Value{cty.MapValEmpty(ty.ElementType())}
+		key, element := "key", cty.NilVal
+		if base != cty.NilVal && base.IsKnown() && !base.IsNull() && base.LengthInt() > 0 {
+			for it := base.ElementIterator(); it.Next(); {
+				k, v := it.Element()
+				key, element = k.AsString(), v
+				break
+			}
+		}
+		for _, value := range l.values(ty.ElementType(), element) {
+			values = append(values, cty.MapVal(map[string]cty.Value{key: value}))
+		}
+		if ty.ElementType() == cty.String {
+			for _, k := range l.primitives(cty.String) {
+				values = append(values, cty.MapVal(map[string]cty.Value{k.AsString(): cty.StringVal("value")}))
+			}
+		}
+		return values
+	case ty.IsObjectType():
+		if base == cty.NilVal || !base.IsKnown() || base.IsNull() {
+			return nil
+		}
+		var values []cty.Value
+		attributes := base.AsValueMap()
+		for name, attributeType := range ty.AttributeTypes() {
+			for _, value := range l.values(attributeType, attributes[name]) {
+				changed := make(map[string]cty.Value, len(attributes))
+				for k, v := range attributes {
+					changed[k] = v
+				}
+				changed[name] = value
+				values = append(values, cty.ObjectVal(changed))
+			}
+			if ty.AttributeOptional(name) {
+				changed := make(map[string]cty.Value, len(attributes))
+				for k, v := range attributes {
+					changed[k] = v
+				}
+				changed[name] = cty.NullVal(attributeType)
+				values = append(values, cty.ObjectVal(changed))
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func emptyCollection(ty cty.Type) cty.Value {
+	if ty.IsSetType() {
+		return cty.SetValEmpty(ty.ElementType())
+	}
+	return cty.ListValEmpty(ty.ElementType())
+}
+
+func collection(ty cty.Type, elements []cty.Value) cty.Value {
+	if ty.IsSetType() {
+		return cty.SetVal(elements)
+	}
+	return cty.ListVal(elements)
+}
+
+func firstElement(value cty.Value) cty.Value {
+	if value == cty.NilVal || !value.IsKnown() || value.IsNull() || !value.CanIterateElements() || value.LengthInt() == 0 {
+		return cty.NilVal
+	}
+	it := value.ElementIterator()
+	it.Next()
+	_, element := it.Element()
+	return element
+}
+
+// patternStrings returns strings built from a regular expression: one with
+// every repetition at its minimum, and ones that each push a single part of
+// the expression to, or just past, its limits: a repetition at its maximum,
+// one more or one fewer, a character outside its class, a literal in the
+// other case. Whether a string still matches is for the caller to check.
+func patternStrings(pattern string) []string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+
+	var texts []string
+	add := func(g generator) {
+		var b strings.Builder
+		if g.write(&b, re) {
+			texts = append(texts, b.String())
+		}
+	}
+	base := generator{}
+	add(base)
+
+	var nodes []*syntax.Regexp
+	var walk func(*syntax.Regexp)
+	walk = func(node *syntax.Regexp) {
+		nodes = append(nodes, node)
+		for _, sub := range node.Sub {
+			walk(sub)
+		}
+	}
+	walk(re)
+
+	for _, node := range nodes {
+		switch node.Op {
+		case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+			min, max := bounds(node)
+			if min > 0 {
+				add(generator{target: node, count: min - 1})
+			}
+			if max >= 0 && max <= maxRepeat {
+				add(generator{target: node, count: max})
+				add(generator{target: node, count: max + 1})
+			} else if max < 0 {
+				add(generator{target: node, count: min + 1})
+			}
+		case syntax.OpCharClass, syntax.OpLiteral:
+			add(generator{target: node, outside: true})
+		}
+	}
+
+	if len(texts) > 0 {
+		texts = append(texts, texts[0]+"!", "!"+texts[0])
+	}
+	return texts
+}
+
+// generator writes a string matching a regular expression, except for
+// target, which it writes count times if it is a repetition or with a
+// character that does not match if outside is set.
+type generator struct {
+	target  *syntax.Regexp
+	count   int
+	outside bool
+}
+
+// write writes a string for re to b and reports whether it could.
+func (g generator) write(b *strings.Builder, re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re == g.target && g.outside {
+				r = otherCase(r)
+			}
+			b.WriteRune(r)
+		}
+	case syntax.OpCharClass:
+		r, ok := classRune(re.Rune, re == g.target && g.outside)
+		if !ok {
+			return false
+		}
+		b.WriteRune(r)
+	case syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		b.WriteRune('a')
+	case syntax.OpCapture:
+		return g.write(b, re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !g.write(b, sub) {
+				return false
+			}
+		}
+	case syntax.OpAlternate:
+		return g.write(b, re.Sub[0])
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		count, _ := bounds(re)
+		if re == g.target {
+			count = g.count
+		}
+		if count > maxRepeat {
+			return false
+		}
+		for i := 0; i < count; i++ {
+			if !g.write(b, re.Sub[0]) {
+				return false
+			}
+		}
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText,
+		syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+	default:
+		return false
+	}
+	return true
+}
+
+// bounds returns the minimum and maximum repetitions of a repetition, with
+// -1 for no maximum.
+func bounds(re *syntax.Regexp) (int, int) {
+	switch re.Op {
+	case syntax.OpStar:
+		return 0, -1
+	case syntax.OpPlus:
+		return 1, -1
+	case syntax.OpQuest:
+		return 0, 1
+	}
+	return re.Min, re.Max
+}
+
+// classPreference is the order characters are picked from a class in, so
+// generated strings read naturally.
+const classPreference = "a0A-_.@ !/"
+
+// classRune picks a character in the class given by its ranges, or outside
+// it.
+func classRune(ranges []rune, outside bool) (rune, bool) {
+	in := func(r rune) bool {
+		for i := 0; i+1 < len(ranges); i += 2 {
+			if ranges[i] <= r && r <= ranges[i+1] {
+				return true
+			}
+		}
+		return false
+	}
+	for _, r := range classPreference {
+		if in(r) != outside {
+			return r, true
+		}
+	}
+	if !outside && len(ranges) > 0 {
+		return ranges[0], true
+	}
+	return 0, false
+}
+
+func otherCase(r rune) rune {
+	if unicode.IsUpper(r) {
+		return unicode.ToLower(r)
+	}
+	if unicode.IsLower(r) {
+		return unicode.ToUpper(r)
+	}
+	// A literal without case is dropped for a character that is unlikely to
+	// be allowed in its place.
+	return '@'
+}
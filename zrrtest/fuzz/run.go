@@ -0,0 +1,188 @@
+package fuzz
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/offline"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tftest"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDirectory is the directory, inside the temporary copy of the module,
+// that holds the generated test files.
+const TestDirectory = "zrr-fuzz"
+
+// Results pairs every case with the result of its plan.
+type Results struct {
+	Cases []Case
+	Runs  []*tftest.RunResult
+
+	// Gaps are the validations no case exercises.
+	Gaps []Gap
+
+	// Diagnostics are those Terraform reported outside of any case, such as
+	// errors in the module's configuration.
+	Diagnostics []tftest.Diagnostic
+}
+
+// Validations generates the cases of the module in options.TerraformDir,
+// plans each against the providers mocked by fixture, and reports every case
+// as a subtest named after its variable and value. A case passes if the plan
+// fails with the error message of every validation the value breaks. The
+// validations no case exercises are logged.
+//
+// options.Vars must hold valid values of the module's required variables;
+// each case changes one variable from there. Values given for object
+// variables also let cases be generated by changing their attributes.
+func Validations(t *testing.T, options *terraform.Options, fixture *offline.Fixture) {
+	results, err := ValidationsE(t, options, fixture)
+	require.NoError(t, err)
+	Report(t, results)
+}
+
+// ValidationsE is like Validations but returns the results instead of
+// reporting them.
+func ValidationsE(t terratesting.TestingT, options *terraform.Options, fixture *offline.Fixture) (*Results, error) {
+	if fixture == nil {
+		fixture = &offline.Fixture{}
+	}
+	variables, err := registry.LoadVariables(options.TerraformDir)
+	if err != nil {
+		return nil, err
+	}
+	base, err := Values(variables, options.Vars)
+	if err != nil {
+		return nil, err
+	}
+	cases, gaps := Generate(variables, base)
+	results := &Results{Cases: cases, Gaps: gaps, Runs: make([]*tftest.RunResult, len(cases))}
+	if len(cases) == 0 {
+		return results, nil
+	}
+
+	mocks, err := fixture.Mocks()
+	if err != nil {
+		return nil, err
+	}
+	testOptions, err := options.Clone()
+	if err != nil {
+		return nil, err
+	}
+	// terraform test has no -target flag.
+	testOptions.Targets = nil
+	var tempDir string
+	testOptions.TerraformDir, tempDir, err = zrrtest.CopyModuleToTemp(options.TerraformDir, "fuzz")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	testDir := filepath.Join(testOptions.TerraformDir, TestDirectory)
+	if err := os.MkdirAll(testDir, 0o755); err != nil {
+		return nil, err
+	}
+	// Terraform skips the runs of a file after one fails, so every case
+	// gets a file of its own.
+	for i, c := range cases {
+		if err := os.WriteFile(filepath.Join(testDir, caseFile(i)), []byte(c.testFile(mocks)), 0o644); err != nil {
+			return nil, err
+		}
+	}
+
+	tests, err := tftest.ExecE(t, testOptions, TestDirectory)
+	if err != nil {
+		return nil, err
+	}
+	results.Diagnostics = tests.Diagnostics
+	for _, file := range tests.Files {
+		var i int
+		if _, err := fmt.Sscanf(path.Base(file.Path), "case_%d.tftest.hcl", &i); err != nil || i >= len(cases) {
+			continue
+		}
+		results.Diagnostics = append(results.Diagnostics, file.Diagnostics...)
+		if len(file.Runs) > 0 {
+			results.Runs[i] = file.Runs[0]
+		}
+	}
+	return results, nil
+}
+
+func caseFile(i int) string {
+	return fmt.Sprintf("case_%04d.tftest.hcl", i)
+}
+
+// testFile renders the case as a Terraform test file with a single plan run.
+func (c Case) testFile(mocks string) string {
+	var b strings.Builder
+	b.WriteString("# Generated by zrrtest/fuzz. Do not edit.\n")
+	b.WriteString(mocks)
+	fmt.Fprintf(&b, "\nrun \"case\" {\n  command = plan\n\n  variables {\n    %s = %s\n  }\n}\n",
+		c.Variable, strings.ReplaceAll(c.HCL(), "\n", "\n    "))
+	return b.String()
+}
+
+// Report reports every case of results as a subtest of t and logs the
+// validations none of them exercise.
+func Report(t *testing.T, results *Results) {
+	t.Helper()
+
+	for _, diagnostic := range results.Diagnostics {
+		if diagnostic.Severity == "error" {
+			t.Error(diagnostic.String())
+		}
+	}
+	byVariable := map[string][]int{}
+	var variables []string
+	for i, c := range results.Cases {
+		if byVariable[c.Variable] == nil {
+			variables = append(variables, c.Variable)
+		}
+		byVariable[c.Variable] = append(byVariable[c.Variable], i)
+	}
+	for _, variable := range variables {
+		indexes := byVariable[variable]
+		t.Run(variable, func(t *testing.T) {
+			for _, i := range indexes {
+				c, run := results.Cases[i], results.Runs[i]
+				t.Run(c.Name(), func(t *testing.T) {
+					checkCase(t, c, run)
+				})
+			}
+		})
+	}
+	for _, gap := range results.Gaps {
+		t.Logf("not exercised: %s", gap)
+	}
+}
+
+// checkCase fails t unless the plan of the case failed with the messages of
+// the validations it breaks.
+func checkCase(t *testing.T, c Case, run *tftest.RunResult) {
+	if run == nil || run.Status == tftest.StatusPending {
+		t.Fatalf("terraform test did not plan var.%s = %s", c.Variable, c.HCL())
+	}
+	var details []string
+	for _, diagnostic := range run.Diagnostics {
+		if diagnostic.Severity == "error" {
+			details = append(details, diagnostic.String())
+		}
+	}
+	if run.Status == tftest.StatusPass || len(details) == 0 {
+		t.Fatalf("the plan succeeded with var.%s = %s; expected it to fail validation at %s", c.Variable, c.HCL(), c.Validations[0])
+	}
+	all := strings.Join(details, "\n")
+	for _, validation := range c.Validations {
+		if !strings.Contains(all, validation.ErrorMessage) {
+			t.Errorf("the plan with var.%s = %s failed without the message of the validation at %s, %q:\n%s",
+				c.Variable, c.HCL(), validation, validation.ErrorMessage, all)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+variable "name" {
+  description = "Name of the storage account"
+  type        = string
+
+  validation {
+    condition     = can(regex("^[a-z0-9]{3,24}$", var.name))
+    error_message = "Name must be 3-24 lowercase letters and numbers."
+  }
+}
+
+variable "sku" {
+  description = "SKU of the storage account"
+  type        = string
+  default     = "Standard"
+
+  validation {
+    condition     = contains(["Standard", "Premium"], var.sku)
+    error_message = "SKU must be Standard or Premium."
+  }
+}
+
+variable "retention_days" {
+  description = "Days to keep deleted blobs"
+  type        = number
+  default     = 7
+
+  validation {
+    condition     = var.retention_days >= 1 && var.retention_days <= 365
+    error_message = "${var.retention_days} is not between 1 and 365."
+  }
+}
+
+variable "network_rules" {
+  description = "Network rules of the storage account"
+  type = object({
+    default_action = string
+    ip_rules       = optional(list(string), [])
+  })
+  default = null
+
+  validation {
+    condition     = var.network_rules == null ? true : contains(["Allow", "Deny"], var.network_rules.default_action)
+    error_message = "Default action must be Allow or Deny."
+  }
+}
+
+variable "tags" {
+  description = "Tags to apply"
+  type        = map(string)
+  default     = {}
+
+  validation {
+    condition     = alltrue([for k, v in var.tags : can(cidrhost(v, 0))])
+    error_message = "Tags must be CIDR ranges."
+  }
+}
+
+variable "address_space" {
+  description = "Address space of the network"
+  type        = string
+  default     = "10.0.0.0/16"
+
+  validation {
+    condition     = can(cidrhost(var.address_space, 0))
+    error_message = "Address space must be a CIDR range."
+  }
+}
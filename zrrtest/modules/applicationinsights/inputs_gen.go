@@ -0,0 +1,203 @@
+// Code generated by zrr-gen from azure/shared/application-insights/variables.tf. DO NOT EDIT.
+
+// Package applicationinsights holds the typed inputs and outputs of azure/shared/application-insights, for its tests.
+package applicationinsights
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/shared/application-insights. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// List of action group IDs for alert notifications.
+	ActionGroupIDs []string `tf:"action_group_ids"`
+
+	// Severity level for standard alerts.
+	AlertSeverity *float64 `tf:"alert_severity"`
+
+	// Configuration for Application Insights analytics items (queries, functions).
+	AnalyticsItems map[string]AnalyticsItem `tf:"analytics_items"`
+
+	// Configuration for Application Insights API keys.
+	APIKeys map[string]APIKey `tf:"api_keys"`
+
+	// Additional tags specific to the Application Insights component.
+	ApplicationInsightsTags map[string]string `tf:"application_insights_tags"`
+
+	// Type of application being monitored.
+	ApplicationType *string `tf:"application_type"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// List of compliance frameworks that apply to this Application Insights component.
+	ComplianceRequirements []string `tf:"compliance_requirements"`
+
+	// Configuration for continuous export.
+	ContinuousExportConfig *ContinuousExportConfig `tf:"continuous_export_config"`
+
+	// Criticality level of the Application Insights component.
+	Criticality *string `tf:"criticality"`
+
+	// Configuration for custom Application Insights alerts.
+	CustomAlerts map[string]CustomAlert `tf:"custom_alerts"`
+
+	// Daily data volume cap in GB (null for automatic based on criticality).
+	DailyDataCapGB *float64 `tf:"daily_data_cap_gb"`
+
+	// Disable notifications when daily data cap is reached.
+	DailyDataCapNotificationsDisabled *bool `tf:"daily_data_cap_notifications_disabled"`
+
+	// Data governance configuration for Application Insights.
+	DataGovernance *DataGovernance `tf:"data_governance"`
+
+	// Disable IP address masking in telemetry.
+	DisableIPMasking *bool `tf:"disable_ip_masking"`
+
+	// Enable continuous export of Application Insights data.
+	EnableContinuousExport *bool `tf:"enable_continuous_export"`
+
+	// Enable standard Application Insights alert rules.
+	EnableStandardAlerts *bool `tf:"enable_standard_alerts"`
+
+	// Environment where the Application Insights component is deployed.
+	Environment *string `tf:"environment"`
+
+	// Threshold for exception rate alert (count).
+	ExceptionRateThreshold *float64 `tf:"exception_rate_threshold"`
+
+	// Threshold for failure rate alert (count).
+	FailureRateThreshold *float64 `tf:"failure_rate_threshold"`
+
+	// Force customer storage for Application Insights Profiler.
+	ForceCustomerStorageForProfiler *bool `tf:"force_customer_storage_for_profiler"`
+
+	// Enable internet ingestion for Application Insights.
+	InternetIngestionEnabled *bool `tf:"internet_ingestion_enabled"`
+
+	// Enable internet query for Application Insights.
+	InternetQueryEnabled *bool `tf:"internet_query_enabled"`
+
+	// Disable local authentication for Application Insights access.
+	LocalAuthenticationDisabled *bool `tf:"local_authentication_disabled"`
+
+	// Azure region where the Application Insights component will be created.
+	Location string `tf:"location"`
+
+	// Name of the Application Insights component.
+	Name string `tf:"name"`
+
+	// Name of the resource group where the Application Insights component will be created.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// Retention period in days for Application Insights data.
+	RetentionInDays *float64 `tf:"retention_in_days"`
+
+	// Percentage of telemetry to sample (null for automatic based on criticality).
+	SamplingPercentage *float64 `tf:"sampling_percentage"`
+
+	// Threshold for server response time alert (in milliseconds).
+	ServerResponseTimeThreshold *float64 `tf:"server_response_time_threshold"`
+
+	// Configuration for Application Insights smart detection rules.
+	SmartDetectionRules map[string]SmartDetectionRule `tf:"smart_detection_rules"`
+
+	// Configuration for Application Insights web tests.
+	WebTests map[string]WebTest `tf:"web_tests"`
+
+	// Configuration for Application Insights workbook templates.
+	WorkbookTemplates map[string]WorkbookTemplate `tf:"workbook_templates"`
+
+	// ID of the Log Analytics workspace to associate with Application Insights.
+	WorkspaceID *string `tf:"workspace_id"`
+
+	// Name of the Log Analytics workspace (alternative to workspace_id).
+	WorkspaceName *string `tf:"workspace_name"`
+
+	// Resource group name of the Log Analytics workspace (required if workspace_name is specified).
+	WorkspaceResourceGroupName *string `tf:"workspace_resource_group_name"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AnalyticsItem is an object of the module's inputs.
+type AnalyticsItem struct {
+	Content       string `tf:"content"`
+	FunctionAlias string `tf:"function_alias"`
+	Scope         string `tf:"scope"`
+	Type          string `tf:"type"`
+}
+
+// APIKey is an object of the module's inputs.
+type APIKey struct {
+	ReadPermissions  []string `tf:"read_permissions"`
+	WritePermissions []string `tf:"write_permissions"`
+}
+
+// ContinuousExportConfig is an object of the module's inputs.
+type ContinuousExportConfig struct {
+	DestinationConfig map[string]string `tf:"destination_config"`
+	DestinationType   string            `tf:"destination_type"`
+	ExportTypes       []string          `tf:"export_types"`
+}
+
+// CustomAlert is an object of the module's inputs.
+type CustomAlert struct {
+	Aggregation     string                 `tf:"aggregation"`
+	Description     string                 `tf:"description"`
+	Dimensions      []CustomAlertDimension `tf:"dimensions"`
+	Enabled         bool                   `tf:"enabled"`
+	Frequency       string                 `tf:"frequency"`
+	MetricName      string                 `tf:"metric_name"`
+	MetricNamespace string                 `tf:"metric_namespace"`
+	Operator        string                 `tf:"operator"`
+	Severity        float64                `tf:"severity"`
+	Threshold       float64                `tf:"threshold"`
+	WindowSize      string                 `tf:"window_size"`
+}
+
+// CustomAlertDimension is an object of the module's inputs.
+type CustomAlertDimension struct {
+	Name     string   `tf:"name"`
+	Operator string   `tf:"operator"`
+	Values   []string `tf:"values"`
+}
+
+// DataGovernance is an object of the module's inputs.
+type DataGovernance struct {
+	DataClassification  string `tf:"data_classification"`
+	DataMaskingEnabled  bool   `tf:"data_masking_enabled"`
+	DataRetentionPolicy string `tf:"data_retention_policy"`
+	PiiDetectionEnabled bool   `tf:"pii_detection_enabled"`
+}
+
+// SmartDetectionRule is an object of the module's inputs.
+type SmartDetectionRule struct {
+	AdditionalEmailRecipients      []string `tf:"additional_email_recipients"`
+	Enabled                        bool     `tf:"enabled"`
+	SendEmailsToSubscriptionOwners bool     `tf:"send_emails_to_subscription_owners"`
+}
+
+// WebTest is an object of the module's inputs.
+type WebTest struct {
+	Configuration string   `tf:"configuration"`
+	Description   string   `tf:"description"`
+	Enabled       bool     `tf:"enabled"`
+	Frequency     float64  `tf:"frequency"`
+	GeoLocations  []string `tf:"geo_locations"`
+	Kind          string   `tf:"kind"`
+	RetryEnabled  bool     `tf:"retry_enabled"`
+	Timeout       float64  `tf:"timeout"`
+}
+
+// WorkbookTemplate is an object of the module's inputs.
+type WorkbookTemplate struct {
+	Author          string        `tf:"author"`
+	GalleryCategory string        `tf:"gallery_category"`
+	GalleryName     string        `tf:"gallery_name"`
+	GalleryOrder    float64       `tf:"gallery_order"`
+	Priority        float64       `tf:"priority"`
+	TemplateItems   []interface{} `tf:"template_items"`
+}
@@ -0,0 +1,424 @@
+// Code generated by zrr-gen from azure/shared/application-insights/outputs.tf. DO NOT EDIT.
+
+package applicationinsights
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/shared/application-insights once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AnalyticsItems returns the analytics_items output: Analytics items (queries
+// and functions) information.
+func (o Outputs) AnalyticsItems() map[string]AnalyticsItemOutput {
+	var value map[string]AnalyticsItemOutput
+	terraform.OutputStruct(o.t, o.options, "analytics_items", &value)
+	return value
+}
+
+// APIKeyValues returns the api_key_values output: API key values (sensitive).
+//
+// The output is sensitive.
+func (o Outputs) APIKeyValues() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "api_key_values", &value)
+	return value
+}
+
+// APIKeys returns the api_keys output: API keys information (keys are sensitive
+// and not exposed).
+func (o Outputs) APIKeys() map[string]APIKeyOutput {
+	var value map[string]APIKeyOutput
+	terraform.OutputStruct(o.t, o.options, "api_keys", &value)
+	return value
+}
+
+// AppID returns the app_id output: Application ID of the Application Insights
+// component.
+func (o Outputs) AppID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "app_id", &value)
+	return value
+}
+
+// ApplicationType returns the application_type output: Application type of the
+// Application Insights component.
+func (o Outputs) ApplicationType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "application_type", &value)
+	return value
+}
+
+// ConnectionString returns the connection_string output: Connection string for
+// the Application Insights component.
+//
+// The output is sensitive.
+func (o Outputs) ConnectionString() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "connection_string", &value)
+	return value
+}
+
+// ContinuousExportConfig returns the continuous_export_config output:
+// Continuous export configuration status.
+func (o Outputs) ContinuousExportConfig() ContinuousExportConfigOutput {
+	var value ContinuousExportConfigOutput
+	terraform.OutputStruct(o.t, o.options, "continuous_export_config", &value)
+	return value
+}
+
+// CustomAlerts returns the custom_alerts output: Custom alert rules
+// information.
+func (o Outputs) CustomAlerts() map[string]CustomAlertOutput {
+	var value map[string]CustomAlertOutput
+	terraform.OutputStruct(o.t, o.options, "custom_alerts", &value)
+	return value
+}
+
+// DailyDataCapInGB returns the daily_data_cap_in_gb output: Daily data volume
+// cap in GB.
+func (o Outputs) DailyDataCapInGB() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "daily_data_cap_in_gb", &value)
+	return value
+}
+
+// DataGovernance returns the data_governance output: Data governance and
+// compliance information.
+func (o Outputs) DataGovernance() DataGovernanceOutput {
+	var value DataGovernanceOutput
+	terraform.OutputStruct(o.t, o.options, "data_governance", &value)
+	return value
+}
+
+// ForAppServiceIntegration returns the for_app_service_integration output:
+// Configuration for App Service integration.
+//
+// The output is sensitive.
+func (o Outputs) ForAppServiceIntegration() ForAppServiceIntegrationOutput {
+	var value ForAppServiceIntegrationOutput
+	terraform.OutputStruct(o.t, o.options, "for_app_service_integration", &value)
+	return value
+}
+
+// ForFunctionAppIntegration returns the for_function_app_integration output:
+// Configuration for Function App integration.
+//
+// The output is sensitive.
+func (o Outputs) ForFunctionAppIntegration() ForFunctionAppIntegrationOutput {
+	var value ForFunctionAppIntegrationOutput
+	terraform.OutputStruct(o.t, o.options, "for_function_app_integration", &value)
+	return value
+}
+
+// ForKubernetesIntegration returns the for_kubernetes_integration output:
+// Configuration for Kubernetes/Container integration.
+//
+// The output is sensitive.
+func (o Outputs) ForKubernetesIntegration() ForKubernetesIntegrationOutput {
+	var value ForKubernetesIntegrationOutput
+	terraform.OutputStruct(o.t, o.options, "for_kubernetes_integration", &value)
+	return value
+}
+
+// ForLogAnalyticsIntegration returns the for_log_analytics_integration output:
+// Information for Log Analytics integration.
+//
+// The output is sensitive.
+func (o Outputs) ForLogAnalyticsIntegration() ForLogAnalyticsIntegrationOutput {
+	var value ForLogAnalyticsIntegrationOutput
+	terraform.OutputStruct(o.t, o.options, "for_log_analytics_integration", &value)
+	return value
+}
+
+// ID returns the id output: ID of the Application Insights component.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// InstrumentationKey returns the instrumentation_key output: Instrumentation
+// key of the Application Insights component.
+//
+// The output is sensitive.
+func (o Outputs) InstrumentationKey() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "instrumentation_key", &value)
+	return value
+}
+
+// MonitoringConfig returns the monitoring_config output: Monitoring
+// configuration summary.
+func (o Outputs) MonitoringConfig() MonitoringConfigOutput {
+	var value MonitoringConfigOutput
+	terraform.OutputStruct(o.t, o.options, "monitoring_config", &value)
+	return value
+}
+
+// Name returns the name output: Name of the Application Insights component.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// ResourceDetails returns the resource_details output: Resource management
+// information.
+func (o Outputs) ResourceDetails() ResourceDetailsOutput {
+	var value ResourceDetailsOutput
+	terraform.OutputStruct(o.t, o.options, "resource_details", &value)
+	return value
+}
+
+// RetentionInDays returns the retention_in_days output: Data retention period
+// in days.
+func (o Outputs) RetentionInDays() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "retention_in_days", &value)
+	return value
+}
+
+// SamplingPercentage returns the sampling_percentage output: Sampling
+// percentage for telemetry data.
+func (o Outputs) SamplingPercentage() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "sampling_percentage", &value)
+	return value
+}
+
+// SecurityConfig returns the security_config output: Security configuration
+// summary.
+func (o Outputs) SecurityConfig() SecurityConfigOutput {
+	var value SecurityConfigOutput
+	terraform.OutputStruct(o.t, o.options, "security_config", &value)
+	return value
+}
+
+// SmartDetectionRules returns the smart_detection_rules output: Smart detection
+// rules configuration.
+func (o Outputs) SmartDetectionRules() map[string]SmartDetectionRuleOutput {
+	var value map[string]SmartDetectionRuleOutput
+	terraform.OutputStruct(o.t, o.options, "smart_detection_rules", &value)
+	return value
+}
+
+// StandardAlerts returns the standard_alerts output: Standard alert rules
+// information.
+func (o Outputs) StandardAlerts() StandardAlertsOutput {
+	var value StandardAlertsOutput
+	terraform.OutputStruct(o.t, o.options, "standard_alerts", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the Application Insights
+// component.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// WebTests returns the web_tests output: Web tests configuration and status.
+func (o Outputs) WebTests() map[string]WebTestOutput {
+	var value map[string]WebTestOutput
+	terraform.OutputStruct(o.t, o.options, "web_tests", &value)
+	return value
+}
+
+// WorkbookTemplates returns the workbook_templates output: Workbook templates
+// information.
+func (o Outputs) WorkbookTemplates() map[string]WorkbookTemplateOutput {
+	var value map[string]WorkbookTemplateOutput
+	terraform.OutputStruct(o.t, o.options, "workbook_templates", &value)
+	return value
+}
+
+// WorkspaceID returns the workspace_id output: Log Analytics workspace ID
+// associated with Application Insights.
+func (o Outputs) WorkspaceID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "workspace_id", &value)
+	return value
+}
+
+// AnalyticsItemOutput is an object of the module's outputs.
+type AnalyticsItemOutput struct {
+	FunctionAlias string `json:"function_alias"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Scope         string `json:"scope"`
+	Type          string `json:"type"`
+	Version       string `json:"version"`
+}
+
+// APIKeyOutput is an object of the module's outputs.
+type APIKeyOutput struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	ReadPermissions  string `json:"read_permissions"`
+	WritePermissions string `json:"write_permissions"`
+}
+
+// ContinuousExportConfigOutput is an object of the module's outputs.
+type ContinuousExportConfigOutput struct {
+	Config  *ContinuousExportConfigConfigOutput `json:"config"`
+	Enabled bool                                `json:"enabled"`
+}
+
+// ContinuousExportConfigConfigOutput is an object of the module's outputs.
+type ContinuousExportConfigConfigOutput struct {
+	DestinationConfig map[string]string `json:"destination_config"`
+	DestinationType   string            `json:"destination_type"`
+	ExportTypes       []string          `json:"export_types"`
+}
+
+// CustomAlertOutput is an object of the module's outputs.
+type CustomAlertOutput struct {
+	Enabled  bool   `json:"enabled"`
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+}
+
+// DataGovernanceOutput is an object of the module's outputs.
+type DataGovernanceOutput struct {
+	ComplianceRequirements []string `json:"compliance_requirements"`
+	DailyCapGB             float64  `json:"daily_cap_gb"`
+	DataClassification     string   `json:"data_classification"`
+	DataMaskingEnabled     bool     `json:"data_masking_enabled"`
+	DataRetentionPolicy    string   `json:"data_retention_policy"`
+	IPMaskingDisabled      bool     `json:"ip_masking_disabled"`
+	PiiDetectionEnabled    bool     `json:"pii_detection_enabled"`
+	RetentionDays          int      `json:"retention_days"`
+}
+
+// ForAppServiceIntegrationOutput is an object of the module's outputs.
+type ForAppServiceIntegrationOutput struct {
+	AppID              string `json:"app_id"`
+	ConnectionString   string `json:"connection_string"`
+	InstrumentationKey string `json:"instrumentation_key"`
+}
+
+// ForFunctionAppIntegrationOutput is an object of the module's outputs.
+type ForFunctionAppIntegrationOutput struct {
+	AppID              string `json:"app_id"`
+	ConnectionString   string `json:"connection_string"`
+	InstrumentationKey string `json:"instrumentation_key"`
+}
+
+// ForKubernetesIntegrationOutput is an object of the module's outputs.
+type ForKubernetesIntegrationOutput struct {
+	AppID              string `json:"app_id"`
+	ConnectionString   string `json:"connection_string"`
+	InstrumentationKey string `json:"instrumentation_key"`
+}
+
+// ForLogAnalyticsIntegrationOutput is an object of the module's outputs.
+type ForLogAnalyticsIntegrationOutput struct {
+	ComponentID        string `json:"component_id"`
+	ConnectionString   string `json:"connection_string"`
+	InstrumentationKey string `json:"instrumentation_key"`
+	WorkspaceID        string `json:"workspace_id"`
+}
+
+// MonitoringConfigOutput is an object of the module's outputs.
+type MonitoringConfigOutput struct {
+	AlertsEnabled       bool                             `json:"alerts_enabled"`
+	CustomAlertsCount   int                              `json:"custom_alerts_count"`
+	SmartDetectionCount int                              `json:"smart_detection_count"`
+	Thresholds          MonitoringConfigThresholdsOutput `json:"thresholds"`
+	WebTestsCount       int                              `json:"web_tests_count"`
+}
+
+// MonitoringConfigThresholdsOutput is an object of the module's outputs.
+type MonitoringConfigThresholdsOutput struct {
+	ExceptionRate      float64 `json:"exception_rate"`
+	FailureRate        float64 `json:"failure_rate"`
+	ServerResponseTime float64 `json:"server_response_time"`
+}
+
+// ResourceDetailsOutput is an object of the module's outputs.
+type ResourceDetailsOutput struct {
+	CreatedDate       string `json:"created_date"`
+	Criticality       string `json:"criticality"`
+	Environment       string `json:"environment"`
+	Location          string `json:"location"`
+	ModuleVersion     string `json:"module_version"`
+	ResourceGroupName string `json:"resource_group_name"`
+}
+
+// SecurityConfigOutput is an object of the module's outputs.
+type SecurityConfigOutput struct {
+	APIKeysCount             int  `json:"api_keys_count"`
+	ForceCustomerStorage     bool `json:"force_customer_storage"`
+	InternetIngestionEnabled bool `json:"internet_ingestion_enabled"`
+	InternetQueryEnabled     bool `json:"internet_query_enabled"`
+	IPMaskingDisabled        bool `json:"ip_masking_disabled"`
+	LocalAuthDisabled        bool `json:"local_auth_disabled"`
+}
+
+// SmartDetectionRuleOutput is an object of the module's outputs.
+type SmartDetectionRuleOutput struct {
+	Enabled bool   `json:"enabled"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+}
+
+// StandardAlertsOutput is an object of the module's outputs.
+type StandardAlertsOutput struct {
+	ExceptionRate      StandardAlertsExceptionRateOutput      `json:"exception_rate"`
+	FailureRate        StandardAlertsFailureRateOutput        `json:"failure_rate"`
+	ServerResponseTime StandardAlertsServerResponseTimeOutput `json:"server_response_time"`
+}
+
+// StandardAlertsExceptionRateOutput is an object of the module's outputs.
+type StandardAlertsExceptionRateOutput struct {
+	Enabled *bool   `json:"enabled"`
+	ID      *string `json:"id"`
+	Name    *string `json:"name"`
+}
+
+// StandardAlertsFailureRateOutput is an object of the module's outputs.
+type StandardAlertsFailureRateOutput struct {
+	Enabled *bool   `json:"enabled"`
+	ID      *string `json:"id"`
+	Name    *string `json:"name"`
+}
+
+// StandardAlertsServerResponseTimeOutput is an object of the module's outputs.
+type StandardAlertsServerResponseTimeOutput struct {
+	Enabled *bool   `json:"enabled"`
+	ID      *string `json:"id"`
+	Name    *string `json:"name"`
+}
+
+// WebTestOutput is an object of the module's outputs.
+type WebTestOutput struct {
+	Enabled            bool   `json:"enabled"`
+	Frequency          string `json:"frequency"`
+	GeoLocations       string `json:"geo_locations"`
+	ID                 string `json:"id"`
+	Kind               string `json:"kind"`
+	Name               string `json:"name"`
+	SyntheticMonitorID string `json:"synthetic_monitor_id"`
+	Timeout            string `json:"timeout"`
+}
+
+// WorkbookTemplateOutput is an object of the module's outputs.
+type WorkbookTemplateOutput struct {
+	Author      string `json:"author"`
+	Description string `json:"description"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+}
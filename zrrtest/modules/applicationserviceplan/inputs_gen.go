@@ -0,0 +1,132 @@
+// Code generated by zrr-gen from azure/shared/application-service-plan/variables.tf. DO NOT EDIT.
+
+// Package applicationserviceplan holds the typed inputs and outputs of azure/shared/application-service-plan, for its tests.
+package applicationserviceplan
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/shared/application-service-plan. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Name of the action group to send alerts to.
+	AlertActionGroupName *string `tf:"alert_action_group_name"`
+
+	// Resource group name where the action group is located (defaults to main resource group).
+	AlertActionGroupResourceGroup *string `tf:"alert_action_group_resource_group"`
+
+	// Additional tags specific to the App Service Plan.
+	ApplicationPlanTags map[string]string `tf:"application_plan_tags"`
+
+	// Auto-scaling notification settings.
+	AutoscaleNotifications *AutoscaleNotifications `tf:"autoscale_notifications"`
+
+	// Auto-scaling configuration settings.
+	AutoscaleSettings *AutoscaleSettings `tf:"autoscale_settings"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// CPU utilization alert settings.
+	CPUAlertSettings *CPUAlertSettings `tf:"cpu_alert_settings"`
+
+	// List of diagnostic log categories to enable.
+	DiagnosticLogCategories []string `tf:"diagnostic_log_categories"`
+
+	// List of diagnostic metrics to enable.
+	DiagnosticMetrics []string `tf:"diagnostic_metrics"`
+
+	// Enable monitoring alerts for the App Service Plan.
+	EnableAlerts *bool `tf:"enable_alerts"`
+
+	// Enable auto-scaling for the App Service Plan.
+	EnableAutoscaling *bool `tf:"enable_autoscaling"`
+
+	// Enable diagnostic settings for the App Service Plan.
+	EnableDiagnosticSettings *bool `tf:"enable_diagnostic_settings"`
+
+	// Azure region where the App Service Plan will be created. If not specified, uses the resource group location.
+	Location *string `tf:"location"`
+
+	// Log Analytics workspace ID for diagnostic settings.
+	LogAnalyticsWorkspaceID *string `tf:"log_analytics_workspace_id"`
+
+	// Maximum number of elastic workers for the App Service Plan (Premium v3 and above).
+	MaximumElasticWorkerCount *float64 `tf:"maximum_elastic_worker_count"`
+
+	// Memory utilization alert settings.
+	MemoryAlertSettings *MemoryAlertSettings `tf:"memory_alert_settings"`
+
+	// Name of the Azure App Service Plan.
+	Name string `tf:"name"`
+
+	// The operating system type for the App Service Plan (Linux or Windows).
+	OSType *string `tf:"os_type"`
+
+	// Enable per-site scaling for the App Service Plan.
+	PerSiteScalingEnabled *bool `tf:"per_site_scaling_enabled"`
+
+	// Name of the resource group where the App Service Plan will be created.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// The SKU name for the App Service Plan. Examples: B1, B2, B3, S1, S2, S3, P1v2, P2v2, P3v2, P1v3, P2v3, P3v3.
+	SKUName *string `tf:"sku_name"`
+
+	// Number of workers (instances) for the App Service Plan.
+	WorkerCount *float64 `tf:"worker_count"`
+
+	// Enable zone balancing for the App Service Plan (requires Premium v2 or Premium v3).
+	ZoneBalancingEnabled *bool `tf:"zone_balancing_enabled"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AutoscaleNotifications is an object of the module's inputs.
+type AutoscaleNotifications struct {
+	CustomEmails                      []string                        `tf:"custom_emails"`
+	SendToSubscriptionAdministrator   *bool                           `tf:"send_to_subscription_administrator"`
+	SendToSubscriptionCoAdministrator *bool                           `tf:"send_to_subscription_co_administrator"`
+	Webhooks                          []AutoscaleNotificationsWebhook `tf:"webhooks"`
+}
+
+// AutoscaleNotificationsWebhook is an object of the module's inputs.
+type AutoscaleNotificationsWebhook struct {
+	Properties map[string]string `tf:"properties"`
+	ServiceURI string            `tf:"service_uri"`
+}
+
+// AutoscaleSettings is an object of the module's inputs.
+type AutoscaleSettings struct {
+	CPUThresholdIn      float64  `tf:"cpu_threshold_in"`
+	CPUThresholdOut     float64  `tf:"cpu_threshold_out"`
+	DefaultInstances    float64  `tf:"default_instances"`
+	EnableMemoryScaling *bool    `tf:"enable_memory_scaling"`
+	MaximumInstances    float64  `tf:"maximum_instances"`
+	MemoryThresholdIn   *float64 `tf:"memory_threshold_in"`
+	MemoryThresholdOut  *float64 `tf:"memory_threshold_out"`
+	MinimumInstances    float64  `tf:"minimum_instances"`
+	ScaleInCooldown     *float64 `tf:"scale_in_cooldown"`
+	ScaleOutCooldown    *float64 `tf:"scale_out_cooldown"`
+}
+
+// CPUAlertSettings is an object of the module's inputs.
+type CPUAlertSettings struct {
+	AutoMitigate *bool    `tf:"auto_mitigate"`
+	Enabled      bool     `tf:"enabled"`
+	Frequency    *float64 `tf:"frequency"`
+	Severity     *float64 `tf:"severity"`
+	Threshold    float64  `tf:"threshold"`
+	WindowSize   *float64 `tf:"window_size"`
+}
+
+// MemoryAlertSettings is an object of the module's inputs.
+type MemoryAlertSettings struct {
+	AutoMitigate *bool    `tf:"auto_mitigate"`
+	Enabled      bool     `tf:"enabled"`
+	Frequency    *float64 `tf:"frequency"`
+	Severity     *float64 `tf:"severity"`
+	Threshold    float64  `tf:"threshold"`
+	WindowSize   *float64 `tf:"window_size"`
+}
@@ -0,0 +1,225 @@
+// Code generated by zrr-gen from azure/shared/application-service-plan/outputs.tf. DO NOT EDIT.
+
+package applicationserviceplan
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/shared/application-service-plan once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AlertsEnabled returns the alerts_enabled output: Whether monitoring alerts
+// are enabled.
+func (o Outputs) AlertsEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "alerts_enabled", &value)
+	return value
+}
+
+// AppServicePlanInfo returns the app_service_plan_info output: Complete App
+// Service Plan information for use by App Services.
+func (o Outputs) AppServicePlanInfo() AppServicePlanInfoOutput {
+	var value AppServicePlanInfoOutput
+	terraform.OutputStruct(o.t, o.options, "app_service_plan_info", &value)
+	return value
+}
+
+// AutoscaleSettingID returns the autoscale_setting_id output: ID of the
+// auto-scaling setting (if enabled).
+func (o Outputs) AutoscaleSettingID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "autoscale_setting_id", &value)
+	return value
+}
+
+// AutoscaleSettingName returns the autoscale_setting_name output: Name of the
+// auto-scaling setting (if enabled).
+func (o Outputs) AutoscaleSettingName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "autoscale_setting_name", &value)
+	return value
+}
+
+// AutoscalingEnabled returns the autoscaling_enabled output: Whether
+// auto-scaling is enabled for the App Service Plan.
+func (o Outputs) AutoscalingEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "autoscaling_enabled", &value)
+	return value
+}
+
+// CPUAlertID returns the cpu_alert_id output: ID of the CPU utilization alert
+// (if enabled).
+func (o Outputs) CPUAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "cpu_alert_id", &value)
+	return value
+}
+
+// DiagnosticSettingEnabled returns the diagnostic_setting_enabled output:
+// Whether diagnostic settings are enabled.
+func (o Outputs) DiagnosticSettingEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "diagnostic_setting_enabled", &value)
+	return value
+}
+
+// DiagnosticSettingID returns the diagnostic_setting_id output: ID of the
+// diagnostic setting (if enabled).
+func (o Outputs) DiagnosticSettingID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "diagnostic_setting_id", &value)
+	return value
+}
+
+// ID returns the id output: ID of the Azure App Service Plan.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// Location returns the location output: Location of the Azure App Service Plan.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// MaximumElasticWorkerCount returns the maximum_elastic_worker_count output:
+// Maximum number of elastic workers for the App Service Plan.
+func (o Outputs) MaximumElasticWorkerCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "maximum_elastic_worker_count", &value)
+	return value
+}
+
+// MemoryAlertID returns the memory_alert_id output: ID of the memory
+// utilization alert (if enabled).
+func (o Outputs) MemoryAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "memory_alert_id", &value)
+	return value
+}
+
+// MonitoringSummary returns the monitoring_summary output: Summary of
+// monitoring configuration.
+func (o Outputs) MonitoringSummary() MonitoringSummaryOutput {
+	var value MonitoringSummaryOutput
+	terraform.OutputStruct(o.t, o.options, "monitoring_summary", &value)
+	return value
+}
+
+// Name returns the name output: Name of the Azure App Service Plan.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// OSType returns the os_type output: Operating system type of the App Service
+// Plan.
+func (o Outputs) OSType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "os_type", &value)
+	return value
+}
+
+// PerSiteScalingEnabled returns the per_site_scaling_enabled output: Whether
+// per-site scaling is enabled for the App Service Plan.
+func (o Outputs) PerSiteScalingEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "per_site_scaling_enabled", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Resource group name
+// of the Azure App Service Plan.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// ScalingSummary returns the scaling_summary output: Summary of scaling
+// configuration.
+func (o Outputs) ScalingSummary() ScalingSummaryOutput {
+	var value ScalingSummaryOutput
+	terraform.OutputStruct(o.t, o.options, "scaling_summary", &value)
+	return value
+}
+
+// SKUName returns the sku_name output: SKU name of the App Service Plan.
+func (o Outputs) SKUName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "sku_name", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the App Service Plan.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// WorkerCount returns the worker_count output: Number of workers (instances) in
+// the App Service Plan.
+func (o Outputs) WorkerCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "worker_count", &value)
+	return value
+}
+
+// ZoneBalancingEnabled returns the zone_balancing_enabled output: Whether zone
+// balancing is enabled for the App Service Plan.
+func (o Outputs) ZoneBalancingEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "zone_balancing_enabled", &value)
+	return value
+}
+
+// AppServicePlanInfoOutput is an object of the module's outputs.
+type AppServicePlanInfoOutput struct {
+	ID                        string `json:"id"`
+	Location                  string `json:"location"`
+	MaximumElasticWorkerCount int    `json:"maximum_elastic_worker_count"`
+	Name                      string `json:"name"`
+	OSType                    string `json:"os_type"`
+	PerSiteScalingEnabled     bool   `json:"per_site_scaling_enabled"`
+	ResourceGroupName         string `json:"resource_group_name"`
+	SKUName                   string `json:"sku_name"`
+	WorkerCount               int    `json:"worker_count"`
+	ZoneBalancingEnabled      bool   `json:"zone_balancing_enabled"`
+}
+
+// MonitoringSummaryOutput is an object of the module's outputs.
+type MonitoringSummaryOutput struct {
+	AlertsEnabled             bool     `json:"alerts_enabled"`
+	CPUAlertEnabled           bool     `json:"cpu_alert_enabled"`
+	CPUAlertThreshold         *float64 `json:"cpu_alert_threshold"`
+	DiagnosticSettingsEnabled bool     `json:"diagnostic_settings_enabled"`
+	MemoryAlertEnabled        bool     `json:"memory_alert_enabled"`
+	MemoryAlertThreshold      *float64 `json:"memory_alert_threshold"`
+}
+
+// ScalingSummaryOutput is an object of the module's outputs.
+type ScalingSummaryOutput struct {
+	AutoscaleMaxInstances     *float64 `json:"autoscale_max_instances"`
+	AutoscaleMinInstances     *float64 `json:"autoscale_min_instances"`
+	AutoscalingEnabled        bool     `json:"autoscaling_enabled"`
+	MaximumElasticWorkerCount int      `json:"maximum_elastic_worker_count"`
+	PerSiteScalingEnabled     bool     `json:"per_site_scaling_enabled"`
+	WorkerCount               int      `json:"worker_count"`
+	ZoneBalancingEnabled      bool     `json:"zone_balancing_enabled"`
+}
@@ -0,0 +1,147 @@
+// Code generated by zrr-gen from azure/state/az-tf-init/variables.tf. DO NOT EDIT.
+
+// Package aztfinit holds the typed inputs and outputs of azure/state/az-tf-init, for its tests.
+package aztfinit
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/state/az-tf-init. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Additional access policies for Key Vault.
+	AdditionalAccessPolicies []AdditionalAccessPolicy `tf:"additional_access_policies"`
+
+	// List of allowed IP ranges for storage account access.
+	AllowedIPRanges []string `tf:"allowed_ip_ranges"`
+
+	// List of allowed subnet IDs for storage account access.
+	AllowedSubnetIDs []string `tf:"allowed_subnet_ids"`
+
+	// Additional tags specific to the Terraform state infrastructure.
+	AzTfInitTags map[string]string `tf:"az_tf_init_tags"`
+
+	// Soft delete retention days for blobs.
+	BlobSoftDeleteRetentionDays *float64 `tf:"blob_soft_delete_retention_days"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Name of the container for storing Terraform state files.
+	ContainerName *string `tf:"container_name"`
+
+	// Soft delete retention days for containers.
+	ContainerSoftDeleteRetentionDays *float64 `tf:"container_soft_delete_retention_days"`
+
+	// Enable blob versioning for state file protection.
+	EnableBlobVersioning *bool `tf:"enable_blob_versioning"`
+
+	// Create Key Vault for state encryption and secrets management.
+	EnableKeyVault *bool `tf:"enable_key_vault"`
+
+	// Enable network access restrictions for Key Vault.
+	EnableKeyVaultNetworkRestrictions *bool `tf:"enable_key_vault_network_restrictions"`
+
+	// Enable public network access to Key Vault.
+	EnableKeyVaultPublicAccess *bool `tf:"enable_key_vault_public_access"`
+
+	// Enable purge protection for Key Vault.
+	EnableKeyVaultPurgeProtection *bool `tf:"enable_key_vault_purge_protection"`
+
+	// Enable RBAC authorization for Key Vault (recommended over access policies).
+	EnableKeyVaultRBAC *bool `tf:"enable_key_vault_rbac"`
+
+	// Enable monitoring and diagnostics for state infrastructure.
+	EnableMonitoring *bool `tf:"enable_monitoring"`
+
+	// Enable network access restrictions for storage account.
+	EnableNetworkRestrictions *bool `tf:"enable_network_restrictions"`
+
+	// Enable public network access to storage account.
+	EnablePublicNetworkAccess *bool `tf:"enable_public_network_access"`
+
+	// Enable shared access keys for storage account (disable for enhanced security).
+	EnableSharedAccessKey *bool `tf:"enable_shared_access_key"`
+
+	// Enable Terraform state locking using Azure Storage.
+	EnableStateLocking *bool `tf:"enable_state_locking"`
+
+	// Environment name (dev, test, staging, prod, dr).
+	Environment string `tf:"environment"`
+
+	// List of principal IDs to grant Key Vault Administrator role.
+	KeyVaultAdministrators []string `tf:"key_vault_administrators"`
+
+	// List of allowed IP ranges for Key Vault access.
+	KeyVaultAllowedIPRanges []string `tf:"key_vault_allowed_ip_ranges"`
+
+	// List of allowed subnet IDs for Key Vault access.
+	KeyVaultAllowedSubnetIDs []string `tf:"key_vault_allowed_subnet_ids"`
+
+	// Custom key vault name (only used if use_naming_convention is false).
+	KeyVaultName *string `tf:"key_vault_name"`
+
+	// Default action for Key Vault network rules.
+	KeyVaultNetworkDefaultAction *string `tf:"key_vault_network_default_action"`
+
+	// Key Vault SKU.
+	KeyVaultSKU *string `tf:"key_vault_sku"`
+
+	// Soft delete retention days for Key Vault.
+	KeyVaultSoftDeleteRetentionDays *float64 `tf:"key_vault_soft_delete_retention_days"`
+
+	// List of principal IDs to grant Key Vault Secrets User role.
+	KeyVaultUsers []string `tf:"key_vault_users"`
+
+	// Azure region for the Terraform state infrastructure.
+	Location *string `tf:"location"`
+
+	// Short location code for naming convention (e.g., 'eus' for East US); derived from location when null.
+	LocationShort *string `tf:"location_short"`
+
+	// Log Analytics workspace retention in days.
+	LogAnalyticsRetentionDays *float64 `tf:"log_analytics_retention_days"`
+
+	// Log Analytics workspace SKU.
+	LogAnalyticsSKU *string `tf:"log_analytics_sku"`
+
+	// Default action for network rules.
+	NetworkDefaultAction *string `tf:"network_default_action"`
+
+	// Name of the project (used in resource naming).
+	ProjectName string `tf:"project_name"`
+
+	// Custom resource group name (only used if use_naming_convention is false).
+	ResourceGroupName *string `tf:"resource_group_name"`
+
+	// Custom storage account name (only used if use_naming_convention is false).
+	StorageAccountName *string `tf:"storage_account_name"`
+
+	// Storage account tier.
+	StorageAccountTier *string `tf:"storage_account_tier"`
+
+	// List of principal IDs to grant Storage Blob Data Contributor role.
+	StorageContributors []string `tf:"storage_contributors"`
+
+	// List of principal IDs to grant Storage Blob Data Reader role.
+	StorageReaders []string `tf:"storage_readers"`
+
+	// Storage account replication type.
+	StorageReplicationType *string `tf:"storage_replication_type"`
+
+	// Use ZRR standardized naming convention for resources.
+	UseNamingConvention *bool `tf:"use_naming_convention"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AdditionalAccessPolicy is an object of the module's inputs.
+type AdditionalAccessPolicy struct {
+	CertificatePermissions []string `tf:"certificate_permissions"`
+	KeyPermissions         []string `tf:"key_permissions"`
+	ObjectID               string   `tf:"object_id"`
+	SecretPermissions      []string `tf:"secret_permissions"`
+	TenantID               string   `tf:"tenant_id"`
+}
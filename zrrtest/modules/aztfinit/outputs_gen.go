@@ -0,0 +1,283 @@
+// Code generated by zrr-gen from azure/state/az-tf-init/outputs.tf. DO NOT EDIT.
+
+package aztfinit
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/state/az-tf-init once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AccessInstructions returns the access_instructions output: Instructions for
+// accessing and using the Terraform state backend.
+func (o Outputs) AccessInstructions() AccessInstructionsOutput {
+	var value AccessInstructionsOutput
+	terraform.OutputStruct(o.t, o.options, "access_instructions", &value)
+	return value
+}
+
+// AppliedTags returns the applied_tags output: Tags applied to all resources.
+func (o Outputs) AppliedTags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "applied_tags", &value)
+	return value
+}
+
+// ConfigurationSummary returns the configuration_summary output: Summary of the
+// configuration applied.
+func (o Outputs) ConfigurationSummary() ConfigurationSummaryOutput {
+	var value ConfigurationSummaryOutput
+	terraform.OutputStruct(o.t, o.options, "configuration_summary", &value)
+	return value
+}
+
+// ContainerID returns the container_id output: ID of the state container.
+func (o Outputs) ContainerID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "container_id", &value)
+	return value
+}
+
+// ContainerName returns the container_name output: Name of the state container.
+func (o Outputs) ContainerName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "container_name", &value)
+	return value
+}
+
+// KeyVaultID returns the key_vault_id output: ID of the Key Vault.
+func (o Outputs) KeyVaultID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "key_vault_id", &value)
+	return value
+}
+
+// KeyVaultName returns the key_vault_name output: Name of the Key Vault.
+func (o Outputs) KeyVaultName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "key_vault_name", &value)
+	return value
+}
+
+// KeyVaultURI returns the key_vault_uri output: URI of the Key Vault.
+func (o Outputs) KeyVaultURI() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "key_vault_uri", &value)
+	return value
+}
+
+// LogAnalyticsWorkspaceID returns the log_analytics_workspace_id output: ID of
+// the Log Analytics workspace.
+func (o Outputs) LogAnalyticsWorkspaceID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "log_analytics_workspace_id", &value)
+	return value
+}
+
+// LogAnalyticsWorkspaceKey returns the log_analytics_workspace_key output:
+// Primary shared key for the Log Analytics workspace.
+//
+// The output is sensitive.
+func (o Outputs) LogAnalyticsWorkspaceKey() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "log_analytics_workspace_key", &value)
+	return value
+}
+
+// LogAnalyticsWorkspaceName returns the log_analytics_workspace_name output:
+// Name of the Log Analytics workspace.
+func (o Outputs) LogAnalyticsWorkspaceName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "log_analytics_workspace_name", &value)
+	return value
+}
+
+// ResourceGroupID returns the resource_group_id output: ID of the resource
+// group.
+func (o Outputs) ResourceGroupID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_id", &value)
+	return value
+}
+
+// ResourceGroupLocation returns the resource_group_location output: Location of
+// the resource group.
+func (o Outputs) ResourceGroupLocation() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_location", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Name of the
+// resource group.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// StateLockContainerID returns the state_lock_container_id output: ID of the
+// state locking container.
+func (o Outputs) StateLockContainerID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "state_lock_container_id", &value)
+	return value
+}
+
+// StateLockContainerName returns the state_lock_container_name output: Name of
+// the state locking container.
+func (o Outputs) StateLockContainerName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "state_lock_container_name", &value)
+	return value
+}
+
+// StorageAccountID returns the storage_account_id output: ID of the storage
+// account.
+func (o Outputs) StorageAccountID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_id", &value)
+	return value
+}
+
+// StorageAccountName returns the storage_account_name output: Name of the
+// storage account.
+func (o Outputs) StorageAccountName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_name", &value)
+	return value
+}
+
+// StorageAccountPrimaryBlobEndpoint returns the
+// storage_account_primary_blob_endpoint output: Primary blob endpoint for the
+// storage account.
+func (o Outputs) StorageAccountPrimaryBlobEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_primary_blob_endpoint", &value)
+	return value
+}
+
+// StorageAccountPrimaryConnectionString returns the
+// storage_account_primary_connection_string output: Primary connection string
+// for the storage account.
+//
+// The output is sensitive.
+func (o Outputs) StorageAccountPrimaryConnectionString() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_primary_connection_string", &value)
+	return value
+}
+
+// StorageAccountPrimaryKey returns the storage_account_primary_key output:
+// Primary access key for the storage account.
+//
+// The output is sensitive.
+func (o Outputs) StorageAccountPrimaryKey() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_primary_key", &value)
+	return value
+}
+
+// StorageAccountSecondaryKey returns the storage_account_secondary_key output:
+// Secondary access key for the storage account.
+//
+// The output is sensitive.
+func (o Outputs) StorageAccountSecondaryKey() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_secondary_key", &value)
+	return value
+}
+
+// TerraformBackendCommand returns the terraform_backend_command output:
+// Terraform init command with backend configuration.
+func (o Outputs) TerraformBackendCommand() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "terraform_backend_command", &value)
+	return value
+}
+
+// TerraformBackendConfig returns the terraform_backend_config output: Terraform
+// backend configuration for use in other projects.
+func (o Outputs) TerraformBackendConfig() TerraformBackendConfigOutput {
+	var value TerraformBackendConfigOutput
+	terraform.OutputStruct(o.t, o.options, "terraform_backend_config", &value)
+	return value
+}
+
+// TerraformBackendHCL returns the terraform_backend_hcl output: HCL
+// configuration snippet for Terraform backend.
+func (o Outputs) TerraformBackendHCL() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "terraform_backend_hcl", &value)
+	return value
+}
+
+// AccessInstructionsOutput is an object of the module's outputs.
+type AccessInstructionsOutput struct {
+	Container        AccessInstructionsContainerOutput        `json:"container"`
+	KeyVault         *AccessInstructionsKeyVaultOutput        `json:"key_vault"`
+	RBACRequirements AccessInstructionsRBACRequirementsOutput `json:"rbac_requirements"`
+	StorageAccount   AccessInstructionsStorageAccountOutput   `json:"storage_account"`
+}
+
+// AccessInstructionsContainerOutput is an object of the module's outputs.
+type AccessInstructionsContainerOutput struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// AccessInstructionsKeyVaultOutput is an object of the module's outputs.
+type AccessInstructionsKeyVaultOutput struct {
+	Name string `json:"name"`
+	URI  string `json:"uri"`
+}
+
+// AccessInstructionsRBACRequirementsOutput is an object of the module's outputs.
+type AccessInstructionsRBACRequirementsOutput struct {
+	KeyVaultAccessRequired     *string `json:"key_vault_access_required"`
+	StorageContributorRequired string  `json:"storage_contributor_required"`
+}
+
+// AccessInstructionsStorageAccountOutput is an object of the module's outputs.
+type AccessInstructionsStorageAccountOutput struct {
+	Location      string `json:"location"`
+	Name          string `json:"name"`
+	ResourceGroup string `json:"resource_group"`
+}
+
+// ConfigurationSummaryOutput is an object of the module's outputs.
+type ConfigurationSummaryOutput struct {
+	BlobVersioningEnabled bool   `json:"blob_versioning_enabled"`
+	Environment           string `json:"environment"`
+	KeyVaultEnabled       bool   `json:"key_vault_enabled"`
+	Location              string `json:"location"`
+	MonitoringEnabled     bool   `json:"monitoring_enabled"`
+	NamingConventionUsed  bool   `json:"naming_convention_used"`
+	NetworkRestrictions   bool   `json:"network_restrictions"`
+	ProjectName           string `json:"project_name"`
+	PublicAccessEnabled   bool   `json:"public_access_enabled"`
+	StateLockingEnabled   bool   `json:"state_locking_enabled"`
+	StorageReplication    string `json:"storage_replication"`
+}
+
+// TerraformBackendConfigOutput is an object of the module's outputs.
+type TerraformBackendConfigOutput struct {
+	ContainerName      string  `json:"container_name"`
+	Key                string  `json:"key"`
+	KeyVaultURI        *string `json:"key_vault_uri"`
+	LockContainer      *string `json:"lock_container"`
+	ResourceGroupName  string  `json:"resource_group_name"`
+	StorageAccountName string  `json:"storage_account_name"`
+	UseKeyVault        bool    `json:"use_key_vault"`
+	UseStateLocking    bool    `json:"use_state_locking"`
+}
@@ -0,0 +1,149 @@
+// Code generated by zrr-gen from azure/application/azure-sql-db/variables.tf. DO NOT EDIT.
+
+// Package azuresqldb holds the typed inputs and outputs of azure/application/azure-sql-db, for its tests.
+package azuresqldb
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/application/azure-sql-db. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Enable log monitoring for audit logs.
+	AuditingLogMonitoringEnabled *bool `tf:"auditing_log_monitoring_enabled"`
+
+	// Number of days to retain audit logs.
+	AuditingRetentionDays *float64 `tf:"auditing_retention_days"`
+
+	// Storage account access key for audit logs.
+	AuditingStorageAccountAccessKey *string `tf:"auditing_storage_account_access_key"`
+
+	// Whether the storage account access key is secondary.
+	AuditingStorageAccountAccessKeyIsSecondary *bool `tf:"auditing_storage_account_access_key_is_secondary"`
+
+	// Storage endpoint for audit logs.
+	AuditingStorageEndpoint *string `tf:"auditing_storage_endpoint"`
+
+	// Time in minutes after which database is automatically paused (-1 to disable).
+	AutoPauseDelayInMinutes *float64 `tf:"auto_pause_delay_in_minutes"`
+
+	// Additional tags specific to the Azure SQL Database.
+	AzureSQLDBTags map[string]string `tf:"azure_sql_db_tags"`
+
+	// Backup interval in hours (12 or 24).
+	BackupIntervalInHours *float64 `tf:"backup_interval_in_hours"`
+
+	// Database collation.
+	Collation *string `tf:"collation"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Database creation mode.
+	CreateMode *string `tf:"create_mode"`
+
+	// ID of the source database for copy operations.
+	CreationSourceDatabaseID *string `tf:"creation_source_database_id"`
+
+	// Enable database auditing.
+	EnableAuditing *bool `tf:"enable_auditing"`
+
+	// Enable threat detection for the database.
+	EnableThreatDetection *bool `tf:"enable_threat_detection"`
+
+	// Enable vulnerability assessment.
+	EnableVulnerabilityAssessment *bool `tf:"enable_vulnerability_assessment"`
+
+	// Enable geo-redundant backup.
+	GeoBackupEnabled *bool `tf:"geo_backup_enabled"`
+
+	// License type for the database (LicenseIncluded or BasePrice).
+	LicenseType *string `tf:"license_type"`
+
+	// Long term retention policy configuration.
+	LongTermRetentionPolicy *LongTermRetentionPolicy `tf:"long_term_retention_policy"`
+
+	// Maximum size of the database in GB.
+	MaxSizeGB *float64 `tf:"max_size_gb"`
+
+	// Minimum capacity for serverless databases.
+	MinCapacity *float64 `tf:"min_capacity"`
+
+	// Name of the Azure SQL Database.
+	Name string `tf:"name"`
+
+	// Number of read replicas.
+	ReadReplicaCount *float64 `tf:"read_replica_count"`
+
+	// Enable read scale-out for the database.
+	ReadScale *bool `tf:"read_scale"`
+
+	// ID of the database to recover from.
+	RecoverDatabaseID *string `tf:"recover_database_id"`
+
+	// Name of the resource group containing the SQL Server.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// ID of the dropped database to restore.
+	RestoreDroppedDatabaseID *string `tf:"restore_dropped_database_id"`
+
+	// Point in time for restore operations (RFC3339 format).
+	RestorePointInTime *string `tf:"restore_point_in_time"`
+
+	// Point in time retention in days.
+	ShortTermRetentionDays *float64 `tf:"short_term_retention_days"`
+
+	// The SKU name of the database. Examples: GP_S_Gen5_1, GP_Gen5_2, HS_Gen5_4, BC_Gen5_8.
+	SKUName *string `tf:"sku_name"`
+
+	// ID of the Azure SQL Server. If not provided, sql_server_name must be specified.
+	SQLServerID *string `tf:"sql_server_id"`
+
+	// Name of the Azure SQL Server. Required if sql_server_id is not provided.
+	SQLServerName *string `tf:"sql_server_name"`
+
+	// Storage account type for backups (Local, Zone, Geo, GeoZone).
+	StorageAccountType *string `tf:"storage_account_type"`
+
+	// List of email addresses to send threat detection alerts to.
+	ThreatDetectionEmailAddresses []string `tf:"threat_detection_email_addresses"`
+
+	// Send threat detection alerts to subscription admins.
+	ThreatDetectionEmailAdmins *bool `tf:"threat_detection_email_admins"`
+
+	// Number of days to retain threat detection logs.
+	ThreatDetectionRetentionDays *float64 `tf:"threat_detection_retention_days"`
+
+	// Storage account access key for threat detection logs.
+	ThreatDetectionStorageAccountAccessKey *string `tf:"threat_detection_storage_account_access_key"`
+
+	// Storage endpoint for threat detection logs.
+	ThreatDetectionStorageEndpoint *string `tf:"threat_detection_storage_endpoint"`
+
+	// Enable transparent data encryption.
+	TransparentDataEncryptionEnabled *bool `tf:"transparent_data_encryption_enabled"`
+
+	// Vulnerability assessment baseline rules.
+	VulnerabilityAssessmentBaselineRules []VulnerabilityAssessmentBaselineRule `tf:"vulnerability_assessment_baseline_rules"`
+
+	// Whether the database is zone redundant.
+	ZoneRedundant *bool `tf:"zone_redundant"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// LongTermRetentionPolicy is an object of the module's inputs.
+type LongTermRetentionPolicy struct {
+	MonthlyRetention *string  `tf:"monthly_retention"`
+	WeekOfYear       *float64 `tf:"week_of_year"`
+	WeeklyRetention  *string  `tf:"weekly_retention"`
+	YearlyRetention  *string  `tf:"yearly_retention"`
+}
+
+// VulnerabilityAssessmentBaselineRule is an object of the module's inputs.
+type VulnerabilityAssessmentBaselineRule struct {
+	BaselineResults []string `tf:"baseline_results"`
+	RuleID          string   `tf:"rule_id"`
+}
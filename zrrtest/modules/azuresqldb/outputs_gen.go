@@ -0,0 +1,187 @@
+// Code generated by zrr-gen from azure/application/azure-sql-db/outputs.tf. DO NOT EDIT.
+
+package azuresqldb
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/application/azure-sql-db once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AuditingEnabled returns the auditing_enabled output: Whether auditing is
+// enabled.
+func (o Outputs) AuditingEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "auditing_enabled", &value)
+	return value
+}
+
+// AutoPauseDelayInMinutes returns the auto_pause_delay_in_minutes output: Auto
+// pause delay in minutes.
+func (o Outputs) AutoPauseDelayInMinutes() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "auto_pause_delay_in_minutes", &value)
+	return value
+}
+
+// BackupIntervalInHours returns the backup_interval_in_hours output: Backup
+// interval in hours.
+func (o Outputs) BackupIntervalInHours() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "backup_interval_in_hours", &value)
+	return value
+}
+
+// Collation returns the collation output: Collation of the database.
+func (o Outputs) Collation() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "collation", &value)
+	return value
+}
+
+// CreateMode returns the create_mode output: Database creation mode.
+func (o Outputs) CreateMode() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "create_mode", &value)
+	return value
+}
+
+// GeoBackupEnabled returns the geo_backup_enabled output: Whether geo-redundant
+// backup is enabled.
+func (o Outputs) GeoBackupEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "geo_backup_enabled", &value)
+	return value
+}
+
+// ID returns the id output: ID of the Azure SQL Database.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// LicenseType returns the license_type output: License type of the database.
+func (o Outputs) LicenseType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "license_type", &value)
+	return value
+}
+
+// MaxSizeGB returns the max_size_gb output: Maximum size of the database in GB.
+func (o Outputs) MaxSizeGB() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "max_size_gb", &value)
+	return value
+}
+
+// MinCapacity returns the min_capacity output: Minimum capacity for serverless
+// databases.
+func (o Outputs) MinCapacity() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "min_capacity", &value)
+	return value
+}
+
+// Name returns the name output: Name of the Azure SQL Database.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// ReadReplicaCount returns the read_replica_count output: Number of read
+// replicas.
+func (o Outputs) ReadReplicaCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "read_replica_count", &value)
+	return value
+}
+
+// ReadScale returns the read_scale output: Whether read scale is enabled.
+func (o Outputs) ReadScale() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "read_scale", &value)
+	return value
+}
+
+// ServerID returns the server_id output: ID of the Azure SQL Server hosting the
+// database.
+func (o Outputs) ServerID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "server_id", &value)
+	return value
+}
+
+// ShortTermRetentionDays returns the short_term_retention_days output: Short
+// term retention period in days.
+func (o Outputs) ShortTermRetentionDays() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "short_term_retention_days", &value)
+	return value
+}
+
+// SKUName returns the sku_name output: SKU name of the database.
+func (o Outputs) SKUName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "sku_name", &value)
+	return value
+}
+
+// StorageAccountType returns the storage_account_type output: Storage account
+// type for backups.
+func (o Outputs) StorageAccountType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_type", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the Azure SQL Database.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// ThreatDetectionEnabled returns the threat_detection_enabled output: Whether
+// threat detection is enabled.
+func (o Outputs) ThreatDetectionEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "threat_detection_enabled", &value)
+	return value
+}
+
+// TransparentDataEncryptionEnabled returns the
+// transparent_data_encryption_enabled output: Whether transparent data
+// encryption is enabled.
+func (o Outputs) TransparentDataEncryptionEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "transparent_data_encryption_enabled", &value)
+	return value
+}
+
+// VulnerabilityAssessmentEnabled returns the vulnerability_assessment_enabled
+// output: Whether vulnerability assessment is enabled.
+func (o Outputs) VulnerabilityAssessmentEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "vulnerability_assessment_enabled", &value)
+	return value
+}
+
+// ZoneRedundant returns the zone_redundant output: Whether the database is zone
+// redundant.
+func (o Outputs) ZoneRedundant() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "zone_redundant", &value)
+	return value
+}
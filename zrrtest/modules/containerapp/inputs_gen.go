@@ -0,0 +1,274 @@
+// Code generated by zrr-gen from azure/application/container-app/variables.tf. DO NOT EDIT.
+
+// Package containerapp holds the typed inputs and outputs of azure/application/container-app, for its tests.
+package containerapp
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/application/container-app. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// List of additional container registries.
+	AdditionalRegistries []AdditionalRegistry `tf:"additional_registries"`
+
+	// Common tags to apply to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// The ID of the Container App Environment.
+	ContainerAppEnvironmentID string `tf:"container_app_environment_id"`
+
+	// Additional tags specific to the Container App.
+	ContainerAppTags map[string]string `tf:"container_app_tags"`
+
+	// Identity for container registry authentication.
+	ContainerRegistryIdentity *string `tf:"container_registry_identity"`
+
+	// Name of the Azure Container Registry.
+	ContainerRegistryName *string `tf:"container_registry_name"`
+
+	// Name of the secret containing the container registry password.
+	ContainerRegistryPasswordSecretName *string `tf:"container_registry_password_secret_name"`
+
+	// Resource group name of the Azure Container Registry.
+	ContainerRegistryResourceGroup *string `tf:"container_registry_resource_group"`
+
+	// Username for container registry authentication.
+	ContainerRegistryUsername *string `tf:"container_registry_username"`
+
+	// List of containers.
+	Containers []Container `tf:"containers"`
+
+	// Dapr configuration.
+	Dapr *Dapr `tf:"dapr"`
+
+	// Environment name (dev, test, stage, prod).
+	Environment *string `tf:"environment"`
+
+	// List of HTTP scaling rules.
+	HTTPScaleRules []HTTPScaleRule `tf:"http_scale_rules"`
+
+	// List of user assigned identity IDs.
+	IdentityIDs []string `tf:"identity_ids"`
+
+	// The type of identity to use (SystemAssigned, UserAssigned).
+	IdentityType *string `tf:"identity_type"`
+
+	// Ingress configuration.
+	Ingress *Ingress `tf:"ingress"`
+
+	// List of init containers.
+	InitContainers []InitContainer `tf:"init_containers"`
+
+	// The maximum number of replicas.
+	MaxReplicas *float64 `tf:"max_replicas"`
+
+	// The minimum number of replicas.
+	MinReplicas *float64 `tf:"min_replicas"`
+
+	// The name of the Container App.
+	Name string `tf:"name"`
+
+	// The name of the resource group.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// The revision mode of the Container App.
+	RevisionMode *string `tf:"revision_mode"`
+
+	// The revision suffix.
+	RevisionSuffix *string `tf:"revision_suffix"`
+
+	// List of secrets.
+	Secrets []Secret `tf:"secrets"`
+
+	// List of volumes.
+	Volumes []Volume `tf:"volumes"`
+
+	// The name of the workload profile.
+	WorkloadProfileName *string `tf:"workload_profile_name"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AdditionalRegistry is an object of the module's inputs.
+type AdditionalRegistry struct {
+	Identity           *string `tf:"identity"`
+	PasswordSecretName string  `tf:"password_secret_name"`
+	Server             string  `tf:"server"`
+	Username           string  `tf:"username"`
+}
+
+// Container is an object of the module's inputs.
+type Container struct {
+	Args           []string                 `tf:"args"`
+	Command        []string                 `tf:"command"`
+	CPU            float64                  `tf:"cpu"`
+	Env            []ContainerEnv           `tf:"env"`
+	Image          string                   `tf:"image"`
+	LivenessProbe  *ContainerLivenessProbe  `tf:"liveness_probe"`
+	Memory         string                   `tf:"memory"`
+	Name           string                   `tf:"name"`
+	ReadinessProbe *ContainerReadinessProbe `tf:"readiness_probe"`
+	StartupProbe   *ContainerStartupProbe   `tf:"startup_probe"`
+	VolumeMounts   []ContainerVolumeMount   `tf:"volume_mounts"`
+}
+
+// ContainerEnv is an object of the module's inputs.
+type ContainerEnv struct {
+	Name       string  `tf:"name"`
+	SecretName *string `tf:"secret_name"`
+	Value      *string `tf:"value"`
+}
+
+// ContainerLivenessProbe is an object of the module's inputs.
+type ContainerLivenessProbe struct {
+	FailureCountThreshold *float64                       `tf:"failure_count_threshold"`
+	Headers               []ContainerLivenessProbeHeader `tf:"headers"`
+	Host                  *string                        `tf:"host"`
+	InitialDelay          *float64                       `tf:"initial_delay"`
+	IntervalSeconds       *float64                       `tf:"interval_seconds"`
+	Path                  *string                        `tf:"path"`
+	Port                  float64                        `tf:"port"`
+	SuccessCountThreshold *float64                       `tf:"success_count_threshold"`
+	Timeout               *float64                       `tf:"timeout"`
+	Transport             string                         `tf:"transport"`
+}
+
+// ContainerLivenessProbeHeader is an object of the module's inputs.
+type ContainerLivenessProbeHeader struct {
+	Name  string `tf:"name"`
+	Value string `tf:"value"`
+}
+
+// ContainerReadinessProbe is an object of the module's inputs.
+type ContainerReadinessProbe struct {
+	FailureCountThreshold *float64                        `tf:"failure_count_threshold"`
+	Headers               []ContainerReadinessProbeHeader `tf:"headers"`
+	Host                  *string                         `tf:"host"`
+	IntervalSeconds       *float64                        `tf:"interval_seconds"`
+	Path                  *string                         `tf:"path"`
+	Port                  float64                         `tf:"port"`
+	SuccessCountThreshold *float64                        `tf:"success_count_threshold"`
+	Timeout               *float64                        `tf:"timeout"`
+	Transport             string                          `tf:"transport"`
+}
+
+// ContainerReadinessProbeHeader is an object of the module's inputs.
+type ContainerReadinessProbeHeader struct {
+	Name  string `tf:"name"`
+	Value string `tf:"value"`
+}
+
+// ContainerStartupProbe is an object of the module's inputs.
+type ContainerStartupProbe struct {
+	FailureCountThreshold *float64                      `tf:"failure_count_threshold"`
+	Headers               []ContainerStartupProbeHeader `tf:"headers"`
+	Host                  *string                       `tf:"host"`
+	IntervalSeconds       *float64                      `tf:"interval_seconds"`
+	Path                  *string                       `tf:"path"`
+	Port                  float64                       `tf:"port"`
+	Timeout               *float64                      `tf:"timeout"`
+	Transport             string                        `tf:"transport"`
+}
+
+// ContainerStartupProbeHeader is an object of the module's inputs.
+type ContainerStartupProbeHeader struct {
+	Name  string `tf:"name"`
+	Value string `tf:"value"`
+}
+
+// ContainerVolumeMount is an object of the module's inputs.
+type ContainerVolumeMount struct {
+	Name string `tf:"name"`
+	Path string `tf:"path"`
+}
+
+// Dapr is an object of the module's inputs.
+type Dapr struct {
+	AppID       string   `tf:"app_id"`
+	AppPort     *float64 `tf:"app_port"`
+	AppProtocol *string  `tf:"app_protocol"`
+}
+
+// HTTPScaleRule is an object of the module's inputs.
+type HTTPScaleRule struct {
+	ConcurrentRequests float64 `tf:"concurrent_requests"`
+	Name               string  `tf:"name"`
+}
+
+// Ingress is an object of the module's inputs.
+type Ingress struct {
+	AllowInsecureConnections *bool                          `tf:"allow_insecure_connections"`
+	CustomDomains            []IngressCustomDomain          `tf:"custom_domains"`
+	ExposedPort              *float64                       `tf:"exposed_port"`
+	ExternalEnabled          *bool                          `tf:"external_enabled"`
+	IPSecurityRestrictions   []IngressIPSecurityRestriction `tf:"ip_security_restrictions"`
+	TargetPort               float64                        `tf:"target_port"`
+	TrafficWeight            []IngressTrafficWeight         `tf:"traffic_weight"`
+	Transport                *string                        `tf:"transport"`
+}
+
+// IngressCustomDomain is an object of the module's inputs.
+type IngressCustomDomain struct {
+	BindingType   *string `tf:"binding_type"`
+	CertificateID *string `tf:"certificate_id"`
+	Name          string  `tf:"name"`
+}
+
+// IngressIPSecurityRestriction is an object of the module's inputs.
+type IngressIPSecurityRestriction struct {
+	Action         string  `tf:"action"`
+	Description    *string `tf:"description"`
+	IPAddressRange string  `tf:"ip_address_range"`
+	Name           string  `tf:"name"`
+}
+
+// IngressTrafficWeight is an object of the module's inputs.
+type IngressTrafficWeight struct {
+	Label          *string `tf:"label"`
+	LatestRevision *bool   `tf:"latest_revision"`
+	Percentage     float64 `tf:"percentage"`
+	RevisionSuffix *string `tf:"revision_suffix"`
+}
+
+// InitContainer is an object of the module's inputs.
+type InitContainer struct {
+	Args         []string                   `tf:"args"`
+	Command      []string                   `tf:"command"`
+	CPU          *float64                   `tf:"cpu"`
+	Env          []InitContainerEnv         `tf:"env"`
+	Image        string                     `tf:"image"`
+	Memory       *string                    `tf:"memory"`
+	Name         string                     `tf:"name"`
+	VolumeMounts []InitContainerVolumeMount `tf:"volume_mounts"`
+}
+
+// InitContainerEnv is an object of the module's inputs.
+type InitContainerEnv struct {
+	Name       string  `tf:"name"`
+	SecretName *string `tf:"secret_name"`
+	Value      *string `tf:"value"`
+}
+
+// InitContainerVolumeMount is an object of the module's inputs.
+type InitContainerVolumeMount struct {
+	Name string `tf:"name"`
+	Path string `tf:"path"`
+}
+
+// Secret is an object of the module's inputs.
+type Secret struct {
+	Identity         *string `tf:"identity"`
+	KeyVaultSecretID *string `tf:"key_vault_secret_id"`
+	Name             string  `tf:"name"`
+	Value            *string `tf:"value"`
+}
+
+// Volume is an object of the module's inputs.
+type Volume struct {
+	Name        string  `tf:"name"`
+	StorageName *string `tf:"storage_name"`
+	StorageType string  `tf:"storage_type"`
+}
@@ -0,0 +1,294 @@
+// Code generated by zrr-gen from azure/application/container-app/outputs.tf. DO NOT EDIT.
+
+package containerapp
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/application/container-app once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// ApplicationURL returns the application_url output: The main application URL
+// (HTTPS).
+func (o Outputs) ApplicationURL() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "application_url", &value)
+	return value
+}
+
+// ApplicationURLHTTP returns the application_url_http output: The main
+// application URL (HTTP).
+func (o Outputs) ApplicationURLHTTP() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "application_url_http", &value)
+	return value
+}
+
+// ConnectionInfo returns the connection_info output: Complete connection
+// information for the Container App.
+func (o Outputs) ConnectionInfo() ConnectionInfoOutput {
+	var value ConnectionInfoOutput
+	terraform.OutputStruct(o.t, o.options, "connection_info", &value)
+	return value
+}
+
+// ContainerAppEnvironmentID returns the container_app_environment_id output:
+// The Container App Environment ID.
+func (o Outputs) ContainerAppEnvironmentID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "container_app_environment_id", &value)
+	return value
+}
+
+// ContainerConfiguration returns the container_configuration output: Container
+// configuration details.
+func (o Outputs) ContainerConfiguration() ContainerConfigurationOutput {
+	var value ContainerConfigurationOutput
+	terraform.OutputStruct(o.t, o.options, "container_configuration", &value)
+	return value
+}
+
+// CustomDomainVerificationID returns the custom_domain_verification_id output:
+// The custom domain verification ID.
+func (o Outputs) CustomDomainVerificationID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "custom_domain_verification_id", &value)
+	return value
+}
+
+// FQDN returns the fqdn output: The fully qualified domain name.
+func (o Outputs) FQDN() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "fqdn", &value)
+	return value
+}
+
+// ID returns the id output: The ID of the Container App.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// Identity returns the identity output: The identity of the Container App.
+func (o Outputs) Identity() interface{} {
+	var value interface{}
+	terraform.OutputStruct(o.t, o.options, "identity", &value)
+	return value
+}
+
+// IngressConfiguration returns the ingress_configuration output: Ingress
+// configuration details.
+func (o Outputs) IngressConfiguration() *IngressConfigurationOutput {
+	var value *IngressConfigurationOutput
+	terraform.OutputStruct(o.t, o.options, "ingress_configuration", &value)
+	return value
+}
+
+// LatestRevisionFQDN returns the latest_revision_fqdn output: The FQDN of the
+// latest revision.
+func (o Outputs) LatestRevisionFQDN() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "latest_revision_fqdn", &value)
+	return value
+}
+
+// LatestRevisionName returns the latest_revision_name output: The name of the
+// latest revision.
+func (o Outputs) LatestRevisionName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "latest_revision_name", &value)
+	return value
+}
+
+// Location returns the location output: The location of the Container App.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// Name returns the name output: The name of the Container App.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// OutboundIPAddresses returns the outbound_ip_addresses output: List of
+// outbound IP addresses.
+func (o Outputs) OutboundIPAddresses() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "outbound_ip_addresses", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: The resource group
+// name of the Container App.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// RevisionMode returns the revision_mode output: The revision mode of the
+// Container App.
+func (o Outputs) RevisionMode() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "revision_mode", &value)
+	return value
+}
+
+// ConnectionInfoOutput is an object of the module's outputs.
+type ConnectionInfoOutput struct {
+	AppID                      string   `json:"app_id"`
+	AppName                    string   `json:"app_name"`
+	CustomDomainVerificationID string   `json:"custom_domain_verification_id"`
+	EnvironmentID              string   `json:"environment_id"`
+	FQDN                       string   `json:"fqdn"`
+	HTTPURL                    *string  `json:"http_url"`
+	HTTPSURL                   *string  `json:"https_url"`
+	LatestRevisionName         string   `json:"latest_revision_name"`
+	Location                   string   `json:"location"`
+	OutboundIPAddresses        []string `json:"outbound_ip_addresses"`
+	ResourceGroupName          string   `json:"resource_group_name"`
+}
+
+// ContainerConfigurationOutput is an object of the module's outputs.
+type ContainerConfigurationOutput struct {
+	Containers     []ContainerConfigurationContainerOutput     `json:"containers"`
+	InitContainers []ContainerConfigurationInitContainerOutput `json:"init_containers"`
+	MaxReplicas    float64                                     `json:"max_replicas"`
+	MinReplicas    float64                                     `json:"min_replicas"`
+	RevisionMode   string                                      `json:"revision_mode"`
+}
+
+// ContainerConfigurationContainerOutput is an object of the module's outputs.
+type ContainerConfigurationContainerOutput struct {
+	Args           []string                                             `json:"args"`
+	Command        []string                                             `json:"command"`
+	CPU            float64                                              `json:"cpu"`
+	Env            []ContainerConfigurationContainerEnvOutput           `json:"env"`
+	Image          string                                               `json:"image"`
+	LivenessProbe  *ContainerConfigurationContainerLivenessProbeOutput  `json:"liveness_probe"`
+	Memory         string                                               `json:"memory"`
+	Name           string                                               `json:"name"`
+	ReadinessProbe *ContainerConfigurationContainerReadinessProbeOutput `json:"readiness_probe"`
+	StartupProbe   *ContainerConfigurationContainerStartupProbeOutput   `json:"startup_probe"`
+	VolumeMounts   []ContainerConfigurationContainerVolumeMountOutput   `json:"volume_mounts"`
+}
+
+// ContainerConfigurationContainerEnvOutput is an object of the module's outputs.
+type ContainerConfigurationContainerEnvOutput struct {
+	Name       string  `json:"name"`
+	SecretName *string `json:"secret_name"`
+	Value      *string `json:"value"`
+}
+
+// ContainerConfigurationContainerLivenessProbeOutput is an object of the module's outputs.
+type ContainerConfigurationContainerLivenessProbeOutput struct {
+	FailureCountThreshold *float64                                                   `json:"failure_count_threshold"`
+	Headers               []ContainerConfigurationContainerLivenessProbeHeaderOutput `json:"headers"`
+	Host                  *string                                                    `json:"host"`
+	InitialDelay          *float64                                                   `json:"initial_delay"`
+	IntervalSeconds       *float64                                                   `json:"interval_seconds"`
+	Path                  *string                                                    `json:"path"`
+	Port                  float64                                                    `json:"port"`
+	SuccessCountThreshold *float64                                                   `json:"success_count_threshold"`
+	Timeout               *float64                                                   `json:"timeout"`
+	Transport             string                                                     `json:"transport"`
+}
+
+// ContainerConfigurationContainerLivenessProbeHeaderOutput is an object of the module's outputs.
+type ContainerConfigurationContainerLivenessProbeHeaderOutput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ContainerConfigurationContainerReadinessProbeOutput is an object of the module's outputs.
+type ContainerConfigurationContainerReadinessProbeOutput struct {
+	FailureCountThreshold *float64                                                    `json:"failure_count_threshold"`
+	Headers               []ContainerConfigurationContainerReadinessProbeHeaderOutput `json:"headers"`
+	Host                  *string                                                     `json:"host"`
+	IntervalSeconds       *float64                                                    `json:"interval_seconds"`
+	Path                  *string                                                     `json:"path"`
+	Port                  float64                                                     `json:"port"`
+	SuccessCountThreshold *float64                                                    `json:"success_count_threshold"`
+	Timeout               *float64                                                    `json:"timeout"`
+	Transport             string                                                      `json:"transport"`
+}
+
+// ContainerConfigurationContainerReadinessProbeHeaderOutput is an object of the module's outputs.
+type ContainerConfigurationContainerReadinessProbeHeaderOutput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ContainerConfigurationContainerStartupProbeOutput is an object of the module's outputs.
+type ContainerConfigurationContainerStartupProbeOutput struct {
+	FailureCountThreshold *float64                                                  `json:"failure_count_threshold"`
+	Headers               []ContainerConfigurationContainerStartupProbeHeaderOutput `json:"headers"`
+	Host                  *string                                                   `json:"host"`
+	IntervalSeconds       *float64                                                  `json:"interval_seconds"`
+	Path                  *string                                                   `json:"path"`
+	Port                  float64                                                   `json:"port"`
+	Timeout               *float64                                                  `json:"timeout"`
+	Transport             string                                                    `json:"transport"`
+}
+
+// ContainerConfigurationContainerStartupProbeHeaderOutput is an object of the module's outputs.
+type ContainerConfigurationContainerStartupProbeHeaderOutput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ContainerConfigurationContainerVolumeMountOutput is an object of the module's outputs.
+type ContainerConfigurationContainerVolumeMountOutput struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ContainerConfigurationInitContainerOutput is an object of the module's outputs.
+type ContainerConfigurationInitContainerOutput struct {
+	Args         []string                                               `json:"args"`
+	Command      []string                                               `json:"command"`
+	CPU          *float64                                               `json:"cpu"`
+	Env          []ContainerConfigurationInitContainerEnvOutput         `json:"env"`
+	Image        string                                                 `json:"image"`
+	Memory       *string                                                `json:"memory"`
+	Name         string                                                 `json:"name"`
+	VolumeMounts []ContainerConfigurationInitContainerVolumeMountOutput `json:"volume_mounts"`
+}
+
+// ContainerConfigurationInitContainerEnvOutput is an object of the module's outputs.
+type ContainerConfigurationInitContainerEnvOutput struct {
+	Name       string  `json:"name"`
+	SecretName *string `json:"secret_name"`
+	Value      *string `json:"value"`
+}
+
+// ContainerConfigurationInitContainerVolumeMountOutput is an object of the module's outputs.
+type ContainerConfigurationInitContainerVolumeMountOutput struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// IngressConfigurationOutput is an object of the module's outputs.
+type IngressConfigurationOutput struct {
+	AllowInsecureConnections *bool    `json:"allow_insecure_connections"`
+	ExposedPort              *float64 `json:"exposed_port"`
+	ExternalEnabled          *bool    `json:"external_enabled"`
+	FQDN                     string   `json:"fqdn"`
+	TargetPort               float64  `json:"target_port"`
+	Transport                *string  `json:"transport"`
+}
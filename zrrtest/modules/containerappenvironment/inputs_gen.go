@@ -0,0 +1,102 @@
+// Code generated by zrr-gen from azure/infrastructure/container-app-environment/variables.tf. DO NOT EDIT.
+
+// Package containerappenvironment holds the typed inputs and outputs of azure/infrastructure/container-app-environment, for its tests.
+package containerappenvironment
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/container-app-environment. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Map of SSL certificates for custom domains.
+	Certificates map[string]Certificate `tf:"certificates"`
+
+	// Common tags to apply to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Map of Dapr components.
+	DaprComponents map[string]DaprComponent `tf:"dapr_components"`
+
+	// Environment name (dev, test, stage, prod).
+	Environment *string `tf:"environment"`
+
+	// Additional tags specific to the Container App Environment.
+	EnvironmentTags map[string]string `tf:"environment_tags"`
+
+	// The subnet ID for Container App Environment infrastructure (optional).
+	InfrastructureSubnetID *string `tf:"infrastructure_subnet_id"`
+
+	// Whether the Container App Environment should use an internal load balancer.
+	InternalLoadBalancerEnabled *bool `tf:"internal_load_balancer_enabled"`
+
+	// The Azure region where the Container App Environment should be created.
+	Location *string `tf:"location"`
+
+	// The ID of the Log Analytics workspace for Container App Environment.
+	LogAnalyticsWorkspaceID string `tf:"log_analytics_workspace_id"`
+
+	// The name of the Container App Environment.
+	Name string `tf:"name"`
+
+	// The name of the resource group.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// Map of Azure Files storage accounts to mount.
+	StorageAccounts map[string]StorageAccount `tf:"storage_accounts"`
+
+	// Map of workload profiles for dedicated environments.
+	WorkloadProfiles map[string]WorkloadProfile `tf:"workload_profiles"`
+
+	// Whether zone redundancy is enabled for the Container App Environment.
+	ZoneRedundancyEnabled *bool `tf:"zone_redundancy_enabled"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// Certificate is an object of the module's inputs.
+type Certificate struct {
+	CertificateBlobBase64 string `tf:"certificate_blob_base64"`
+	CertificatePassword   string `tf:"certificate_password"`
+}
+
+// DaprComponent is an object of the module's inputs.
+type DaprComponent struct {
+	ComponentType string                  `tf:"component_type"`
+	IgnoreErrors  *bool                   `tf:"ignore_errors"`
+	InitTimeout   *string                 `tf:"init_timeout"`
+	Metadata      []DaprComponentMetadata `tf:"metadata"`
+	Scopes        []string                `tf:"scopes"`
+	Secrets       []DaprComponentSecret   `tf:"secrets"`
+	Version       string                  `tf:"version"`
+}
+
+// DaprComponentMetadata is an object of the module's inputs.
+type DaprComponentMetadata struct {
+	Name  string `tf:"name"`
+	Value string `tf:"value"`
+}
+
+// DaprComponentSecret is an object of the module's inputs.
+type DaprComponentSecret struct {
+	Name  string `tf:"name"`
+	Value string `tf:"value"`
+}
+
+// StorageAccount is an object of the module's inputs.
+type StorageAccount struct {
+	AccessKey   string `tf:"access_key"`
+	AccessMode  string `tf:"access_mode"`
+	AccountName string `tf:"account_name"`
+	ShareName   string `tf:"share_name"`
+}
+
+// WorkloadProfile is an object of the module's inputs.
+type WorkloadProfile struct {
+	MaximumCount        float64 `tf:"maximum_count"`
+	MinimumCount        float64 `tf:"minimum_count"`
+	Name                string  `tf:"name"`
+	WorkloadProfileType string  `tf:"workload_profile_type"`
+}
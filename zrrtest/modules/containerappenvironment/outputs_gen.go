@@ -0,0 +1,165 @@
+// Code generated by zrr-gen from azure/infrastructure/container-app-environment/outputs.tf. DO NOT EDIT.
+
+package containerappenvironment
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/container-app-environment once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// Certificates returns the certificates output: Map of configured certificates.
+func (o Outputs) Certificates() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "certificates", &value)
+	return value
+}
+
+// ConnectionInfo returns the connection_info output: Connection information for
+// Container Apps.
+func (o Outputs) ConnectionInfo() ConnectionInfoOutput {
+	var value ConnectionInfoOutput
+	terraform.OutputStruct(o.t, o.options, "connection_info", &value)
+	return value
+}
+
+// DaprComponents returns the dapr_components output: Map of configured Dapr
+// components.
+func (o Outputs) DaprComponents() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "dapr_components", &value)
+	return value
+}
+
+// DefaultDomain returns the default_domain output: The default domain of the
+// Container App Environment.
+func (o Outputs) DefaultDomain() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "default_domain", &value)
+	return value
+}
+
+// DockerBridgeCIDR returns the docker_bridge_cidr output: The Docker bridge
+// CIDR of the Container App Environment.
+func (o Outputs) DockerBridgeCIDR() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "docker_bridge_cidr", &value)
+	return value
+}
+
+// ID returns the id output: The ID of the Container App Environment.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// InfrastructureSubnetID returns the infrastructure_subnet_id output: The
+// infrastructure subnet ID.
+func (o Outputs) InfrastructureSubnetID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "infrastructure_subnet_id", &value)
+	return value
+}
+
+// InternalLoadBalancerEnabled returns the internal_load_balancer_enabled
+// output: Whether internal load balancer is enabled.
+func (o Outputs) InternalLoadBalancerEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "internal_load_balancer_enabled", &value)
+	return value
+}
+
+// Location returns the location output: The location of the Container App
+// Environment.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// LogAnalyticsWorkspaceID returns the log_analytics_workspace_id output: The
+// Log Analytics workspace ID.
+func (o Outputs) LogAnalyticsWorkspaceID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "log_analytics_workspace_id", &value)
+	return value
+}
+
+// Name returns the name output: The name of the Container App Environment.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// PlatformReservedCIDR returns the platform_reserved_cidr output: The platform
+// reserved CIDR of the Container App Environment.
+func (o Outputs) PlatformReservedCIDR() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "platform_reserved_cidr", &value)
+	return value
+}
+
+// PlatformReservedDNSIPAddress returns the platform_reserved_dns_ip_address
+// output: The platform reserved DNS IP address of the Container App
+// Environment.
+func (o Outputs) PlatformReservedDNSIPAddress() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "platform_reserved_dns_ip_address", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: The resource group
+// name of the Container App Environment.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// StaticIPAddress returns the static_ip_address output: The static IP address
+// of the Container App Environment.
+func (o Outputs) StaticIPAddress() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "static_ip_address", &value)
+	return value
+}
+
+// StorageAccounts returns the storage_accounts output: Map of configured
+// storage accounts.
+func (o Outputs) StorageAccounts() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "storage_accounts", &value)
+	return value
+}
+
+// ZoneRedundancyEnabled returns the zone_redundancy_enabled output: Whether
+// zone redundancy is enabled.
+func (o Outputs) ZoneRedundancyEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "zone_redundancy_enabled", &value)
+	return value
+}
+
+// ConnectionInfoOutput is an object of the module's outputs.
+type ConnectionInfoOutput struct {
+	DefaultDomain                string `json:"default_domain"`
+	EnvironmentID                string `json:"environment_id"`
+	EnvironmentName              string `json:"environment_name"`
+	Location                     string `json:"location"`
+	LogAnalyticsWorkspaceID      string `json:"log_analytics_workspace_id"`
+	PlatformReservedDNSIPAddress string `json:"platform_reserved_dns_ip_address"`
+	ResourceGroupName            string `json:"resource_group_name"`
+	StaticIPAddress              string `json:"static_ip_address"`
+}
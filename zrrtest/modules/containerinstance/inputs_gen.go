@@ -0,0 +1,225 @@
+// Code generated by zrr-gen from azure/application/container-instance/variables.tf. DO NOT EDIT.
+
+// Package containerinstance holds the typed inputs and outputs of azure/application/container-instance, for its tests.
+package containerinstance
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/application/container-instance. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Azure Monitor Action Group ID for alerts.
+	ActionGroupID *string `tf:"action_group_id"`
+
+	// Additional image registries for authentication.
+	AdditionalImageRegistries []AdditionalImageRegistry `tf:"additional_image_registries"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Additional tags specific to the container instance.
+	ContainerInstanceTags map[string]string `tf:"container_instance_tags"`
+
+	// Name of the Azure Container Registry.
+	ContainerRegistryName *string `tf:"container_registry_name"`
+
+	// Password for container registry authentication.
+	ContainerRegistryPassword *string `tf:"container_registry_password"`
+
+	// Resource group name of the Azure Container Registry.
+	ContainerRegistryResourceGroup *string `tf:"container_registry_resource_group"`
+
+	// Username for container registry authentication.
+	ContainerRegistryUsername *string `tf:"container_registry_username"`
+
+	// List of containers to run in the container group.
+	Containers []Container `tf:"containers"`
+
+	// CPU usage alert threshold (percentage).
+	CPUAlertThreshold *float64 `tf:"cpu_alert_threshold"`
+
+	// DNS configuration for the container group.
+	DNSConfig *DNSConfig `tf:"dns_config"`
+
+	// DNS name label for the container group.
+	DNSNameLabel *string `tf:"dns_name_label"`
+
+	// Enable automatic DNS name generation.
+	EnableDNSNameGeneration *bool `tf:"enable_dns_name_generation"`
+
+	// Enable container monitoring and alerting.
+	EnableMonitoring *bool `tf:"enable_monitoring"`
+
+	// Environment name for naming convention.
+	Environment *string `tf:"environment"`
+
+	// List of ports to expose for public access.
+	ExposedPorts []ExposedPort `tf:"exposed_ports"`
+
+	// IP address type for the container group.
+	IPAddressType *string `tf:"ip_address_type"`
+
+	// Azure region for the container instance.
+	Location string `tf:"location"`
+
+	// Short location code for naming convention.
+	LocationShort *string `tf:"location_short"`
+
+	// SKU for Log Analytics workspace.
+	LogAnalyticsSKU *string `tf:"log_analytics_sku"`
+
+	// Log retention period in days.
+	LogRetentionDays *float64 `tf:"log_retention_days"`
+
+	// Managed identity configuration for the container group.
+	ManagedIdentity *ManagedIdentity `tf:"managed_identity"`
+
+	// Memory usage alert threshold (percentage).
+	MemoryAlertThreshold *float64 `tf:"memory_alert_threshold"`
+
+	// Name of the container instance group.
+	Name string `tf:"name"`
+
+	// Operating system type for the container group.
+	OSType *string `tf:"os_type"`
+
+	// Resource ID of an existing resource group (takes precedence over resource_group_name).
+	ResourceGroupID *string `tf:"resource_group_id"`
+
+	// Name of the resource group where the container instance will be created.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// Restart policy for the container group.
+	RestartPolicy *string `tf:"restart_policy"`
+
+	// Subnet ID for private container deployment.
+	SubnetID *string `tf:"subnet_id"`
+
+	// Use ZRR naming convention for container instance name.
+	UseNamingConvention *bool `tf:"use_naming_convention"`
+
+	// List of volumes to mount in the container group.
+	Volumes []Volume `tf:"volumes"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AdditionalImageRegistry is an object of the module's inputs.
+type AdditionalImageRegistry struct {
+	Password string `tf:"password"`
+	Server   string `tf:"server"`
+	Username string `tf:"username"`
+}
+
+// Container is an object of the module's inputs.
+type Container struct {
+	Commands                   []string                 `tf:"commands"`
+	CPU                        float64                  `tf:"cpu"`
+	EnvironmentVariables       map[string]string        `tf:"environment_variables"`
+	GPU                        *ContainerGPU            `tf:"gpu"`
+	Image                      string                   `tf:"image"`
+	LivenessProbe              *ContainerLivenessProbe  `tf:"liveness_probe"`
+	Memory                     float64                  `tf:"memory"`
+	Name                       string                   `tf:"name"`
+	Ports                      []ContainerPort          `tf:"ports"`
+	ReadinessProbe             *ContainerReadinessProbe `tf:"readiness_probe"`
+	SecureEnvironmentVariables map[string]string        `tf:"secure_environment_variables"`
+	VolumeMounts               []ContainerVolumeMount   `tf:"volume_mounts"`
+}
+
+// ContainerGPU is an object of the module's inputs.
+type ContainerGPU struct {
+	Count float64 `tf:"count"`
+	SKU   string  `tf:"sku"`
+}
+
+// ContainerLivenessProbe is an object of the module's inputs.
+type ContainerLivenessProbe struct {
+	Exec                []string                        `tf:"exec"`
+	FailureThreshold    *float64                        `tf:"failure_threshold"`
+	HTTPGet             []ContainerLivenessProbeHTTPGet `tf:"http_get"`
+	InitialDelaySeconds *float64                        `tf:"initial_delay_seconds"`
+	PeriodSeconds       *float64                        `tf:"period_seconds"`
+	SuccessThreshold    *float64                        `tf:"success_threshold"`
+	TimeoutSeconds      *float64                        `tf:"timeout_seconds"`
+}
+
+// ContainerLivenessProbeHTTPGet is an object of the module's inputs.
+type ContainerLivenessProbeHTTPGet struct {
+	Path   *string `tf:"path"`
+	Port   float64 `tf:"port"`
+	Scheme *string `tf:"scheme"`
+}
+
+// ContainerPort is an object of the module's inputs.
+type ContainerPort struct {
+	Port     float64 `tf:"port"`
+	Protocol *string `tf:"protocol"`
+}
+
+// ContainerReadinessProbe is an object of the module's inputs.
+type ContainerReadinessProbe struct {
+	Exec                []string                         `tf:"exec"`
+	FailureThreshold    *float64                         `tf:"failure_threshold"`
+	HTTPGet             []ContainerReadinessProbeHTTPGet `tf:"http_get"`
+	InitialDelaySeconds *float64                         `tf:"initial_delay_seconds"`
+	PeriodSeconds       *float64                         `tf:"period_seconds"`
+	SuccessThreshold    *float64                         `tf:"success_threshold"`
+	TimeoutSeconds      *float64                         `tf:"timeout_seconds"`
+}
+
+// ContainerReadinessProbeHTTPGet is an object of the module's inputs.
+type ContainerReadinessProbeHTTPGet struct {
+	Path   *string `tf:"path"`
+	Port   float64 `tf:"port"`
+	Scheme *string `tf:"scheme"`
+}
+
+// ContainerVolumeMount is an object of the module's inputs.
+type ContainerVolumeMount struct {
+	MountPath string `tf:"mount_path"`
+	Name      string `tf:"name"`
+	ReadOnly  *bool  `tf:"read_only"`
+}
+
+// DNSConfig is an object of the module's inputs.
+type DNSConfig struct {
+	Nameservers   []string `tf:"nameservers"`
+	Options       []string `tf:"options"`
+	SearchDomains []string `tf:"search_domains"`
+}
+
+// ExposedPort is an object of the module's inputs.
+type ExposedPort struct {
+	Port     float64 `tf:"port"`
+	Protocol string  `tf:"protocol"`
+}
+
+// ManagedIdentity is an object of the module's inputs.
+type ManagedIdentity struct {
+	IdentityIDs []string `tf:"identity_ids"`
+	Type        string   `tf:"type"`
+}
+
+// Volume is an object of the module's inputs.
+type Volume struct {
+	EmptyDir           *bool             `tf:"empty_dir"`
+	GitRepo            *VolumeGitRepo    `tf:"git_repo"`
+	MountPath          *string           `tf:"mount_path"`
+	Name               string            `tf:"name"`
+	ReadOnly           *bool             `tf:"read_only"`
+	Secret             map[string]string `tf:"secret"`
+	ShareName          *string           `tf:"share_name"`
+	StorageAccountKey  *string           `tf:"storage_account_key"`
+	StorageAccountName *string           `tf:"storage_account_name"`
+}
+
+// VolumeGitRepo is an object of the module's inputs.
+type VolumeGitRepo struct {
+	Directory *string `tf:"directory"`
+	Revision  *string `tf:"revision"`
+	URL       string  `tf:"url"`
+}
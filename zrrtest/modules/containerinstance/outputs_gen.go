@@ -0,0 +1,431 @@
+// Code generated by zrr-gen from azure/application/container-instance/outputs.tf. DO NOT EDIT.
+
+package containerinstance
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/application/container-instance once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AdditionalRegistriesCount returns the additional_registries_count output:
+// Number of additional image registries configured.
+//
+// The output is sensitive.
+func (o Outputs) AdditionalRegistriesCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "additional_registries_count", &value)
+	return value
+}
+
+// AlertThresholds returns the alert_thresholds output: Configured alert
+// thresholds.
+func (o Outputs) AlertThresholds() AlertThresholdsOutput {
+	var value AlertThresholdsOutput
+	terraform.OutputStruct(o.t, o.options, "alert_thresholds", &value)
+	return value
+}
+
+// ContainerCount returns the container_count output: Number of containers in
+// the group.
+func (o Outputs) ContainerCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "container_count", &value)
+	return value
+}
+
+// ContainerGroupSummary returns the container_group_summary output:
+// Comprehensive summary of the container group configuration.
+func (o Outputs) ContainerGroupSummary() ContainerGroupSummaryOutput {
+	var value ContainerGroupSummaryOutput
+	terraform.OutputStruct(o.t, o.options, "container_group_summary", &value)
+	return value
+}
+
+// ContainerNameDetails returns the container_name_details output: Container
+// group naming details.
+func (o Outputs) ContainerNameDetails() ContainerNameDetailsOutput {
+	var value ContainerNameDetailsOutput
+	terraform.OutputStruct(o.t, o.options, "container_name_details", &value)
+	return value
+}
+
+// ContainerRegistryConfigured returns the container_registry_configured output:
+// Whether a container registry is configured.
+func (o Outputs) ContainerRegistryConfigured() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "container_registry_configured", &value)
+	return value
+}
+
+// ContainerRegistryServer returns the container_registry_server output:
+// Container registry server URL.
+func (o Outputs) ContainerRegistryServer() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "container_registry_server", &value)
+	return value
+}
+
+// Containers returns the containers output: Information about containers in the
+// group.
+func (o Outputs) Containers() map[string]ContainerOutput {
+	var value map[string]ContainerOutput
+	terraform.OutputStruct(o.t, o.options, "containers", &value)
+	return value
+}
+
+// ContainersWithLivenessProbes returns the containers_with_liveness_probes
+// output: Number of containers with liveness probes.
+func (o Outputs) ContainersWithLivenessProbes() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "containers_with_liveness_probes", &value)
+	return value
+}
+
+// ContainersWithReadinessProbes returns the containers_with_readiness_probes
+// output: Number of containers with readiness probes.
+func (o Outputs) ContainersWithReadinessProbes() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "containers_with_readiness_probes", &value)
+	return value
+}
+
+// CPUAlertID returns the cpu_alert_id output: ID of the CPU metric alert.
+func (o Outputs) CPUAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "cpu_alert_id", &value)
+	return value
+}
+
+// DNSConfig returns the dns_config output: DNS configuration details.
+func (o Outputs) DNSConfig() *DNSConfigOutput {
+	var value *DNSConfigOutput
+	terraform.OutputStruct(o.t, o.options, "dns_config", &value)
+	return value
+}
+
+// DNSNameLabel returns the dns_name_label output: DNS name label of the
+// container group.
+func (o Outputs) DNSNameLabel() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "dns_name_label", &value)
+	return value
+}
+
+// EnvironmentVariablesCount returns the environment_variables_count output:
+// Total number of environment variables across all containers.
+func (o Outputs) EnvironmentVariablesCount() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "environment_variables_count", &value)
+	return value
+}
+
+// ExposedPorts returns the exposed_ports output: List of exposed ports.
+func (o Outputs) ExposedPorts() []ExposedPortOutput {
+	var value []ExposedPortOutput
+	terraform.OutputStruct(o.t, o.options, "exposed_ports", &value)
+	return value
+}
+
+// FQDN returns the fqdn output: Fully qualified domain name of the container
+// group.
+func (o Outputs) FQDN() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "fqdn", &value)
+	return value
+}
+
+// GPUEnabledContainers returns the gpu_enabled_containers output: Number of
+// containers with GPU resources.
+func (o Outputs) GPUEnabledContainers() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "gpu_enabled_containers", &value)
+	return value
+}
+
+// HasManagedIdentity returns the has_managed_identity output: Whether managed
+// identity is configured.
+func (o Outputs) HasManagedIdentity() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "has_managed_identity", &value)
+	return value
+}
+
+// ID returns the id output: ID of the container group.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// Identity returns the identity output: Managed identity information.
+func (o Outputs) Identity() *IdentityOutput {
+	var value *IdentityOutput
+	terraform.OutputStruct(o.t, o.options, "identity", &value)
+	return value
+}
+
+// IPAddress returns the ip_address output: IP address of the container group.
+func (o Outputs) IPAddress() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "ip_address", &value)
+	return value
+}
+
+// IPAddressType returns the ip_address_type output: IP address type of the
+// container group.
+func (o Outputs) IPAddressType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "ip_address_type", &value)
+	return value
+}
+
+// Location returns the location output: Location of the container group.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// LogAnalyticsWorkspaceID returns the log_analytics_workspace_id output: Log
+// Analytics workspace ID.
+func (o Outputs) LogAnalyticsWorkspaceID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "log_analytics_workspace_id", &value)
+	return value
+}
+
+// LogAnalyticsWorkspaceKey returns the log_analytics_workspace_key output: Log
+// Analytics workspace key.
+//
+// The output is sensitive.
+func (o Outputs) LogAnalyticsWorkspaceKey() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "log_analytics_workspace_key", &value)
+	return value
+}
+
+// MemoryAlertID returns the memory_alert_id output: ID of the memory metric
+// alert.
+func (o Outputs) MemoryAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "memory_alert_id", &value)
+	return value
+}
+
+// MonitoringEnabled returns the monitoring_enabled output: Whether monitoring
+// is enabled.
+func (o Outputs) MonitoringEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "monitoring_enabled", &value)
+	return value
+}
+
+// Name returns the name output: Name of the container group.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// NamingConventionUsed returns the naming_convention_used output: Whether
+// naming convention was used.
+func (o Outputs) NamingConventionUsed() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "naming_convention_used", &value)
+	return value
+}
+
+// OSType returns the os_type output: Operating system type of the container
+// group.
+func (o Outputs) OSType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "os_type", &value)
+	return value
+}
+
+// PrimaryContainerName returns the primary_container_name output: Name of the
+// primary (first) container.
+func (o Outputs) PrimaryContainerName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_container_name", &value)
+	return value
+}
+
+// PrivateDeployment returns the private_deployment output: Whether this is a
+// private deployment.
+func (o Outputs) PrivateDeployment() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "private_deployment", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Resource group name
+// containing the container group.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// RestartPolicy returns the restart_policy output: Restart policy of the
+// container group.
+func (o Outputs) RestartPolicy() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "restart_policy", &value)
+	return value
+}
+
+// SecureEnvironmentVariablesCount returns the
+// secure_environment_variables_count output: Total number of secure environment
+// variables across all containers.
+//
+// The output is sensitive.
+func (o Outputs) SecureEnvironmentVariablesCount() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "secure_environment_variables_count", &value)
+	return value
+}
+
+// SubnetID returns the subnet_id output: Subnet ID for private deployment.
+func (o Outputs) SubnetID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "subnet_id", &value)
+	return value
+}
+
+// SubnetIDs returns the subnet_ids output: Subnet IDs assigned to the container
+// group.
+func (o Outputs) SubnetIDs() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "subnet_ids", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the container group.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// TotalCPUAllocation returns the total_cpu_allocation output: Total CPU
+// allocation across all containers.
+func (o Outputs) TotalCPUAllocation() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "total_cpu_allocation", &value)
+	return value
+}
+
+// TotalMemoryAllocation returns the total_memory_allocation output: Total
+// memory allocation across all containers (GB).
+func (o Outputs) TotalMemoryAllocation() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "total_memory_allocation", &value)
+	return value
+}
+
+// VolumeCount returns the volume_count output: Number of volumes configured.
+func (o Outputs) VolumeCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "volume_count", &value)
+	return value
+}
+
+// VolumesConfigured returns the volumes_configured output: Information about
+// configured volumes.
+func (o Outputs) VolumesConfigured() map[string]VolumesConfiguredOutput {
+	var value map[string]VolumesConfiguredOutput
+	terraform.OutputStruct(o.t, o.options, "volumes_configured", &value)
+	return value
+}
+
+// AlertThresholdsOutput is an object of the module's outputs.
+type AlertThresholdsOutput struct {
+	CPUThreshold    float64 `json:"cpu_threshold"`
+	MemoryThreshold float64 `json:"memory_threshold"`
+}
+
+// ContainerGroupSummaryOutput is an object of the module's outputs.
+type ContainerGroupSummaryOutput struct {
+	ContainerCount     int     `json:"container_count"`
+	FQDN               string  `json:"fqdn"`
+	HasManagedIdentity bool    `json:"has_managed_identity"`
+	IPAddress          string  `json:"ip_address"`
+	Location           string  `json:"location"`
+	MonitoringEnabled  bool    `json:"monitoring_enabled"`
+	Name               string  `json:"name"`
+	OSType             string  `json:"os_type"`
+	PrivateDeployment  bool    `json:"private_deployment"`
+	RegistryConfigured bool    `json:"registry_configured"`
+	ResourceGroup      string  `json:"resource_group"`
+	RestartPolicy      string  `json:"restart_policy"`
+	TotalCPU           float64 `json:"total_cpu"`
+	TotalMemory        float64 `json:"total_memory"`
+	VolumeCount        int     `json:"volume_count"`
+}
+
+// ContainerNameDetailsOutput is an object of the module's outputs.
+type ContainerNameDetailsOutput struct {
+	Environment      string `json:"environment"`
+	FinalName        string `json:"final_name"`
+	LocationShort    string `json:"location_short"`
+	NamingConvention bool   `json:"naming_convention"`
+	OriginalName     string `json:"original_name"`
+}
+
+// ContainerOutput is an object of the module's outputs.
+type ContainerOutput struct {
+	CPU    float64               `json:"cpu"`
+	Image  string                `json:"image"`
+	Memory float64               `json:"memory"`
+	Ports  []ContainerPortOutput `json:"ports"`
+}
+
+// ContainerPortOutput is an object of the module's outputs.
+type ContainerPortOutput struct {
+	Port     float64 `json:"port"`
+	Protocol *string `json:"protocol"`
+}
+
+// DNSConfigOutput is an object of the module's outputs.
+type DNSConfigOutput struct {
+	Nameservers   []string `json:"nameservers"`
+	Options       []string `json:"options"`
+	SearchDomains []string `json:"search_domains"`
+}
+
+// ExposedPortOutput is an object of the module's outputs.
+type ExposedPortOutput struct {
+	Port     float64 `json:"port"`
+	Protocol string  `json:"protocol"`
+}
+
+// IdentityOutput is an object of the module's outputs.
+type IdentityOutput struct {
+	IdentityIDs string `json:"identity_ids"`
+	PrincipalID string `json:"principal_id"`
+	TenantID    string `json:"tenant_id"`
+	Type        string `json:"type"`
+}
+
+// VolumesConfiguredOutput is an object of the module's outputs.
+type VolumesConfiguredOutput struct {
+	EmptyDir           *bool   `json:"empty_dir"`
+	HasGitRepo         bool    `json:"has_git_repo"`
+	HasSecrets         bool    `json:"has_secrets"`
+	MountPath          *string `json:"mount_path"`
+	ReadOnly           *bool   `json:"read_only"`
+	ShareName          *string `json:"share_name"`
+	StorageAccountName *string `json:"storage_account_name"`
+}
@@ -0,0 +1,119 @@
+// Code generated by zrr-gen from azure/infrastructure/container-registry/variables.tf. DO NOT EDIT.
+
+// Package containerregistry holds the typed inputs and outputs of azure/infrastructure/container-registry, for its tests.
+package containerregistry
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/container-registry. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Additional tags specific to the container registry.
+	AcrTags map[string]string `tf:"acr_tags"`
+
+	// Enable admin user for the container registry.
+	AdminEnabled *bool `tf:"admin_enabled"`
+
+	// Common tags to apply to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Encryption configuration (Premium SKU only).
+	Encryption *Encryption `tf:"encryption"`
+
+	// Environment name (dev, test, stage, prod).
+	Environment *string `tf:"environment"`
+
+	// List of geo-replication configurations (Premium SKU only).
+	Georeplications []Georeplication `tf:"georeplications"`
+
+	// List of user assigned identity IDs.
+	IdentityIDs []string `tf:"identity_ids"`
+
+	// The type of identity to use (SystemAssigned, UserAssigned, SystemAssigned, UserAssigned).
+	IdentityType *string `tf:"identity_type"`
+
+	// Map of images to import from Docker Hub or other registries.
+	ImagesToImport map[string]ImagesToImport `tf:"images_to_import"`
+
+	// The Azure region where the container registry should be created.
+	Location *string `tf:"location"`
+
+	// The name of the container registry.
+	Name string `tf:"name"`
+
+	// Network rule set configuration (Premium SKU only).
+	NetworkRuleSet *NetworkRuleSet `tf:"network_rule_set"`
+
+	// Whether public network access is allowed for the container registry.
+	PublicNetworkAccessEnabled *bool `tf:"public_network_access_enabled"`
+
+	// The name of the resource group.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// Retention policy configuration (Premium SKU only).
+	RetentionPolicy *RetentionPolicy `tf:"retention_policy"`
+
+	// Map of scheduled tasks to import images.
+	ScheduledImportTasks map[string]ScheduledImportTask `tf:"scheduled_import_tasks"`
+
+	// The SKU of the container registry (Basic, Standard, Premium).
+	SKU *string `tf:"sku"`
+
+	// Trust policy configuration (Premium SKU only).
+	TrustPolicy *TrustPolicy `tf:"trust_policy"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// Encryption is an object of the module's inputs.
+type Encryption struct {
+	Enabled          bool   `tf:"enabled"`
+	IdentityClientID string `tf:"identity_client_id"`
+	KeyVaultKeyID    string `tf:"key_vault_key_id"`
+}
+
+// Georeplication is an object of the module's inputs.
+type Georeplication struct {
+	Location              string            `tf:"location"`
+	Tags                  map[string]string `tf:"tags"`
+	ZoneRedundancyEnabled *bool             `tf:"zone_redundancy_enabled"`
+}
+
+// ImagesToImport is an object of the module's inputs.
+type ImagesToImport struct {
+	Source string  `tf:"source"`
+	Target *string `tf:"target"`
+}
+
+// NetworkRuleSet is an object of the module's inputs.
+type NetworkRuleSet struct {
+	DefaultAction string                 `tf:"default_action"`
+	IPRules       []NetworkRuleSetIPRule `tf:"ip_rules"`
+}
+
+// NetworkRuleSetIPRule is an object of the module's inputs.
+type NetworkRuleSetIPRule struct {
+	Action  string `tf:"action"`
+	IPRange string `tf:"ip_range"`
+}
+
+// RetentionPolicy is an object of the module's inputs.
+type RetentionPolicy struct {
+	Days    float64 `tf:"days"`
+	Enabled bool    `tf:"enabled"`
+}
+
+// ScheduledImportTask is an object of the module's inputs.
+type ScheduledImportTask struct {
+	Schedule *string `tf:"schedule"`
+	Source   string  `tf:"source"`
+	Target   string  `tf:"target"`
+}
+
+// TrustPolicy is an object of the module's inputs.
+type TrustPolicy struct {
+	Enabled bool `tf:"enabled"`
+}
@@ -0,0 +1,103 @@
+// Code generated by zrr-gen from azure/infrastructure/container-registry/outputs.tf. DO NOT EDIT.
+
+package containerregistry
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/container-registry once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AdminPassword returns the admin_password output: The admin password of the
+// container registry.
+//
+// The output is sensitive.
+func (o Outputs) AdminPassword() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "admin_password", &value)
+	return value
+}
+
+// AdminUsername returns the admin_username output: The admin username of the
+// container registry.
+//
+// The output is sensitive.
+func (o Outputs) AdminUsername() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "admin_username", &value)
+	return value
+}
+
+// ID returns the id output: The ID of the container registry.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// Identity returns the identity output: The identity of the container registry.
+func (o Outputs) Identity() interface{} {
+	var value interface{}
+	terraform.OutputStruct(o.t, o.options, "identity", &value)
+	return value
+}
+
+// ImportedImages returns the imported_images output: Map of imported images.
+func (o Outputs) ImportedImages() map[string]ImportedImageOutput {
+	var value map[string]ImportedImageOutput
+	terraform.OutputStruct(o.t, o.options, "imported_images", &value)
+	return value
+}
+
+// Location returns the location output: The location of the container registry.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// LoginServer returns the login_server output: The login server of the
+// container registry.
+func (o Outputs) LoginServer() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "login_server", &value)
+	return value
+}
+
+// Name returns the name output: The name of the container registry.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: The resource group
+// name of the container registry.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// SKU returns the sku output: The SKU of the container registry.
+func (o Outputs) SKU() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "sku", &value)
+	return value
+}
+
+// ImportedImageOutput is an object of the module's outputs.
+type ImportedImageOutput struct {
+	Source string  `json:"source"`
+	Target *string `json:"target"`
+}
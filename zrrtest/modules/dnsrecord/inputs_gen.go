@@ -0,0 +1,122 @@
+// Code generated by zrr-gen from azure/infrastructure/dns-record/variables.tf. DO NOT EDIT.
+
+// Package dnsrecord holds the typed inputs and outputs of azure/infrastructure/dns-record, for its tests.
+package dnsrecord
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/dns-record. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Send alerts when DNS record is modified.
+	AlertOnChanges *bool `tf:"alert_on_changes"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// List of compliance requirements for the DNS record.
+	ComplianceRequirements []string `tf:"compliance_requirements"`
+
+	// Criticality level of the DNS record.
+	Criticality *string `tf:"criticality"`
+
+	// Default TTL to use when ttl is not specified.
+	DefaultTTL *float64 `tf:"default_ttl"`
+
+	// Additional tags specific to the DNS record.
+	DNSRecordTags map[string]string `tf:"dns_record_tags"`
+
+	// Resource ID of the public DNS zone.
+	DNSZoneID *string `tf:"dns_zone_id"`
+
+	// Name of the public DNS zone.
+	DNSZoneName *string `tf:"dns_zone_name"`
+
+	// Resource group name of the public DNS zone (required when using dns_zone_name).
+	DNSZoneResourceGroupName *string `tf:"dns_zone_resource_group_name"`
+
+	// Enable monitoring for DNS record changes and health.
+	EnableMonitoring *bool `tf:"enable_monitoring"`
+
+	// Environment name (used for validation and governance).
+	Environment *string `tf:"environment"`
+
+	// Enable health monitoring for the DNS record.
+	HealthCheckEnabled *bool `tf:"health_check_enabled"`
+
+	// List of MX record configurations (required for MX record type).
+	MxRecords []MxRecord `tf:"mx_records"`
+
+	// Name of the DNS record (use '@' for apex record).
+	Name string `tf:"name"`
+
+	// Name of the private DNS zone.
+	PrivateDNSZoneName *string `tf:"private_dns_zone_name"`
+
+	// Resource group name of the private DNS zone (required when using private_dns_zone_name).
+	PrivateDNSZoneResourceGroupName *string `tf:"private_dns_zone_resource_group_name"`
+
+	// Lifecycle management configuration for the DNS record.
+	RecordLifecycle *RecordLifecycle `tf:"record_lifecycle"`
+
+	// Type of DNS record to create.
+	RecordType string `tf:"record_type"`
+
+	// List of record values (format depends on record type).
+	Records []string `tf:"records"`
+
+	// Security configuration for the DNS record.
+	SecurityConfig *SecurityConfig `tf:"security_config"`
+
+	// List of SRV record configurations (required for SRV record type).
+	SrvRecords []SrvRecord `tf:"srv_records"`
+
+	// Time to Live (TTL) for the DNS record in seconds.
+	TTL *float64 `tf:"ttl"`
+
+	// Additional validation rules for record values.
+	ValidationRules *ValidationRules `tf:"validation_rules"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// MxRecord is an object of the module's inputs.
+type MxRecord struct {
+	Exchange   string  `tf:"exchange"`
+	Preference float64 `tf:"preference"`
+}
+
+// RecordLifecycle is an object of the module's inputs.
+type RecordLifecycle struct {
+	AutoDeleteAfterDays    *float64 `tf:"auto_delete_after_days"`
+	BackupEnabled          *bool    `tf:"backup_enabled"`
+	ChangeApprovalRequired *bool    `tf:"change_approval_required"`
+	ScheduledUpdates       *bool    `tf:"scheduled_updates"`
+}
+
+// SecurityConfig is an object of the module's inputs.
+type SecurityConfig struct {
+	AccessRestrictions  []string `tf:"access_restrictions"`
+	AuditLogging        *bool    `tf:"audit_logging"`
+	ChangeProtection    *bool    `tf:"change_protection"`
+	EncryptionInTransit *bool    `tf:"encryption_in_transit"`
+}
+
+// SrvRecord is an object of the module's inputs.
+type SrvRecord struct {
+	Port     float64 `tf:"port"`
+	Priority float64 `tf:"priority"`
+	Target   string  `tf:"target"`
+	Weight   float64 `tf:"weight"`
+}
+
+// ValidationRules is an object of the module's inputs.
+type ValidationRules struct {
+	AllowWildcardRecords *bool    `tf:"allow_wildcard_records"`
+	ForbiddenValues      []string `tf:"forbidden_values"`
+	MaxRecordCount       *float64 `tf:"max_record_count"`
+	StrictFormatChecking *bool    `tf:"strict_format_checking"`
+}
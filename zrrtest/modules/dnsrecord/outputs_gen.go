@@ -0,0 +1,287 @@
+// Code generated by zrr-gen from azure/infrastructure/dns-record/outputs.tf. DO NOT EDIT.
+
+package dnsrecord
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/dns-record once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AlertOnChanges returns the alert_on_changes output: Whether alerts are
+// enabled for record changes.
+func (o Outputs) AlertOnChanges() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "alert_on_changes", &value)
+	return value
+}
+
+// AppliedTags returns the applied_tags output: Tags applied to the DNS record.
+func (o Outputs) AppliedTags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "applied_tags", &value)
+	return value
+}
+
+// ComplianceRequirements returns the compliance_requirements output: Compliance
+// requirements for the DNS record.
+func (o Outputs) ComplianceRequirements() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "compliance_requirements", &value)
+	return value
+}
+
+// ComplianceStatus returns the compliance_status output: Compliance and
+// governance status.
+func (o Outputs) ComplianceStatus() ComplianceStatusOutput {
+	var value ComplianceStatusOutput
+	terraform.OutputStruct(o.t, o.options, "compliance_status", &value)
+	return value
+}
+
+// Criticality returns the criticality output: Criticality level of the DNS
+// record.
+func (o Outputs) Criticality() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "criticality", &value)
+	return value
+}
+
+// DNSZoneName returns the dns_zone_name output: Name of the DNS zone containing
+// the record.
+func (o Outputs) DNSZoneName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "dns_zone_name", &value)
+	return value
+}
+
+// DNSZoneType returns the dns_zone_type output: Type of DNS zone (public or
+// private).
+func (o Outputs) DNSZoneType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "dns_zone_type", &value)
+	return value
+}
+
+// Environment returns the environment output: Environment of the DNS record.
+func (o Outputs) Environment() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "environment", &value)
+	return value
+}
+
+// FQDN returns the fqdn output: Fully qualified domain name of the DNS record.
+func (o Outputs) FQDN() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "fqdn", &value)
+	return value
+}
+
+// HealthCheckEnabled returns the health_check_enabled output: Whether health
+// monitoring is enabled.
+func (o Outputs) HealthCheckEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "health_check_enabled", &value)
+	return value
+}
+
+// ID returns the id output: ID of the DNS record.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// LifecycleConfiguration returns the lifecycle_configuration output: Summary of
+// lifecycle configuration.
+func (o Outputs) LifecycleConfiguration() LifecycleConfigurationOutput {
+	var value LifecycleConfigurationOutput
+	terraform.OutputStruct(o.t, o.options, "lifecycle_configuration", &value)
+	return value
+}
+
+// MonitoringEnabled returns the monitoring_enabled output: Whether monitoring
+// is enabled for the DNS record.
+func (o Outputs) MonitoringEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "monitoring_enabled", &value)
+	return value
+}
+
+// MxRecords returns the mx_records output: MX record configurations (if
+// applicable).
+func (o Outputs) MxRecords() []MxRecordOutput {
+	var value []MxRecordOutput
+	terraform.OutputStruct(o.t, o.options, "mx_records", &value)
+	return value
+}
+
+// Name returns the name output: Name of the DNS record.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// NetworkInformation returns the network_information output: Network-related
+// information about the DNS record.
+func (o Outputs) NetworkInformation() NetworkInformationOutput {
+	var value NetworkInformationOutput
+	terraform.OutputStruct(o.t, o.options, "network_information", &value)
+	return value
+}
+
+// RecordManagement returns the record_management output: Summary of record
+// management capabilities.
+func (o Outputs) RecordManagement() RecordManagementOutput {
+	var value RecordManagementOutput
+	terraform.OutputStruct(o.t, o.options, "record_management", &value)
+	return value
+}
+
+// RecordType returns the record_type output: Type of the DNS record.
+func (o Outputs) RecordType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "record_type", &value)
+	return value
+}
+
+// Records returns the records output: Values of the DNS record.
+func (o Outputs) Records() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "records", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Resource group name
+// of the DNS zone.
+func (o Outputs) ResourceGroupName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// SecurityConfiguration returns the security_configuration output: Summary of
+// security configuration.
+func (o Outputs) SecurityConfiguration() SecurityConfigurationOutput {
+	var value SecurityConfigurationOutput
+	terraform.OutputStruct(o.t, o.options, "security_configuration", &value)
+	return value
+}
+
+// SrvRecords returns the srv_records output: SRV record configurations (if
+// applicable).
+func (o Outputs) SrvRecords() []SrvRecordOutput {
+	var value []SrvRecordOutput
+	terraform.OutputStruct(o.t, o.options, "srv_records", &value)
+	return value
+}
+
+// TTL returns the ttl output: TTL (Time to Live) of the DNS record.
+func (o Outputs) TTL() *float64 {
+	var value *float64
+	terraform.OutputStruct(o.t, o.options, "ttl", &value)
+	return value
+}
+
+// ValidationConfiguration returns the validation_configuration output: Summary
+// of validation configuration.
+func (o Outputs) ValidationConfiguration() ValidationConfigurationOutput {
+	var value ValidationConfigurationOutput
+	terraform.OutputStruct(o.t, o.options, "validation_configuration", &value)
+	return value
+}
+
+// ValidationStatus returns the validation_status output: Status of record
+// validation.
+func (o Outputs) ValidationStatus() ValidationStatusOutput {
+	var value ValidationStatusOutput
+	terraform.OutputStruct(o.t, o.options, "validation_status", &value)
+	return value
+}
+
+// ComplianceStatusOutput is an object of the module's outputs.
+type ComplianceStatusOutput struct {
+	AuditLoggingEnabled       *bool  `json:"audit_logging_enabled"`
+	ChangeProtectionEnabled   *bool  `json:"change_protection_enabled"`
+	ComplianceRequirementsMet bool   `json:"compliance_requirements_met"`
+	CriticalityLevel          string `json:"criticality_level"`
+	EnvironmentValidated      bool   `json:"environment_validated"`
+	MonitoringEnabled         bool   `json:"monitoring_enabled"`
+}
+
+// LifecycleConfigurationOutput is an object of the module's outputs.
+type LifecycleConfigurationOutput struct {
+	AutoDeleteAfterDays    *float64 `json:"auto_delete_after_days"`
+	BackupEnabled          *bool    `json:"backup_enabled"`
+	ChangeApprovalRequired *bool    `json:"change_approval_required"`
+	ScheduledUpdates       *bool    `json:"scheduled_updates"`
+}
+
+// MxRecordOutput is an object of the module's outputs.
+type MxRecordOutput struct {
+	Exchange   string  `json:"exchange"`
+	Preference float64 `json:"preference"`
+}
+
+// NetworkInformationOutput is an object of the module's outputs.
+type NetworkInformationOutput struct {
+	IsPrivateZone bool    `json:"is_private_zone"`
+	RecordFQDN    *string `json:"record_fqdn"`
+	RecordName    string  `json:"record_name"`
+	ResourceGroup *string `json:"resource_group"`
+	ZoneName      *string `json:"zone_name"`
+}
+
+// RecordManagementOutput is an object of the module's outputs.
+type RecordManagementOutput struct {
+	ApexRecord             bool     `json:"apex_record"`
+	RecordCount            int      `json:"record_count"`
+	RecordType             string   `json:"record_type"`
+	SupportsMultipleValues bool     `json:"supports_multiple_values"`
+	TTLConfigured          *float64 `json:"ttl_configured"`
+	ZoneType               string   `json:"zone_type"`
+}
+
+// SecurityConfigurationOutput is an object of the module's outputs.
+type SecurityConfigurationOutput struct {
+	AccessRestrictions  []string `json:"access_restrictions"`
+	AuditLogging        *bool    `json:"audit_logging"`
+	ChangeProtection    *bool    `json:"change_protection"`
+	EncryptionInTransit *bool    `json:"encryption_in_transit"`
+}
+
+// SrvRecordOutput is an object of the module's outputs.
+type SrvRecordOutput struct {
+	Port     float64 `json:"port"`
+	Priority float64 `json:"priority"`
+	Target   string  `json:"target"`
+	Weight   float64 `json:"weight"`
+}
+
+// ValidationConfigurationOutput is an object of the module's outputs.
+type ValidationConfigurationOutput struct {
+	AllowWildcardRecords *bool    `json:"allow_wildcard_records"`
+	ForbiddenValues      []string `json:"forbidden_values"`
+	MaxRecordCount       *float64 `json:"max_record_count"`
+	StrictFormatChecking *bool    `json:"strict_format_checking"`
+}
+
+// ValidationStatusOutput is an object of the module's outputs.
+type ValidationStatusOutput struct {
+	CnameCountValid     bool `json:"cname_count_valid"`
+	RecordTypeSupported bool `json:"record_type_supported"`
+	RecordValuesValid   bool `json:"record_values_valid"`
+	TTLValid            bool `json:"ttl_valid"`
+	ZoneReferenceValid  bool `json:"zone_reference_valid"`
+}
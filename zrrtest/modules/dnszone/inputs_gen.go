@@ -0,0 +1,176 @@
+// Code generated by zrr-gen from azure/infrastructure/dns-zone/variables.tf. DO NOT EDIT.
+
+// Package dnszone holds the typed inputs and outputs of azure/infrastructure/dns-zone, for its tests.
+package dnszone
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/dns-zone. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// List of A records to create in the DNS zone.
+	ARecords []ARecord `tf:"a_records"`
+
+	// List of AAAA records to create in the DNS zone.
+	AaaaRecords []AaaaRecord `tf:"aaaa_records"`
+
+	// Azure Monitor Action Group ID for DNS alerts.
+	ActionGroupID *string `tf:"action_group_id"`
+
+	// List of CNAME records to create in the DNS zone.
+	CnameRecords []CnameRecord `tf:"cname_records"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// TTL for delegation NS records.
+	DelegationTTL *float64 `tf:"delegation_ttl"`
+
+	// Additional tags specific to the DNS zone.
+	DNSZoneTags map[string]string `tf:"dns_zone_tags"`
+
+	// Domain suffix for naming convention.
+	DomainSuffix *string `tf:"domain_suffix"`
+
+	// Enable auto-registration of virtual machine records in the private DNS zone.
+	EnableAutoRegistration *bool `tf:"enable_auto_registration"`
+
+	// Enable DNS delegation by creating NS records in parent zone.
+	EnableDelegation *bool `tf:"enable_delegation"`
+
+	// Enable DNS zone monitoring and alerting.
+	EnableMonitoring *bool `tf:"enable_monitoring"`
+
+	// Enable DNSSEC zone signing (requires Azure DNS premium).
+	EnableZoneSigning *bool `tf:"enable_zone_signing"`
+
+	// Environment name for naming convention.
+	Environment *string `tf:"environment"`
+
+	// List of MX records to create in the DNS zone.
+	MxRecords []MxRecord `tf:"mx_records"`
+
+	// Name of the DNS zone (domain name or subdomain).
+	Name string `tf:"name"`
+
+	// Name of the parent DNS zone for delegation.
+	ParentZoneName *string `tf:"parent_zone_name"`
+
+	// Resource group name of the parent DNS zone (if different from current zone).
+	ParentZoneResourceGroupName *string `tf:"parent_zone_resource_group_name"`
+
+	// List of PTR records to create in the DNS zone.
+	PtrRecords []PtrRecord `tf:"ptr_records"`
+
+	// Threshold for DNS query volume alerts.
+	QueryVolumeThreshold *float64 `tf:"query_volume_threshold"`
+
+	// Threshold for DNS record set count alerts.
+	RecordSetCountThreshold *float64 `tf:"record_set_count_threshold"`
+
+	// Resource ID of an existing resource group (takes precedence over resource_group_name).
+	ResourceGroupID *string `tf:"resource_group_id"`
+
+	// Name of the resource group where the DNS zone will be created.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// Custom SOA record configuration.
+	SoaRecord *SoaRecord `tf:"soa_record"`
+
+	// List of SRV records to create in the DNS zone.
+	SrvRecords []SrvRecord `tf:"srv_records"`
+
+	// List of TXT records to create in the DNS zone.
+	TxtRecords []TxtRecord `tf:"txt_records"`
+
+	// Use ZRR naming convention for DNS zone name.
+	UseNamingConvention *bool `tf:"use_naming_convention"`
+
+	// Verify that parent zone exists before creating delegation.
+	VerifyDelegation *bool `tf:"verify_delegation"`
+
+	// Virtual Network ID to link with the private DNS zone.
+	VirtualNetworkID *string `tf:"virtual_network_id"`
+
+	// Frequency of zone signing key rollover in days.
+	ZoneSigningKeyRolloverFrequency *float64 `tf:"zone_signing_key_rollover_frequency"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// ARecord is an object of the module's inputs.
+type ARecord struct {
+	Name    string   `tf:"name"`
+	Records []string `tf:"records"`
+	TTL     float64  `tf:"ttl"`
+}
+
+// AaaaRecord is an object of the module's inputs.
+type AaaaRecord struct {
+	Name    string   `tf:"name"`
+	Records []string `tf:"records"`
+	TTL     float64  `tf:"ttl"`
+}
+
+// CnameRecord is an object of the module's inputs.
+type CnameRecord struct {
+	Name   string  `tf:"name"`
+	Record string  `tf:"record"`
+	TTL    float64 `tf:"ttl"`
+}
+
+// MxRecord is an object of the module's inputs.
+type MxRecord struct {
+	Name    string           `tf:"name"`
+	Records []MxRecordRecord `tf:"records"`
+	TTL     float64          `tf:"ttl"`
+}
+
+// MxRecordRecord is an object of the module's inputs.
+type MxRecordRecord struct {
+	Exchange   string  `tf:"exchange"`
+	Preference float64 `tf:"preference"`
+}
+
+// PtrRecord is an object of the module's inputs.
+type PtrRecord struct {
+	Name    string   `tf:"name"`
+	Records []string `tf:"records"`
+	TTL     float64  `tf:"ttl"`
+}
+
+// SoaRecord is an object of the module's inputs.
+type SoaRecord struct {
+	Email        *string  `tf:"email"`
+	ExpireTime   *float64 `tf:"expire_time"`
+	MinimumTTL   *float64 `tf:"minimum_ttl"`
+	RefreshTime  *float64 `tf:"refresh_time"`
+	RetryTime    *float64 `tf:"retry_time"`
+	SerialNumber *float64 `tf:"serial_number"`
+	TTL          *float64 `tf:"ttl"`
+}
+
+// SrvRecord is an object of the module's inputs.
+type SrvRecord struct {
+	Name    string            `tf:"name"`
+	Records []SrvRecordRecord `tf:"records"`
+	TTL     float64           `tf:"ttl"`
+}
+
+// SrvRecordRecord is an object of the module's inputs.
+type SrvRecordRecord struct {
+	Port     float64 `tf:"port"`
+	Priority float64 `tf:"priority"`
+	Target   string  `tf:"target"`
+	Weight   float64 `tf:"weight"`
+}
+
+// TxtRecord is an object of the module's inputs.
+type TxtRecord struct {
+	Name    string   `tf:"name"`
+	Records []string `tf:"records"`
+	TTL     float64  `tf:"ttl"`
+}
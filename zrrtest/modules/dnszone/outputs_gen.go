@@ -0,0 +1,368 @@
+// Code generated by zrr-gen from azure/infrastructure/dns-zone/outputs.tf. DO NOT EDIT.
+
+package dnszone
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/dns-zone once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// ARecords returns the a_records output: Information about created A records.
+func (o Outputs) ARecords() map[string]ARecordOutput {
+	var value map[string]ARecordOutput
+	terraform.OutputStruct(o.t, o.options, "a_records", &value)
+	return value
+}
+
+// AaaaRecords returns the aaaa_records output: Information about created AAAA
+// records.
+func (o Outputs) AaaaRecords() map[string]AaaaRecordOutput {
+	var value map[string]AaaaRecordOutput
+	terraform.OutputStruct(o.t, o.options, "aaaa_records", &value)
+	return value
+}
+
+// AlertThresholds returns the alert_thresholds output: Configured alert
+// thresholds.
+func (o Outputs) AlertThresholds() AlertThresholdsOutput {
+	var value AlertThresholdsOutput
+	terraform.OutputStruct(o.t, o.options, "alert_thresholds", &value)
+	return value
+}
+
+// AutoRegistrationEnabled returns the auto_registration_enabled output: Whether
+// auto-registration is enabled.
+func (o Outputs) AutoRegistrationEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "auto_registration_enabled", &value)
+	return value
+}
+
+// CnameRecords returns the cname_records output: Information about created
+// CNAME records.
+func (o Outputs) CnameRecords() map[string]CnameRecordOutput {
+	var value map[string]CnameRecordOutput
+	terraform.OutputStruct(o.t, o.options, "cname_records", &value)
+	return value
+}
+
+// DelegationEnabled returns the delegation_enabled output: Whether DNS
+// delegation is enabled.
+func (o Outputs) DelegationEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "delegation_enabled", &value)
+	return value
+}
+
+// DelegationNsRecordID returns the delegation_ns_record_id output: ID of the
+// delegation NS record in parent zone.
+func (o Outputs) DelegationNsRecordID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "delegation_ns_record_id", &value)
+	return value
+}
+
+// FQDN returns the fqdn output: Fully qualified domain name of the DNS zone.
+func (o Outputs) FQDN() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "fqdn", &value)
+	return value
+}
+
+// ID returns the id output: ID of the DNS zone.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// MonitoringEnabled returns the monitoring_enabled output: Whether monitoring
+// is enabled for the DNS zone.
+func (o Outputs) MonitoringEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "monitoring_enabled", &value)
+	return value
+}
+
+// MxRecords returns the mx_records output: Information about created MX
+// records.
+func (o Outputs) MxRecords() map[string]MxRecordOutput {
+	var value map[string]MxRecordOutput
+	terraform.OutputStruct(o.t, o.options, "mx_records", &value)
+	return value
+}
+
+// Name returns the name output: Name of the DNS zone.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// NameServers returns the name_servers output: List of name servers for the DNS
+// zone.
+func (o Outputs) NameServers() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "name_servers", &value)
+	return value
+}
+
+// NamingConventionUsed returns the naming_convention_used output: Whether
+// naming convention was used.
+func (o Outputs) NamingConventionUsed() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "naming_convention_used", &value)
+	return value
+}
+
+// ParentZoneName returns the parent_zone_name output: Parent zone name for
+// delegation.
+func (o Outputs) ParentZoneName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "parent_zone_name", &value)
+	return value
+}
+
+// PrimaryNameServer returns the primary_name_server output: Primary name server
+// for the DNS zone.
+func (o Outputs) PrimaryNameServer() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "primary_name_server", &value)
+	return value
+}
+
+// PtrRecords returns the ptr_records output: Information about created PTR
+// records.
+func (o Outputs) PtrRecords() map[string]PtrRecordOutput {
+	var value map[string]PtrRecordOutput
+	terraform.OutputStruct(o.t, o.options, "ptr_records", &value)
+	return value
+}
+
+// QueryVolumeAlertID returns the query_volume_alert_id output: ID of the query
+// volume metric alert.
+func (o Outputs) QueryVolumeAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "query_volume_alert_id", &value)
+	return value
+}
+
+// RecordCount returns the record_count output: Total number of DNS records
+// created.
+func (o Outputs) RecordCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "record_count", &value)
+	return value
+}
+
+// RecordCountAlertID returns the record_count_alert_id output: ID of the record
+// count metric alert.
+func (o Outputs) RecordCountAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "record_count_alert_id", &value)
+	return value
+}
+
+// RecordTypesSummary returns the record_types_summary output: Summary of record
+// types and counts.
+func (o Outputs) RecordTypesSummary() RecordTypesSummaryOutput {
+	var value RecordTypesSummaryOutput
+	terraform.OutputStruct(o.t, o.options, "record_types_summary", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Resource group name
+// containing the DNS zone.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// SrvRecords returns the srv_records output: Information about created SRV
+// records.
+func (o Outputs) SrvRecords() map[string]SrvRecordOutput {
+	var value map[string]SrvRecordOutput
+	terraform.OutputStruct(o.t, o.options, "srv_records", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the DNS zone.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// TxtRecords returns the txt_records output: Information about created TXT
+// records.
+func (o Outputs) TxtRecords() map[string]TxtRecordOutput {
+	var value map[string]TxtRecordOutput
+	terraform.OutputStruct(o.t, o.options, "txt_records", &value)
+	return value
+}
+
+// VnetLinkEnabled returns the vnet_link_enabled output: Whether virtual network
+// link is enabled.
+func (o Outputs) VnetLinkEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "vnet_link_enabled", &value)
+	return value
+}
+
+// VnetLinkID returns the vnet_link_id output: ID of the virtual network link.
+func (o Outputs) VnetLinkID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "vnet_link_id", &value)
+	return value
+}
+
+// ZoneName returns the zone_name output: DNS zone name (same as name output for
+// consistency).
+func (o Outputs) ZoneName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "zone_name", &value)
+	return value
+}
+
+// ZoneNameDetails returns the zone_name_details output: DNS zone naming
+// details.
+func (o Outputs) ZoneNameDetails() ZoneNameDetailsOutput {
+	var value ZoneNameDetailsOutput
+	terraform.OutputStruct(o.t, o.options, "zone_name_details", &value)
+	return value
+}
+
+// ZoneSigningEnabled returns the zone_signing_enabled output: Whether DNSSEC
+// zone signing is enabled.
+func (o Outputs) ZoneSigningEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "zone_signing_enabled", &value)
+	return value
+}
+
+// ZoneSigningKeyRolloverFrequency returns the
+// zone_signing_key_rollover_frequency output: Zone signing key rollover
+// frequency in days.
+func (o Outputs) ZoneSigningKeyRolloverFrequency() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "zone_signing_key_rollover_frequency", &value)
+	return value
+}
+
+// ZoneSummary returns the zone_summary output: Comprehensive summary of the DNS
+// zone configuration.
+func (o Outputs) ZoneSummary() ZoneSummaryOutput {
+	var value ZoneSummaryOutput
+	terraform.OutputStruct(o.t, o.options, "zone_summary", &value)
+	return value
+}
+
+// ARecordOutput is an object of the module's outputs.
+type ARecordOutput struct {
+	FQDN    string `json:"fqdn"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Records string `json:"records"`
+	TTL     string `json:"ttl"`
+}
+
+// AaaaRecordOutput is an object of the module's outputs.
+type AaaaRecordOutput struct {
+	FQDN    string `json:"fqdn"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Records string `json:"records"`
+	TTL     string `json:"ttl"`
+}
+
+// AlertThresholdsOutput is an object of the module's outputs.
+type AlertThresholdsOutput struct {
+	QueryVolume    float64 `json:"query_volume"`
+	RecordSetCount float64 `json:"record_set_count"`
+}
+
+// CnameRecordOutput is an object of the module's outputs.
+type CnameRecordOutput struct {
+	FQDN   string `json:"fqdn"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Record string `json:"record"`
+	TTL    string `json:"ttl"`
+}
+
+// MxRecordOutput is an object of the module's outputs.
+type MxRecordOutput struct {
+	FQDN string `json:"fqdn"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	TTL  string `json:"ttl"`
+}
+
+// PtrRecordOutput is an object of the module's outputs.
+type PtrRecordOutput struct {
+	FQDN    string `json:"fqdn"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Records string `json:"records"`
+	TTL     string `json:"ttl"`
+}
+
+// RecordTypesSummaryOutput is an object of the module's outputs.
+type RecordTypesSummaryOutput struct {
+	ARecords     int `json:"a_records"`
+	AaaaRecords  int `json:"aaaa_records"`
+	CnameRecords int `json:"cname_records"`
+	MxRecords    int `json:"mx_records"`
+	PtrRecords   int `json:"ptr_records"`
+	SrvRecords   int `json:"srv_records"`
+	TxtRecords   int `json:"txt_records"`
+}
+
+// SrvRecordOutput is an object of the module's outputs.
+type SrvRecordOutput struct {
+	FQDN string `json:"fqdn"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	TTL  string `json:"ttl"`
+}
+
+// TxtRecordOutput is an object of the module's outputs.
+type TxtRecordOutput struct {
+	FQDN string `json:"fqdn"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	TTL  string `json:"ttl"`
+}
+
+// ZoneNameDetailsOutput is an object of the module's outputs.
+type ZoneNameDetailsOutput struct {
+	DomainSuffix     string `json:"domain_suffix"`
+	Environment      string `json:"environment"`
+	FinalZoneName    string `json:"final_zone_name"`
+	NamingConvention bool   `json:"naming_convention"`
+	OriginalName     string `json:"original_name"`
+}
+
+// ZoneSummaryOutput is an object of the module's outputs.
+type ZoneSummaryOutput struct {
+	DelegationEnabled  bool     `json:"delegation_enabled"`
+	MonitoringEnabled  bool     `json:"monitoring_enabled"`
+	NameServers        []string `json:"name_servers"`
+	ResourceGroup      string   `json:"resource_group"`
+	TotalRecords       int      `json:"total_records"`
+	VnetLinked         bool     `json:"vnet_linked"`
+	ZoneName           string   `json:"zone_name"`
+	ZoneSigningEnabled bool     `json:"zone_signing_enabled"`
+}
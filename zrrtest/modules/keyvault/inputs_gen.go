@@ -0,0 +1,132 @@
+// Code generated by zrr-gen from azure/security/key-vault/variables.tf. DO NOT EDIT.
+
+// Package keyvault holds the typed inputs and outputs of azure/security/key-vault, for its tests.
+package keyvault
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/security/key-vault. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Map of access policies for the Key Vault.
+	AccessPolicies map[string]AccessPolicy `tf:"access_policies"`
+
+	// List of certificate contacts for the Key Vault.
+	CertificateContacts []CertificateContact `tf:"certificate_contacts"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Diagnostic setting configuration for the Key Vault.
+	DiagnosticSetting *DiagnosticSetting `tf:"diagnostic_setting"`
+
+	// Boolean flag to specify whether Azure Key Vault uses Role Based Access Control (RBAC) for authorization of data actions.
+	EnableRBACAuthorization *bool `tf:"enable_rbac_authorization"`
+
+	// Boolean flag to specify whether Azure Virtual Machines are permitted to retrieve certificates stored as secrets from the key vault.
+	EnabledForDeployment *bool `tf:"enabled_for_deployment"`
+
+	// Boolean flag to specify whether Azure Disk Encryption is permitted to retrieve secrets from the vault and unwrap keys.
+	EnabledForDiskEncryption *bool `tf:"enabled_for_disk_encryption"`
+
+	// Boolean flag to specify whether Azure Resource Manager is permitted to retrieve secrets from the key vault.
+	EnabledForTemplateDeployment *bool `tf:"enabled_for_template_deployment"`
+
+	// Additional tags specific to the Key Vault.
+	KeyVaultTags map[string]string `tf:"key_vault_tags"`
+
+	// Map of keys to create in the Key Vault.
+	Keys map[string]Key `tf:"keys"`
+
+	// Azure region where the Key Vault will be created.
+	Location *string `tf:"location"`
+
+	// Name of the Key Vault.
+	Name string `tf:"name"`
+
+	// Network ACLs configuration for the Key Vault.
+	NetworkACLs *NetworkACLs `tf:"network_acls"`
+
+	// Private endpoint configuration for the Key Vault.
+	PrivateEndpoint *PrivateEndpoint `tf:"private_endpoint"`
+
+	// Whether public network access is allowed for this Key Vault.
+	PublicNetworkAccessEnabled *bool `tf:"public_network_access_enabled"`
+
+	// Is Purge Protection enabled for this Key Vault?.
+	PurgeProtectionEnabled *bool `tf:"purge_protection_enabled"`
+
+	// Name of the resource group. If not provided, a new resource group will be created.
+	ResourceGroupName *string `tf:"resource_group_name"`
+
+	// Map of secrets to create in the Key Vault.
+	Secrets map[string]Secret `tf:"secrets"`
+
+	// The Name of the SKU used for this Key Vault. Possible values are standard and premium.
+	SKUName *string `tf:"sku_name"`
+
+	// The number of days that items should be retained for once soft-deleted. This value can be between 7 and 90 days.
+	SoftDeleteRetentionDays *float64 `tf:"soft_delete_retention_days"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AccessPolicy is an object of the module's inputs.
+type AccessPolicy struct {
+	CertificatePermissions []string `tf:"certificate_permissions"`
+	KeyPermissions         []string `tf:"key_permissions"`
+	ObjectID               string   `tf:"object_id"`
+	SecretPermissions      []string `tf:"secret_permissions"`
+}
+
+// CertificateContact is an object of the module's inputs.
+type CertificateContact struct {
+	Email string  `tf:"email"`
+	Name  *string `tf:"name"`
+	Phone *string `tf:"phone"`
+}
+
+// DiagnosticSetting is an object of the module's inputs.
+type DiagnosticSetting struct {
+	LogAnalyticsWorkspaceID *string  `tf:"log_analytics_workspace_id"`
+	LogCategories           []string `tf:"log_categories"`
+	MetricCategories        []string `tf:"metric_categories"`
+	StorageAccountID        *string  `tf:"storage_account_id"`
+}
+
+// Key is an object of the module's inputs.
+type Key struct {
+	Curve          *string           `tf:"curve"`
+	ExpirationDate *string           `tf:"expiration_date"`
+	KeyOpts        []string          `tf:"key_opts"`
+	KeySize        *float64          `tf:"key_size"`
+	KeyType        string            `tf:"key_type"`
+	NotBeforeDate  *string           `tf:"not_before_date"`
+	Tags           map[string]string `tf:"tags"`
+}
+
+// NetworkACLs is an object of the module's inputs.
+type NetworkACLs struct {
+	Bypass                  string   `tf:"bypass"`
+	DefaultAction           string   `tf:"default_action"`
+	IPRules                 []string `tf:"ip_rules"`
+	VirtualNetworkSubnetIDs []string `tf:"virtual_network_subnet_ids"`
+}
+
+// PrivateEndpoint is an object of the module's inputs.
+type PrivateEndpoint struct {
+	PrivateDNSZoneIDs []string `tf:"private_dns_zone_ids"`
+	SubnetID          string   `tf:"subnet_id"`
+}
+
+// Secret is an object of the module's inputs.
+type Secret struct {
+	ContentType    *string           `tf:"content_type"`
+	ExpirationDate *string           `tf:"expiration_date"`
+	NotBeforeDate  *string           `tf:"not_before_date"`
+	Tags           map[string]string `tf:"tags"`
+	Value          string            `tf:"value"`
+}
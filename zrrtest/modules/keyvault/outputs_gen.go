@@ -0,0 +1,153 @@
+// Code generated by zrr-gen from azure/security/key-vault/outputs.tf. DO NOT EDIT.
+
+package keyvault
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/security/key-vault once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AccessPolicyObjectIDs returns the access_policy_object_ids output: List of
+// object IDs that have access policies configured.
+func (o Outputs) AccessPolicyObjectIDs() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "access_policy_object_ids", &value)
+	return value
+}
+
+// DiagnosticSettingID returns the diagnostic_setting_id output: ID of the
+// diagnostic setting (if created).
+func (o Outputs) DiagnosticSettingID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "diagnostic_setting_id", &value)
+	return value
+}
+
+// ID returns the id output: ID of the Key Vault.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// KeyIDs returns the key_ids output: Map of key names to their IDs.
+func (o Outputs) KeyIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "key_ids", &value)
+	return value
+}
+
+// KeyVersionIDs returns the key_version_ids output: Map of key names to their
+// version IDs.
+func (o Outputs) KeyVersionIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "key_version_ids", &value)
+	return value
+}
+
+// KeyVersions returns the key_versions output: Map of key names to their
+// versions.
+func (o Outputs) KeyVersions() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "key_versions", &value)
+	return value
+}
+
+// Location returns the location output: Location of the Key Vault.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// Name returns the name output: Name of the Key Vault.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// PrivateEndpointID returns the private_endpoint_id output: ID of the private
+// endpoint (if created).
+func (o Outputs) PrivateEndpointID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "private_endpoint_id", &value)
+	return value
+}
+
+// PrivateEndpointIPAddress returns the private_endpoint_ip_address output:
+// Private IP address of the private endpoint (if created).
+func (o Outputs) PrivateEndpointIPAddress() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "private_endpoint_ip_address", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Name of the
+// resource group containing the Key Vault.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// SecretIDs returns the secret_ids output: Map of secret names to their IDs.
+//
+// The output is sensitive.
+func (o Outputs) SecretIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "secret_ids", &value)
+	return value
+}
+
+// SecretVersionIDs returns the secret_version_ids output: Map of secret names
+// to their version IDs.
+//
+// The output is sensitive.
+func (o Outputs) SecretVersionIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "secret_version_ids", &value)
+	return value
+}
+
+// SecretVersions returns the secret_versions output: Map of secret names to
+// their versions.
+//
+// The output is sensitive.
+func (o Outputs) SecretVersions() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "secret_versions", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the Key Vault.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// TenantID returns the tenant_id output: Tenant ID of the Key Vault.
+func (o Outputs) TenantID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "tenant_id", &value)
+	return value
+}
+
+// URI returns the uri output: URI of the Key Vault.
+func (o Outputs) URI() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "uri", &value)
+	return value
+}
@@ -0,0 +1,45 @@
+// Code generated by zrr-gen from azure/security/key-vault-secret/variables.tf. DO NOT EDIT.
+
+// Package keyvaultsecret holds the typed inputs and outputs of azure/security/key-vault-secret, for its tests.
+package keyvaultsecret
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/security/key-vault-secret. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Specifies the content type for the key vault secret.
+	ContentType *string `tf:"content_type"`
+
+	// Expiration UTC datetime (Y-m-d'T'H:M:S'Z').
+	ExpirationDate *string `tf:"expiration_date"`
+
+	// ID of the key vault to store the secret in.
+	KeyVaultID *string `tf:"key_vault_id"`
+
+	// Name of the key vault to store the secret in.
+	KeyVaultName *string `tf:"key_vault_name"`
+
+	// Resource group name of the key vault (required when using key_vault_name).
+	KeyVaultResourceGroupName *string `tf:"key_vault_resource_group_name"`
+
+	// Additional tags specific to the key vault secret.
+	KeyVaultSecretTags map[string]string `tf:"key_vault_secret_tags"`
+
+	// Name of the key vault secret.
+	Name string `tf:"name"`
+
+	// Key not usable before the provided UTC datetime (Y-m-d'T'H:M:S'Z').
+	NotBeforeDate *string `tf:"not_before_date"`
+
+	// The value of the key vault secret.
+	Value string `tf:"value"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
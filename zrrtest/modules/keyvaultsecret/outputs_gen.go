@@ -0,0 +1,80 @@
+// Code generated by zrr-gen from azure/security/key-vault-secret/outputs.tf. DO NOT EDIT.
+
+package keyvaultsecret
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/security/key-vault-secret once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// ID returns the id output: ID of the key vault secret.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// KeyVaultID returns the key_vault_id output: ID of the key vault containing
+// the secret.
+func (o Outputs) KeyVaultID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "key_vault_id", &value)
+	return value
+}
+
+// Name returns the name output: Name of the key vault secret.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// ResourceID returns the resource_id output: The resource ID of the key vault
+// secret.
+func (o Outputs) ResourceID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_id", &value)
+	return value
+}
+
+// ResourceVersionlessID returns the resource_versionless_id output: The
+// versionless resource ID of the key vault secret.
+func (o Outputs) ResourceVersionlessID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_versionless_id", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the key vault secret.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// Version returns the version output: The current version of the key vault
+// secret.
+func (o Outputs) Version() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "version", &value)
+	return value
+}
+
+// VersionlessID returns the versionless_id output: The versionless ID of the
+// key vault secret.
+func (o Outputs) VersionlessID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "versionless_id", &value)
+	return value
+}
@@ -0,0 +1,18 @@
+// Code generated by zrr-gen from azure/shared/locations/variables.tf. DO NOT EDIT.
+
+// Package locations holds the typed inputs and outputs of azure/shared/locations, for its tests.
+package locations
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/shared/locations. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Azure region, either its canonical name (eastus) or its display name (East US).
+	Location string `tf:"location"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
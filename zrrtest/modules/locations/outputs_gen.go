@@ -0,0 +1,42 @@
+// Code generated by zrr-gen from azure/shared/locations/outputs.tf. DO NOT EDIT.
+
+package locations
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/shared/locations once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// DisplayName returns the display_name output: Display name of the location
+// (e.g., East US).
+func (o Outputs) DisplayName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "display_name", &value)
+	return value
+}
+
+// Name returns the name output: Canonical name of the location (e.g., eastus).
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// Short returns the short output: Naming-convention short code of the location
+// (e.g., eus).
+func (o Outputs) Short() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "short", &value)
+	return value
+}
@@ -0,0 +1,112 @@
+// Code generated by zrr-gen from azure/infrastructure/log-analytics-workspace/variables.tf. DO NOT EDIT.
+
+// Package loganalyticsworkspace holds the typed inputs and outputs of azure/infrastructure/log-analytics-workspace, for its tests.
+package loganalyticsworkspace
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/log-analytics-workspace. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Common tags to apply to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// The daily ingestion quota in GB. Set to -1 for no limit.
+	DailyQuotaGB *float64 `tf:"daily_quota_gb"`
+
+	// Map of data collection rules.
+	DataCollectionRules map[string]DataCollectionRule `tf:"data_collection_rules"`
+
+	// Environment name (dev, test, stage, prod).
+	Environment *string `tf:"environment"`
+
+	// List of user assigned identity IDs (required if identity_type is UserAssigned).
+	IdentityIDs []string `tf:"identity_ids"`
+
+	// The type of identity to use (SystemAssigned, UserAssigned).
+	IdentityType *string `tf:"identity_type"`
+
+	// Whether internet ingestion is enabled for the workspace.
+	InternetIngestionEnabled *bool `tf:"internet_ingestion_enabled"`
+
+	// Whether internet query is enabled for the workspace.
+	InternetQueryEnabled *bool `tf:"internet_query_enabled"`
+
+	// Whether local authentication is disabled for the workspace.
+	LocalAuthenticationDisabled *bool `tf:"local_authentication_disabled"`
+
+	// The Azure region where the Log Analytics workspace should be created.
+	Location *string `tf:"location"`
+
+	// The name of the Log Analytics workspace.
+	Name string `tf:"name"`
+
+	// The capacity reservation level in GB per day (100, 200, 300, 400, 500, 1000, 2000, 5000).
+	ReservationCapacityInGBPerDay *float64 `tf:"reservation_capacity_in_gb_per_day"`
+
+	// The name of the resource group.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// The workspace data retention in days (30-730).
+	RetentionInDays *float64 `tf:"retention_in_days"`
+
+	// Map of saved searches.
+	SavedSearches map[string]SavedSearche `tf:"saved_searches"`
+
+	// The SKU of the Log Analytics workspace.
+	SKU *string `tf:"sku"`
+
+	// Map of Log Analytics solutions to install.
+	Solutions map[string]Solution `tf:"solutions"`
+
+	// Additional tags specific to the Log Analytics workspace.
+	WorkspaceTags map[string]string `tf:"workspace_tags"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// DataCollectionRule is an object of the module's inputs.
+type DataCollectionRule struct {
+	DataFlows           []DataCollectionRuleDataFlow           `tf:"data_flows"`
+	Description         string                                 `tf:"description"`
+	PerformanceCounters []DataCollectionRulePerformanceCounter `tf:"performance_counters"`
+	WindowsEventLogs    []DataCollectionRuleWindowsEventLog    `tf:"windows_event_logs"`
+}
+
+// DataCollectionRuleDataFlow is an object of the module's inputs.
+type DataCollectionRuleDataFlow struct {
+	Streams []string `tf:"streams"`
+}
+
+// DataCollectionRulePerformanceCounter is an object of the module's inputs.
+type DataCollectionRulePerformanceCounter struct {
+	CounterSpecifiers          []string `tf:"counter_specifiers"`
+	Name                       string   `tf:"name"`
+	SamplingFrequencyInSeconds float64  `tf:"sampling_frequency_in_seconds"`
+	Streams                    []string `tf:"streams"`
+}
+
+// DataCollectionRuleWindowsEventLog is an object of the module's inputs.
+type DataCollectionRuleWindowsEventLog struct {
+	Name         string   `tf:"name"`
+	Streams      []string `tf:"streams"`
+	XPathQueries []string `tf:"x_path_queries"`
+}
+
+// SavedSearche is an object of the module's inputs.
+type SavedSearche struct {
+	Category           string   `tf:"category"`
+	DisplayName        string   `tf:"display_name"`
+	FunctionAlias      *string  `tf:"function_alias"`
+	FunctionParameters []string `tf:"function_parameters"`
+	Query              string   `tf:"query"`
+}
+
+// Solution is an object of the module's inputs.
+type Solution struct {
+	Product   string `tf:"product"`
+	Publisher string `tf:"publisher"`
+}
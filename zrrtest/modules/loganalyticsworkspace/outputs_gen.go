@@ -0,0 +1,168 @@
+// Code generated by zrr-gen from azure/infrastructure/log-analytics-workspace/outputs.tf. DO NOT EDIT.
+
+package loganalyticsworkspace
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/log-analytics-workspace once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// ConnectionInfo returns the connection_info output: Connection information for
+// integrating with other Azure services.
+//
+// The output is sensitive.
+func (o Outputs) ConnectionInfo() ConnectionInfoOutput {
+	var value ConnectionInfoOutput
+	terraform.OutputStruct(o.t, o.options, "connection_info", &value)
+	return value
+}
+
+// DailyQuotaGB returns the daily_quota_gb output: The daily quota in GB of the
+// Log Analytics workspace.
+func (o Outputs) DailyQuotaGB() float64 {
+	var value float64
+	terraform.OutputStruct(o.t, o.options, "daily_quota_gb", &value)
+	return value
+}
+
+// DataCollectionRules returns the data_collection_rules output: Map of data
+// collection rules.
+func (o Outputs) DataCollectionRules() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "data_collection_rules", &value)
+	return value
+}
+
+// ID returns the id output: The ID of the Log Analytics workspace.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// Identity returns the identity output: The identity of the Log Analytics
+// workspace.
+func (o Outputs) Identity() interface{} {
+	var value interface{}
+	terraform.OutputStruct(o.t, o.options, "identity", &value)
+	return value
+}
+
+// InternetIngestionEnabled returns the internet_ingestion_enabled output:
+// Whether internet ingestion is enabled.
+func (o Outputs) InternetIngestionEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "internet_ingestion_enabled", &value)
+	return value
+}
+
+// InternetQueryEnabled returns the internet_query_enabled output: Whether
+// internet query is enabled.
+func (o Outputs) InternetQueryEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "internet_query_enabled", &value)
+	return value
+}
+
+// Location returns the location output: The location of the Log Analytics
+// workspace.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// Name returns the name output: The name of the Log Analytics workspace.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// PrimarySharedKey returns the primary_shared_key output: The primary shared
+// key of the Log Analytics workspace.
+//
+// The output is sensitive.
+func (o Outputs) PrimarySharedKey() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_shared_key", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: The resource group
+// name of the Log Analytics workspace.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// RetentionInDays returns the retention_in_days output: The retention period in
+// days of the Log Analytics workspace.
+func (o Outputs) RetentionInDays() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "retention_in_days", &value)
+	return value
+}
+
+// SavedSearches returns the saved_searches output: Map of saved searches.
+func (o Outputs) SavedSearches() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "saved_searches", &value)
+	return value
+}
+
+// SecondarySharedKey returns the secondary_shared_key output: The secondary
+// shared key of the Log Analytics workspace.
+//
+// The output is sensitive.
+func (o Outputs) SecondarySharedKey() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_shared_key", &value)
+	return value
+}
+
+// SKU returns the sku output: The SKU of the Log Analytics workspace.
+func (o Outputs) SKU() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "sku", &value)
+	return value
+}
+
+// Solutions returns the solutions output: Map of installed Log Analytics
+// solutions.
+func (o Outputs) Solutions() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "solutions", &value)
+	return value
+}
+
+// WorkspaceID returns the workspace_id output: The workspace ID of the Log
+// Analytics workspace.
+func (o Outputs) WorkspaceID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "workspace_id", &value)
+	return value
+}
+
+// ConnectionInfoOutput is an object of the module's outputs.
+type ConnectionInfoOutput struct {
+	Location           string `json:"location"`
+	Name               string `json:"name"`
+	PrimarySharedKey   string `json:"primary_shared_key"`
+	ResourceGroupName  string `json:"resource_group_name"`
+	ResourceID         string `json:"resource_id"`
+	SecondarySharedKey string `json:"secondary_shared_key"`
+	WorkspaceID        string `json:"workspace_id"`
+}
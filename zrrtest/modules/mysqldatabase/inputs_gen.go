@@ -0,0 +1,111 @@
+// Code generated by zrr-gen from azure/infrastructure/mysql-database/variables.tf. DO NOT EDIT.
+
+// Package mysqldatabase holds the typed inputs and outputs of azure/infrastructure/mysql-database, for its tests.
+package mysqldatabase
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/mysql-database. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Azure Monitor Action Group ID for alerts.
+	ActionGroupID *string `tf:"action_group_id"`
+
+	// List of additional databases to create on the same server.
+	AdditionalDatabases []AdditionalDatabase `tf:"additional_databases"`
+
+	// Audit log events to capture.
+	AuditLogEvents *string `tf:"audit_log_events"`
+
+	// Character set for the database.
+	Charset *string `tf:"charset"`
+
+	// Collation for the database.
+	Collation *string `tf:"collation"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Threshold for database connection alerts.
+	ConnectionAlertThreshold *float64 `tf:"connection_alert_threshold"`
+
+	// List of database users to create with their privileges.
+	DatabaseUsers []DatabaseUser `tf:"database_users"`
+
+	// Enable audit logging for the database (Single Server only).
+	EnableAuditLogging *bool `tf:"enable_audit_logging"`
+
+	// Enable database monitoring and alerting.
+	EnableMonitoring *bool `tf:"enable_monitoring"`
+
+	// Enable slow query logging.
+	EnableSlowQueryLog *bool `tf:"enable_slow_query_log"`
+
+	// Environment name for naming convention.
+	Environment *string `tf:"environment"`
+
+	// Short location code for naming convention.
+	LocationShort *string `tf:"location_short"`
+
+	// Additional tags specific to the MySQL database.
+	MySQLDatabaseTags map[string]string `tf:"mysql_database_tags"`
+
+	// Resource ID of an existing MySQL Flexible Server.
+	MySQLFlexibleServerID *string `tf:"mysql_flexible_server_id"`
+
+	// Resource ID of an existing MySQL Single Server.
+	MySQLServerID *string `tf:"mysql_server_id"`
+
+	// Name of the MySQL server (used when server IDs are not provided).
+	MySQLServerName *string `tf:"mysql_server_name"`
+
+	// Name of the MySQL database.
+	Name string `tf:"name"`
+
+	// Performance-related MySQL server configurations (Single Server only).
+	PerformanceConfigurations map[string]string `tf:"performance_configurations"`
+
+	// Name of the resource group containing the MySQL server.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// Threshold in seconds for slow query logging.
+	SlowQueryThreshold *float64 `tf:"slow_query_threshold"`
+
+	// Threshold for database storage usage alerts (percentage).
+	StorageAlertThreshold *float64 `tf:"storage_alert_threshold"`
+
+	// Subnet ID for VNet integration (Single Server only).
+	SubnetID *string `tf:"subnet_id"`
+
+	// Whether to use MySQL Flexible Server (true) or Single Server (false).
+	UseFlexibleServer *bool `tf:"use_flexible_server"`
+
+	// Use ZRR naming convention for database name.
+	UseNamingConvention *bool `tf:"use_naming_convention"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AdditionalDatabase is an object of the module's inputs.
+type AdditionalDatabase struct {
+	Charset   *string `tf:"charset"`
+	Collation *string `tf:"collation"`
+	Name      string  `tf:"name"`
+}
+
+// DatabaseUser is an object of the module's inputs.
+type DatabaseUser struct {
+	Password   string                  `tf:"password"`
+	Privileges []DatabaseUserPrivilege `tf:"privileges"`
+	Username   string                  `tf:"username"`
+}
+
+// DatabaseUserPrivilege is an object of the module's inputs.
+type DatabaseUserPrivilege struct {
+	Database string  `tf:"database"`
+	Table    *string `tf:"table"`
+	Type     string  `tf:"type"`
+}
@@ -0,0 +1,271 @@
+// Code generated by zrr-gen from azure/infrastructure/mysql-database/outputs.tf. DO NOT EDIT.
+
+package mysqldatabase
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/mysql-database once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AdditionalDatabaseCount returns the additional_database_count output: Number
+// of additional databases created.
+func (o Outputs) AdditionalDatabaseCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "additional_database_count", &value)
+	return value
+}
+
+// AdditionalDatabases returns the additional_databases output: Information
+// about additional databases created.
+func (o Outputs) AdditionalDatabases() map[string]AdditionalDatabaseOutput {
+	var value map[string]AdditionalDatabaseOutput
+	terraform.OutputStruct(o.t, o.options, "additional_databases", &value)
+	return value
+}
+
+// AlertThresholds returns the alert_thresholds output: Configured alert
+// thresholds.
+func (o Outputs) AlertThresholds() AlertThresholdsOutput {
+	var value AlertThresholdsOutput
+	terraform.OutputStruct(o.t, o.options, "alert_thresholds", &value)
+	return value
+}
+
+// AuditLoggingEnabled returns the audit_logging_enabled output: Whether audit
+// logging is enabled.
+func (o Outputs) AuditLoggingEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "audit_logging_enabled", &value)
+	return value
+}
+
+// Charset returns the charset output: Character set of the database.
+func (o Outputs) Charset() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "charset", &value)
+	return value
+}
+
+// Collation returns the collation output: Collation of the database.
+func (o Outputs) Collation() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "collation", &value)
+	return value
+}
+
+// ConnectionAlertID returns the connection_alert_id output: ID of the
+// connection metric alert.
+func (o Outputs) ConnectionAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "connection_alert_id", &value)
+	return value
+}
+
+// DatabaseNameConvention returns the database_name_convention output: Database
+// naming convention used.
+func (o Outputs) DatabaseNameConvention() DatabaseNameConventionOutput {
+	var value DatabaseNameConventionOutput
+	terraform.OutputStruct(o.t, o.options, "database_name_convention", &value)
+	return value
+}
+
+// DatabaseSummary returns the database_summary output: Comprehensive summary of
+// the database configuration.
+//
+// The output is sensitive.
+func (o Outputs) DatabaseSummary() DatabaseSummaryOutput {
+	var value DatabaseSummaryOutput
+	terraform.OutputStruct(o.t, o.options, "database_summary", &value)
+	return value
+}
+
+// ID returns the id output: ID of the MySQL database.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// IsFlexibleServer returns the is_flexible_server output: Whether the database
+// is on a Flexible Server.
+func (o Outputs) IsFlexibleServer() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "is_flexible_server", &value)
+	return value
+}
+
+// LoggingConfiguration returns the logging_configuration output: Logging
+// configuration details.
+func (o Outputs) LoggingConfiguration() LoggingConfigurationOutput {
+	var value LoggingConfigurationOutput
+	terraform.OutputStruct(o.t, o.options, "logging_configuration", &value)
+	return value
+}
+
+// MonitoringEnabled returns the monitoring_enabled output: Whether monitoring
+// is enabled for the database.
+func (o Outputs) MonitoringEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "monitoring_enabled", &value)
+	return value
+}
+
+// Name returns the name output: Name of the MySQL database.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// PerformanceConfigCount returns the performance_config_count output: Number of
+// performance configurations applied.
+func (o Outputs) PerformanceConfigCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "performance_config_count", &value)
+	return value
+}
+
+// PerformanceConfigurations returns the performance_configurations output:
+// Applied performance configurations.
+func (o Outputs) PerformanceConfigurations() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "performance_configurations", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Resource group name
+// containing the database.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// ServerName returns the server_name output: Name of the MySQL server hosting
+// the database.
+func (o Outputs) ServerName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "server_name", &value)
+	return value
+}
+
+// ServerType returns the server_type output: Type of MySQL server (flexible or
+// single).
+func (o Outputs) ServerType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "server_type", &value)
+	return value
+}
+
+// SlowQueryLoggingEnabled returns the slow_query_logging_enabled output:
+// Whether slow query logging is enabled.
+func (o Outputs) SlowQueryLoggingEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "slow_query_logging_enabled", &value)
+	return value
+}
+
+// StorageAlertID returns the storage_alert_id output: ID of the storage metric
+// alert.
+func (o Outputs) StorageAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "storage_alert_id", &value)
+	return value
+}
+
+// SubnetID returns the subnet_id output: Subnet ID used for VNet integration.
+func (o Outputs) SubnetID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "subnet_id", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to monitoring resources.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// UserCount returns the user_count output: Number of database users configured
+// (for reference only).
+//
+// The output is sensitive.
+func (o Outputs) UserCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "user_count", &value)
+	return value
+}
+
+// UserManagementNote returns the user_management_note output: Note about user
+// management.
+func (o Outputs) UserManagementNote() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "user_management_note", &value)
+	return value
+}
+
+// VnetRuleID returns the vnet_rule_id output: ID of the VNet rule (Single
+// Server only).
+func (o Outputs) VnetRuleID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "vnet_rule_id", &value)
+	return value
+}
+
+// AdditionalDatabaseOutput is an object of the module's outputs.
+type AdditionalDatabaseOutput struct {
+	Charset   string `json:"charset"`
+	Collation string `json:"collation"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+}
+
+// AlertThresholdsOutput is an object of the module's outputs.
+type AlertThresholdsOutput struct {
+	Connections float64 `json:"connections"`
+	Storage     float64 `json:"storage"`
+}
+
+// DatabaseNameConventionOutput is an object of the module's outputs.
+type DatabaseNameConventionOutput struct {
+	Environment   string `json:"environment"`
+	FinalName     string `json:"final_name"`
+	LocationShort string `json:"location_short"`
+	OriginalName  string `json:"original_name"`
+	UseConvention bool   `json:"use_convention"`
+}
+
+// DatabaseSummaryOutput is an object of the module's outputs.
+type DatabaseSummaryOutput struct {
+	AdditionalDbs     int     `json:"additional_dbs"`
+	AuditEnabled      bool    `json:"audit_enabled"`
+	Charset           string  `json:"charset"`
+	Collation         string  `json:"collation"`
+	MonitoringEnabled bool    `json:"monitoring_enabled"`
+	Name              string  `json:"name"`
+	ServerName        *string `json:"server_name"`
+	ServerType        string  `json:"server_type"`
+	SlowLogEnabled    bool    `json:"slow_log_enabled"`
+	UsersConfigured   int     `json:"users_configured"`
+	VnetIntegrated    bool    `json:"vnet_integrated"`
+}
+
+// LoggingConfigurationOutput is an object of the module's outputs.
+type LoggingConfigurationOutput struct {
+	AuditEvents        string  `json:"audit_events"`
+	AuditLogging       bool    `json:"audit_logging"`
+	SlowQueryLog       bool    `json:"slow_query_log"`
+	SlowQueryThreshold float64 `json:"slow_query_threshold"`
+}
@@ -0,0 +1,79 @@
+// Code generated by zrr-gen from azure/security/mysql-firewall-rule/variables.tf. DO NOT EDIT.
+
+// Package mysqlfirewallrule holds the typed inputs and outputs of azure/security/mysql-firewall-rule, for its tests.
+package mysqlfirewallrule
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/security/mysql-firewall-rule. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Send alerts when firewall rules are modified.
+	AlertOnRuleChanges *bool `tf:"alert_on_rule_changes"`
+
+	// List of application subnet CIDR blocks to allow access.
+	AllowApplicationSubnets []string `tf:"allow_application_subnets"`
+
+	// Whether to allow access from Azure services and resources.
+	AllowAzureServices *bool `tf:"allow_azure_services"`
+
+	// List of developer IP addresses to allow access (for development environments).
+	AllowDeveloperIPs []string `tf:"allow_developer_ips"`
+
+	// List of office IP addresses or ranges to allow access.
+	AllowOfficeIPs []string `tf:"allow_office_ips"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Additional compliance tags to apply to firewall rules.
+	ComplianceTags map[string]string `tf:"compliance_tags"`
+
+	// Enable strict IP range validation (start_ip <= end_ip).
+	EnableIPRangeValidation *bool `tf:"enable_ip_range_validation"`
+
+	// Enable monitoring and alerting for firewall rule changes.
+	EnableMonitoring *bool `tf:"enable_monitoring"`
+
+	// Environment name (used for rule naming and validation).
+	Environment *string `tf:"environment"`
+
+	// List of firewall rules to create for the MySQL server.
+	FirewallRules []FirewallRule `tf:"firewall_rules"`
+
+	// Maximum number of firewall rules allowed (Azure limit is 128).
+	MaxFirewallRules *float64 `tf:"max_firewall_rules"`
+
+	// Additional tags specific to the MySQL firewall rules.
+	MySQLFirewallRuleTags map[string]string `tf:"mysql_firewall_rule_tags"`
+
+	// Name of the MySQL Flexible Server.
+	MySQLFlexibleServerName *string `tf:"mysql_flexible_server_name"`
+
+	// Resource group name of the MySQL Flexible Server (required when using mysql_flexible_server_name).
+	MySQLFlexibleServerResourceGroupName *string `tf:"mysql_flexible_server_resource_group_name"`
+
+	// Resource ID of the MySQL server (supports both Single Server and Flexible Server).
+	MySQLServerID *string `tf:"mysql_server_id"`
+
+	// Name of the MySQL Single Server.
+	MySQLServerName *string `tf:"mysql_server_name"`
+
+	// Resource group name of the MySQL Single Server (required when using mysql_server_name).
+	MySQLServerResourceGroupName *string `tf:"mysql_server_resource_group_name"`
+
+	// Require justification tags for firewall rules in production environments.
+	RequireJustification *bool `tf:"require_justification"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// FirewallRule is an object of the module's inputs.
+type FirewallRule struct {
+	EndIPAddress   string `tf:"end_ip_address"`
+	Name           string `tf:"name"`
+	StartIPAddress string `tf:"start_ip_address"`
+}
@@ -0,0 +1,181 @@
+// Code generated by zrr-gen from azure/security/mysql-firewall-rule/outputs.tf. DO NOT EDIT.
+
+package mysqlfirewallrule
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/security/mysql-firewall-rule once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// ApplicationSubnetsCount returns the application_subnets_count output: Number
+// of application subnets configured.
+func (o Outputs) ApplicationSubnetsCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "application_subnets_count", &value)
+	return value
+}
+
+// AppliedTags returns the applied_tags output: Tags applied to the firewall
+// rules.
+func (o Outputs) AppliedTags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "applied_tags", &value)
+	return value
+}
+
+// AzureServicesAllowed returns the azure_services_allowed output: Whether Azure
+// services access is enabled.
+func (o Outputs) AzureServicesAllowed() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "azure_services_allowed", &value)
+	return value
+}
+
+// ComplianceStatus returns the compliance_status output: Compliance and
+// governance status.
+func (o Outputs) ComplianceStatus() ComplianceStatusOutput {
+	var value ComplianceStatusOutput
+	terraform.OutputStruct(o.t, o.options, "compliance_status", &value)
+	return value
+}
+
+// DeveloperIPsCount returns the developer_ips_count output: Number of developer
+// IP addresses configured.
+func (o Outputs) DeveloperIPsCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "developer_ips_count", &value)
+	return value
+}
+
+// FirewallRuleIDs returns the firewall_rule_ids output: Map of firewall rule
+// names to their IDs.
+func (o Outputs) FirewallRuleIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "firewall_rule_ids", &value)
+	return value
+}
+
+// FirewallRuleNames returns the firewall_rule_names output: List of created
+// firewall rule names.
+func (o Outputs) FirewallRuleNames() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "firewall_rule_names", &value)
+	return value
+}
+
+// FirewallRulesCount returns the firewall_rules_count output: Total number of
+// firewall rules created.
+func (o Outputs) FirewallRulesCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "firewall_rules_count", &value)
+	return value
+}
+
+// FirewallRulesDetails returns the firewall_rules_details output: Detailed
+// information about all firewall rules.
+func (o Outputs) FirewallRulesDetails() map[string]FirewallRulesDetailOutput {
+	var value map[string]FirewallRulesDetailOutput
+	terraform.OutputStruct(o.t, o.options, "firewall_rules_details", &value)
+	return value
+}
+
+// MySQLServerReference returns the mysql_server_reference output: Reference
+// information for the MySQL server.
+func (o Outputs) MySQLServerReference() MySQLServerReferenceOutput {
+	var value MySQLServerReferenceOutput
+	terraform.OutputStruct(o.t, o.options, "mysql_server_reference", &value)
+	return value
+}
+
+// NetworkAccessSummary returns the network_access_summary output: Summary of
+// network access configuration.
+func (o Outputs) NetworkAccessSummary() NetworkAccessSummaryOutput {
+	var value NetworkAccessSummaryOutput
+	terraform.OutputStruct(o.t, o.options, "network_access_summary", &value)
+	return value
+}
+
+// OfficeIPsCount returns the office_ips_count output: Number of office IP
+// ranges configured.
+func (o Outputs) OfficeIPsCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "office_ips_count", &value)
+	return value
+}
+
+// SecurityConfiguration returns the security_configuration output: Summary of
+// security configuration.
+func (o Outputs) SecurityConfiguration() SecurityConfigurationOutput {
+	var value SecurityConfigurationOutput
+	terraform.OutputStruct(o.t, o.options, "security_configuration", &value)
+	return value
+}
+
+// ServerName returns the server_name output: Name of the MySQL server.
+func (o Outputs) ServerName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "server_name", &value)
+	return value
+}
+
+// ServerType returns the server_type output: Type of MySQL server (single or
+// flexible).
+func (o Outputs) ServerType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "server_type", &value)
+	return value
+}
+
+// ComplianceStatusOutput is an object of the module's outputs.
+type ComplianceStatusOutput struct {
+	AzureServicesAccess   bool `json:"azure_services_access"`
+	ComplianceTagsApplied bool `json:"compliance_tags_applied"`
+	EnvironmentValidated  bool `json:"environment_validated"`
+	RuleCountWithinLimit  bool `json:"rule_count_within_limit"`
+}
+
+// FirewallRulesDetailOutput is an object of the module's outputs.
+type FirewallRulesDetailOutput struct {
+	EndIP   string `json:"end_ip"`
+	StartIP string `json:"start_ip"`
+	Type    string `json:"type"`
+}
+
+// MySQLServerReferenceOutput is an object of the module's outputs.
+type MySQLServerReferenceOutput struct {
+	ResourceGroup *string `json:"resource_group"`
+	ServerID      *string `json:"server_id"`
+	ServerName    *string `json:"server_name"`
+	ServerType    string  `json:"server_type"`
+}
+
+// NetworkAccessSummaryOutput is an object of the module's outputs.
+type NetworkAccessSummaryOutput struct {
+	ApplicationNetworks   int  `json:"application_networks"`
+	AzureServicesEnabled  bool `json:"azure_services_enabled"`
+	CustomRules           int  `json:"custom_rules"`
+	DeveloperAccessPoints int  `json:"developer_access_points"`
+	OfficeLocations       int  `json:"office_locations"`
+	TotalRules            int  `json:"total_rules"`
+}
+
+// SecurityConfigurationOutput is an object of the module's outputs.
+type SecurityConfigurationOutput struct {
+	AlertOnChanges           bool    `json:"alert_on_changes"`
+	Environment              string  `json:"environment"`
+	IPRangeValidationEnabled bool    `json:"ip_range_validation_enabled"`
+	JustificationRequired    bool    `json:"justification_required"`
+	MaxFirewallRulesLimit    float64 `json:"max_firewall_rules_limit"`
+	MonitoringEnabled        bool    `json:"monitoring_enabled"`
+}
@@ -0,0 +1,219 @@
+// Code generated by zrr-gen from azure/infrastructure/mysql-flexible-server/variables.tf. DO NOT EDIT.
+
+// Package mysqlflexibleserver holds the typed inputs and outputs of azure/infrastructure/mysql-flexible-server, for its tests.
+package mysqlflexibleserver
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/mysql-flexible-server. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Azure AD administrator configuration.
+	AadAdministrator *AadAdministrator `tf:"aad_administrator"`
+
+	// Administrator login for the MySQL server.
+	AdministratorLogin string `tf:"administrator_login"`
+
+	// Administrator password for the MySQL server.
+	AdministratorPassword string `tf:"administrator_password"`
+
+	// List of email addresses for alerts.
+	AlertEmailAddresses []string `tf:"alert_email_addresses"`
+
+	// Primary availability zone for the server.
+	AvailabilityZone *string `tf:"availability_zone"`
+
+	// Backup retention period in days.
+	BackupRetentionDays *float64 `tf:"backup_retention_days"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Connection alert severity.
+	ConnectionAlertSeverity *float64 `tf:"connection_alert_severity"`
+
+	// Active connections alert threshold.
+	ConnectionAlertThreshold *float64 `tf:"connection_alert_threshold"`
+
+	// CPU alert severity.
+	CPUAlertSeverity *float64 `tf:"cpu_alert_severity"`
+
+	// CPU utilization alert threshold percentage.
+	CPUAlertThreshold *float64 `tf:"cpu_alert_threshold"`
+
+	// Customer managed key ID for data encryption.
+	CustomerManagedKeyID *string `tf:"customer_managed_key_id"`
+
+	// List of databases to create.
+	Databases []Database `tf:"databases"`
+
+	// ID of the delegated subnet for private access.
+	DelegatedSubnetID *string `tf:"delegated_subnet_id"`
+
+	// List of diagnostic logs.
+	DiagnosticLogs []DiagnosticLog `tf:"diagnostic_logs"`
+
+	// List of diagnostic metrics.
+	DiagnosticMetrics []DiagnosticMetric `tf:"diagnostic_metrics"`
+
+	// Storage account ID for diagnostic logs.
+	DiagnosticStorageAccountID *string `tf:"diagnostic_storage_account_id"`
+
+	// Enable diagnostic settings.
+	EnableDiagnosticSettings *bool `tf:"enable_diagnostic_settings"`
+
+	// Enable monitoring and alerting.
+	EnableMonitoring *bool `tf:"enable_monitoring"`
+
+	// Enable private endpoint.
+	EnablePrivateEndpoint *bool `tf:"enable_private_endpoint"`
+
+	// Environment name (used in naming convention).
+	Environment *string `tf:"environment"`
+
+	// List of firewall rules.
+	FirewallRules []FirewallRule `tf:"firewall_rules"`
+
+	// Geo backup key vault key ID.
+	GeoBackupKeyVaultKeyID *string `tf:"geo_backup_key_vault_key_id"`
+
+	// Geo backup user assigned identity ID.
+	GeoBackupUserAssignedIdentityID *string `tf:"geo_backup_user_assigned_identity_id"`
+
+	// Enable geo-redundant backup.
+	GeoRedundantBackupEnabled *bool `tf:"geo_redundant_backup_enabled"`
+
+	// High availability mode for the server.
+	HighAvailabilityMode *string `tf:"high_availability_mode"`
+
+	// List of user assigned identity IDs.
+	IdentityIDs []string `tf:"identity_ids"`
+
+	// Type of managed identity.
+	IdentityType *string `tf:"identity_type"`
+
+	// Azure region where resources will be created.
+	Location *string `tf:"location"`
+
+	// Short location code for naming convention.
+	LocationShort *string `tf:"location_short"`
+
+	// Log Analytics workspace ID.
+	LogAnalyticsWorkspaceID *string `tf:"log_analytics_workspace_id"`
+
+	// Maintenance window configuration.
+	MaintenanceWindow *MaintenanceWindow `tf:"maintenance_window"`
+
+	// Memory alert severity.
+	MemoryAlertSeverity *float64 `tf:"memory_alert_severity"`
+
+	// Memory utilization alert threshold percentage.
+	MemoryAlertThreshold *float64 `tf:"memory_alert_threshold"`
+
+	// Additional tags specific to the MySQL server.
+	MySQLTags map[string]string `tf:"mysql_tags"`
+
+	// Version of MySQL server.
+	MySQLVersion *string `tf:"mysql_version"`
+
+	// Name of the MySQL Flexible Server.
+	Name string `tf:"name"`
+
+	// Primary user assigned identity ID for customer managed key.
+	PrimaryUserAssignedIdentityID *string `tf:"primary_user_assigned_identity_id"`
+
+	// ID of the private DNS zone.
+	PrivateDNSZoneID *string `tf:"private_dns_zone_id"`
+
+	// Private DNS zone ID for private endpoint.
+	PrivateEndpointDNSZoneID *string `tf:"private_endpoint_dns_zone_id"`
+
+	// Subnet ID for private endpoint.
+	PrivateEndpointSubnetID *string `tf:"private_endpoint_subnet_id"`
+
+	// Enable public network access.
+	PublicNetworkAccessEnabled *bool `tf:"public_network_access_enabled"`
+
+	// Name of the resource group.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// Map of server configuration parameters.
+	ServerConfigurations map[string]string `tf:"server_configurations"`
+
+	// SKU name for the MySQL server.
+	SKUName *string `tf:"sku_name"`
+
+	// Standby availability zone for high availability.
+	StandbyAvailabilityZone *string `tf:"standby_availability_zone"`
+
+	// Enable storage auto-grow.
+	StorageAutoGrowEnabled *bool `tf:"storage_auto_grow_enabled"`
+
+	// Storage IOPS (Input/Output Operations Per Second).
+	StorageIOPS *float64 `tf:"storage_iops"`
+
+	// Storage size in GB.
+	StorageSizeGB *float64 `tf:"storage_size_gb"`
+
+	// Use standardized naming convention.
+	UseNamingConvention *bool `tf:"use_naming_convention"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AadAdministrator is an object of the module's inputs.
+type AadAdministrator struct {
+	IdentityID string `tf:"identity_id"`
+	Login      string `tf:"login"`
+	ObjectID   string `tf:"object_id"`
+	TenantID   string `tf:"tenant_id"`
+}
+
+// Database is an object of the module's inputs.
+type Database struct {
+	Charset   *string `tf:"charset"`
+	Collation *string `tf:"collation"`
+	Name      string  `tf:"name"`
+}
+
+// DiagnosticLog is an object of the module's inputs.
+type DiagnosticLog struct {
+	Category        string                       `tf:"category"`
+	RetentionPolicy DiagnosticLogRetentionPolicy `tf:"retention_policy"`
+}
+
+// DiagnosticLogRetentionPolicy is an object of the module's inputs.
+type DiagnosticLogRetentionPolicy struct {
+	Days    float64 `tf:"days"`
+	Enabled bool    `tf:"enabled"`
+}
+
+// DiagnosticMetric is an object of the module's inputs.
+type DiagnosticMetric struct {
+	Category        string                          `tf:"category"`
+	Enabled         bool                            `tf:"enabled"`
+	RetentionPolicy DiagnosticMetricRetentionPolicy `tf:"retention_policy"`
+}
+
+// DiagnosticMetricRetentionPolicy is an object of the module's inputs.
+type DiagnosticMetricRetentionPolicy struct {
+	Days    float64 `tf:"days"`
+	Enabled bool    `tf:"enabled"`
+}
+
+// FirewallRule is an object of the module's inputs.
+type FirewallRule struct {
+	EndIPAddress   string `tf:"end_ip_address"`
+	Name           string `tf:"name"`
+	StartIPAddress string `tf:"start_ip_address"`
+}
+
+// MaintenanceWindow is an object of the module's inputs.
+type MaintenanceWindow struct {
+	DayOfWeek   float64 `tf:"day_of_week"`
+	StartHour   float64 `tf:"start_hour"`
+	StartMinute float64 `tf:"start_minute"`
+}
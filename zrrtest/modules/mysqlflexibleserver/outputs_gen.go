@@ -0,0 +1,324 @@
+// Code generated by zrr-gen from azure/infrastructure/mysql-flexible-server/outputs.tf. DO NOT EDIT.
+
+package mysqlflexibleserver
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/mysql-flexible-server once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AadAdministrator returns the aad_administrator output: Azure AD administrator
+// configuration.
+func (o Outputs) AadAdministrator() *AadAdministratorOutput {
+	var value *AadAdministratorOutput
+	terraform.OutputStruct(o.t, o.options, "aad_administrator", &value)
+	return value
+}
+
+// ActionGroupID returns the action_group_id output: ID of the monitor action
+// group.
+func (o Outputs) ActionGroupID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "action_group_id", &value)
+	return value
+}
+
+// AdministratorLogin returns the administrator_login output: Administrator
+// login name.
+func (o Outputs) AdministratorLogin() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "administrator_login", &value)
+	return value
+}
+
+// BackupRetentionDays returns the backup_retention_days output: Backup
+// retention period in days.
+func (o Outputs) BackupRetentionDays() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "backup_retention_days", &value)
+	return value
+}
+
+// ConnectionAlertID returns the connection_alert_id output: ID of the
+// connection metric alert.
+func (o Outputs) ConnectionAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "connection_alert_id", &value)
+	return value
+}
+
+// ConnectionString returns the connection_string output: MySQL connection
+// string.
+//
+// The output is sensitive.
+func (o Outputs) ConnectionString() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "connection_string", &value)
+	return value
+}
+
+// CPUAlertID returns the cpu_alert_id output: ID of the CPU metric alert.
+func (o Outputs) CPUAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "cpu_alert_id", &value)
+	return value
+}
+
+// Databases returns the databases output: Created databases.
+func (o Outputs) Databases() map[string]DatabaseOutput {
+	var value map[string]DatabaseOutput
+	terraform.OutputStruct(o.t, o.options, "databases", &value)
+	return value
+}
+
+// DelegatedSubnetID returns the delegated_subnet_id output: ID of the delegated
+// subnet.
+func (o Outputs) DelegatedSubnetID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "delegated_subnet_id", &value)
+	return value
+}
+
+// DiagnosticSettingID returns the diagnostic_setting_id output: ID of the
+// diagnostic setting.
+func (o Outputs) DiagnosticSettingID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "diagnostic_setting_id", &value)
+	return value
+}
+
+// FirewallRules returns the firewall_rules output: Created firewall rules.
+func (o Outputs) FirewallRules() map[string]FirewallRuleOutput {
+	var value map[string]FirewallRuleOutput
+	terraform.OutputStruct(o.t, o.options, "firewall_rules", &value)
+	return value
+}
+
+// FQDN returns the fqdn output: Fully qualified domain name of the MySQL
+// Flexible Server.
+func (o Outputs) FQDN() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "fqdn", &value)
+	return value
+}
+
+// GeoRedundantBackupEnabled returns the geo_redundant_backup_enabled output:
+// Whether geo-redundant backup is enabled.
+func (o Outputs) GeoRedundantBackupEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "geo_redundant_backup_enabled", &value)
+	return value
+}
+
+// HighAvailabilityEnabled returns the high_availability_enabled output: Whether
+// high availability is enabled.
+func (o Outputs) HighAvailabilityEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "high_availability_enabled", &value)
+	return value
+}
+
+// HighAvailabilityMode returns the high_availability_mode output: High
+// availability mode.
+func (o Outputs) HighAvailabilityMode() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "high_availability_mode", &value)
+	return value
+}
+
+// ID returns the id output: ID of the MySQL Flexible Server.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// Identity returns the identity output: Identity configuration of the server.
+func (o Outputs) Identity() *IdentityOutput {
+	var value *IdentityOutput
+	terraform.OutputStruct(o.t, o.options, "identity", &value)
+	return value
+}
+
+// MaintenanceWindow returns the maintenance_window output: Maintenance window
+// configuration.
+func (o Outputs) MaintenanceWindow() *MaintenanceWindowOutput {
+	var value *MaintenanceWindowOutput
+	terraform.OutputStruct(o.t, o.options, "maintenance_window", &value)
+	return value
+}
+
+// MemoryAlertID returns the memory_alert_id output: ID of the memory metric
+// alert.
+func (o Outputs) MemoryAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "memory_alert_id", &value)
+	return value
+}
+
+// Name returns the name output: Name of the MySQL Flexible Server.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// PrivateDNSZoneID returns the private_dns_zone_id output: ID of the private
+// DNS zone.
+func (o Outputs) PrivateDNSZoneID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "private_dns_zone_id", &value)
+	return value
+}
+
+// PrivateEndpointID returns the private_endpoint_id output: ID of the private
+// endpoint.
+func (o Outputs) PrivateEndpointID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "private_endpoint_id", &value)
+	return value
+}
+
+// PrivateEndpointIPAddresses returns the private_endpoint_ip_addresses output:
+// Private IP addresses of the private endpoint.
+func (o Outputs) PrivateEndpointIPAddresses() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "private_endpoint_ip_addresses", &value)
+	return value
+}
+
+// PublicNetworkAccessEnabled returns the public_network_access_enabled output:
+// Whether public network access is enabled.
+func (o Outputs) PublicNetworkAccessEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "public_network_access_enabled", &value)
+	return value
+}
+
+// ReplicaCapacity returns the replica_capacity output: Replica capacity of the
+// server.
+func (o Outputs) ReplicaCapacity() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "replica_capacity", &value)
+	return value
+}
+
+// ServerConfigurations returns the server_configurations output: Applied server
+// configurations.
+func (o Outputs) ServerConfigurations() map[string]ServerConfigurationOutput {
+	var value map[string]ServerConfigurationOutput
+	terraform.OutputStruct(o.t, o.options, "server_configurations", &value)
+	return value
+}
+
+// SKUName returns the sku_name output: SKU name of the server.
+func (o Outputs) SKUName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "sku_name", &value)
+	return value
+}
+
+// StandbyAvailabilityZone returns the standby_availability_zone output: Standby
+// availability zone.
+func (o Outputs) StandbyAvailabilityZone() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "standby_availability_zone", &value)
+	return value
+}
+
+// StorageAutoGrowEnabled returns the storage_auto_grow_enabled output: Whether
+// storage auto-grow is enabled.
+func (o Outputs) StorageAutoGrowEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "storage_auto_grow_enabled", &value)
+	return value
+}
+
+// StorageIOPS returns the storage_iops output: Storage IOPS.
+func (o Outputs) StorageIOPS() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "storage_iops", &value)
+	return value
+}
+
+// StorageSizeGB returns the storage_size_gb output: Storage size in GB.
+func (o Outputs) StorageSizeGB() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "storage_size_gb", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the MySQL server.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// Version returns the version output: MySQL version of the server.
+func (o Outputs) Version() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "version", &value)
+	return value
+}
+
+// Zone returns the zone output: Availability zone of the server.
+func (o Outputs) Zone() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "zone", &value)
+	return value
+}
+
+// AadAdministratorOutput is an object of the module's outputs.
+type AadAdministratorOutput struct {
+	IdentityID string `json:"identity_id"`
+	Login      string `json:"login"`
+	ObjectID   string `json:"object_id"`
+	TenantID   string `json:"tenant_id"`
+}
+
+// DatabaseOutput is an object of the module's outputs.
+type DatabaseOutput struct {
+	Charset   string `json:"charset"`
+	Collation string `json:"collation"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+}
+
+// FirewallRuleOutput is an object of the module's outputs.
+type FirewallRuleOutput struct {
+	EndIPAddress   string `json:"end_ip_address"`
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	StartIPAddress string `json:"start_ip_address"`
+}
+
+// IdentityOutput is an object of the module's outputs.
+type IdentityOutput struct {
+	IdentityIDs string `json:"identity_ids"`
+	Type        string `json:"type"`
+}
+
+// MaintenanceWindowOutput is an object of the module's outputs.
+type MaintenanceWindowOutput struct {
+	DayOfWeek   string `json:"day_of_week"`
+	StartHour   string `json:"start_hour"`
+	StartMinute string `json:"start_minute"`
+}
+
+// ServerConfigurationOutput is an object of the module's outputs.
+type ServerConfigurationOutput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,75 @@
+// Code generated by zrr-gen from azure/security/network-security-group/variables.tf. DO NOT EDIT.
+
+// Package networksecuritygroup holds the typed inputs and outputs of azure/security/network-security-group, for its tests.
+package networksecuritygroup
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/security/network-security-group. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Whether to create a new resource group.
+	CreateResourceGroup *bool `tf:"create_resource_group"`
+
+	// Enable flow logs for the network security group.
+	EnableFlowLogs *bool `tf:"enable_flow_logs"`
+
+	// Format type for flow logs.
+	FlowLogFormatType *string `tf:"flow_log_format_type"`
+
+	// Format version for flow logs.
+	FlowLogFormatVersion *float64 `tf:"flow_log_format_version"`
+
+	// Number of days to retain flow logs.
+	FlowLogRetentionDays *float64 `tf:"flow_log_retention_days"`
+
+	// Storage account ID for flow logs (required if enable_flow_logs is true).
+	FlowLogStorageAccountID *string `tf:"flow_log_storage_account_id"`
+
+	// Azure region where the network security group will be created.
+	Location *string `tf:"location"`
+
+	// Name of the network security group.
+	Name string `tf:"name"`
+
+	// List of network interface IDs to associate with the network security group.
+	NetworkInterfaceIDs []string `tf:"network_interface_ids"`
+
+	// Additional tags specific to the network security group.
+	NetworkSecurityGroupTags map[string]string `tf:"network_security_group_tags"`
+
+	// Name of the resource group. Required if create_resource_group is false.
+	ResourceGroupName *string `tf:"resource_group_name"`
+
+	// List of security rules to create.
+	SecurityRules []SecurityRule `tf:"security_rules"`
+
+	// ID of the subnet to associate with the network security group.
+	SubnetID *string `tf:"subnet_id"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// SecurityRule is an object of the module's inputs.
+type SecurityRule struct {
+	Access                     string   `tf:"access"`
+	Description                *string  `tf:"description"`
+	DestinationAddressPrefix   *string  `tf:"destination_address_prefix"`
+	DestinationAddressPrefixes []string `tf:"destination_address_prefixes"`
+	DestinationPortRange       *string  `tf:"destination_port_range"`
+	DestinationPortRanges      []string `tf:"destination_port_ranges"`
+	Direction                  string   `tf:"direction"`
+	Name                       string   `tf:"name"`
+	Priority                   float64  `tf:"priority"`
+	Protocol                   string   `tf:"protocol"`
+	SourceAddressPrefix        *string  `tf:"source_address_prefix"`
+	SourceAddressPrefixes      []string `tf:"source_address_prefixes"`
+	SourcePortRange            *string  `tf:"source_port_range"`
+	SourcePortRanges           []string `tf:"source_port_ranges"`
+}
@@ -0,0 +1,154 @@
+// Code generated by zrr-gen from azure/security/network-security-group/outputs.tf. DO NOT EDIT.
+
+package networksecuritygroup
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/security/network-security-group once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// EffectiveSecurityRulesCount returns the effective_security_rules_count
+// output: Number of security rules created.
+func (o Outputs) EffectiveSecurityRulesCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "effective_security_rules_count", &value)
+	return value
+}
+
+// HasInboundRules returns the has_inbound_rules output: Whether the NSG has any
+// inbound rules.
+func (o Outputs) HasInboundRules() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "has_inbound_rules", &value)
+	return value
+}
+
+// HasOutboundRules returns the has_outbound_rules output: Whether the NSG has
+// any outbound rules.
+func (o Outputs) HasOutboundRules() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "has_outbound_rules", &value)
+	return value
+}
+
+// ID returns the id output: ID of the network security group.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// InboundRulesCount returns the inbound_rules_count output: Number of inbound
+// security rules.
+func (o Outputs) InboundRulesCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "inbound_rules_count", &value)
+	return value
+}
+
+// Location returns the location output: Location of the network security group.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// Name returns the name output: Name of the network security group.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// NetworkInterfaceAssociationIDs returns the network_interface_association_ids
+// output: Map of network interface IDs to their association IDs.
+func (o Outputs) NetworkInterfaceAssociationIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "network_interface_association_ids", &value)
+	return value
+}
+
+// OutboundRulesCount returns the outbound_rules_count output: Number of
+// outbound security rules.
+func (o Outputs) OutboundRulesCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "outbound_rules_count", &value)
+	return value
+}
+
+// ResourceGroupID returns the resource_group_id output: ID of the resource
+// group (if created by this module).
+func (o Outputs) ResourceGroupID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "resource_group_id", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Resource group name
+// of the network security group.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// SecurityRuleIDs returns the security_rule_ids output: Map of security rule
+// names to their IDs.
+func (o Outputs) SecurityRuleIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "security_rule_ids", &value)
+	return value
+}
+
+// SecurityRules returns the security_rules output: List of security rules
+// created.
+func (o Outputs) SecurityRules() map[string]SecurityRuleOutput {
+	var value map[string]SecurityRuleOutput
+	terraform.OutputStruct(o.t, o.options, "security_rules", &value)
+	return value
+}
+
+// SubnetAssociationID returns the subnet_association_id output: ID of the
+// subnet association (if created).
+func (o Outputs) SubnetAssociationID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "subnet_association_id", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the network security group.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// SecurityRuleOutput is an object of the module's outputs.
+type SecurityRuleOutput struct {
+	Access                     string   `json:"access"`
+	Description                string   `json:"description"`
+	DestinationAddressPrefix   string   `json:"destination_address_prefix"`
+	DestinationAddressPrefixes []string `json:"destination_address_prefixes"`
+	DestinationPortRange       string   `json:"destination_port_range"`
+	DestinationPortRanges      []string `json:"destination_port_ranges"`
+	Direction                  string   `json:"direction"`
+	ID                         string   `json:"id"`
+	Name                       string   `json:"name"`
+	Priority                   int      `json:"priority"`
+	Protocol                   string   `json:"protocol"`
+	SourceAddressPrefix        string   `json:"source_address_prefix"`
+	SourceAddressPrefixes      []string `json:"source_address_prefixes"`
+	SourcePortRange            string   `json:"source_port_range"`
+	SourcePortRanges           []string `json:"source_port_ranges"`
+}
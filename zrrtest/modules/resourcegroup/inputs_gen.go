@@ -0,0 +1,63 @@
+// Code generated by zrr-gen from azure/infrastructure/resource-group/variables.tf. DO NOT EDIT.
+
+// Package resourcegroup holds the typed inputs and outputs of azure/infrastructure/resource-group, for its tests.
+package resourcegroup
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/resource-group. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Budget amount in USD.
+	BudgetAmount *float64 `tf:"budget_amount"`
+
+	// List of email addresses to notify when budget threshold is exceeded.
+	BudgetContactEmails []string `tf:"budget_contact_emails"`
+
+	// Start date for the budget in ISO 8601 format (YYYY-MM-DDTHH:MM:SSZ).
+	BudgetStartDate *string `tf:"budget_start_date"`
+
+	// Threshold percentage for budget alert.
+	BudgetThresholdPercentage *float64 `tf:"budget_threshold_percentage"`
+
+	// Time grain for the budget (Monthly, Quarterly, Annually).
+	BudgetTimeGrain *string `tf:"budget_time_grain"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Enable budget alert for the resource group.
+	EnableBudgetAlert *bool `tf:"enable_budget_alert"`
+
+	// Enable resource lock on the resource group.
+	EnableResourceLock *bool `tf:"enable_resource_lock"`
+
+	// Environment name (e.g., dev, staging, prod).
+	Environment *string `tf:"environment"`
+
+	// Azure region where the resource group will be created.
+	Location string `tf:"location"`
+
+	// Short abbreviation for the Azure region (e.g., eus for eastus); derived from location when empty.
+	LocationShort *string `tf:"location_short"`
+
+	// Lock level for the resource group (CanNotDelete or ReadOnly).
+	LockLevel *string `tf:"lock_level"`
+
+	// Notes for the resource lock.
+	LockNotes *string `tf:"lock_notes"`
+
+	// Name of the resource group.
+	Name string `tf:"name"`
+
+	// Additional tags specific to the resource group.
+	ResourceGroupTags map[string]string `tf:"resource_group_tags"`
+
+	// Use ZRR naming convention for resource group name.
+	UseNamingConvention *bool `tf:"use_naming_convention"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
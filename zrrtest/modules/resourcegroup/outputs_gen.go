@@ -0,0 +1,116 @@
+// Code generated by zrr-gen from azure/infrastructure/resource-group/outputs.tf. DO NOT EDIT.
+
+package resourcegroup
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/resource-group once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// BudgetAmount returns the budget_amount output: Budget amount configured for
+// the resource group.
+func (o Outputs) BudgetAmount() *float64 {
+	var value *float64
+	terraform.OutputStruct(o.t, o.options, "budget_amount", &value)
+	return value
+}
+
+// BudgetID returns the budget_id output: ID of the budget alert (if enabled).
+func (o Outputs) BudgetID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "budget_id", &value)
+	return value
+}
+
+// HasBudgetAlert returns the has_budget_alert output: Boolean indicating if the
+// resource group has a budget alert.
+func (o Outputs) HasBudgetAlert() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "has_budget_alert", &value)
+	return value
+}
+
+// ID returns the id output: ID of the resource group.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// IsLocked returns the is_locked output: Boolean indicating if the resource
+// group has a lock.
+func (o Outputs) IsLocked() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "is_locked", &value)
+	return value
+}
+
+// Location returns the location output: Location of the resource group.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// LockID returns the lock_id output: ID of the resource lock (if enabled).
+func (o Outputs) LockID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "lock_id", &value)
+	return value
+}
+
+// LockLevel returns the lock_level output: Lock level applied to the resource
+// group.
+func (o Outputs) LockLevel() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "lock_level", &value)
+	return value
+}
+
+// Name returns the name output: Name of the resource group.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// ResourceGroupURN returns the resource_group_urn output: Uniform Resource Name
+// for the resource group.
+func (o Outputs) ResourceGroupURN() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_urn", &value)
+	return value
+}
+
+// SubscriptionID returns the subscription_id output: Subscription ID where the
+// resource group is created.
+func (o Outputs) SubscriptionID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "subscription_id", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the resource group.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// TenantID returns the tenant_id output: Tenant ID of the subscription.
+func (o Outputs) TenantID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "tenant_id", &value)
+	return value
+}
@@ -0,0 +1,372 @@
+// Code generated by zrr-gen from azure/infrastructure/storage-account/variables.tf. DO NOT EDIT.
+
+// Package storageaccount holds the typed inputs and outputs of azure/infrastructure/storage-account, for its tests.
+package storageaccount
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/storage-account. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Access tier for BlobStorage, StorageV2 and FileStorage accounts.
+	AccessTier *string `tf:"access_tier"`
+
+	// Storage account kind.
+	AccountKind *string `tf:"account_kind"`
+
+	// Storage account tier (Standard or Premium).
+	AccountTier *string `tf:"account_tier"`
+
+	// Allow public access to blobs and containers.
+	AllowPublicAccess *bool `tf:"allow_public_access"`
+
+	// List of allowed IP ranges for network access.
+	AllowedIPRanges []string `tf:"allowed_ip_ranges"`
+
+	// List of allowed subnet IDs for network access.
+	AllowedSubnetIDs []string `tf:"allowed_subnet_ids"`
+
+	// Enable blob change feed.
+	BlobChangeFeedEnabled *bool `tf:"blob_change_feed_enabled"`
+
+	// Retention days for blob change feed.
+	BlobChangeFeedRetentionDays *float64 `tf:"blob_change_feed_retention_days"`
+
+	// CORS rules for blob service.
+	BlobCorsRules []BlobCorsRule `tf:"blob_cors_rules"`
+
+	// Default service version for blob requests.
+	BlobDefaultServiceVersion *string `tf:"blob_default_service_version"`
+
+	// Retention days for deleted blobs.
+	BlobDeleteRetentionDays *float64 `tf:"blob_delete_retention_days"`
+
+	// Enable last access time tracking for blobs.
+	BlobLastAccessTimeEnabled *bool `tf:"blob_last_access_time_enabled"`
+
+	// Point-in-time restore retention days.
+	BlobRestoreDays *float64 `tf:"blob_restore_days"`
+
+	// Enable blob versioning.
+	BlobVersioningEnabled *bool `tf:"blob_versioning_enabled"`
+
+	// Common tags to apply to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Configure network rules using separate resource instead of inline.
+	ConfigureNetworkRulesSeparately *bool `tf:"configure_network_rules_separately"`
+
+	// Retention days for deleted containers.
+	ContainerDeleteRetentionDays *float64 `tf:"container_delete_retention_days"`
+
+	// List of storage containers to create.
+	Containers []Container `tf:"containers"`
+
+	// User assigned identity ID for customer-managed key access.
+	CustomerManagedKeyUserAssignedIdentityID *string `tf:"customer_managed_key_user_assigned_identity_id"`
+
+	// Key Vault key ID for customer-managed encryption.
+	CustomerManagedKeyVaultKeyID *string `tf:"customer_managed_key_vault_key_id"`
+
+	// Enable blob properties configuration.
+	EnableBlobProperties *bool `tf:"enable_blob_properties"`
+
+	// Forces HTTPS traffic only.
+	EnableHTTPSTrafficOnly *bool `tf:"enable_https_traffic_only"`
+
+	// Enable infrastructure encryption for enhanced security.
+	EnableInfrastructureEncryption *bool `tf:"enable_infrastructure_encryption"`
+
+	// Enable lifecycle management policies.
+	EnableLifecycleManagement *bool `tf:"enable_lifecycle_management"`
+
+	// Enable network access rules for the storage account.
+	EnableNetworkRules *bool `tf:"enable_network_rules"`
+
+	// Enable private endpoints for the storage account.
+	EnablePrivateEndpoints *bool `tf:"enable_private_endpoints"`
+
+	// Enable public network access to the storage account.
+	EnablePublicNetworkAccess *bool `tf:"enable_public_network_access"`
+
+	// Enable queue logging.
+	EnableQueueLogging *bool `tf:"enable_queue_logging"`
+
+	// Enable queue metrics.
+	EnableQueueMetrics *bool `tf:"enable_queue_metrics"`
+
+	// Enable queue properties configuration.
+	EnableQueueProperties *bool `tf:"enable_queue_properties"`
+
+	// Enable file share properties configuration.
+	EnableShareProperties *bool `tf:"enable_share_properties"`
+
+	// Enable shared access key authentication.
+	EnableSharedAccessKey *bool `tf:"enable_shared_access_key"`
+
+	// Enable SMB settings for file shares.
+	EnableSmbSettings *bool `tf:"enable_smb_settings"`
+
+	// Enable static website hosting.
+	EnableStaticWebsite *bool `tf:"enable_static_website"`
+
+	// Environment name (dev, test, staging, prod, dr).
+	Environment string `tf:"environment"`
+
+	// List of file shares to create.
+	FileShares []FileShare `tf:"file_shares"`
+
+	// List of user assigned identity IDs.
+	IdentityIDs []string `tf:"identity_ids"`
+
+	// Type of managed identity.
+	IdentityType *string `tf:"identity_type"`
+
+	// Lifecycle management rules.
+	LifecycleRules []LifecycleRule `tf:"lifecycle_rules"`
+
+	// Short location code for naming convention.
+	LocationShort *string `tf:"location_short"`
+
+	// Minimum TLS version for requests.
+	MinTLSVersion *string `tf:"min_tls_version"`
+
+	// Name of the storage account. If use_naming_convention is true, this will be part of the generated name.
+	Name string `tf:"name"`
+
+	// Bypass network rules for Azure services.
+	NetworkBypass []string `tf:"network_bypass"`
+
+	// Default action for network rules.
+	NetworkDefaultAction *string `tf:"network_default_action"`
+
+	// Private DNS zone ID for blob private endpoint.
+	PrivateDNSZoneBlobID *string `tf:"private_dns_zone_blob_id"`
+
+	// Private DNS zone ID for file private endpoint.
+	PrivateDNSZoneFileID *string `tf:"private_dns_zone_file_id"`
+
+	// Subnet ID for private endpoints.
+	PrivateEndpointSubnetID *string `tf:"private_endpoint_subnet_id"`
+
+	// List of subresource names for private endpoints.
+	PrivateEndpointSubresourceNames []string `tf:"private_endpoint_subresource_names"`
+
+	// Private link access rules configuration.
+	PrivateLinkAccessRules []PrivateLinkAccessRule `tf:"private_link_access_rules"`
+
+	// CORS rules for queue service.
+	QueueCorsRules []QueueCorsRule `tf:"queue_cors_rules"`
+
+	// Log delete operations.
+	QueueLoggingDelete *bool `tf:"queue_logging_delete"`
+
+	// Log read operations.
+	QueueLoggingRead *bool `tf:"queue_logging_read"`
+
+	// Queue logging retention days.
+	QueueLoggingRetentionDays *float64 `tf:"queue_logging_retention_days"`
+
+	// Queue logging version.
+	QueueLoggingVersion *string `tf:"queue_logging_version"`
+
+	// Log write operations.
+	QueueLoggingWrite *bool `tf:"queue_logging_write"`
+
+	// Include API metrics in queue metrics.
+	QueueMetricsIncludeApis *bool `tf:"queue_metrics_include_apis"`
+
+	// Queue metrics retention days.
+	QueueMetricsRetentionDays *float64 `tf:"queue_metrics_retention_days"`
+
+	// Queue metrics version.
+	QueueMetricsVersion *string `tf:"queue_metrics_version"`
+
+	// List of storage queues to create.
+	Queues []Queue `tf:"queues"`
+
+	// Storage account replication type.
+	ReplicationType *string `tf:"replication_type"`
+
+	// Name of the resource group where the storage account will be created.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// CORS rules for file share service.
+	ShareCorsRules []ShareCorsRule `tf:"share_cors_rules"`
+
+	// Retention days for file shares.
+	ShareRetentionDays *float64 `tf:"share_retention_days"`
+
+	// SMB authentication types.
+	SmbAuthenticationTypes []string `tf:"smb_authentication_types"`
+
+	// SMB channel encryption type.
+	SmbChannelEncryption []string `tf:"smb_channel_encryption"`
+
+	// Kerberos ticket encryption type.
+	SmbKerberosTicketEncryption []string `tf:"smb_kerberos_ticket_encryption"`
+
+	// Supported SMB versions.
+	SmbVersions []string `tf:"smb_versions"`
+
+	// Error document for static website.
+	StaticWebsiteErrorDocument *string `tf:"static_website_error_document"`
+
+	// Index document for static website.
+	StaticWebsiteIndexDocument *string `tf:"static_website_index_document"`
+
+	// Additional tags specific to the storage account.
+	StorageAccountTags map[string]string `tf:"storage_account_tags"`
+
+	// List of storage tables to create.
+	Tables []Table `tf:"tables"`
+
+	// Use standardized naming convention for storage account.
+	UseNamingConvention *bool `tf:"use_naming_convention"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// BlobCorsRule is an object of the module's inputs.
+type BlobCorsRule struct {
+	AllowedHeaders  []string `tf:"allowed_headers"`
+	AllowedMethods  []string `tf:"allowed_methods"`
+	AllowedOrigins  []string `tf:"allowed_origins"`
+	ExposedHeaders  []string `tf:"exposed_headers"`
+	MaxAgeInSeconds float64  `tf:"max_age_in_seconds"`
+}
+
+// Container is an object of the module's inputs.
+type Container struct {
+	AccessType *string           `tf:"access_type"`
+	Metadata   map[string]string `tf:"metadata"`
+	Name       string            `tf:"name"`
+}
+
+// FileShare is an object of the module's inputs.
+type FileShare struct {
+	AccessTier *string           `tf:"access_tier"`
+	ACL        []FileShareACL    `tf:"acl"`
+	Metadata   map[string]string `tf:"metadata"`
+	Name       string            `tf:"name"`
+	Protocol   *string           `tf:"protocol"`
+	QuotaGB    *float64          `tf:"quota_gb"`
+}
+
+// FileShareACL is an object of the module's inputs.
+type FileShareACL struct {
+	AccessPolicy []FileShareACLAccessPolicy `tf:"access_policy"`
+	ID           string                     `tf:"id"`
+}
+
+// FileShareACLAccessPolicy is an object of the module's inputs.
+type FileShareACLAccessPolicy struct {
+	Expiry      *string `tf:"expiry"`
+	Permissions string  `tf:"permissions"`
+	Start       *string `tf:"start"`
+}
+
+// LifecycleRule is an object of the module's inputs.
+type LifecycleRule struct {
+	Actions LifecycleRuleActions `tf:"actions"`
+	Enabled *bool                `tf:"enabled"`
+	Filters LifecycleRuleFilters `tf:"filters"`
+	Name    string               `tf:"name"`
+}
+
+// LifecycleRuleActions is an object of the module's inputs.
+type LifecycleRuleActions struct {
+	BaseBlob *LifecycleRuleActionsBaseBlob `tf:"base_blob"`
+	Snapshot *LifecycleRuleActionsSnapshot `tf:"snapshot"`
+	Version  *LifecycleRuleActionsVersion  `tf:"version"`
+}
+
+// LifecycleRuleActionsBaseBlob is an object of the module's inputs.
+type LifecycleRuleActionsBaseBlob struct {
+	DeleteAfterDays                  *float64 `tf:"delete_after_days"`
+	DeleteAfterLastAccessDays        *float64 `tf:"delete_after_last_access_days"`
+	TierToArchiveAfterDays           *float64 `tf:"tier_to_archive_after_days"`
+	TierToArchiveAfterLastAccessDays *float64 `tf:"tier_to_archive_after_last_access_days"`
+	TierToCoolAfterDays              *float64 `tf:"tier_to_cool_after_days"`
+	TierToCoolAfterLastAccessDays    *float64 `tf:"tier_to_cool_after_last_access_days"`
+}
+
+// LifecycleRuleActionsSnapshot is an object of the module's inputs.
+type LifecycleRuleActionsSnapshot struct {
+	DeleteAfterDays        *float64 `tf:"delete_after_days"`
+	TierToArchiveAfterDays *float64 `tf:"tier_to_archive_after_days"`
+	TierToCoolAfterDays    *float64 `tf:"tier_to_cool_after_days"`
+}
+
+// LifecycleRuleActionsVersion is an object of the module's inputs.
+type LifecycleRuleActionsVersion struct {
+	DeleteAfterDays        *float64 `tf:"delete_after_days"`
+	TierToArchiveAfterDays *float64 `tf:"tier_to_archive_after_days"`
+	TierToCoolAfterDays    *float64 `tf:"tier_to_cool_after_days"`
+}
+
+// LifecycleRuleFilters is an object of the module's inputs.
+type LifecycleRuleFilters struct {
+	BlobTypes         []string                                `tf:"blob_types"`
+	MatchBlobIndexTag []LifecycleRuleFiltersMatchBlobIndexTag `tf:"match_blob_index_tag"`
+	PrefixMatch       []string                                `tf:"prefix_match"`
+}
+
+// LifecycleRuleFiltersMatchBlobIndexTag is an object of the module's inputs.
+type LifecycleRuleFiltersMatchBlobIndexTag struct {
+	Name      string  `tf:"name"`
+	Operation *string `tf:"operation"`
+	Value     string  `tf:"value"`
+}
+
+// PrivateLinkAccessRule is an object of the module's inputs.
+type PrivateLinkAccessRule struct {
+	EndpointResourceID string  `tf:"endpoint_resource_id"`
+	EndpointTenantID   *string `tf:"endpoint_tenant_id"`
+}
+
+// QueueCorsRule is an object of the module's inputs.
+type QueueCorsRule struct {
+	AllowedHeaders  []string `tf:"allowed_headers"`
+	AllowedMethods  []string `tf:"allowed_methods"`
+	AllowedOrigins  []string `tf:"allowed_origins"`
+	ExposedHeaders  []string `tf:"exposed_headers"`
+	MaxAgeInSeconds float64  `tf:"max_age_in_seconds"`
+}
+
+// Queue is an object of the module's inputs.
+type Queue struct {
+	Metadata map[string]string `tf:"metadata"`
+	Name     string            `tf:"name"`
+}
+
+// ShareCorsRule is an object of the module's inputs.
+type ShareCorsRule struct {
+	AllowedHeaders  []string `tf:"allowed_headers"`
+	AllowedMethods  []string `tf:"allowed_methods"`
+	AllowedOrigins  []string `tf:"allowed_origins"`
+	ExposedHeaders  []string `tf:"exposed_headers"`
+	MaxAgeInSeconds float64  `tf:"max_age_in_seconds"`
+}
+
+// Table is an object of the module's inputs.
+type Table struct {
+	ACL  []TableACL `tf:"acl"`
+	Name string     `tf:"name"`
+}
+
+// TableACL is an object of the module's inputs.
+type TableACL struct {
+	AccessPolicy []TableACLAccessPolicy `tf:"access_policy"`
+	ID           string                 `tf:"id"`
+}
+
+// TableACLAccessPolicy is an object of the module's inputs.
+type TableACLAccessPolicy struct {
+	Expiry      *string `tf:"expiry"`
+	Permissions string  `tf:"permissions"`
+	Start       *string `tf:"start"`
+}
@@ -0,0 +1,502 @@
+// Code generated by zrr-gen from azure/infrastructure/storage-account/outputs.tf. DO NOT EDIT.
+
+package storageaccount
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/storage-account once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// Containers returns the containers output: Map of created storage containers.
+func (o Outputs) Containers() map[string]ContainerOutput {
+	var value map[string]ContainerOutput
+	terraform.OutputStruct(o.t, o.options, "containers", &value)
+	return value
+}
+
+// FileShares returns the file_shares output: Map of created file shares.
+func (o Outputs) FileShares() map[string]FileShareOutput {
+	var value map[string]FileShareOutput
+	terraform.OutputStruct(o.t, o.options, "file_shares", &value)
+	return value
+}
+
+// Identity returns the identity output: The managed identity of the storage
+// account.
+func (o Outputs) Identity() *IdentityOutput {
+	var value *IdentityOutput
+	terraform.OutputStruct(o.t, o.options, "identity", &value)
+	return value
+}
+
+// LifecycleManagementPolicyID returns the lifecycle_management_policy_id
+// output: The ID of the lifecycle management policy.
+func (o Outputs) LifecycleManagementPolicyID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "lifecycle_management_policy_id", &value)
+	return value
+}
+
+// Location returns the location output: The location of the storage account.
+func (o Outputs) Location() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "location", &value)
+	return value
+}
+
+// NetworkRules returns the network_rules output: Network rules configuration.
+func (o Outputs) NetworkRules() *NetworkRulesOutput {
+	var value *NetworkRulesOutput
+	terraform.OutputStruct(o.t, o.options, "network_rules", &value)
+	return value
+}
+
+// PrimaryAccessKey returns the primary_access_key output: The primary access
+// key for the storage account.
+//
+// The output is sensitive.
+func (o Outputs) PrimaryAccessKey() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_access_key", &value)
+	return value
+}
+
+// PrimaryBlobConnectionString returns the primary_blob_connection_string
+// output: The connection string associated with the primary blob location.
+//
+// The output is sensitive.
+func (o Outputs) PrimaryBlobConnectionString() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_blob_connection_string", &value)
+	return value
+}
+
+// PrimaryBlobEndpoint returns the primary_blob_endpoint output: The endpoint
+// URL for blob storage in the primary location.
+func (o Outputs) PrimaryBlobEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_blob_endpoint", &value)
+	return value
+}
+
+// PrimaryBlobHost returns the primary_blob_host output: The hostname with port
+// if applicable for blob storage in the primary location.
+func (o Outputs) PrimaryBlobHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_blob_host", &value)
+	return value
+}
+
+// PrimaryConnectionString returns the primary_connection_string output: The
+// primary connection string for the storage account.
+//
+// The output is sensitive.
+func (o Outputs) PrimaryConnectionString() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_connection_string", &value)
+	return value
+}
+
+// PrimaryDfsEndpoint returns the primary_dfs_endpoint output: The endpoint URL
+// for DFS storage in the primary location.
+func (o Outputs) PrimaryDfsEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_dfs_endpoint", &value)
+	return value
+}
+
+// PrimaryDfsHost returns the primary_dfs_host output: The hostname with port if
+// applicable for DFS storage in the primary location.
+func (o Outputs) PrimaryDfsHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_dfs_host", &value)
+	return value
+}
+
+// PrimaryFileEndpoint returns the primary_file_endpoint output: The endpoint
+// URL for file storage in the primary location.
+func (o Outputs) PrimaryFileEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_file_endpoint", &value)
+	return value
+}
+
+// PrimaryFileHost returns the primary_file_host output: The hostname with port
+// if applicable for file storage in the primary location.
+func (o Outputs) PrimaryFileHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_file_host", &value)
+	return value
+}
+
+// PrimaryQueueEndpoint returns the primary_queue_endpoint output: The endpoint
+// URL for queue storage in the primary location.
+func (o Outputs) PrimaryQueueEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_queue_endpoint", &value)
+	return value
+}
+
+// PrimaryQueueHost returns the primary_queue_host output: The hostname with
+// port if applicable for queue storage in the primary location.
+func (o Outputs) PrimaryQueueHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_queue_host", &value)
+	return value
+}
+
+// PrimaryTableEndpoint returns the primary_table_endpoint output: The endpoint
+// URL for table storage in the primary location.
+func (o Outputs) PrimaryTableEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_table_endpoint", &value)
+	return value
+}
+
+// PrimaryTableHost returns the primary_table_host output: The hostname with
+// port if applicable for table storage in the primary location.
+func (o Outputs) PrimaryTableHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_table_host", &value)
+	return value
+}
+
+// PrimaryWebEndpoint returns the primary_web_endpoint output: The endpoint URL
+// for web storage in the primary location.
+func (o Outputs) PrimaryWebEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_web_endpoint", &value)
+	return value
+}
+
+// PrimaryWebHost returns the primary_web_host output: The hostname with port if
+// applicable for web storage in the primary location.
+func (o Outputs) PrimaryWebHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "primary_web_host", &value)
+	return value
+}
+
+// PrivateEndpointBlob returns the private_endpoint_blob output: Blob private
+// endpoint details.
+func (o Outputs) PrivateEndpointBlob() *PrivateEndpointBlobOutput {
+	var value *PrivateEndpointBlobOutput
+	terraform.OutputStruct(o.t, o.options, "private_endpoint_blob", &value)
+	return value
+}
+
+// PrivateEndpointFile returns the private_endpoint_file output: File private
+// endpoint details.
+func (o Outputs) PrivateEndpointFile() *PrivateEndpointFileOutput {
+	var value *PrivateEndpointFileOutput
+	terraform.OutputStruct(o.t, o.options, "private_endpoint_file", &value)
+	return value
+}
+
+// Queues returns the queues output: Map of created storage queues.
+func (o Outputs) Queues() map[string]QueueOutput {
+	var value map[string]QueueOutput
+	terraform.OutputStruct(o.t, o.options, "queues", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: The name of the
+// resource group.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// SecondaryAccessKey returns the secondary_access_key output: The secondary
+// access key for the storage account.
+//
+// The output is sensitive.
+func (o Outputs) SecondaryAccessKey() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_access_key", &value)
+	return value
+}
+
+// SecondaryBlobConnectionString returns the secondary_blob_connection_string
+// output: The connection string associated with the secondary blob location.
+//
+// The output is sensitive.
+func (o Outputs) SecondaryBlobConnectionString() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_blob_connection_string", &value)
+	return value
+}
+
+// SecondaryBlobEndpoint returns the secondary_blob_endpoint output: The
+// endpoint URL for blob storage in the secondary location.
+func (o Outputs) SecondaryBlobEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_blob_endpoint", &value)
+	return value
+}
+
+// SecondaryBlobHost returns the secondary_blob_host output: The hostname with
+// port if applicable for blob storage in the secondary location.
+func (o Outputs) SecondaryBlobHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_blob_host", &value)
+	return value
+}
+
+// SecondaryConnectionString returns the secondary_connection_string output: The
+// secondary connection string for the storage account.
+//
+// The output is sensitive.
+func (o Outputs) SecondaryConnectionString() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_connection_string", &value)
+	return value
+}
+
+// SecondaryDfsEndpoint returns the secondary_dfs_endpoint output: The endpoint
+// URL for DFS storage in the secondary location.
+func (o Outputs) SecondaryDfsEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_dfs_endpoint", &value)
+	return value
+}
+
+// SecondaryDfsHost returns the secondary_dfs_host output: The hostname with
+// port if applicable for DFS storage in the secondary location.
+func (o Outputs) SecondaryDfsHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_dfs_host", &value)
+	return value
+}
+
+// SecondaryFileEndpoint returns the secondary_file_endpoint output: The
+// endpoint URL for file storage in the secondary location.
+func (o Outputs) SecondaryFileEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_file_endpoint", &value)
+	return value
+}
+
+// SecondaryFileHost returns the secondary_file_host output: The hostname with
+// port if applicable for file storage in the secondary location.
+func (o Outputs) SecondaryFileHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_file_host", &value)
+	return value
+}
+
+// SecondaryQueueEndpoint returns the secondary_queue_endpoint output: The
+// endpoint URL for queue storage in the secondary location.
+func (o Outputs) SecondaryQueueEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_queue_endpoint", &value)
+	return value
+}
+
+// SecondaryQueueHost returns the secondary_queue_host output: The hostname with
+// port if applicable for queue storage in the secondary location.
+func (o Outputs) SecondaryQueueHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_queue_host", &value)
+	return value
+}
+
+// SecondaryTableEndpoint returns the secondary_table_endpoint output: The
+// endpoint URL for table storage in the secondary location.
+func (o Outputs) SecondaryTableEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_table_endpoint", &value)
+	return value
+}
+
+// SecondaryTableHost returns the secondary_table_host output: The hostname with
+// port if applicable for table storage in the secondary location.
+func (o Outputs) SecondaryTableHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_table_host", &value)
+	return value
+}
+
+// SecondaryWebEndpoint returns the secondary_web_endpoint output: The endpoint
+// URL for web storage in the secondary location.
+func (o Outputs) SecondaryWebEndpoint() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_web_endpoint", &value)
+	return value
+}
+
+// SecondaryWebHost returns the secondary_web_host output: The hostname with
+// port if applicable for web storage in the secondary location.
+func (o Outputs) SecondaryWebHost() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "secondary_web_host", &value)
+	return value
+}
+
+// StaticWebsite returns the static_website output: Static website
+// configuration.
+func (o Outputs) StaticWebsite() *StaticWebsiteOutput {
+	var value *StaticWebsiteOutput
+	terraform.OutputStruct(o.t, o.options, "static_website", &value)
+	return value
+}
+
+// StorageAccountID returns the storage_account_id output: The ID of the storage
+// account.
+func (o Outputs) StorageAccountID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_id", &value)
+	return value
+}
+
+// StorageAccountKind returns the storage_account_kind output: The kind of the
+// storage account.
+func (o Outputs) StorageAccountKind() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_kind", &value)
+	return value
+}
+
+// StorageAccountName returns the storage_account_name output: The name of the
+// storage account.
+func (o Outputs) StorageAccountName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_name", &value)
+	return value
+}
+
+// StorageAccountPrimaryLocation returns the storage_account_primary_location
+// output: The primary location of the storage account.
+func (o Outputs) StorageAccountPrimaryLocation() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_primary_location", &value)
+	return value
+}
+
+// StorageAccountReplicationType returns the storage_account_replication_type
+// output: The replication type of the storage account.
+func (o Outputs) StorageAccountReplicationType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_replication_type", &value)
+	return value
+}
+
+// StorageAccountSecondaryLocation returns the
+// storage_account_secondary_location output: The secondary location of the
+// storage account.
+func (o Outputs) StorageAccountSecondaryLocation() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_secondary_location", &value)
+	return value
+}
+
+// StorageAccountTier returns the storage_account_tier output: The tier of the
+// storage account.
+func (o Outputs) StorageAccountTier() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_tier", &value)
+	return value
+}
+
+// Tables returns the tables output: Map of created storage tables.
+func (o Outputs) Tables() map[string]TableOutput {
+	var value map[string]TableOutput
+	terraform.OutputStruct(o.t, o.options, "tables", &value)
+	return value
+}
+
+// Tags returns the tags output: The tags applied to the storage account.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// ContainerOutput is an object of the module's outputs.
+type ContainerOutput struct {
+	ContainerAccessType   string            `json:"container_access_type"`
+	HasImmutabilityPolicy bool              `json:"has_immutability_policy"`
+	HasLegalHold          bool              `json:"has_legal_hold"`
+	ID                    string            `json:"id"`
+	Metadata              map[string]string `json:"metadata"`
+	Name                  string            `json:"name"`
+	ResourceManagerID     string            `json:"resource_manager_id"`
+}
+
+// FileShareOutput is an object of the module's outputs.
+type FileShareOutput struct {
+	AccessTier        string            `json:"access_tier"`
+	EnabledProtocol   string            `json:"enabled_protocol"`
+	ID                string            `json:"id"`
+	Metadata          map[string]string `json:"metadata"`
+	Name              string            `json:"name"`
+	Quota             int               `json:"quota"`
+	ResourceManagerID string            `json:"resource_manager_id"`
+	URL               string            `json:"url"`
+}
+
+// IdentityOutput is an object of the module's outputs.
+type IdentityOutput struct {
+	IdentityIDs string `json:"identity_ids"`
+	PrincipalID string `json:"principal_id"`
+	TenantID    string `json:"tenant_id"`
+	Type        string `json:"type"`
+}
+
+// NetworkRulesOutput is an object of the module's outputs.
+type NetworkRulesOutput struct {
+	Bypass                  []string `json:"bypass"`
+	DefaultAction           string   `json:"default_action"`
+	IPRules                 []string `json:"ip_rules"`
+	VirtualNetworkSubnetIDs []string `json:"virtual_network_subnet_ids"`
+}
+
+// PrivateEndpointBlobOutput is an object of the module's outputs.
+type PrivateEndpointBlobOutput struct {
+	ID                         string `json:"id"`
+	Name                       string `json:"name"`
+	NetworkInterfaceIDs        string `json:"network_interface_ids"`
+	PrivateIPAddress           string `json:"private_ip_address"`
+	PrivateServiceConnectionID string `json:"private_service_connection_id"`
+}
+
+// PrivateEndpointFileOutput is an object of the module's outputs.
+type PrivateEndpointFileOutput struct {
+	ID                         string `json:"id"`
+	Name                       string `json:"name"`
+	NetworkInterfaceIDs        string `json:"network_interface_ids"`
+	PrivateIPAddress           string `json:"private_ip_address"`
+	PrivateServiceConnectionID string `json:"private_service_connection_id"`
+}
+
+// QueueOutput is an object of the module's outputs.
+type QueueOutput struct {
+	ID                string            `json:"id"`
+	Metadata          map[string]string `json:"metadata"`
+	Name              string            `json:"name"`
+	ResourceManagerID string            `json:"resource_manager_id"`
+}
+
+// StaticWebsiteOutput is an object of the module's outputs.
+type StaticWebsiteOutput struct {
+	Error404Document string `json:"error_404_document"`
+	IndexDocument    string `json:"index_document"`
+}
+
+// TableOutput is an object of the module's outputs.
+type TableOutput struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
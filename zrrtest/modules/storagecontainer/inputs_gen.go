@@ -0,0 +1,80 @@
+// Code generated by zrr-gen from azure/infrastructure/storage-container/variables.tf. DO NOT EDIT.
+
+// Package storagecontainer holds the typed inputs and outputs of azure/infrastructure/storage-container, for its tests.
+package storagecontainer
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/storage-container. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// The access level configured for this container.
+	ContainerAccessType *string `tf:"container_access_type"`
+
+	// Configuration for immutability policy on the container.
+	ImmutabilityPolicy *ImmutabilityPolicy `tf:"immutability_policy"`
+
+	// Configuration for legal hold on the container.
+	LegalHold *LegalHold `tf:"legal_hold"`
+
+	// List of lifecycle management rules for the container.
+	LifecycleRules []LifecycleRule `tf:"lifecycle_rules"`
+
+	// A map of custom metadata to assign to the storage container.
+	Metadata *Metadata `tf:"metadata"`
+
+	// Name of the storage container.
+	Name string `tf:"name"`
+
+	// Resource ID of the storage account.
+	StorageAccountID *string `tf:"storage_account_id"`
+
+	// Name of the storage account.
+	StorageAccountName *string `tf:"storage_account_name"`
+
+	// Resource group name of the storage account (required when using storage_account_name).
+	StorageAccountResourceGroupName *string `tf:"storage_account_resource_group_name"`
+
+	// Additional tags specific to the storage container.
+	StorageContainerTags map[string]string `tf:"storage_container_tags"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// ImmutabilityPolicy is an object of the module's inputs.
+type ImmutabilityPolicy struct {
+	Locked       bool    `tf:"locked"`
+	PeriodInDays float64 `tf:"period_in_days"`
+}
+
+// LegalHold is an object of the module's inputs.
+type LegalHold struct {
+	Tags []string `tf:"tags"`
+}
+
+// LifecycleRule is an object of the module's inputs.
+type LifecycleRule struct {
+	BlobTypes               []string `tf:"blob_types"`
+	DeleteAfterDays         *float64 `tf:"delete_after_days"`
+	Enabled                 bool     `tf:"enabled"`
+	Name                    string   `tf:"name"`
+	PrefixMatch             []string `tf:"prefix_match"`
+	SnapshotDeleteAfterDays *float64 `tf:"snapshot_delete_after_days"`
+	TierToArchiveAfterDays  *float64 `tf:"tier_to_archive_after_days"`
+	TierToCoolAfterDays     *float64 `tf:"tier_to_cool_after_days"`
+	VersionDeleteAfterDays  *float64 `tf:"version_delete_after_days"`
+}
+
+// Metadata is an object of the module's inputs.
+type Metadata struct {
+	Environment *string `tf:"environment"`
+	Owner       *string `tf:"owner"`
+	Project     *string `tf:"project"`
+	Purpose     *string `tf:"purpose"`
+}
@@ -0,0 +1,161 @@
+// Code generated by zrr-gen from azure/infrastructure/storage-container/outputs.tf. DO NOT EDIT.
+
+package storagecontainer
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/storage-container once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// ContainerAccessType returns the container_access_type output: The access type
+// of the container.
+func (o Outputs) ContainerAccessType() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "container_access_type", &value)
+	return value
+}
+
+// ContainerURL returns the container_url output: The URL of the storage
+// container.
+func (o Outputs) ContainerURL() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "container_url", &value)
+	return value
+}
+
+// HasImmutabilityPolicy returns the has_immutability_policy output: Whether the
+// container has an immutability policy.
+func (o Outputs) HasImmutabilityPolicy() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "has_immutability_policy", &value)
+	return value
+}
+
+// HasLegalHold returns the has_legal_hold output: Whether the container has a
+// legal hold.
+func (o Outputs) HasLegalHold() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "has_legal_hold", &value)
+	return value
+}
+
+// ID returns the id output: The ID of the storage container.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// ImmutabilityPeriodDays returns the immutability_period_days output: The
+// immutability period in days (if configured).
+func (o Outputs) ImmutabilityPeriodDays() *float64 {
+	var value *float64
+	terraform.OutputStruct(o.t, o.options, "immutability_period_days", &value)
+	return value
+}
+
+// ImmutabilityPolicyEnabled returns the immutability_policy_enabled output:
+// Whether immutability policy is configured for the container.
+func (o Outputs) ImmutabilityPolicyEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "immutability_policy_enabled", &value)
+	return value
+}
+
+// ImmutabilityPolicyLocked returns the immutability_policy_locked output:
+// Whether the immutability policy is locked (if configured).
+func (o Outputs) ImmutabilityPolicyLocked() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "immutability_policy_locked", &value)
+	return value
+}
+
+// LegalHoldEnabled returns the legal_hold_enabled output: Whether legal hold is
+// configured for the container.
+func (o Outputs) LegalHoldEnabled() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "legal_hold_enabled", &value)
+	return value
+}
+
+// LegalHoldTags returns the legal_hold_tags output: The legal hold tags (if
+// configured).
+func (o Outputs) LegalHoldTags() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "legal_hold_tags", &value)
+	return value
+}
+
+// LifecycleRulesCount returns the lifecycle_rules_count output: The number of
+// lifecycle rules configured.
+func (o Outputs) LifecycleRulesCount() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "lifecycle_rules_count", &value)
+	return value
+}
+
+// ManagementPolicyID returns the management_policy_id output: The ID of the
+// storage management policy (if created).
+func (o Outputs) ManagementPolicyID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "management_policy_id", &value)
+	return value
+}
+
+// Metadata returns the metadata output: The metadata assigned to the storage
+// container.
+func (o Outputs) Metadata() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "metadata", &value)
+	return value
+}
+
+// Name returns the name output: The name of the storage container.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// ResourceManagerID returns the resource_manager_id output: The Resource
+// Manager ID of the storage container.
+func (o Outputs) ResourceManagerID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_manager_id", &value)
+	return value
+}
+
+// SecurityFeatures returns the security_features output: Summary of security
+// features enabled on the container.
+func (o Outputs) SecurityFeatures() SecurityFeaturesOutput {
+	var value SecurityFeaturesOutput
+	terraform.OutputStruct(o.t, o.options, "security_features", &value)
+	return value
+}
+
+// StorageAccountName returns the storage_account_name output: The name of the
+// storage account containing the container.
+func (o Outputs) StorageAccountName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_name", &value)
+	return value
+}
+
+// SecurityFeaturesOutput is an object of the module's outputs.
+type SecurityFeaturesOutput struct {
+	AccessType            string `json:"access_type"`
+	HasImmutabilityPolicy bool   `json:"has_immutability_policy"`
+	HasLegalHold          bool   `json:"has_legal_hold"`
+	LifecycleRulesCount   int    `json:"lifecycle_rules_count"`
+}
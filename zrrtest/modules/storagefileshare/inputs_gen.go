@@ -0,0 +1,145 @@
+// Code generated by zrr-gen from azure/infrastructure/storage-file-share/variables.tf. DO NOT EDIT.
+
+// Package storagefileshare holds the typed inputs and outputs of azure/infrastructure/storage-file-share, for its tests.
+package storagefileshare
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/storage-file-share. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// List of access policies for the file share.
+	AccessPolicies []AccessPolicy `tf:"access_policies"`
+
+	// Access tier for the file share.
+	AccessTier *string `tf:"access_tier"`
+
+	// List of email addresses to receive alerts.
+	AlertEmailAddresses []string `tf:"alert_email_addresses"`
+
+	// Backup policy configuration.
+	BackupPolicy *BackupPolicy `tf:"backup_policy"`
+
+	// Enable public network access to backup vault.
+	BackupPublicAccessEnabled *bool `tf:"backup_public_access_enabled"`
+
+	// Enable soft delete for backup vault.
+	BackupSoftDeleteEnabled *bool `tf:"backup_soft_delete_enabled"`
+
+	// Name of the backup vault (if null, will be auto-generated).
+	BackupVaultName *string `tf:"backup_vault_name"`
+
+	// SKU for the backup vault.
+	BackupVaultSKU *string `tf:"backup_vault_sku"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// List of directories to create in the file share.
+	Directories []Directory `tf:"directories"`
+
+	// Enable backup for the file share.
+	EnableBackup *bool `tf:"enable_backup"`
+
+	// Enable monitoring and alerting for the file share.
+	EnableMonitoring *bool `tf:"enable_monitoring"`
+
+	// Enable private endpoint for the storage account.
+	EnablePrivateEndpoint *bool `tf:"enable_private_endpoint"`
+
+	// Protocol enabled for the file share.
+	EnabledProtocol *string `tf:"enabled_protocol"`
+
+	// Additional tags specific to the file share.
+	FileShareTags map[string]string `tf:"file_share_tags"`
+
+	// Azure region where resources will be created.
+	Location *string `tf:"location"`
+
+	// Metadata for the file share.
+	Metadata map[string]string `tf:"metadata"`
+
+	// Name of the file share.
+	Name string `tf:"name"`
+
+	// Private DNS zone ID for the private endpoint.
+	PrivateDNSZoneID *string `tf:"private_dns_zone_id"`
+
+	// Subnet ID for the private endpoint.
+	PrivateEndpointSubnetID *string `tf:"private_endpoint_subnet_id"`
+
+	// Severity level for quota alerts.
+	QuotaAlertSeverity *float64 `tf:"quota_alert_severity"`
+
+	// Threshold percentage for quota usage alert (0 to disable).
+	QuotaAlertThresholdPercentage *float64 `tf:"quota_alert_threshold_percentage"`
+
+	// Maximum size of the file share in GB.
+	QuotaGB *float64 `tf:"quota_gb"`
+
+	// Name of the resource group.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// Name of the storage account where the file share will be created.
+	StorageAccountName string `tf:"storage_account_name"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// AccessPolicy is an object of the module's inputs.
+type AccessPolicy struct {
+	AccessPolicies []AccessPolicyAccessPolicy `tf:"access_policies"`
+	ID             string                     `tf:"id"`
+}
+
+// AccessPolicyAccessPolicy is an object of the module's inputs.
+type AccessPolicyAccessPolicy struct {
+	Expiry      string `tf:"expiry"`
+	Permissions string `tf:"permissions"`
+	Start       string `tf:"start"`
+}
+
+// BackupPolicy is an object of the module's inputs.
+type BackupPolicy struct {
+	Frequency        string                        `tf:"frequency"`
+	RetentionDaily   *BackupPolicyRetentionDaily   `tf:"retention_daily"`
+	RetentionMonthly *BackupPolicyRetentionMonthly `tf:"retention_monthly"`
+	RetentionWeekly  *BackupPolicyRetentionWeekly  `tf:"retention_weekly"`
+	RetentionYearly  *BackupPolicyRetentionYearly  `tf:"retention_yearly"`
+	Time             string                        `tf:"time"`
+}
+
+// BackupPolicyRetentionDaily is an object of the module's inputs.
+type BackupPolicyRetentionDaily struct {
+	Count float64 `tf:"count"`
+}
+
+// BackupPolicyRetentionMonthly is an object of the module's inputs.
+type BackupPolicyRetentionMonthly struct {
+	Count    float64  `tf:"count"`
+	Weekdays []string `tf:"weekdays"`
+	Weeks    []string `tf:"weeks"`
+}
+
+// BackupPolicyRetentionWeekly is an object of the module's inputs.
+type BackupPolicyRetentionWeekly struct {
+	Count    float64  `tf:"count"`
+	Weekdays []string `tf:"weekdays"`
+}
+
+// BackupPolicyRetentionYearly is an object of the module's inputs.
+type BackupPolicyRetentionYearly struct {
+	Count    float64  `tf:"count"`
+	Months   []string `tf:"months"`
+	Weekdays []string `tf:"weekdays"`
+	Weeks    []string `tf:"weeks"`
+}
+
+// Directory is an object of the module's inputs.
+type Directory struct {
+	Metadata map[string]string `tf:"metadata"`
+	Name     string            `tf:"name"`
+}
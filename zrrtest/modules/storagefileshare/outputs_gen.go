@@ -0,0 +1,163 @@
+// Code generated by zrr-gen from azure/infrastructure/storage-file-share/outputs.tf. DO NOT EDIT.
+
+package storagefileshare
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/storage-file-share once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// AccessTier returns the access_tier output: Access tier of the file share.
+func (o Outputs) AccessTier() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "access_tier", &value)
+	return value
+}
+
+// ActionGroupID returns the action_group_id output: ID of the monitor action
+// group (if monitoring is enabled).
+func (o Outputs) ActionGroupID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "action_group_id", &value)
+	return value
+}
+
+// BackupPolicyID returns the backup_policy_id output: ID of the backup policy
+// (if backup is enabled).
+func (o Outputs) BackupPolicyID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "backup_policy_id", &value)
+	return value
+}
+
+// BackupProtectedFileShareID returns the backup_protected_file_share_id output:
+// ID of the protected file share backup (if backup is enabled).
+func (o Outputs) BackupProtectedFileShareID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "backup_protected_file_share_id", &value)
+	return value
+}
+
+// BackupVaultID returns the backup_vault_id output: ID of the backup vault (if
+// backup is enabled).
+func (o Outputs) BackupVaultID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "backup_vault_id", &value)
+	return value
+}
+
+// BackupVaultName returns the backup_vault_name output: Name of the backup
+// vault (if backup is enabled).
+func (o Outputs) BackupVaultName() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "backup_vault_name", &value)
+	return value
+}
+
+// Directories returns the directories output: Created directories in the file
+// share.
+func (o Outputs) Directories() map[string]DirectoryOutput {
+	var value map[string]DirectoryOutput
+	terraform.OutputStruct(o.t, o.options, "directories", &value)
+	return value
+}
+
+// EnabledProtocol returns the enabled_protocol output: Enabled protocol for the
+// file share.
+func (o Outputs) EnabledProtocol() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "enabled_protocol", &value)
+	return value
+}
+
+// ID returns the id output: ID of the storage file share.
+func (o Outputs) ID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "id", &value)
+	return value
+}
+
+// Metadata returns the metadata output: Metadata of the file share.
+func (o Outputs) Metadata() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "metadata", &value)
+	return value
+}
+
+// Name returns the name output: Name of the storage file share.
+func (o Outputs) Name() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "name", &value)
+	return value
+}
+
+// PrivateEndpointID returns the private_endpoint_id output: ID of the private
+// endpoint (if enabled).
+func (o Outputs) PrivateEndpointID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "private_endpoint_id", &value)
+	return value
+}
+
+// PrivateEndpointIPAddresses returns the private_endpoint_ip_addresses output:
+// Private IP addresses of the private endpoint (if enabled).
+func (o Outputs) PrivateEndpointIPAddresses() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "private_endpoint_ip_addresses", &value)
+	return value
+}
+
+// QuotaAlertID returns the quota_alert_id output: ID of the quota metric alert
+// (if monitoring is enabled).
+func (o Outputs) QuotaAlertID() *string {
+	var value *string
+	terraform.OutputStruct(o.t, o.options, "quota_alert_id", &value)
+	return value
+}
+
+// QuotaGB returns the quota_gb output: Quota of the file share in GB.
+func (o Outputs) QuotaGB() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "quota_gb", &value)
+	return value
+}
+
+// StorageAccountName returns the storage_account_name output: Name of the
+// storage account containing the file share.
+func (o Outputs) StorageAccountName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "storage_account_name", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the resources.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// URL returns the url output: URL of the storage file share.
+func (o Outputs) URL() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "url", &value)
+	return value
+}
+
+// DirectoryOutput is an object of the module's outputs.
+type DirectoryOutput struct {
+	ID       string            `json:"id"`
+	Metadata map[string]string `json:"metadata"`
+	Name     string            `json:"name"`
+}
@@ -0,0 +1,122 @@
+// Code generated by zrr-gen from azure/infrastructure/virtual-network/variables.tf. DO NOT EDIT.
+
+// Package virtualnetwork holds the typed inputs and outputs of azure/infrastructure/virtual-network, for its tests.
+package virtualnetwork
+
+import "github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tfvars"
+
+// Inputs are the variables of azure/infrastructure/virtual-network. Required variables are values; optional
+// ones are nil unless set, so that the module's defaults apply.
+type Inputs struct {
+	// Address space for the virtual network (CIDR notation).
+	AddressSpace []string `tf:"address_space"`
+
+	// Automatically calculate subnet addresses based on the VNet address space.
+	AutoCalculateSubnets *bool `tf:"auto_calculate_subnets"`
+
+	// Common tags to be applied to all resources.
+	CommonTags map[string]string `tf:"common_tags"`
+
+	// Create default NSG rules for security baseline.
+	CreateDefaultNsgRules *bool `tf:"create_default_nsg_rules"`
+
+	// ID of the DDoS protection plan to associate with the VNet.
+	DdosProtectionPlanID *string `tf:"ddos_protection_plan_id"`
+
+	// List of custom DNS servers for the VNet.
+	DNSServers []string `tf:"dns_servers"`
+
+	// Enable DDoS protection plan for the VNet.
+	EnableDdosProtection *bool `tf:"enable_ddos_protection"`
+
+	// Enable Network Watcher flow logs for NSGs.
+	EnableFlowLogs *bool `tf:"enable_flow_logs"`
+
+	// Enable traffic analytics for flow logs.
+	EnableTrafficAnalytics *bool `tf:"enable_traffic_analytics"`
+
+	// Environment name (e.g., dev, staging, prod).
+	Environment *string `tf:"environment"`
+
+	// Number of days to retain flow logs.
+	FlowLogRetentionDays *float64 `tf:"flow_log_retention_days"`
+
+	// Storage account ID for storing flow logs.
+	FlowLogStorageAccountID *string `tf:"flow_log_storage_account_id"`
+
+	// Short abbreviation for the Azure region (e.g., eus for eastus).
+	LocationShort *string `tf:"location_short"`
+
+	// Log Analytics workspace ID for traffic analytics.
+	LogAnalyticsWorkspaceID *string `tf:"log_analytics_workspace_id"`
+
+	// Region of the Log Analytics workspace.
+	LogAnalyticsWorkspaceRegion *string `tf:"log_analytics_workspace_region"`
+
+	// Resource ID of the Log Analytics workspace.
+	LogAnalyticsWorkspaceResourceID *string `tf:"log_analytics_workspace_resource_id"`
+
+	// Name of the virtual network.
+	Name string `tf:"name"`
+
+	// Name of the Network Watcher instance.
+	NetworkWatcherName *string `tf:"network_watcher_name"`
+
+	// Resource group name of the Network Watcher.
+	NetworkWatcherResourceGroupName *string `tf:"network_watcher_resource_group_name"`
+
+	// Name of the resource group where the VNet will be created.
+	ResourceGroupName string `tf:"resource_group_name"`
+
+	// List of subnets to create within the VNet.
+	Subnets []Subnet `tf:"subnets"`
+
+	// Use ZRR naming convention for resources.
+	UseNamingConvention *bool `tf:"use_naming_convention"`
+
+	// Map of VNet peering configurations.
+	VnetPeerings map[string]VnetPeering `tf:"vnet_peerings"`
+
+	// Additional tags specific to the virtual network.
+	VnetTags map[string]string `tf:"vnet_tags"`
+}
+
+// Vars returns the inputs as terraform.Options.Vars.
+func (in Inputs) Vars() map[string]interface{} {
+	return tfvars.Vars(in)
+}
+
+// Subnet is an object of the module's inputs.
+type Subnet struct {
+	AddressPrefixes                          []string           `tf:"address_prefixes"`
+	CreateNsg                                *bool              `tf:"create_nsg"`
+	CreateRouteTable                         *bool              `tf:"create_route_table"`
+	Delegations                              []SubnetDelegation `tf:"delegations"`
+	DisableBgpRoutePropagation               *bool              `tf:"disable_bgp_route_propagation"`
+	Name                                     string             `tf:"name"`
+	Newbits                                  *float64           `tf:"newbits"`
+	PrivateEndpointNetworkPolicies           *string            `tf:"private_endpoint_network_policies"`
+	PrivateLinkServiceNetworkPoliciesEnabled *bool              `tf:"private_link_service_network_policies_enabled"`
+	ServiceEndpoints                         []string           `tf:"service_endpoints"`
+}
+
+// SubnetDelegation is an object of the module's inputs.
+type SubnetDelegation struct {
+	Name              string                            `tf:"name"`
+	ServiceDelegation SubnetDelegationServiceDelegation `tf:"service_delegation"`
+}
+
+// SubnetDelegationServiceDelegation is an object of the module's inputs.
+type SubnetDelegationServiceDelegation struct {
+	Actions []string `tf:"actions"`
+	Name    string   `tf:"name"`
+}
+
+// VnetPeering is an object of the module's inputs.
+type VnetPeering struct {
+	AllowForwardedTraffic     *bool  `tf:"allow_forwarded_traffic"`
+	AllowGatewayTransit       *bool  `tf:"allow_gateway_transit"`
+	AllowVirtualNetworkAccess *bool  `tf:"allow_virtual_network_access"`
+	RemoteVnetID              string `tf:"remote_vnet_id"`
+	UseRemoteGateways         *bool  `tf:"use_remote_gateways"`
+}
@@ -0,0 +1,223 @@
+// Code generated by zrr-gen from azure/infrastructure/virtual-network/outputs.tf. DO NOT EDIT.
+
+package virtualnetwork
+
+import (
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// Outputs reads the outputs of azure/infrastructure/virtual-network once applied, by name.
+type Outputs struct {
+	t       testing.TestingT
+	options *terraform.Options
+}
+
+// NewOutputs returns the outputs of the module applied with options.
+func NewOutputs(t testing.TestingT, options *terraform.Options) Outputs {
+	return Outputs{t: t, options: options}
+}
+
+// HasDdosProtection returns the has_ddos_protection output: Boolean indicating
+// if DDoS protection is enabled.
+func (o Outputs) HasDdosProtection() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "has_ddos_protection", &value)
+	return value
+}
+
+// HasFlowLogs returns the has_flow_logs output: Boolean indicating if flow logs
+// are enabled.
+func (o Outputs) HasFlowLogs() bool {
+	var value bool
+	terraform.OutputStruct(o.t, o.options, "has_flow_logs", &value)
+	return value
+}
+
+// NsgIDs returns the nsg_ids output: Map of NSG names to their IDs.
+func (o Outputs) NsgIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "nsg_ids", &value)
+	return value
+}
+
+// NsgNames returns the nsg_names output: List of NSG names.
+func (o Outputs) NsgNames() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "nsg_names", &value)
+	return value
+}
+
+// PeeringIDs returns the peering_ids output: Map of peering names to their IDs.
+func (o Outputs) PeeringIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "peering_ids", &value)
+	return value
+}
+
+// PeeringStates returns the peering_states output: Map of peering names to
+// their connection states.
+func (o Outputs) PeeringStates() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "peering_states", &value)
+	return value
+}
+
+// ResourceGroupLocation returns the resource_group_location output: Location of
+// the resource group.
+func (o Outputs) ResourceGroupLocation() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_location", &value)
+	return value
+}
+
+// ResourceGroupName returns the resource_group_name output: Name of the
+// resource group containing the VNet.
+func (o Outputs) ResourceGroupName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "resource_group_name", &value)
+	return value
+}
+
+// RouteTableIDs returns the route_table_ids output: Map of route table names to
+// their IDs.
+func (o Outputs) RouteTableIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "route_table_ids", &value)
+	return value
+}
+
+// RouteTableNames returns the route_table_names output: List of route table
+// names.
+func (o Outputs) RouteTableNames() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "route_table_names", &value)
+	return value
+}
+
+// SubnetAddressPrefixes returns the subnet_address_prefixes output: Map of
+// subnet names to their address prefixes.
+func (o Outputs) SubnetAddressPrefixes() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "subnet_address_prefixes", &value)
+	return value
+}
+
+// SubnetIDs returns the subnet_ids output: Map of subnet names to their IDs.
+func (o Outputs) SubnetIDs() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "subnet_ids", &value)
+	return value
+}
+
+// SubnetNames returns the subnet_names output: List of subnet names.
+func (o Outputs) SubnetNames() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "subnet_names", &value)
+	return value
+}
+
+// Subnets returns the subnets output: Complete subnet information.
+func (o Outputs) Subnets() map[string]SubnetOutput {
+	var value map[string]SubnetOutput
+	terraform.OutputStruct(o.t, o.options, "subnets", &value)
+	return value
+}
+
+// SubscriptionID returns the subscription_id output: Subscription ID where the
+// VNet is created.
+func (o Outputs) SubscriptionID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "subscription_id", &value)
+	return value
+}
+
+// Tags returns the tags output: Tags applied to the virtual network.
+func (o Outputs) Tags() map[string]string {
+	var value map[string]string
+	terraform.OutputStruct(o.t, o.options, "tags", &value)
+	return value
+}
+
+// TenantID returns the tenant_id output: Tenant ID of the subscription.
+func (o Outputs) TenantID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "tenant_id", &value)
+	return value
+}
+
+// TotalNsgs returns the total_nsgs output: Total number of NSGs created.
+func (o Outputs) TotalNsgs() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "total_nsgs", &value)
+	return value
+}
+
+// TotalSubnets returns the total_subnets output: Total number of subnets
+// created.
+func (o Outputs) TotalSubnets() int {
+	var value int
+	terraform.OutputStruct(o.t, o.options, "total_subnets", &value)
+	return value
+}
+
+// VnetAddressSpace returns the vnet_address_space output: Address space of the
+// virtual network.
+func (o Outputs) VnetAddressSpace() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "vnet_address_space", &value)
+	return value
+}
+
+// VnetDNSServers returns the vnet_dns_servers output: DNS servers configured
+// for the virtual network.
+func (o Outputs) VnetDNSServers() []string {
+	var value []string
+	terraform.OutputStruct(o.t, o.options, "vnet_dns_servers", &value)
+	return value
+}
+
+// VnetGuid returns the vnet_guid output: GUID of the virtual network.
+func (o Outputs) VnetGuid() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "vnet_guid", &value)
+	return value
+}
+
+// VnetID returns the vnet_id output: ID of the virtual network.
+func (o Outputs) VnetID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "vnet_id", &value)
+	return value
+}
+
+// VnetLocation returns the vnet_location output: Location of the virtual
+// network.
+func (o Outputs) VnetLocation() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "vnet_location", &value)
+	return value
+}
+
+// VnetName returns the vnet_name output: Name of the virtual network.
+func (o Outputs) VnetName() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "vnet_name", &value)
+	return value
+}
+
+// VnetResourceID returns the vnet_resource_id output: Full resource ID of the
+// virtual network.
+func (o Outputs) VnetResourceID() string {
+	var value string
+	terraform.OutputStruct(o.t, o.options, "vnet_resource_id", &value)
+	return value
+}
+
+// SubnetOutput is an object of the module's outputs.
+type SubnetOutput struct {
+	AddressPrefixes  string `json:"address_prefixes"`
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	ServiceEndpoints string `json:"service_endpoints"`
+}
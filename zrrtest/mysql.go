@@ -0,0 +1,187 @@
+package zrrtest
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MySQLTLSConfigName is the go-sql-driver TLS profile used for every Azure
+// MySQL connection opened by the kit.
+const MySQLTLSConfigName = "azure"
+
+// mysqlRetryInterval is the base wait between connection attempts; attempt n
+// waits n times this long.
+const mysqlRetryInterval = 30 * time.Second
+
+// mysqlPort is the port Azure MySQL servers listen on.
+const mysqlPort = "3306"
+
+// azureMySQLRoots is the pinned bundle of root certificates Azure MySQL server
+// certificates chain to.
+//
+//go:embed certs/azure-mysql-roots.pem
+var azureMySQLRoots []byte
+
+// mysqlRootCAs are the only roots MySQL server certificates are verified
+// against.
+var mysqlRootCAs = mustCertPool(azureMySQLRoots)
+
+var (
+	mysqlTLSOnce sync.Once
+	mysqlTLSErr  error
+)
+
+func mustCertPool(pem []byte) *x509.CertPool {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		panic("zrrtest: no certificates in the MySQL root bundle")
+	}
+	return pool
+}
+
+// MySQLDSN returns the go-sql-driver DSN for an Azure MySQL server. host is
+// the server FQDN, optionally followed by a port; the port defaults to 3306.
+func MySQLDSN(host, username, password, database string) string {
+	address := host
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		address = net.JoinHostPort(host, mysqlPort)
+	}
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?tls=%s&allowNativePasswords=true",
+		username, password, address, database, MySQLTLSConfigName)
+}
+
+// registerMySQLTLSConfig registers the MySQLTLSConfigName profile once per
+// process. The profile leaves ServerName empty, so the driver verifies the
+// certificate against the host of each DSN, the server FQDN.
+func registerMySQLTLSConfig(t testing.TestingT) {
+	mysqlTLSOnce.Do(func() {
+		mysqlTLSErr = mysql.RegisterTLSConfig(MySQLTLSConfigName, mysqlTLSConfig(mysqlRootCAs))
+	})
+	require.NoError(t, mysqlTLSErr, "Should be able to register MySQL TLS config")
+}
+
+// mysqlTLSConfig returns the MySQLTLSConfigName profile verifying server
+// certificates against roots.
+func mysqlTLSConfig(roots *x509.CertPool) *tls.Config {
+	return &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+}
+
+// certificateError reports whether err is a failed server certificate
+// verification, which no amount of retrying fixes.
+func certificateError(err error) bool {
+	var verification *tls.CertificateVerificationError
+	var hostname x509.HostnameError
+	var authority x509.UnknownAuthorityError
+	return errors.As(err, &verification) || errors.As(err, &hostname) || errors.As(err, &authority)
+}
+
+// OpenMySQL opens a TLS connection to the server and pings it, retrying up to
+// maxRetries times with a linearly increasing back-off while the server
+// finishes provisioning. The server certificate must chain to the pinned
+// Azure roots and match host; a certificate that does not fails at once.
+func OpenMySQL(t testing.TestingT, host, username, password, database string, maxRetries int) *sql.DB {
+	registerMySQLTLSConfig(t)
+	dsn := MySQLDSN(host, username, password, database)
+
+	var db *sql.DB
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		db, err = sql.Open("mysql", dsn)
+		if err == nil {
+			if err = db.Ping(); err == nil {
+				break
+			}
+			db.Close()
+		}
+		if certificateError(err) {
+			break
+		}
+
+		if i < maxRetries-1 {
+			waitTime := time.Duration(i+1) * mysqlRetryInterval
+			logger.Logf(t, "Connection attempt %d failed, retrying in %v. Error: %v", i+1, waitTime, err)
+			time.Sleep(waitTime)
+		}
+	}
+
+	require.NoError(t, err, "Should be able to connect to MySQL server")
+	return db
+}
+
+// CheckMySQLConnectivity connects to the server and checks that it reports a
+// MySQL 8.0.x version.
+func CheckMySQLConnectivity(t testing.TestingT, host, username, password, database string, maxRetries int) {
+	db := OpenMySQL(t, host, username, password, database, maxRetries)
+	defer db.Close()
+
+	var version string
+	err := db.QueryRow("SELECT VERSION()").Scan(&version)
+	require.NoError(t, err, "Should be able to query MySQL version")
+	assert.Contains(t, version, "8.0", "MySQL version should be 8.0.x")
+
+	logger.Logf(t, "Successfully connected to MySQL server %s, version: %s", host, version)
+}
+
+// CheckMySQLDatabaseExists checks that the database is listed in the server's
+// information_schema.
+func CheckMySQLDatabaseExists(t testing.TestingT, host, username, password, database string) {
+	db := OpenMySQL(t, host, username, password, "mysql", 1)
+	defer db.Close()
+
+	var count int
+	err := db.QueryRow("SELECT COUNT(*) FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?", database).Scan(&count)
+	require.NoError(t, err, "Should be able to query database existence")
+	assert.Equal(t, 1, count, "Database should exist")
+
+	logger.Logf(t, "Successfully verified database %s exists on server %s", database, host)
+}
+
+// CheckMySQLOperations runs a create/insert/select/drop round trip against the
+// database and logs its character set and collation.
+func CheckMySQLOperations(t testing.TestingT, host, username, password, database string) {
+	db := OpenMySQL(t, host, username, password, database, 1)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS test_table (
+		id INT AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(100) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`)
+	require.NoError(t, err, "Should be able to create test table")
+
+	result, err := db.Exec("INSERT INTO test_table (name) VALUES (?)", "test_entry")
+	require.NoError(t, err, "Should be able to insert data")
+
+	lastInsertID, err := result.LastInsertId()
+	require.NoError(t, err, "Should be able to get last insert ID")
+	assert.Greater(t, lastInsertID, int64(0), "Last insert ID should be greater than 0")
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM test_table WHERE name = ?", "test_entry").Scan(&count)
+	require.NoError(t, err, "Should be able to query data")
+	assert.Equal(t, 1, count, "Should find one test entry")
+
+	var charset, collation string
+	err = db.QueryRow(`SELECT DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME
+		FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?`, database).Scan(&charset, &collation)
+	require.NoError(t, err, "Should be able to query database charset and collation")
+	logger.Logf(t, "Database charset: %s, collation: %s", charset, collation)
+
+	_, err = db.Exec("DROP TABLE test_table")
+	require.NoError(t, err, "Should be able to drop test table")
+
+	logger.Logf(t, "Database operations test completed successfully for database: %s", database)
+}
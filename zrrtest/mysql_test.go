@@ -0,0 +1,366 @@
+package zrrtest
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"io"
+	"math/big"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAzureMySQLRoots(t *testing.T) {
+	t.Parallel()
+
+	var names []string
+	rest := azureMySQLRoots
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		assert.True(t, cert.IsCA, cert.Subject.CommonName)
+		names = append(names, cert.Subject.CommonName)
+	}
+	assert.Equal(t, []string{
+		"DigiCert Global Root CA",
+		"DigiCert Global Root G2",
+		"Microsoft RSA Root Certificate Authority 2017",
+	}, names)
+}
+
+func TestMySQLDSN(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "admin:pw@tcp(server.mysql.database.azure.com:3306)/app?tls=azure&allowNativePasswords=true",
+		MySQLDSN("server.mysql.database.azure.com", "admin", "pw", "app"))
+	assert.Equal(t, "admin:pw@tcp(localhost:13306)/app?tls=azure&allowNativePasswords=true",
+		MySQLDSN("localhost:13306", "admin", "pw", "app"))
+}
+
+// TestMySQLVerifiedTLS connects to in-process MySQL stand-ins whose
+// certificates are issued for localhost by test CAs.
+func TestMySQLVerifiedTLS(t *testing.T) {
+	t.Parallel()
+
+	password := Password(t, MySQLPasswordPolicy, "mysqladmin")
+	trusted := newTestCA(t)
+	trustMySQLCA(t, trusted)
+	assert.True(t, mysqlRootCAs.Equal(mustCertPool(azureMySQLRoots)), "the pinned roots must not change")
+	server := newFakeMySQL(t, trusted, "mysqladmin", password)
+
+	// Validate the verified path runs the connectivity check
+	CheckMySQLConnectivity(t, "localhost:"+server.port, "mysqladmin", password, "mysql", 1)
+	CheckMySQLConnectivity(t, "localhost:"+server.port, "mysqladmin", password, "mysql", 1)
+	assert.Equal(t, 2, server.handshakes())
+
+	// Validate the certificate must match the host connected to
+	err := pingMySQL(t, "127.0.0.1:"+server.port, "mysqladmin", password)
+	var hostname x509.HostnameError
+	assert.True(t, errors.As(err, &hostname), "expected a hostname error, got %v", err)
+	assert.True(t, certificateError(err))
+
+	// Validate the certificate must chain to a pinned root
+	untrusted := newFakeMySQL(t, newTestCA(t), "mysqladmin", password)
+	err = pingMySQL(t, "localhost:"+untrusted.port, "mysqladmin", password)
+	var authority x509.UnknownAuthorityError
+	assert.True(t, errors.As(err, &authority), "expected an unknown authority error, got %v", err)
+	assert.True(t, certificateError(err))
+	assert.Zero(t, untrusted.handshakes())
+
+	// Validate a wrong password is not mistaken for a certificate problem
+	err = pingMySQL(t, "localhost:"+server.port, "mysqladmin", password+"x")
+	assert.ErrorContains(t, err, "Access denied")
+	assert.False(t, certificateError(err))
+}
+
+// trustMySQLCA registers the MySQL TLS profile with ca next to the pinned
+// roots for the rest of the test. The pinned pool itself is left alone, and
+// the profile is restored to it when the test ends.
+func trustMySQLCA(t *testing.T, ca *testCA) {
+	registerMySQLTLSConfig(t)
+	roots := mysqlRootCAs.Clone()
+	roots.AddCert(ca.cert)
+	require.NoError(t, mysql.RegisterTLSConfig(MySQLTLSConfigName, mysqlTLSConfig(roots)))
+	t.Cleanup(func() {
+		assert.NoError(t, mysql.RegisterTLSConfig(MySQLTLSConfigName, mysqlTLSConfig(mysqlRootCAs)))
+	})
+}
+
+func pingMySQL(t *testing.T, host, username, password string) error {
+	registerMySQLTLSConfig(t)
+	db, err := sql.Open("mysql", MySQLDSN(host, username, password, "mysql"))
+	require.NoError(t, err)
+	defer db.Close()
+	return db.Ping()
+}
+
+// testCA is a self-signed CA issuing a server certificate for localhost.
+type testCA struct {
+	cert       *x509.Certificate
+	serverCert tls.Certificate
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "zrrtest CA " + RandomString(6)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	serverKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serverTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	serverDER, err := x509.CreateCertificate(rand.Reader, serverTemplate, caCert, &serverKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	return &testCA{
+		cert:       caCert,
+		serverCert: tls.Certificate{Certificate: [][]byte{serverDER}, PrivateKey: serverKey},
+	}
+}
+
+// MySQL protocol constants the stand-in needs.
+const (
+	clientLongPassword           = 0x00000001
+	clientConnectWithDB          = 0x00000008
+	clientProtocol41             = 0x00000200
+	clientSSL                    = 0x00000800
+	clientTransactions           = 0x00002000
+	clientSecureConn             = 0x00008000
+	clientPluginAuth             = 0x00080000
+	fakeMySQLCapabilities uint32 = clientLongPassword | clientConnectWithDB | clientProtocol41 | clientSSL |
+		clientTransactions | clientSecureConn | clientPluginAuth
+
+	comQuit  = 0x01
+	comQuery = 0x03
+	comPing  = 0x0e
+)
+
+// fakeMySQL is an in-process stand-in for an Azure MySQL server. It requires
+// TLS, checks mysql_native_password credentials and answers pings and
+// SELECT VERSION().
+type fakeMySQL struct {
+	port     string
+	tls      *tls.Config
+	username string
+	password string
+
+	mu        sync.Mutex
+	completed int
+}
+
+func newFakeMySQL(t *testing.T, ca *testCA, username, password string) *fakeMySQL {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	server := &fakeMySQL{
+		port:     port,
+		tls:      &tls.Config{Certificates: []tls.Certificate{ca.serverCert}},
+		username: username,
+		password: password,
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+// handshakes returns how many connections completed the TLS handshake.
+func (s *fakeMySQL) handshakes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.completed
+}
+
+func (s *fakeMySQL) serve(raw net.Conn) {
+	defer raw.Close()
+	raw.SetDeadline(time.Now().Add(time.Minute))
+
+	scramble := make([]byte, 20)
+	rand.Read(scramble)
+	for i := range scramble {
+		// The scramble is sent NUL-terminated, so it must not contain NUL.
+		scramble[i] = scramble[i]%94 + 33
+	}
+
+	var greeting bytes.Buffer
+	greeting.WriteByte(10)
+	greeting.WriteString("8.0.21-zrrtest\x00")
+	greeting.Write([]byte{1, 0, 0, 0})
+	greeting.Write(scramble[:8])
+	greeting.WriteByte(0)
+	capabilities := binary.LittleEndian.AppendUint32(nil, fakeMySQLCapabilities)
+	greeting.Write(capabilities[:2])
+	greeting.WriteByte(0x21)
+	greeting.Write([]byte{2, 0})
+	greeting.Write(capabilities[2:])
+	greeting.WriteByte(21)
+	greeting.Write(make([]byte, 10))
+	greeting.Write(scramble[8:])
+	greeting.WriteByte(0)
+	greeting.WriteString("mysql_native_password\x00")
+	if writePacket(raw, 0, greeting.Bytes()) != nil {
+		return
+	}
+
+	request, _, err := readPacket(raw)
+	if err != nil || len(request) < 4 || binary.LittleEndian.Uint32(request)&clientSSL == 0 {
+		return
+	}
+	conn := tls.Server(raw, s.tls)
+	if conn.Handshake() != nil {
+		return
+	}
+	s.mu.Lock()
+	s.completed++
+	s.mu.Unlock()
+
+	response, seq, err := readPacket(conn)
+	if err != nil || len(response) < 33 {
+		return
+	}
+	fields := response[32:]
+	user, fields, _ := bytes.Cut(fields, []byte{0})
+	if len(fields) == 0 || int(fields[0]) >= len(fields) {
+		return
+	}
+	auth := fields[1 : 1+int(fields[0])]
+	if string(user) != s.username || !bytes.Equal(auth, nativePassword(scramble, s.password)) {
+		writeError(conn, seq+1, 1045, "28000", "Access denied for user '"+string(user)+"'")
+		return
+	}
+	if writeOK(conn, seq+1) != nil {
+		return
+	}
+
+	for {
+		command, _, err := readPacket(conn)
+		if err != nil || len(command) == 0 {
+			return
+		}
+		switch command[0] {
+		case comQuit:
+			return
+		case comPing:
+			writeOK(conn, 1)
+		case comQuery:
+			if string(command[1:]) != "SELECT VERSION()" {
+				writeError(conn, 1, 1064, "42000", "unsupported query")
+				continue
+			}
+			writeResult(conn, "VERSION()", "8.0.21-zrrtest")
+		default:
+			writeError(conn, 1, 1047, "08S01", "unknown command")
+		}
+	}
+}
+
+// nativePassword is the mysql_native_password response for password:
+// SHA1(password) XOR SHA1(scramble + SHA1(SHA1(password))).
+func nativePassword(scramble []byte, password string) []byte {
+	stage1 := sha1.Sum([]byte(password))
+	stage2 := sha1.Sum(stage1[:])
+	mix := sha1.Sum(append(append([]byte{}, scramble...), stage2[:]...))
+	for i := range mix {
+		mix[i] ^= stage1[i]
+	}
+	return mix[:]
+}
+
+func readPacket(r io.Reader) ([]byte, byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, 0, err
+	}
+	payload := make([]byte, int(header[0])|int(header[1])<<8|int(header[2])<<16)
+	_, err := io.ReadFull(r, payload)
+	return payload, header[3], err
+}
+
+func writePacket(w io.Writer, seq byte, payload []byte) error {
+	n := len(payload)
+	_, err := w.Write(append([]byte{byte(n), byte(n >> 8), byte(n >> 16), seq}, payload...))
+	return err
+}
+
+func writeOK(w io.Writer, seq byte) error {
+	return writePacket(w, seq, []byte{0, 0, 0, 2, 0, 0, 0})
+}
+
+func writeError(w io.Writer, seq byte, code uint16, state, message string) error {
+	payload := []byte{0xff, byte(code), byte(code >> 8), '#'}
+	payload = append(payload, state...)
+	return writePacket(w, seq, append(payload, message...))
+}
+
+func writeEOF(w io.Writer, seq byte) error {
+	return writePacket(w, seq, []byte{0xfe, 0, 0, 2, 0})
+}
+
+// writeResult writes a text result set of one string column and one row.
+func writeResult(w io.Writer, column, value string) {
+	lenenc := func(b []byte, s string) []byte { return append(append(b, byte(len(s))), s...) }
+
+	var definition []byte
+	for _, field := range []string{"def", "", "", "", column, ""} {
+		definition = lenenc(definition, field)
+	}
+	definition = append(definition, 0x0c, 0x21, 0, 0xff, 0, 0, 0, 0xfd, 0, 0, 0, 0, 0)
+
+	writePacket(w, 1, []byte{1})
+	writePacket(w, 2, definition)
+	writeEOF(w, 3)
+	writePacket(w, 4, lenenc(nil, value))
+	writeEOF(w, 5)
+}
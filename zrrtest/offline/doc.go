@@ -0,0 +1,29 @@
+// Package offline plans a module without an Azure login.
+//
+// Most modules read data sources such as azurerm_resource_group or
+// azurerm_key_vault before they can plan, so a plain `terraform plan` needs
+// credentials even in a unit test. This package instead copies the module to
+// a temporary directory, generates a Terraform test file that replaces the
+// azurerm provider with mock_provider and pins the data sources to values
+// from a fixture, and runs it with `terraform test -verbose`. The plan
+// Terraform prints is returned as a *plan.Plan, so the usual assertions work:
+//
+//	fixture := offline.LoadFixture(t, "resource_group")
+//	planned := offline.InitAndPlan(t, terraformOptions, fixture)
+//	planned.Resource("azurerm_virtual_network.main").
+//		Attr("location").Equals("eastus")
+//
+// Fixtures live in tests/fixtures/<name>.json and map data source addresses
+// to the attributes they should return:
+//
+//	{
+//	  "data": {
+//	    "data.azurerm_resource_group.main": {
+//	      "name": "test-rg",
+//	      "location": "eastus"
+//	    }
+//	  }
+//	}
+//
+// Mock providers need Terraform 1.7 or later.
+package offline
@@ -0,0 +1,113 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// FixtureDir is where a module keeps its offline fixtures, as seen from a
+// suite under tests/unit or tests/integration.
+const FixtureDir = "../fixtures"
+
+// DefaultProviders are the providers mocked when a fixture does not list any.
+var DefaultProviders = []string{"azurerm"}
+
+// Fixture describes what the mocked providers return during an offline plan.
+type Fixture struct {
+	// Providers are the local names of the providers to replace with
+	// mock_provider. Defaults to DefaultProviders.
+	Providers []string `json:"providers,omitempty"`
+
+	// Data maps data source addresses, without an instance key, to the
+	// attributes they return, e.g. "data.azurerm_resource_group.main".
+	// Data sources without an entry get values generated by the mock
+	// provider.
+	Data map[string]map[string]interface{} `json:"data,omitempty"`
+
+	// Resources maps resource addresses, without an instance key, to the
+	// computed attributes they get once planned, e.g. a predictable id.
+	Resources map[string]map[string]interface{} `json:"resources,omitempty"`
+}
+
+// FixturePath returns the fixture file called name.
+func FixturePath(name string) string {
+	return filepath.Join(FixtureDir, name+".json")
+}
+
+// LoadFixture reads tests/fixtures/<name>.json.
+func LoadFixture(t testing.TestingT, name string) *Fixture {
+	fixture, err := LoadFixtureE(name)
+	require.NoError(t, err)
+	return fixture
+}
+
+// LoadFixtureE reads tests/fixtures/<name>.json.
+func LoadFixtureE(name string) (*Fixture, error) {
+	path := FixturePath(name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fixture := &Fixture{}
+	if err := json.Unmarshal(data, fixture); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// TestFile renders the fixture as a Terraform test file with a single plan
+// run.
+func (f *Fixture) TestFile() (string, error) {
+	mocks, err := f.Mocks()
+	if err != nil {
+		return "", err
+	}
+	return "# Generated by zrrtest/offline. Do not edit.\n" + mocks +
+		"\nrun \"plan\" {\n  command = plan\n}\n", nil
+}
+
+// Mocks renders the fixture's mock_provider, override_data and
+// override_resource blocks, which apply to every run of a test file. A value
+// that cannot be rendered as HCL is an error.
+func (f *Fixture) Mocks() (string, error) {
+	var b strings.Builder
+	providers := f.Providers
+	if len(providers) == 0 {
+		providers = DefaultProviders
+	}
+	for _, provider := range providers {
+		fmt.Fprintf(&b, "\nmock_provider %q {}\n", provider)
+	}
+
+	if err := writeOverrides(&b, "override_data", f.Data); err != nil {
+		return "", err
+	}
+	if err := writeOverrides(&b, "override_resource", f.Resources); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func writeOverrides(b *strings.Builder, block string, overrides map[string]map[string]interface{}) error {
+	targets := make([]string, 0, len(overrides))
+	for target := range overrides {
+		targets = append(targets, target)
+	}
+	sort.Strings(targets)
+
+	for _, target := range targets {
+		fmt.Fprintf(b, "\n%s {\n  target = %s\n  values = ", block, target)
+		if err := writeValue(b, map[string]interface{}(overrides[target]), 1); err != nil {
+			return fmt.Errorf("%s %s: %w", block, target, err)
+		}
+		b.WriteString("\n}\n")
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package offline
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// writeValue renders a JSON-decoded value as an HCL expression. Nested
+// values are indented by two spaces per level. Values without a JSON form
+// are an error.
+func writeValue(b *strings.Builder, value interface{}, depth int) error {
+	indent := strings.Repeat("  ", depth)
+
+	switch v := value.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case string:
+		b.WriteString(quote(v))
+	case json.Number:
+		b.WriteString(v.String())
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case []interface{}:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return nil
+		}
+		b.WriteString("[\n")
+		for _, item := range v {
+			b.WriteString(indent + "  ")
+			if err := writeValue(b, item, depth+1); err != nil {
+				return err
+			}
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "]")
+	case map[string]interface{}:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		b.WriteString("{\n")
+		for _, key := range keys {
+			b.WriteString(indent + "  " + quote(key) + " = ")
+			if err := writeValue(b, v[key], depth+1); err != nil {
+				return err
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString(indent + "}")
+	default:
+		// Anything else, e.g. a typed struct, goes through its JSON form.
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("cannot render %T as HCL: %w", v, err)
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return fmt.Errorf("cannot render %T as HCL: %w", v, err)
+		}
+		return writeValue(b, decoded, depth)
+	}
+	return nil
+}
+
+// quote renders s as an HCL string literal. Template sequences are escaped
+// so fixture values are taken literally, and characters that are not
+// printable use HCL's \u escapes rather than Go's.
+func quote(s string) string {
+	return string(hclwrite.TokensForValue(cty.StringVal(s)).Bytes())
+}
@@ -0,0 +1,166 @@
+package offline
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tftest"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDirectory is the directory, inside the temporary copy of the
+// configuration, that holds the generated test file.
+const TestDirectory = "zrr-offline"
+
+// TestFileName is the name of the generated test file.
+const TestFileName = "offline.tftest.hcl"
+
+// InitAndPlan plans the configuration in options.TerraformDir against the
+// mocked providers described by fixture and wraps the resulting plan.
+// Variables in options are passed to the plan as usual. The configuration is
+// copied to a temporary directory first, so the module tree is left alone
+// and the caller's options are not modified.
+func InitAndPlan(t testing.TestingT, options *terraform.Options, fixture *Fixture) *plan.Plan {
+	planned, err := InitAndPlanE(t, options, fixture)
+	require.NoError(t, err)
+	return planned
+}
+
+// InitAndPlanE is like InitAndPlan but returns an error instead of failing
+// the test. When Terraform rejects the configuration, e.g. because a
+// variable validation failed, the error carries its diagnostics.
+func InitAndPlanE(t testing.TestingT, options *terraform.Options, fixture *Fixture) (*plan.Plan, error) {
+	planJSON, err := InitAndPlanJSONE(t, options, fixture)
+	if err != nil {
+		return nil, err
+	}
+	planStruct, err := terraform.ParsePlanJSON(planJSON)
+	if err != nil {
+		return nil, err
+	}
+	return plan.New(t, planStruct), nil
+}
+
+// InitAndPlanJSONE plans like InitAndPlanE and returns the plan in the
+// format of `terraform show -json`.
+func InitAndPlanJSONE(t testing.TestingT, options *terraform.Options, fixture *Fixture) (string, error) {
+	testOptions, tempDir, err := prepare(t, options, fixture)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	if _, err := terraform.InitE(t, testOptions); err != nil {
+		return "", err
+	}
+
+	stdout, runErr := terraform.RunTerraformCommandAndGetStdoutE(t, testOptions,
+		terraform.FormatArgs(testOptions, "test", "-json", "-verbose", "-test-directory="+TestDirectory)...)
+
+	planJSON, err := parseTestOutput(stdout)
+	if err != nil {
+		return "", err
+	}
+	if runErr != nil {
+		return "", runErr
+	}
+	return planJSON, nil
+}
+
+// prepare copies the configuration to a temporary directory, writes the
+// generated test file next to it and returns options pointing at the copy,
+// along with the temporary directory, which the caller removes when done.
+// The whole module is copied, not just TerraformDir, so examples that
+// reference the module by a relative path keep working.
+func prepare(t testing.TestingT, options *terraform.Options, fixture *Fixture) (*terraform.Options, string, error) {
+	if fixture == nil {
+		fixture = &Fixture{}
+	}
+
+	testOptions, err := options.Clone()
+	if err != nil {
+		return nil, "", err
+	}
+
+	moduleDir, err := filepath.Abs(zrrtest.ModuleDir)
+	if err != nil {
+		return nil, "", err
+	}
+	terraformDir, err := filepath.Abs(options.TerraformDir)
+	if err != nil {
+		return nil, "", err
+	}
+	relative, err := filepath.Rel(moduleDir, terraformDir)
+	if err != nil || strings.HasPrefix(relative, "..") {
+		return nil, "", fmt.Errorf("%s is not inside the module under test (%s)", options.TerraformDir, moduleDir)
+	}
+
+	// terraform test has no -target flag.
+	testOptions.Targets = nil
+	// The copy is made even when a SKIP_<stage> variable is set, unlike
+	// with test_structure, so the generated test file never lands in the
+	// module.
+	testFile, err := fixture.TestFile()
+	if err != nil {
+		return nil, "", err
+	}
+	copied, tempDir, err := zrrtest.CopyModuleToTemp(moduleDir, "offline")
+	if err != nil {
+		return nil, "", err
+	}
+	testOptions.TerraformDir = filepath.Join(copied, relative)
+
+	testDir := filepath.Join(testOptions.TerraformDir, TestDirectory)
+	if err := os.MkdirAll(testDir, 0o755); err != nil {
+		os.RemoveAll(tempDir)
+		return nil, "", err
+	}
+	if err := os.WriteFile(filepath.Join(testDir, TestFileName), []byte(testFile), 0o644); err != nil {
+		os.RemoveAll(tempDir)
+		return nil, "", err
+	}
+	return testOptions, tempDir, nil
+}
+
+// parseTestOutput extracts the plan printed by `terraform test -json
+// -verbose`. If Terraform reported errors instead, they are returned
+// together as one error.
+func parseTestOutput(stdout string) (string, error) {
+	messages, err := tftest.Parse(strings.NewReader(stdout))
+	if err != nil {
+		return "", err
+	}
+
+	var planJSON string
+	var diagnostics []string
+	for _, message := range messages {
+		switch message.Type {
+		case "test_plan":
+			planJSON = string(message.TestPlan)
+		case "diagnostic":
+			if message.Diagnostic == nil || message.Diagnostic.Severity != "error" {
+				continue
+			}
+			diagnostic := message.Diagnostic.Summary
+			if message.Diagnostic.Detail != "" {
+				diagnostic += ": " + message.Diagnostic.Detail
+			}
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+
+	if len(diagnostics) > 0 {
+		return "", errors.New(strings.Join(diagnostics, "\n"))
+	}
+	if planJSON == "" {
+		return "", errors.New("terraform test did not print a plan; Terraform 1.7 or later is required")
+	}
+	return planJSON, nil
+}
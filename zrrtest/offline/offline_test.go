@@ -0,0 +1,161 @@
+package offline
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureTestFile(t *testing.T) {
+	t.Parallel()
+
+	fixture := &Fixture{
+		Data: map[string]map[string]interface{}{
+			"data.azurerm_resource_group.main": {
+				"name":     "test-rg",
+				"location": "eastus",
+				"tags":     map[string]interface{}{"Owner": "${var.owner}"},
+			},
+			"data.azurerm_client_config.current": {
+				"tenant_id": "00000000-0000-0000-0000-000000000000",
+			},
+		},
+		Resources: map[string]map[string]interface{}{
+			"azurerm_virtual_network.main": {
+				"id":     "/subscriptions/x/vnet",
+				"guid":   nil,
+				"subnet": []interface{}{},
+				"dns":    []interface{}{"10.0.0.4", true, 3.5},
+			},
+		},
+	}
+
+	expected := `# Generated by zrrtest/offline. Do not edit.
+
+mock_provider "azurerm" {}
+
+override_data {
+  target = data.azurerm_client_config.current
+  values = {
+    "tenant_id" = "00000000-0000-0000-0000-000000000000"
+  }
+}
+
+override_data {
+  target = data.azurerm_resource_group.main
+  values = {
+    "location" = "eastus"
+    "name" = "test-rg"
+    "tags" = {
+      "Owner" = "$${var.owner}"
+    }
+  }
+}
+
+override_resource {
+  target = azurerm_virtual_network.main
+  values = {
+    "dns" = [
+      "10.0.0.4",
+      true,
+      3.5,
+    ]
+    "guid" = null
+    "id" = "/subscriptions/x/vnet"
+    "subnet" = []
+  }
+}
+
+run "plan" {
+  command = plan
+}
+`
+	testFile, err := fixture.TestFile()
+	require.NoError(t, err)
+	assert.Equal(t, expected, testFile)
+}
+
+func TestFixtureProviders(t *testing.T) {
+	t.Parallel()
+
+	fixture := &Fixture{Providers: []string{"azurerm", "null"}}
+	testFile, err := fixture.TestFile()
+	require.NoError(t, err)
+
+	assert.Contains(t, testFile, "mock_provider \"azurerm\" {}\n")
+	assert.Contains(t, testFile, "mock_provider \"null\" {}\n")
+	assert.NotContains(t, testFile, "override_")
+}
+
+func TestFixtureUnrenderable(t *testing.T) {
+	t.Parallel()
+
+	fixture := &Fixture{Resources: map[string]map[string]interface{}{
+		"azurerm_virtual_network.main": {"id": make(chan int)},
+	}}
+	_, err := fixture.TestFile()
+	assert.ErrorContains(t, err, "override_resource azurerm_virtual_network.main: cannot render chan int as HCL")
+}
+
+func TestQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `"plain"`, quote("plain"))
+	assert.Equal(t, `"say \"hi\"\n"`, quote("say \"hi\"\n"))
+	assert.Equal(t, `"$${a} %%{ if b }"`, quote("${a} %{ if b }"))
+	assert.Equal(t, `"nul\u0000 bell\u0007 é"`, quote("nul\x00 bell\a é"))
+
+	// Whatever the fixture holds, the literal parses back to it.
+	for _, s := range []string{"tab\tcr\r", "\x00\x1b[0m\u2028", "100% ${var.x} \\"} {
+		expr, diags := hclsyntax.ParseExpression([]byte(quote(s)), "quote.hcl", hcl.InitialPos)
+		require.False(t, diags.HasErrors(), "%q: %s", s, diags)
+		value, diags := expr.Value(nil)
+		require.False(t, diags.HasErrors(), "%q: %s", s, diags)
+		assert.Equal(t, s, value.AsString())
+	}
+}
+
+func TestParseTestOutputReturnsPlan(t *testing.T) {
+	t.Parallel()
+
+	stdout, err := os.ReadFile("testdata/test_plan.jsonl")
+	require.NoError(t, err)
+
+	planJSON, err := parseTestOutput(string(stdout))
+	require.NoError(t, err)
+
+	planStruct, err := terraform.ParsePlanJSON(planJSON)
+	require.NoError(t, err)
+	plan.New(t, planStruct).
+		Count("azurerm_virtual_network.main", 1).
+		OnlyCreates().
+		Resource("azurerm_virtual_network.main").
+		Attr("location").Equals("eastus").
+		Attr("resource_group_name").Equals("test-rg").
+		Attr("id").Unknown()
+}
+
+func TestParseTestOutputReturnsErrors(t *testing.T) {
+	t.Parallel()
+
+	stdout, err := os.ReadFile("testdata/test_error.jsonl")
+	require.NoError(t, err)
+
+	_, err = parseTestOutput(string(stdout))
+	require.Error(t, err)
+	assert.Equal(t, "Invalid value for variable: Virtual network name must be 1-64 characters long.", err.Error())
+}
+
+func TestParseTestOutputWithoutPlan(t *testing.T) {
+	t.Parallel()
+
+	_, err := parseTestOutput("Error: Unsupported block type\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Terraform 1.7 or later")
+}
@@ -0,0 +1,59 @@
+package offline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/tftest"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// RunTests runs the module's own Terraform test files like tftest.Run, with
+// the providers and data sources of fixture mocked in every file, so their
+// plan runs need no Azure login. A nil fixture mocks DefaultProviders. Test
+// files must not declare mock providers of their own for the same providers.
+func RunTests(t *testing.T, options *terraform.Options, fixture *Fixture) {
+	results, err := RunTestsE(t, options, fixture)
+	require.NoError(t, err)
+	tftest.Report(t, results)
+}
+
+// RunTestsE is like RunTests but returns the results instead of reporting
+// them.
+func RunTestsE(t terratesting.TestingT, options *terraform.Options, fixture *Fixture) (*tftest.Results, error) {
+	if fixture == nil {
+		fixture = &Fixture{}
+	}
+	mocks, err := fixture.Mocks()
+	if err != nil {
+		return nil, err
+	}
+	testOptions, testDir, tempDir, err := tftest.Prepare(options)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	testFiles, err := filepath.Glob(filepath.Join(testOptions.TerraformDir, filepath.FromSlash(testDir), "*.tftest.hcl"))
+	if err != nil {
+		return nil, err
+	}
+	mocks = "\n# Added by zrrtest/offline.\n" + mocks
+	for _, testFile := range testFiles {
+		file, err := os.OpenFile(testFile, os.O_APPEND|os.O_WRONLY, 0)
+		if err != nil {
+			return nil, err
+		}
+		_, err = file.WriteString(mocks)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tftest.ExecE(t, testOptions, testDir)
+}
@@ -0,0 +1,90 @@
+package zrrtest
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// ModuleDir is the module root as seen from a suite under tests/unit or
+// tests/integration.
+const ModuleDir = "../.."
+
+// Retry defaults applied by WithDefaults when a suite does not set its own.
+// Terratest has a single budget for every retryable error, so it is sized for
+// the slowest of them: a new role assignment, which Azure documents can take
+// up to ten minutes to propagate.
+const (
+	DefaultMaxRetries         = 20
+	DefaultTimeBetweenRetries = 30 * time.Second
+)
+
+// WithDefaults returns a copy of options with the library-wide defaults
+// applied: colourless output, the retryable errors of RetryableErrors, the
+// default retry budget and MaskingLogger. Values already set on options take
+// precedence, but a retryable error pattern that matches any output, such as
+// ".*", fails the test: it would retry validation errors and other real
+// failures as well.
+func WithDefaults(t testing.TestingT, options *terraform.Options) *terraform.Options {
+	newOptions, err := options.Clone()
+	require.NoError(t, err)
+
+	newOptions.NoColor = true
+
+	if newOptions.RetryableTerraformErrors == nil {
+		newOptions.RetryableTerraformErrors = map[string]string{}
+	}
+	for pattern := range newOptions.RetryableTerraformErrors {
+		require.False(t, catchAllPattern(pattern),
+			"retryable error pattern %q matches any output; rely on zrrtest.AzureRetryableErrors instead", pattern)
+	}
+	for pattern, message := range RetryableErrors() {
+		if _, ok := newOptions.RetryableTerraformErrors[pattern]; !ok {
+			newOptions.RetryableTerraformErrors[pattern] = message
+		}
+	}
+
+	if newOptions.MaxRetries == 0 {
+		newOptions.MaxRetries = DefaultMaxRetries
+	}
+	if newOptions.TimeBetweenRetries == 0 {
+		newOptions.TimeBetweenRetries = DefaultTimeBetweenRetries
+	}
+	if newOptions.Logger == nil {
+		newOptions.Logger = MaskingLogger
+	}
+
+	return newOptions
+}
+
+// ModuleOptions returns options that run the module under test directly with
+// the given variables.
+func ModuleOptions(t testing.TestingT, vars map[string]interface{}) *terraform.Options {
+	return WithDefaults(t, &terraform.Options{
+		TerraformDir: ModuleDir,
+		Vars:         vars,
+	})
+}
+
+// ExampleOptions returns options that run examples/<example> of the module
+// under test with the given variables.
+func ExampleOptions(t testing.TestingT, example string, vars map[string]interface{}) *terraform.Options {
+	return WithDefaults(t, &terraform.Options{
+		TerraformDir: filepath.Join(ModuleDir, "examples", example),
+		Vars:         vars,
+	})
+}
+
+// TestTags returns the common_tags every suite passes to a module. The testID
+// is recorded as the TestID tag so leaked resources can be traced back to the
+// run that created them.
+func TestTags(testID string) map[string]string {
+	return map[string]string{
+		"Environment": "test",
+		"Project":     "terratest",
+		"TestID":      testID,
+	}
+}
@@ -0,0 +1,61 @@
+package zrrtest
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithDefaults(t *testing.T) {
+	t.Parallel()
+
+	original := &terraform.Options{
+		TerraformDir: ModuleDir,
+		RetryableTerraformErrors: map[string]string{
+			"custom": "custom message",
+		},
+		MaxRetries: 7,
+	}
+	options := WithDefaults(t, original)
+
+	assert.True(t, options.NoColor)
+	assert.Equal(t, 7, options.MaxRetries)
+	assert.Equal(t, DefaultTimeBetweenRetries, options.TimeBetweenRetries)
+	assert.Equal(t, "custom message", options.RetryableTerraformErrors["custom"])
+	for pattern := range terraform.DefaultRetryableTerraformErrors {
+		assert.Contains(t, options.RetryableTerraformErrors, pattern)
+	}
+
+	// The caller's options must not be modified.
+	assert.False(t, original.NoColor)
+	assert.Len(t, original.RetryableTerraformErrors, 1)
+	assert.Equal(t, time.Duration(0), original.TimeBetweenRetries)
+}
+
+func TestModuleAndExampleOptions(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]interface{}{"name": "test"}
+
+	module := ModuleOptions(t, vars)
+	assert.Equal(t, ModuleDir, module.TerraformDir)
+	assert.Equal(t, vars, module.Vars)
+	assert.Equal(t, DefaultMaxRetries, module.MaxRetries)
+
+	example := ExampleOptions(t, "advanced", vars)
+	assert.Equal(t, filepath.Join("..", "..", "examples", "advanced"), example.TerraformDir)
+	assert.Equal(t, vars, example.Vars)
+}
+
+func TestTestTags(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, map[string]string{
+		"Environment": "test",
+		"Project":     "terratest",
+		"TestID":      "abc123",
+	}, TestTags("abc123"))
+}
@@ -0,0 +1,225 @@
+package zrrtest
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// DefaultPasswordLength is the length of generated passwords when the policy
+// allows it.
+const DefaultPasswordLength = 24
+
+// passwordSymbols are the non-alphanumeric characters generated passwords
+// use. They need no escaping in an HCL string, a -var argument or a MySQL
+// DSN: no quotes, '$', '%', '@', '/', ':' or backslash.
+const passwordSymbols = "!#*+-.=?^_~"
+
+// passwordCategories are the character categories the Azure complexity rules
+// count.
+var passwordCategories = []string{
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"abcdefghijklmnopqrstuvwxyz",
+	"0123456789",
+	passwordSymbols,
+}
+
+// PasswordPolicy is an Azure administrator password complexity rule.
+type PasswordPolicy struct {
+	Name      string
+	MinLength int
+	MaxLength int
+
+	// MinCategories is how many of the four categories (uppercase,
+	// lowercase, digits, other characters) a password must contain.
+	MinCategories int
+
+	// RejectLogin rejects passwords that contain three or more consecutive
+	// alphanumeric characters of the login name.
+	RejectLogin bool
+
+	// Disallowed are passwords Azure rejects regardless of complexity.
+	Disallowed []string
+}
+
+// Password policies of the resources the modules create.
+var (
+	MySQLPasswordPolicy = PasswordPolicy{
+		Name:          "MySQL Flexible Server",
+		MinLength:     8,
+		MaxLength:     128,
+		MinCategories: 3,
+		RejectLogin:   true,
+	}
+	MSSQLPasswordPolicy = PasswordPolicy{
+		Name:          "Azure SQL",
+		MinLength:     8,
+		MaxLength:     128,
+		MinCategories: 3,
+		RejectLogin:   true,
+	}
+	// VMPasswordPolicy covers both Windows and Linux virtual machines: the
+	// Windows minimum length and the Linux maximum.
+	VMPasswordPolicy = PasswordPolicy{
+		Name:          "virtual machine",
+		MinLength:     12,
+		MaxLength:     72,
+		MinCategories: 3,
+		RejectLogin:   true,
+		Disallowed: []string{
+			"abc@123", "P@$$w0rd", "P@ssw0rd", "P@ssword123", "Pa$$word",
+			"pass@word1", "Password!", "Password1", "Password22", "iloveyou!",
+		},
+	}
+)
+
+// Validate returns an error describing the first rule password breaks.
+func (p PasswordPolicy) Validate(password, login string) error {
+	if n := len([]rune(password)); n < p.MinLength || n > p.MaxLength {
+		return fmt.Errorf("%s passwords must be %d-%d characters long, got %d", p.Name, p.MinLength, p.MaxLength, n)
+	}
+
+	var upper, lower, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	categories := 0
+	for _, present := range []bool{upper, lower, digit, other} {
+		if present {
+			categories++
+		}
+	}
+	if categories < p.MinCategories {
+		return fmt.Errorf("%s passwords must use %d of uppercase, lowercase, digits and symbols, got %d", p.Name, p.MinCategories, categories)
+	}
+
+	for _, disallowed := range p.Disallowed {
+		if password == disallowed {
+			return fmt.Errorf("%s rejects the password %q", p.Name, disallowed)
+		}
+	}
+
+	if p.RejectLogin {
+		lowered := strings.ToLower(password)
+		for _, part := range loginParts(login) {
+			if strings.Contains(lowered, part) {
+				return fmt.Errorf("%s passwords must not contain part of the login name %q", p.Name, login)
+			}
+		}
+	}
+	return nil
+}
+
+// loginParts returns every run of three consecutive alphanumeric characters
+// of a login name, in lowercase.
+func loginParts(login string) []string {
+	var parts []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(login), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		for i := 0; i+3 <= len(runes); i++ {
+			parts = append(parts, string(runes[i:i+3]))
+		}
+	}
+	return parts
+}
+
+// Password returns a new random password for the administrator login that
+// satisfies policy. The password is registered with RegisterSecret, so the
+// terratest logger of WithDefaults masks it.
+func Password(t testing.TestingT, policy PasswordPolicy, login string) string {
+	password, err := PasswordE(policy, login)
+	require.NoError(t, err)
+	return password
+}
+
+// PasswordE is like Password but returns an error instead of failing the
+// test.
+func PasswordE(policy PasswordPolicy, login string) (string, error) {
+	length := DefaultPasswordLength
+	if length < policy.MinLength {
+		length = policy.MinLength
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		length = policy.MaxLength
+	}
+	if length < len(passwordCategories) {
+		return "", fmt.Errorf("%s passwords are too short to generate", policy.Name)
+	}
+
+	// A password rarely contains part of the login by chance; try again
+	// when it does.
+	for attempt := 0; attempt < 100; attempt++ {
+		password, err := randomPassword(length)
+		if err != nil {
+			return "", err
+		}
+		if policy.Validate(password, login) == nil {
+			RegisterSecret(password)
+			return password, nil
+		}
+	}
+	return "", errors.New("could not generate a password for " + policy.Name)
+}
+
+// MustPassword is like Password but panics on error. It is meant for
+// package-level test variables, which have no test to fail.
+func MustPassword(policy PasswordPolicy, login string) string {
+	password, err := PasswordE(policy, login)
+	if err != nil {
+		panic(err)
+	}
+	return password
+}
+
+// randomPassword returns a password of the given length with at least one
+// character of every category.
+func randomPassword(length int) (string, error) {
+	all := strings.Join(passwordCategories, "")
+	password := make([]byte, length)
+	for i := range password {
+		set := all
+		if i < len(passwordCategories) {
+			set = passwordCategories[i]
+		}
+		c, err := randomChar(set)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// Shuffle so the guaranteed characters are not always first.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
+
+func randomChar(set string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(set))))
+	if err != nil {
+		return 0, err
+	}
+	return set[n.Int64()], nil
+}
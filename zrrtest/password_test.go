@@ -0,0 +1,72 @@
+package zrrtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		login    string
+		valid    bool
+	}{
+		{"complex", MySQLPasswordPolicy, "Xk7#pQ2.wZ", "mysqladmin", true},
+		{"three categories", MSSQLPasswordPolicy, "xk7#pq2.wz", "sqladmin", true},
+		{"too short", MySQLPasswordPolicy, "Xk7#pQ", "mysqladmin", false},
+		{"too long", VMPasswordPolicy, strings.Repeat("Xk7#", 19), "azureuser", false},
+		{"two categories", MySQLPasswordPolicy, "xkpqwzabcd12", "mysqladmin", false},
+		{"contains login", MySQLPasswordPolicy, "Xk7#ADMin2.wZ", "mysqladmin", false},
+		{"contains login word", VMPasswordPolicy, "Xk7#pQ2.wZ-user", "azure.user", false},
+		{"short login", VMPasswordPolicy, "Xk7#pQ2.wZab", "ab", true},
+		{"disallowed", VMPasswordPolicy, "P@ssword123", "azureuser", false},
+		{"disallowed elsewhere", MySQLPasswordPolicy, "P@ssword123", "mysqladmin", true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.policy.Validate(tc.password, tc.login)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPassword(t *testing.T) {
+	t.Parallel()
+
+	for _, policy := range []PasswordPolicy{MySQLPasswordPolicy, MSSQLPasswordPolicy, VMPasswordPolicy} {
+		seen := map[string]bool{}
+		for i := 0; i < 50; i++ {
+			password := Password(t, policy, "azureadmin")
+			require.NoError(t, policy.Validate(password, "azureadmin"))
+			assert.Len(t, password, DefaultPasswordLength)
+			assert.NotContains(t, password, "$")
+			assert.NotContains(t, password, "@")
+			assert.False(t, seen[password], "passwords must not repeat")
+			seen[password] = true
+		}
+	}
+
+	// Validate the length is clamped to the policy
+	short := PasswordPolicy{Name: "short", MinLength: 8, MaxLength: 10, MinCategories: 4}
+	assert.Len(t, MustPassword(short, ""), 10)
+	long := PasswordPolicy{Name: "long", MinLength: 40, MaxLength: 128, MinCategories: 4}
+	assert.Len(t, MustPassword(long, ""), 40)
+
+	_, err := PasswordE(PasswordPolicy{Name: "tiny", MinLength: 1, MaxLength: 3}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+// Package plan provides fluent assertions over a Terraform plan.
+//
+// Unit suites plan a module and then check what Terraform intends to build.
+// Terratest hands back a *terraform.PlanStruct, which leaves each suite to dig
+// through nested maps and type assertions on its own. This package wraps the
+// struct so a check reads as a sentence:
+//
+//	p := plan.InitAndPlan(t, options)
+//	p.Count("azurerm_dns_a_record.a_records", 2)
+//	p.Resource("azurerm_dns_zone.main").
+//		Attr("name").Equals("test-app.dev.internal").
+//		Attr("tags.ManagedBy").Equals("Terraform")
+//
+// Attribute paths are dotted lookups into the planned values. Nested blocks and
+// lists are indexed with a number, either as a path segment or in brackets, and
+// map keys that contain dots are quoted in brackets:
+//
+//	container.0.ports[0].port
+//	tags["kubernetes.io/role"]
+//
+// Failed assertions report the resource address and attribute path together
+// with a diff of expected and planned values, and do not stop the test, so a
+// single run lists every mismatch.
+package plan
@@ -0,0 +1,139 @@
+package plan
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// segment is one step of an attribute path: a map key or a list index.
+type segment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+func (s segment) String() string {
+	if s.isIndex {
+		return strconv.Itoa(s.index)
+	}
+	return s.key
+}
+
+// parsePath splits an attribute path such as `container.0.ports[0].port` or
+// `tags["kubernetes.io/role"]` into segments. A leading "$." is accepted so
+// JSON-path style lookups work unchanged.
+func parsePath(path string) ([]segment, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if rest == "" {
+		return nil, fmt.Errorf("empty attribute path %q", path)
+	}
+
+	var segments []segment
+	for len(rest) > 0 {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			if rest == "" || rest[0] == '.' || rest[0] == '[' {
+				return nil, fmt.Errorf("invalid attribute path %q: empty segment", path)
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid attribute path %q: unclosed bracket", path)
+			}
+			inner := rest[1:end]
+			if strings.HasPrefix(inner, `"`) {
+				key, err := strconv.Unquote(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid attribute path %q: %w", path, err)
+				}
+				segments = append(segments, segment{key: key})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("invalid attribute path %q: bad index %q", path, inner)
+				}
+				segments = append(segments, segment{index: index, isIndex: true})
+			}
+			rest = rest[end+1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			segments = append(segments, segment{key: rest[:end]})
+			rest = rest[end:]
+		}
+	}
+	return segments, nil
+}
+
+// lookup resolves path against a decoded JSON value. Plain segments that are
+// all digits index into lists, so `container.0.name` and `container[0].name`
+// are equivalent.
+func lookup(value interface{}, path string) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := value
+	for i, seg := range segments {
+		at := formatSegments(segments[:i+1])
+		switch node := current.(type) {
+		case map[string]interface{}:
+			key := seg.String()
+			next, ok := node[key]
+			if !ok {
+				return nil, fmt.Errorf("%s: key %q not found (have: %s)", at, key, strings.Join(sortedKeys(node), ", "))
+			}
+			current = next
+		case []interface{}:
+			index := seg.index
+			if !seg.isIndex {
+				index, err = strconv.Atoi(seg.key)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %q is not a list index", at, seg.key)
+				}
+			}
+			if index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("%s: index %d out of range (length %d)", at, index, len(node))
+			}
+			current = node[index]
+		case nil:
+			return nil, fmt.Errorf("%s: parent is null", at)
+		default:
+			return nil, fmt.Errorf("%s: cannot descend into %T", at, node)
+		}
+	}
+	return current, nil
+}
+
+func formatSegments(segments []segment) string {
+	var b strings.Builder
+	for i, seg := range segments {
+		switch {
+		case seg.isIndex:
+			fmt.Fprintf(&b, "[%d]", seg.index)
+		case strings.ContainsAny(seg.key, ".[]"):
+			fmt.Fprintf(&b, "[%q]", seg.key)
+		default:
+			if i > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(seg.key)
+		}
+	}
+	return b.String()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
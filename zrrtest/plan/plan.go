@@ -0,0 +1,150 @@
+package plan
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Plan wraps a parsed Terraform plan together with the test it reports to.
+type Plan struct {
+	t testing.TestingT
+
+	// Struct is the underlying Terratest plan, for checks the DSL does not
+	// cover.
+	Struct *terraform.PlanStruct
+}
+
+// New wraps an already parsed plan.
+func New(t testing.TestingT, planStruct *terraform.PlanStruct) *Plan {
+	require.NotNil(t, planStruct, "plan must not be nil")
+	return &Plan{t: t, Struct: planStruct}
+}
+
+// InitAndPlan runs terraform init and plan with the given options and wraps
+// the resulting plan. The caller's options are not modified.
+func InitAndPlan(t testing.TestingT, options *terraform.Options) *Plan {
+	planStruct, err := terraform.ParsePlanJSON(InitAndPlanJSON(t, options))
+	require.NoError(t, err)
+	return New(t, planStruct)
+}
+
+// InitAndPlanJSON runs terraform init and plan with the given options and
+// returns the plan as rendered by `terraform show -json`. When options has no
+// PlanFilePath the plan is written to a temporary file that is removed
+// afterwards. The caller's options are not modified.
+func InitAndPlanJSON(t testing.TestingT, options *terraform.Options) string {
+	planOptions, err := options.Clone()
+	require.NoError(t, err)
+
+	if planOptions.PlanFilePath == "" {
+		planFile, err := os.CreateTemp("", "zrrtest-plan-")
+		require.NoError(t, err)
+		require.NoError(t, planFile.Close())
+		defer os.Remove(planFile.Name())
+		planOptions.PlanFilePath = planFile.Name()
+	}
+
+	terraform.InitAndPlan(t, planOptions)
+
+	// The JSON rendering of a plan is large and adds nothing to the log that
+	// the plan output above has not already shown.
+	planOptions.Logger = logger.Discard
+	return terraform.Show(t, planOptions)
+}
+
+// Addresses returns the sorted addresses of every resource in the plan.
+func (p *Plan) Addresses() []string {
+	addresses := make([]string, 0, len(p.Struct.ResourcePlannedValuesMap))
+	for address := range p.Struct.ResourcePlannedValuesMap {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+	return addresses
+}
+
+// instances returns the planned addresses that are address itself or one of
+// its count/for_each instances.
+func (p *Plan) instances(address string) []string {
+	var matches []string
+	for _, planned := range p.Addresses() {
+		if planned == address || strings.HasPrefix(planned, address+"[") {
+			matches = append(matches, planned)
+		}
+	}
+	return matches
+}
+
+// Count asserts that the plan contains exactly expected instances of the
+// resource at address. Instances created by count or for_each are counted
+// together, so "azurerm_dns_a_record.a_records" matches both
+// `a_records["www"]` and `a_records["api"]`.
+func (p *Plan) Count(address string, expected int) *Plan {
+	instances := p.instances(address)
+	assert.Len(p.t, instances, expected, "planned instances of %s", address)
+	return p
+}
+
+// Exists asserts that the plan contains at least one instance of the
+// resource at address.
+func (p *Plan) Exists(address string) *Plan {
+	if len(p.instances(address)) == 0 {
+		assert.Fail(p.t, "resource not planned: "+address, "planned resources:\n  %s", strings.Join(p.Addresses(), "\n  "))
+	}
+	return p
+}
+
+// Absent asserts that the plan contains no instance of the resource at
+// address.
+func (p *Plan) Absent(address string) *Plan {
+	instances := p.instances(address)
+	assert.Empty(p.t, instances, "expected %s not to be planned", address)
+	return p
+}
+
+// OnlyCreates asserts that the plan only creates resources: nothing is
+// updated, replaced or destroyed. Data source reads and no-ops are allowed.
+// This is what a plan against an empty state must look like.
+func (p *Plan) OnlyCreates() *Plan {
+	var unexpected []string
+	for _, change := range p.Struct.RawPlan.ResourceChanges {
+		if change.Change == nil {
+			continue
+		}
+		actions := change.Change.Actions
+		if actions.Create() || actions.Read() || actions.NoOp() {
+			continue
+		}
+		unexpected = append(unexpected, fmt.Sprintf("%s %v", change.Address, actions))
+	}
+	sort.Strings(unexpected)
+	assert.Empty(p.t, unexpected, "expected the plan to only create resources")
+	return p
+}
+
+// Resource returns the planned resource at the exact address, including any
+// instance key, e.g. `azurerm_dns_a_record.a_records["www"]`. A missing
+// resource is reported once; assertions on it are then skipped.
+func (p *Plan) Resource(address string) *Resource {
+	resource := &Resource{plan: p, Address: address}
+
+	planned, ok := p.Struct.ResourcePlannedValuesMap[address]
+	if !ok {
+		assert.Fail(p.t, "resource not planned: "+address, "planned resources:\n  %s", strings.Join(p.Addresses(), "\n  "))
+		return resource
+	}
+
+	resource.found = true
+	resource.values = normalize(planned.AttributeValues)
+	if change, ok := p.Struct.ResourceChangesMap[address]; ok && change.Change != nil {
+		resource.unknown = normalize(change.Change.AfterUnknown)
+	}
+	return resource
+}
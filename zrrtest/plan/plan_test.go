@@ -0,0 +1,193 @@
+package plan
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a TestingT that collects failures instead of failing the test,
+// so the failure paths of the DSL can be asserted on.
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Fail()                                     {}
+func (r *recorder) FailNow()                                  {}
+func (r *recorder) Fatal(args ...interface{})                 { r.Error(args...) }
+func (r *recorder) Fatalf(format string, args ...interface{}) { r.Errorf(format, args...) }
+func (r *recorder) Error(args ...interface{})                 { r.errors = append(r.errors, fmt.Sprint(args...)) }
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+func (r *recorder) Name() string { return "recorder" }
+
+func loadPlan(t *testing.T) *terraform.PlanStruct {
+	t.Helper()
+	data, err := os.ReadFile("testdata/dns_zone.json")
+	require.NoError(t, err)
+	planStruct, err := terraform.ParsePlanJSON(string(data))
+	require.NoError(t, err)
+	return planStruct
+}
+
+func TestPlanAssertionsPass(t *testing.T) {
+	t.Parallel()
+
+	p := New(t, loadPlan(t))
+
+	p.Count("azurerm_dns_zone.main", 1).
+		Count("azurerm_dns_a_record.a_records", 2).
+		Count("azurerm_dns_a", 0).
+		Exists("azurerm_dns_mx_record.mx_records").
+		Absent("azurerm_dns_ns_record.delegation").
+		OnlyCreates()
+
+	p.Resource("azurerm_dns_zone.main").
+		Attr("name").Equals("test-app.dev.internal").
+		Attr("tags.ManagedBy").Equals("Terraform").
+		Attr("$.tags.Layer").Equals("infrastructure").
+		Attr(`tags["kubernetes.io/role"]`).Equals("dns").
+		Attr("tags").Contains("Module").
+		Attr("tags").HasLen(6).
+		Attr("timeouts").Null().
+		Attr("not_planned").Null().
+		Attr("name_servers").Unknown().
+		Attr("id").Unknown()
+
+	mxRecords := []map[string]interface{}{
+		{"exchange": "mail.example.com", "preference": 10},
+	}
+	p.Resource(`azurerm_dns_a_record.a_records["www"]`).
+		Attr("ttl").Equals(3600).
+		Attr("records").Equals([]string{"1.2.3.4", "5.6.7.8"}).
+		Attr("records").Contains("5.6.7.8").
+		Attr("records[1]").Equals("5.6.7.8").
+		Attr("records.0").Matches(`^\d+\.\d+\.\d+\.\d+$`).
+		Attr("zone_name").NotEquals("example.com").
+		Resource(`azurerm_dns_mx_record.mx_records["@"]`).
+		Attr("record.0.exchange").Equals("mail.example.com").
+		Attr("record[0].preference").Equals(10).
+		Attr("record").Equals(mxRecords)
+
+	assert.Equal(t, []string{
+		`azurerm_dns_a_record.a_records["api"]`,
+		`azurerm_dns_a_record.a_records["www"]`,
+		`azurerm_dns_mx_record.mx_records["@"]`,
+		"azurerm_dns_zone.main",
+	}, p.Addresses())
+	assert.Equal(t, "api", p.Resource(`azurerm_dns_a_record.a_records["api"]`).Values()["name"])
+}
+
+func TestPlanAssertionsReportFailures(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		check  func(p *Plan)
+		expect []string
+	}{
+		{
+			name:   "count mismatch",
+			check:  func(p *Plan) { p.Count("azurerm_dns_a_record.a_records", 3) },
+			expect: []string{"planned instances of azurerm_dns_a_record.a_records"},
+		},
+		{
+			name:   "missing resource lists planned addresses",
+			check:  func(p *Plan) { p.Resource("azurerm_dns_zone.other").Attr("name").Equals("x") },
+			expect: []string{"resource not planned: azurerm_dns_zone.other", "azurerm_dns_zone.main"},
+		},
+		{
+			name:   "value mismatch shows address, path and diff",
+			check:  func(p *Plan) { p.Resource("azurerm_dns_zone.main").Attr("tags.ManagedBy").Equals("Manual") },
+			expect: []string{"azurerm_dns_zone.main: tags.ManagedBy", "-Manual", "+Terraform"},
+		},
+		{
+			name:   "missing key lists available keys",
+			check:  func(p *Plan) { p.Resource("azurerm_dns_zone.main").Attr("tags.Owner").NotEmpty() },
+			expect: []string{`tags.Owner: key "Owner" not found`, "Environment, Layer, ManagedBy"},
+		},
+		{
+			name:   "index out of range",
+			check:  func(p *Plan) { p.Resource(`azurerm_dns_a_record.a_records["api"]`).Attr("records[3]").NotEmpty() },
+			expect: []string{"records[3]: index 3 out of range (length 1)"},
+		},
+		{
+			name:   "known value reported as unknown",
+			check:  func(p *Plan) { p.Resource("azurerm_dns_zone.main").Attr("name").Unknown() },
+			expect: []string{"azurerm_dns_zone.main: name: expected value to be known only after apply"},
+		},
+		{
+			name:   "non-null value",
+			check:  func(p *Plan) { p.Resource("azurerm_dns_zone.main").Attr("resource_group_name").Null() },
+			expect: []string{"resource_group_name: expected null"},
+		},
+		{
+			name: "update in a plan that should only create",
+			check: func(p *Plan) {
+				p.Struct.RawPlan.ResourceChanges[0].Change.Actions = tfjson.Actions{tfjson.ActionUpdate}
+				p.OnlyCreates()
+			},
+			expect: []string{"azurerm_dns_zone.main [update]"},
+		},
+		{
+			name:   "invalid pattern",
+			check:  func(p *Plan) { p.Resource("azurerm_dns_zone.main").Attr("name").Matches(`test-(app`) },
+			expect: []string{"azurerm_dns_zone.main: name: invalid pattern", "missing closing )"},
+		},
+		{
+			name:   "unexpected resource",
+			check:  func(p *Plan) { p.Absent("azurerm_dns_zone.main") },
+			expect: []string{"expected azurerm_dns_zone.main not to be planned"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &recorder{}
+			tc.check(New(r, loadPlan(t)))
+
+			require.Len(t, r.errors, 1, "expected exactly one failure")
+			for _, want := range tc.expect {
+				assert.Contains(t, r.errors[0], want)
+			}
+		})
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		path   string
+		expect string
+		err    bool
+	}{
+		{path: "name", expect: "name"},
+		{path: "$.tags.ManagedBy", expect: "tags.ManagedBy"},
+		{path: "container.0.ports[0].port", expect: "container.0.ports[0].port"},
+		{path: `tags["kubernetes.io/role"]`, expect: `tags["kubernetes.io/role"]`},
+		{path: "", err: true},
+		{path: "tags..x", err: true},
+		{path: "records[", err: true},
+		{path: "records[-1]", err: true},
+	}
+
+	for _, tc := range testCases {
+		segments, err := parsePath(tc.path)
+		if tc.err {
+			assert.Error(t, err, tc.path)
+			continue
+		}
+		require.NoError(t, err, tc.path)
+		assert.Equal(t, tc.expect, formatSegments(segments), tc.path)
+	}
+}
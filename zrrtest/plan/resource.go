@@ -0,0 +1,174 @@
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Resource is a single planned resource instance.
+type Resource struct {
+	plan    *Plan
+	found   bool
+	values  interface{}
+	unknown interface{}
+
+	// Address is the full resource address, including any instance key.
+	Address string
+}
+
+// Attr selects the planned value at path for assertion.
+func (r *Resource) Attr(path string) *Value {
+	value := &Value{resource: r, path: path}
+	if r.found {
+		value.value, value.err = lookup(r.values, path)
+	}
+	return value
+}
+
+// Values returns the planned attribute values of the resource, decoded from
+// JSON: objects are map[string]interface{}, lists []interface{} and numbers
+// float64. Values that are only known after apply are not included.
+func (r *Resource) Values() map[string]interface{} {
+	values, _ := r.values.(map[string]interface{})
+	return values
+}
+
+// Resource switches to another resource of the same plan, so checks on
+// related resources can be chained.
+func (r *Resource) Resource(address string) *Resource {
+	return r.plan.Resource(address)
+}
+
+// Value is a planned attribute value selected by Resource.Attr. Each assertion
+// returns the owning Resource so further attributes can be checked in the same
+// chain.
+type Value struct {
+	resource *Resource
+	path     string
+	value    interface{}
+	err      error
+}
+
+func (v *Value) label() string {
+	return fmt.Sprintf("%s: %s", v.resource.Address, v.path)
+}
+
+// resolved reports whether the value can be asserted on. A lookup error is
+// reported against the test; a missing resource has already been reported by
+// Plan.Resource.
+func (v *Value) resolved() bool {
+	if !v.resource.found {
+		return false
+	}
+	if v.err != nil {
+		assert.Fail(v.resource.plan.t, fmt.Sprintf("%s: %v", v.resource.Address, v.err))
+		return false
+	}
+	return true
+}
+
+// Raw returns the planned value, or nil when it could not be resolved.
+func (v *Value) Raw() interface{} {
+	return v.value
+}
+
+// Equals asserts that the planned value equals expected. Expected is
+// compared after a JSON round trip, so Go ints, string maps and slices of
+// structs can be written as they are passed in Vars.
+func (v *Value) Equals(expected interface{}) *Resource {
+	if v.resolved() {
+		assert.Equal(v.resource.plan.t, normalize(expected), v.value, v.label())
+	}
+	return v.resource
+}
+
+// NotEquals asserts that the planned value differs from unexpected.
+func (v *Value) NotEquals(unexpected interface{}) *Resource {
+	if v.resolved() {
+		assert.NotEqual(v.resource.plan.t, normalize(unexpected), v.value, v.label())
+	}
+	return v.resource
+}
+
+// Contains asserts that a planned string contains element as a substring,
+// that a planned list contains element, or that a planned map has element as
+// a key.
+func (v *Value) Contains(element interface{}) *Resource {
+	if v.resolved() {
+		assert.Contains(v.resource.plan.t, v.value, normalize(element), v.label())
+	}
+	return v.resource
+}
+
+// Matches asserts that the planned value is a string matching pattern. A
+// pattern that does not compile fails the assertion.
+func (v *Value) Matches(pattern string) *Resource {
+	if v.resolved() {
+		if _, ok := v.value.(string); !ok {
+			assert.Fail(v.resource.plan.t, fmt.Sprintf("%s: expected a string, got %T", v.label(), v.value))
+			return v.resource
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			v.resource.plan.t.Errorf("%s: invalid pattern: %v", v.label(), err)
+			return v.resource
+		}
+		assert.Regexp(v.resource.plan.t, re, v.value, v.label())
+	}
+	return v.resource
+}
+
+// HasLen asserts that the planned string, list or map has length expected.
+func (v *Value) HasLen(expected int) *Resource {
+	if v.resolved() {
+		assert.Len(v.resource.plan.t, v.value, expected, v.label())
+	}
+	return v.resource
+}
+
+// NotEmpty asserts that the planned value is neither null nor empty.
+func (v *Value) NotEmpty() *Resource {
+	if v.resolved() {
+		assert.NotEmpty(v.resource.plan.t, v.value, v.label())
+	}
+	return v.resource
+}
+
+// Null asserts that the attribute is absent from the planned values or set to
+// null. Attributes that are only known after apply are also absent; use
+// Unknown to tell the two apart.
+func (v *Value) Null() *Resource {
+	if v.resource.found && v.err == nil && v.value != nil {
+		assert.Fail(v.resource.plan.t, v.label()+": expected null", "planned value: %#v", v.value)
+	}
+	return v.resource
+}
+
+// Unknown asserts that the attribute is only known after apply.
+func (v *Value) Unknown() *Resource {
+	if !v.resource.found {
+		return v.resource
+	}
+	unknown, err := lookup(v.resource.unknown, v.path)
+	if err != nil || unknown != true {
+		assert.Fail(v.resource.plan.t, v.label()+": expected value to be known only after apply")
+	}
+	return v.resource
+}
+
+// normalize round-trips value through JSON so expected values written as Go
+// literals compare equal to values decoded from the plan.
+func normalize(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
@@ -0,0 +1,34 @@
+package zrrtest
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/random"
+)
+
+// lowerAlphanumeric is the character set accepted by the strictest Azure
+// naming rules (storage accounts, key vault suffixes, file shares).
+const lowerAlphanumeric = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// UniqueID returns a short lowercase identifier suitable for suffixing test
+// resource names and for the TestID tag.
+func UniqueID() string {
+	return strings.ToLower(random.UniqueId())
+}
+
+// RandomString returns a random string of the given length made of lowercase
+// letters and digits.
+func RandomString(length int) string {
+	max := big.NewInt(int64(len(lowerAlphanumeric)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(err)
+		}
+		b[i] = lowerAlphanumeric[n.Int64()]
+	}
+	return string(b)
+}
@@ -0,0 +1,27 @@
+package zrrtest
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomString(t *testing.T) {
+	t.Parallel()
+
+	for _, length := range []int{0, 1, 6, 24} {
+		s := RandomString(length)
+		assert.Len(t, s, length)
+		assert.Regexp(t, regexp.MustCompile(`^[a-z0-9]*$`), s)
+	}
+	assert.NotEqual(t, RandomString(24), RandomString(24))
+}
+
+func TestUniqueID(t *testing.T) {
+	t.Parallel()
+
+	id := UniqueID()
+	assert.Len(t, id, 6)
+	assert.Regexp(t, regexp.MustCompile(`^[a-z0-9]+$`), id)
+}
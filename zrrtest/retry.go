@@ -0,0 +1,81 @@
+package zrrtest
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+)
+
+// AzureRetryableErrors maps regular expressions for transient Azure and
+// azurerm failures to a description of the failure. WithDefaults adds them to
+// every suite's RetryableTerraformErrors, next to Terratest's own defaults.
+//
+// An entry belongs here only if the same apply reliably succeeds when run
+// again a little later. Errors caused by the configuration, such as a failed
+// variable validation or a name that is already taken, must fail the test on
+// the first attempt.
+var AzureRetryableErrors = map[string]string{
+	// ARM and the resource providers answer 429 when a subscription or a
+	// resource sends too many requests, which parallel suites easily do.
+	`StatusCode=429|Status=429|unexpected status 429|TooManyRequests|SubscriptionRequestsThrottled|ResourceRequestsThrottled`: "Azure throttled the request.",
+
+	// Only one write may run against a resource, or a resource and its
+	// parent, at a time.
+	`AnotherOperationInProgress|Code="RetryableError"|StorageAccountOperationInProgress`: "Another operation on the resource was still in progress.",
+
+	// A new principal or role assignment takes a few minutes to be visible
+	// to every Azure service. A Key Vault access policy that does not grant
+	// a permission fails the same way whether or not it was just created,
+	// so its "does not have ... permission on key vault" is not retried.
+	`PrincipalNotFound|ForbiddenByRbac|please observe propagation time`: "A role assignment had not propagated yet.",
+
+	// Key Vault names and objects stay reserved while they are soft-deleted
+	// or being purged by the previous run's destroy.
+	`already exists in deleted state|is currently in a deleted but recoverable state|is currently being deleted|ObjectIsBeingDeleted`: "A Key Vault or Key Vault object was still being deleted or purged.",
+
+	// Deletions that cascade asynchronously.
+	`ResourceGroupBeingDeleted|ContainerBeingDeleted|ShareBeingDeleted|InUseSubnetCannotBeDeleted|InUseNetworkSecurityGroupCannotBeDeleted`: "A dependent resource was still being deleted.",
+
+	// Server-side failures of ARM or a resource provider.
+	`(StatusCode=|Status=|unexpected status )50[0234]\b|Code="(InternalServerError|ServiceUnavailable|GatewayTimeout)"`: "Azure returned a server error.",
+}
+
+// catchAllPattern matches retryable error patterns that match any output,
+// such as ".*", which would retry real failures too.
+func catchAllPattern(pattern string) bool {
+	re, err := regexp.Compile(pattern)
+	return err == nil && re.MatchString("")
+}
+
+// RetryableErrors returns the retryable error patterns WithDefaults applies:
+// Terratest's defaults and AzureRetryableErrors.
+func RetryableErrors() map[string]string {
+	errors := map[string]string{}
+	for pattern, description := range terraform.DefaultRetryableTerraformErrors {
+		errors[pattern] = description
+	}
+	for pattern, description := range AzureRetryableErrors {
+		errors[pattern] = description
+	}
+	return errors
+}
+
+// TransientError reports whether Terraform output matches one of
+// RetryableErrors and returns the description of the first matching
+// pattern, in lexical order.
+func TransientError(output string) (string, bool) {
+	errors := RetryableErrors()
+	patterns := make([]string, 0, len(errors))
+	for pattern := range errors {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if regexp.MustCompile(pattern).MatchString(output) {
+			return errors[pattern], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,104 @@
+package zrrtest
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTransientError classifies Terraform output captured from failed runs.
+func TestTransientError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		file        string
+		description string
+	}{
+		{"throttled.txt", "Azure throttled the request."},
+		{"throttled_go_azure_sdk.txt", "Azure throttled the request."},
+		{"another_operation.txt", "Another operation on the resource was still in progress."},
+		{"retryable_error.txt", "Another operation on the resource was still in progress."},
+		{"principal_not_found.txt", "A role assignment had not propagated yet."},
+		{"key_vault_rbac.txt", "A role assignment had not propagated yet."},
+		{"key_vault_soft_deleted.txt", "A Key Vault or Key Vault object was still being deleted or purged."},
+		{"key_vault_secret_deleted.txt", "A Key Vault or Key Vault object was still being deleted or purged."},
+		{"resource_group_being_deleted.txt", "A dependent resource was still being deleted."},
+		{"subnet_in_use.txt", "A dependent resource was still being deleted."},
+		{"server_error.txt", "Azure returned a server error."},
+
+		// Real failures must not be retried.
+		{"validation_failed.txt", ""},
+		{"name_taken.txt", ""},
+		{"authorization_failed.txt", ""},
+		{"key_vault_access_policy.txt", ""},
+		{"quota_exceeded.txt", ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+
+			output, err := os.ReadFile(filepath.Join("testdata", "errors", tc.file))
+			require.NoError(t, err)
+
+			description, ok := TransientError(string(output))
+			assert.Equal(t, tc.description != "", ok)
+			assert.Equal(t, tc.description, description)
+		})
+	}
+}
+
+func TestAzureRetryableErrorsAreSpecific(t *testing.T) {
+	t.Parallel()
+
+	for pattern := range AzureRetryableErrors {
+		_, err := regexp.Compile(pattern)
+		assert.NoError(t, err, pattern)
+		assert.False(t, catchAllPattern(pattern), pattern)
+	}
+	assert.True(t, catchAllPattern(".*"))
+	assert.True(t, catchAllPattern("(?s).*"))
+}
+
+func TestWithDefaultsAddsAzureRetryableErrors(t *testing.T) {
+	t.Parallel()
+
+	options := WithDefaults(t, &terraform.Options{TerraformDir: ModuleDir})
+	for pattern := range AzureRetryableErrors {
+		assert.Contains(t, options.RetryableTerraformErrors, pattern)
+	}
+}
+
+func TestWithDefaultsRejectsCatchAllRetries(t *testing.T) {
+	t.Parallel()
+
+	fake := &recordingT{}
+	func() {
+		defer func() { recover() }()
+		WithDefaults(fake, &terraform.Options{
+			TerraformDir:             ModuleDir,
+			RetryableTerraformErrors: map[string]string{".*": "Transient error occurred"},
+		})
+	}()
+	assert.True(t, fake.failed)
+}
+
+// recordingT is a testing.TestingT that records a failure instead of
+// failing the calling test.
+type recordingT struct {
+	failed bool
+}
+
+func (r *recordingT) Fail()                                     { r.failed = true }
+func (r *recordingT) FailNow()                                  { r.failed = true; panic("FailNow") }
+func (r *recordingT) Fatal(args ...interface{})                 { r.FailNow() }
+func (r *recordingT) Fatalf(format string, args ...interface{}) { r.FailNow() }
+func (r *recordingT) Error(args ...interface{})                 { r.Fail() }
+func (r *recordingT) Errorf(format string, args ...interface{}) { r.Fail() }
+func (r *recordingT) Name() string                              { return "recordingT" }
@@ -0,0 +1,163 @@
+package zrrtest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	gotesting "testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// SecretMask replaces registered secrets in log output.
+const SecretMask = "********"
+
+// DebugKeyVaultEnvVar names the environment variable holding the resource ID
+// of the Key Vault KeepSecretOnFailure stores secrets in.
+const DebugKeyVaultEnvVar = "ZRR_DEBUG_KEY_VAULT_ID"
+
+// DebugSecretLifetime is how long a secret stored for debugging stays valid.
+const DebugSecretLifetime = 7 * 24 * time.Hour
+
+// keyVaultSecretModuleDir is the key-vault-secret module as seen from a suite
+// under tests/unit or tests/integration of any other module.
+var keyVaultSecretModuleDir = filepath.Join(ModuleDir, "..", "..", "security", "key-vault-secret")
+
+var (
+	secretsMu sync.RWMutex
+	secrets   = map[string]bool{}
+)
+
+// RegisterSecret adds secret to the values MaskSecrets replaces. Passwords
+// returned by Password are registered already.
+func RegisterSecret(secret string) {
+	if secret == "" {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	secrets[secret] = true
+}
+
+// MaskSecrets returns s with every registered secret replaced by SecretMask.
+func MaskSecrets(s string) string {
+	secretsMu.RLock()
+	registered := make([]string, 0, len(secrets))
+	for secret := range secrets {
+		registered = append(registered, secret)
+	}
+	secretsMu.RUnlock()
+
+	// Replace longer secrets first so a secret containing another one is
+	// masked whole.
+	sort.Slice(registered, func(i, j int) bool { return len(registered[i]) > len(registered[j]) })
+	for _, secret := range registered {
+		s = strings.ReplaceAll(s, secret, SecretMask)
+	}
+	return s
+}
+
+// MaskingLogger is a terratest logger that masks registered secrets before
+// writing to stdout. WithDefaults installs it on every suite's options, so
+// passwords passed as variables or returned as outputs stay out of the
+// command logs.
+var MaskingLogger = logger.New(maskingLogger{out: os.Stdout})
+
+type maskingLogger struct {
+	out io.Writer
+}
+
+// Logf logs like terratest's default logger, including the caller's file and
+// line.
+func (l maskingLogger) Logf(t testing.TestingT, format string, args ...interface{}) {
+	logger.DoLog(t, 3, l.out, MaskSecrets(fmt.Sprintf(format, args...)))
+}
+
+// KeepSecretOnFailure stores secret in the Key Vault named by
+// DebugKeyVaultEnvVar when the test fails, so the resources a failed run
+// leaves behind can be inspected. Without the variable it only logs how to
+// enable this. The secret is stored under DebugSecretName(t, name).
+func KeepSecretOnFailure(t *gotesting.T, name, secret string) {
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		keyVaultID := os.Getenv(DebugKeyVaultEnvVar)
+		if keyVaultID == "" {
+			logger.Logf(t, "Not keeping secret %s of the failed test: set %s to a Key Vault ID to keep it", name, DebugKeyVaultEnvVar)
+			return
+		}
+		secretName := DebugSecretName(t, name)
+		if err := StoreSecretE(t, keyVaultID, secretName, secret); err != nil {
+			logger.Logf(t, "Could not keep secret %s in %s: %v", secretName, keyVaultID, err)
+			return
+		}
+		logger.Logf(t, "Kept secret %s of the failed test in %s as %s", name, keyVaultID, secretName)
+	})
+}
+
+var invalidSecretNameChars = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+
+// DebugSecretName returns the Key Vault secret name a test's secret is kept
+// under: the test name and name, reduced to the characters Key Vault allows.
+func DebugSecretName(t testing.TestingT, name string) string {
+	secretName := invalidSecretNameChars.ReplaceAllString(t.Name()+"-"+name, "-")
+	secretName = strings.Trim(secretName, "-")
+	if len(secretName) > 127 {
+		secretName = secretName[len(secretName)-127:]
+		secretName = strings.TrimLeft(secretName, "-")
+	}
+	return secretName
+}
+
+// StoreSecret stores secret in the Key Vault with the given resource ID
+// using the key-vault-secret module. The secret expires after
+// DebugSecretLifetime and is not destroyed when the test ends.
+func StoreSecret(t testing.TestingT, keyVaultID, name, secret string) {
+	require.NoError(t, StoreSecretE(t, keyVaultID, name, secret))
+}
+
+// StoreSecretE is like StoreSecret but returns an error instead of failing
+// the test.
+func StoreSecretE(t testing.TestingT, keyVaultID, name, secret string) error {
+	RegisterSecret(secret)
+
+	terraformDir, err := files.CopyTerraformFolderToTemp(keyVaultSecretModuleDir, "key-vault-secret")
+	if err != nil {
+		return err
+	}
+	provider := "provider \"azurerm\" {\n  features {}\n}\n"
+	if err := os.WriteFile(filepath.Join(terraformDir, "provider.tf"), []byte(provider), 0o644); err != nil {
+		return err
+	}
+
+	options := WithDefaults(t, &terraform.Options{
+		TerraformDir: terraformDir,
+		Vars: map[string]interface{}{
+			"name":            name,
+			"key_vault_id":    keyVaultID,
+			"content_type":    "zrrtest debug secret",
+			"expiration_date": time.Now().UTC().Add(DebugSecretLifetime).Format(time.RFC3339),
+			"common_tags": map[string]string{
+				"Environment": "test",
+				"Project":     "terratest",
+				"TestName":    invalidSecretNameChars.ReplaceAllString(t.Name(), "-"),
+			},
+		},
+		// The value is passed through the environment so it is not part of
+		// the command line.
+		EnvVars: map[string]string{"TF_VAR_value": secret},
+	})
+	_, err = terraform.InitAndApplyE(t, options)
+	return err
+}
@@ -0,0 +1,52 @@
+package zrrtest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskSecrets(t *testing.T) {
+	t.Parallel()
+
+	password := Password(t, MySQLPasswordPolicy, "mysqladmin")
+	RegisterSecret("secret-value")
+	RegisterSecret("secret-value-longer")
+	RegisterSecret("")
+
+	assert.Equal(t, "-var administrator_password="+SecretMask, MaskSecrets("-var administrator_password="+password))
+	assert.Equal(t, SecretMask+" and "+SecretMask, MaskSecrets("secret-value-longer and secret-value"))
+	assert.Equal(t, "nothing to hide", MaskSecrets("nothing to hide"))
+}
+
+func TestMaskingLogger(t *testing.T) {
+	t.Parallel()
+
+	password := Password(t, VMPasswordPolicy, "azureuser")
+
+	var out bytes.Buffer
+	logger.New(maskingLogger{out: &out}).Logf(t, "Running command terraform with args [-var admin_password=%s]", password)
+	assert.NotContains(t, out.String(), password)
+	assert.Contains(t, out.String(), "admin_password="+SecretMask)
+	assert.Contains(t, out.String(), "secrets_test.go")
+
+	// Validate WithDefaults installs the logger unless a suite chose one
+	options := WithDefaults(t, &terraform.Options{TerraformDir: ModuleDir})
+	assert.Same(t, MaskingLogger, options.Logger)
+	options = WithDefaults(t, &terraform.Options{TerraformDir: ModuleDir, Logger: logger.Discard})
+	assert.Equal(t, logger.Discard, options.Logger)
+}
+
+func TestDebugSecretName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "TestDebugSecretName-administrator-password", DebugSecretName(t, "administrator_password"))
+	t.Run("sub test/with spaces", func(t *testing.T) {
+		name := DebugSecretName(t, "password")
+		assert.Regexp(t, `^[a-zA-Z0-9-]{1,127}$`, name)
+		assert.Equal(t, "TestDebugSecretName-sub-test-with-spaces-password", name)
+	})
+}
@@ -0,0 +1,25 @@
+// Package snapshot compares Terraform plans against committed golden files.
+//
+// The snapshot suite plans each example of a module and stores the
+// normalized output of `terraform show -json` under tests/testdata. Any change
+// to the module that alters what Terraform would build then shows up as a
+// diff of those files in review, without deploying anything.
+//
+// The suite in zrrtest/snapshot/examples covers every module whose examples
+// plan without variables. Run it with ZRR_UPDATE_SNAPSHOTS set to write new
+// golden files after an intended change:
+//
+//	ZRR_UPDATE_SNAPSHOTS=1 go test ./zrrtest/snapshot/examples -args -module=azure/infrastructure/dns-zone
+//
+// A module whose examples need variables keeps its own suite, passing them
+// keyed by example name:
+//
+//	func TestExamplesPlanSnapshot(t *testing.T) {
+//		t.Parallel()
+//		snapshot.Examples(t, map[string]map[string]interface{}{
+//			"basic": {"administrator_password": password},
+//		})
+//	}
+//
+// Volatile values are normalized before comparison; see Normalize.
+package snapshot
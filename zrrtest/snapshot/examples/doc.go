@@ -0,0 +1,11 @@
+// Package examples holds the plan snapshot suite of the modules that have
+// none of their own. A module only needs its own TestExamplesPlanSnapshot
+// when an example must be planned with variables; every other module is
+// covered by this one, which zrr-test runs in the plan tier with the module's
+// path:
+//
+//	go test ./zrrtest/snapshot/examples -args -module=azure/infrastructure/dns-zone
+//
+// Set ZRR_UPDATE_SNAPSHOTS to rewrite the module's golden files under
+// tests/testdata.
+package examples
@@ -0,0 +1,26 @@
+package examples
+
+import (
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/snapshot"
+)
+
+// repositoryRoot is the root of the repository as seen from this package.
+const repositoryRoot = "../../.."
+
+var module = flag.String("module", "", "path of the module whose examples to snapshot, relative to the repository root")
+
+// TestExamplesPlanSnapshot compares the plan of every example of -module with
+// its golden file under tests/testdata. Run with ZRR_UPDATE_SNAPSHOTS=1 to
+// accept an intended change.
+func TestExamplesPlanSnapshot(t *testing.T) {
+	if *module == "" {
+		t.Skip("no -module given")
+	}
+	t.Parallel()
+
+	snapshot.ModuleExamples(t, filepath.Join(repositoryRoot, filepath.FromSlash(*module)), nil)
+}
@@ -0,0 +1,270 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Placeholders substituted for volatile values.
+const (
+	ZeroGUID  = "00000000-0000-0000-0000-000000000000"
+	Sensitive = "(sensitive)"
+)
+
+// snapshotKeys are the parts of `terraform show -json` kept in a snapshot.
+// Everything else either repeats the configuration (configuration,
+// relevant_attributes), depends on the machine or Terraform release
+// (terraform_version, timestamp) or holds unredacted inputs (variables,
+// prior_state).
+var snapshotKeys = []string{"planned_values", "resource_changes", "output_changes"}
+
+// randomAttributes are the attributes of the hashicorp/random resources that
+// carry the generated value.
+var randomAttributes = []string{"result", "hex", "b64_std", "b64_url", "dec", "id"}
+
+var guidPattern = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+
+// Normalize turns the output of `terraform show -json` into a stable,
+// indented snapshot:
+//
+//   - only planned_values, resource_changes and output_changes are kept;
+//   - values Terraform marks as sensitive are replaced with Sensitive;
+//   - values generated by random_* resources already in state are replaced
+//     with the resource address, e.g. ${random_string.suffix.result};
+//   - every key in replace is substituted with its value, so per-run inputs
+//     such as zrrtest.UniqueID() can be masked;
+//   - GUIDs, which cover subscription and tenant IDs, become ZeroGUID;
+//   - resources are sorted by address.
+func Normalize(planJSON []byte, replace map[string]string) ([]byte, error) {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(planJSON, &raw); err != nil {
+		return nil, err
+	}
+
+	doc := map[string]interface{}{}
+	for _, key := range snapshotKeys {
+		if value, ok := raw[key]; ok {
+			doc[key] = value
+		}
+	}
+
+	redactPlannedValues(doc["planned_values"])
+	redactChanges(doc["resource_changes"])
+	if outputs, ok := doc["output_changes"].(map[string]interface{}); ok {
+		for _, change := range outputs {
+			redactChange(change)
+		}
+	}
+
+	replacements := randomValues(doc["planned_values"])
+	for old, placeholder := range replace {
+		if old != "" {
+			replacements = append(replacements, replacement{old: old, new: placeholder})
+		}
+	}
+	// Replace longer values first so a value that contains another is masked
+	// as a whole.
+	sort.SliceStable(replacements, func(i, j int) bool {
+		return len(replacements[i].old) > len(replacements[j].old)
+	})
+
+	normalized := rewriteStrings(doc, func(s string) string {
+		for _, r := range replacements {
+			s = strings.ReplaceAll(s, r.old, r.new)
+		}
+		return guidPattern.ReplaceAllString(s, ZeroGUID)
+	})
+	sortResources(normalized)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(normalized); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type replacement struct {
+	old, new string
+}
+
+// redactPlannedValues masks sensitive attribute values and outputs in the
+// planned_values tree.
+func redactPlannedValues(plannedValues interface{}) {
+	values, ok := plannedValues.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if outputs, ok := values["outputs"].(map[string]interface{}); ok {
+		for _, output := range outputs {
+			if output, ok := output.(map[string]interface{}); ok && output["sensitive"] == true {
+				output["value"] = Sensitive
+			}
+		}
+	}
+	forEachResource(values["root_module"], func(resource map[string]interface{}) {
+		resource["values"] = redact(resource["values"], resource["sensitive_values"])
+	})
+}
+
+func redactChanges(changes interface{}) {
+	list, _ := changes.([]interface{})
+	for _, item := range list {
+		if change, ok := item.(map[string]interface{}); ok {
+			redactChange(change["change"])
+		}
+	}
+}
+
+// redactChange masks the before and after values of a change according to
+// before_sensitive and after_sensitive.
+func redactChange(change interface{}) {
+	values, ok := change.(map[string]interface{})
+	if !ok {
+		return
+	}
+	values["before"] = redact(values["before"], values["before_sensitive"])
+	values["after"] = redact(values["after"], values["after_sensitive"])
+}
+
+// redact returns value with every part marked true in the parallel
+// sensitivity tree replaced by Sensitive.
+func redact(value, sensitivity interface{}) interface{} {
+	switch mask := sensitivity.(type) {
+	case bool:
+		if mask && value != nil {
+			return Sensitive
+		}
+	case map[string]interface{}:
+		if object, ok := value.(map[string]interface{}); ok {
+			for key, child := range mask {
+				if _, present := object[key]; present {
+					object[key] = redact(object[key], child)
+				}
+			}
+		}
+	case []interface{}:
+		if list, ok := value.([]interface{}); ok {
+			for i := range list {
+				if i < len(mask) {
+					list[i] = redact(list[i], mask[i])
+				}
+			}
+		}
+	}
+	return value
+}
+
+// randomValues collects the generated values of random_* resources that are
+// already known, mapped to a placeholder naming the resource.
+func randomValues(plannedValues interface{}) []replacement {
+	values, ok := plannedValues.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var replacements []replacement
+	seen := map[string]bool{}
+	forEachResource(values["root_module"], func(resource map[string]interface{}) {
+		resourceType, _ := resource["type"].(string)
+		address, _ := resource["address"].(string)
+		attributes, _ := resource["values"].(map[string]interface{})
+		if !strings.HasPrefix(resourceType, "random_") || attributes == nil {
+			return
+		}
+		for _, name := range randomAttributes {
+			// random_string repeats its result as id; the first attribute
+			// carrying a value names the placeholder.
+			if value, ok := attributes[name].(string); ok && value != "" && !seen[value] {
+				seen[value] = true
+				replacements = append(replacements, replacement{old: value, new: "${" + address + "." + name + "}"})
+			}
+		}
+	})
+	return replacements
+}
+
+// forEachResource calls fn for every resource of a planned_values module and
+// its child modules.
+func forEachResource(module interface{}, fn func(map[string]interface{})) {
+	values, ok := module.(map[string]interface{})
+	if !ok {
+		return
+	}
+	resources, _ := values["resources"].([]interface{})
+	for _, item := range resources {
+		if resource, ok := item.(map[string]interface{}); ok {
+			fn(resource)
+		}
+	}
+	children, _ := values["child_modules"].([]interface{})
+	for _, child := range children {
+		forEachResource(child, fn)
+	}
+}
+
+// rewriteStrings returns a copy of value with fn applied to every string,
+// including map keys.
+func rewriteStrings(value interface{}, fn func(string) string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return fn(v)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			out[fn(key)] = rewriteStrings(child, fn)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, child := range v {
+			out[i] = rewriteStrings(child, fn)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// sortResources orders resource lists by address so snapshots do not depend
+// on the order Terraform happened to walk the graph in.
+func sortResources(doc interface{}) {
+	values, ok := doc.(map[string]interface{})
+	if !ok {
+		return
+	}
+	sortByAddress(values["resource_changes"])
+	if plannedValues, ok := values["planned_values"].(map[string]interface{}); ok {
+		sortModule(plannedValues["root_module"])
+	}
+}
+
+func sortModule(module interface{}) {
+	values, ok := module.(map[string]interface{})
+	if !ok {
+		return
+	}
+	sortByAddress(values["resources"])
+	sortByAddress(values["child_modules"])
+	children, _ := values["child_modules"].([]interface{})
+	for _, child := range children {
+		sortModule(child)
+	}
+}
+
+func sortByAddress(list interface{}) {
+	items, ok := list.([]interface{})
+	if !ok {
+		return
+	}
+	address := func(i int) string {
+		item, _ := items[i].(map[string]interface{})
+		s, _ := item["address"].(string)
+		return s
+	}
+	sort.SliceStable(items, func(i, j int) bool { return address(i) < address(j) })
+}
@@ -0,0 +1,53 @@
+package snapshot
+
+import (
+	"flag"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const normalizedGolden = "testdata/plan.normalized.json"
+
+var update = flag.Bool("update", false, "rewrite testdata/plan.normalized.json instead of comparing against it")
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+
+	planJSON, err := os.ReadFile("testdata/plan.json")
+	require.NoError(t, err)
+
+	replace := map[string]string{"ab12cd": "<test-id>"}
+	normalized, err := Normalize(planJSON, replace)
+	require.NoError(t, err)
+
+	if *update {
+		require.NoError(t, os.WriteFile(normalizedGolden, normalized, 0o644))
+	}
+	expected, err := os.ReadFile(normalizedGolden)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(normalized))
+
+	text := string(normalized)
+	for _, volatile := range []string{"hunter2", "3f2a9c1e", "x7k2p9q1", "ab12cd", "timestamp", "terraform_version", "prior_state", "variables"} {
+		assert.NotContains(t, text, volatile)
+	}
+	assert.Contains(t, text, `"name": "test-mysql-${random_string.suffix.result}"`)
+	assert.Contains(t, text, `"/subscriptions/`+ZeroGUID+`/resourceGroups/shared"`)
+	assert.Less(t, strings.Index(text, `"address": "azurerm_resource_group.main"`), strings.Index(text, `"address": "random_string.suffix"`))
+
+	// Normalizing a snapshot again must not change it.
+	again, err := Normalize(normalized, replace)
+	require.NoError(t, err)
+	assert.Equal(t, string(normalized), string(again))
+}
+
+func TestNormalizeRejectsInvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	_, err := Normalize([]byte("Error: No configuration files"), nil)
+	assert.Error(t, err)
+}
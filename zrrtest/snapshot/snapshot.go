@@ -0,0 +1,109 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// GoldenDir is where a module keeps its snapshots, as seen from a suite under
+// tests/unit or tests/integration.
+const GoldenDir = "../testdata"
+
+// UpdateEnvVar names the environment variable that, when set, makes the
+// snapshot suites rewrite their golden files instead of comparing against
+// them. It is not a flag so that the library registers none a test package
+// may define as well, and so that one zrr-test run passes it to every suite.
+const UpdateEnvVar = "ZRR_UPDATE_SNAPSHOTS"
+
+// Updating reports whether UpdateEnvVar is set.
+func Updating() bool {
+	return os.Getenv(UpdateEnvVar) != ""
+}
+
+// GoldenPath returns the golden file of the snapshot called name.
+func GoldenPath(name string) string {
+	return filepath.Join(GoldenDir, name+".plan.json")
+}
+
+// Assert normalizes planJSON and compares it with the golden file of the
+// snapshot called name. When Updating the golden file is rewritten instead.
+func Assert(t terratesting.TestingT, name string, planJSON string, replace map[string]string) {
+	assertGolden(t, GoldenPath(name), planJSON, replace)
+}
+
+// assertGolden is Assert against the golden file at path.
+func assertGolden(t terratesting.TestingT, path string, planJSON string, replace map[string]string) {
+	actual, err := Normalize([]byte(planJSON), replace)
+	require.NoError(t, err)
+
+	if Updating() {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+		return
+	}
+
+	expected, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Fatalf("golden file %s does not exist; run the test with %s=1 to create it", path, UpdateEnvVar)
+	}
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual), "plan differs from %s; run the test with %s=1 to accept the change", path, UpdateEnvVar)
+}
+
+// Examples plans every example under examples/ of the module under test and
+// compares each plan with its golden file, tests/testdata/<example>.plan.json.
+// vars holds the variables to pass to an example, keyed by example name;
+// examples without an entry are planned with their defaults.
+func Examples(t *testing.T, vars map[string]map[string]interface{}) {
+	ModuleExamples(t, zrrtest.ModuleDir, vars)
+}
+
+// ModuleExamples is like Examples for the module in dir instead of the module
+// under test. The shared suite in package examples uses it for the modules
+// that have no snapshot suite of their own.
+func ModuleExamples(t *testing.T, dir string, vars map[string]map[string]interface{}) {
+	examples := exampleNames(t, dir)
+	require.NotEmpty(t, examples, "module has no examples")
+
+	for _, example := range examples {
+		example := example
+		t.Run(example, func(t *testing.T) {
+			t.Parallel()
+
+			options := zrrtest.WithDefaults(t, &terraform.Options{
+				TerraformDir: filepath.Join(dir, "examples", example),
+				Vars:         vars[example],
+			})
+			golden := filepath.Join(dir, "tests", "testdata", example+".plan.json")
+			assertGolden(t, golden, plan.InitAndPlanJSON(t, options), nil)
+		})
+	}
+}
+
+// ExampleNames returns the sorted names of the module's examples.
+func ExampleNames(t terratesting.TestingT) []string {
+	return exampleNames(t, zrrtest.ModuleDir)
+}
+
+func exampleNames(t terratesting.TestingT, dir string) []string {
+	entries, err := os.ReadDir(filepath.Join(dir, "examples"))
+	require.NoError(t, err)
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,33 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssertGoldenUpdates(t *testing.T) {
+	planJSON, err := os.ReadFile("testdata/plan.json")
+	require.NoError(t, err)
+	golden := filepath.Join(t.TempDir(), "testdata", "basic.plan.json")
+
+	t.Setenv(UpdateEnvVar, "")
+	assert.False(t, Updating())
+
+	t.Setenv(UpdateEnvVar, "1")
+	require.True(t, Updating())
+	assertGolden(t, golden, string(planJSON), nil)
+
+	written, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	normalized, err := Normalize(planJSON, nil)
+	require.NoError(t, err)
+	assert.Equal(t, string(normalized), string(written))
+
+	// Once written, the golden file matches without updating.
+	t.Setenv(UpdateEnvVar, "")
+	assertGolden(t, golden, string(planJSON), nil)
+}
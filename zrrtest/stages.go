@@ -0,0 +1,234 @@
+package zrrtest
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/gruntwork-io/terratest/modules/files"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	test_structure "github.com/gruntwork-io/terratest/modules/test-structure"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// Stages of an integration test. Setting SKIP_<stage>, for example
+// SKIP_teardown=true, skips that stage.
+const (
+	StageSetup    = "setup"
+	StageDeploy   = "deploy"
+	StageValidate = "validate"
+	StageTeardown = "teardown"
+)
+
+// TestDataDir is the directory, next to the suite, in which staged tests keep
+// their Terraform working copy, state and generated values between runs.
+const TestDataDir = ".test-data"
+
+const (
+	optionsFile  = "terraform_options.json"
+	workingCopy  = "terraform"
+	dataFileMode = 0o600
+)
+
+// Stages runs an integration test as the named stages setup, deploy,
+// validate and teardown, so a deployment can be kept and validated again
+// without redeploying it:
+//
+//	SKIP_teardown=true go test -run TestMySQLFlexibleServerAdvancedIntegration ./integration
+//	SKIP_setup=true SKIP_deploy=true SKIP_teardown=true go test -run ...   # iterate on validate
+//	SKIP_setup=true SKIP_deploy=true SKIP_validate=true go test -run ...   # destroy it
+//
+// Values the test generates in setup, such as names and passwords, and the
+// Terraform options are stored under Dir and loaded when setup is skipped.
+// Terraform runs in a copy of the configuration under Dir, so its state
+// survives between runs and parallel tests never share a working directory.
+type Stages struct {
+	t         testing.TestingT
+	moduleDir string
+	root      string
+
+	// Dir is the test's directory under TestDataDir.
+	Dir string
+}
+
+var unsafePathChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// NewStages returns the stages of the test.
+func NewStages(t testing.TestingT) *Stages {
+	dir := filepath.Join(TestDataDir, unsafePathChars.ReplaceAllString(t.Name(), "_"))
+	return &Stages{t: t, moduleDir: ModuleDir, root: dir, Dir: dir}
+}
+
+// Deployment returns the stages of a further deployment of the test, such as
+// a server the module under test needs. Its options and working copy are
+// kept under name in Dir, apart from those of s.
+func (s *Stages) Deployment(name string) *Stages {
+	deployment := *s
+	deployment.Dir = filepath.Join(s.Dir, unsafePathChars.ReplaceAllString(name, "_"))
+	return &deployment
+}
+
+// Skipped reports whether SKIP_<stage> is set.
+func (s *Stages) Skipped(stage string) bool {
+	return os.Getenv(test_structure.SKIP_STAGE_ENV_VAR_PREFIX+stage) != ""
+}
+
+// Run runs fn as the named stage unless it is skipped.
+func (s *Stages) Run(stage string, fn func()) {
+	test_structure.RunTestStage(s.t, stage, fn)
+}
+
+// Value returns a value generated in setup. When setup runs, generate is
+// called and its result stored under name; when setup is skipped, the stored
+// value is returned.
+func (s *Stages) Value(name string, generate func() string) string {
+	var value string
+	if s.Skipped(StageSetup) {
+		s.load(name+".json", &value)
+		return value
+	}
+	value = generate()
+	s.save(name+".json", value)
+	return value
+}
+
+// UniqueID returns a UniqueID kept for the lifetime of the deployment.
+func (s *Stages) UniqueID() string {
+	return s.Value("unique_id", UniqueID)
+}
+
+// Password returns a Password for login kept for the lifetime of the
+// deployment under name. Stored passwords are registered for masking when
+// they are loaded.
+func (s *Stages) Password(name string, policy PasswordPolicy, login string) string {
+	password := s.Value(name, func() string { return Password(s.t, policy, login) })
+	RegisterSecret(password)
+	return password
+}
+
+// Options prepares options in setup: it copies the configuration into Dir,
+// points TerraformDir at the copy and stores the result. When setup is
+// skipped the stored options are returned instead and options is ignored.
+//
+// Setup refuses to run while Dir holds the options of an earlier deployment
+// whose teardown was skipped; tear that deployment down first.
+func (s *Stages) Options(options *terraform.Options) *terraform.Options {
+	if s.Skipped(StageSetup) {
+		var stored terraform.Options
+		s.load(optionsFile, &stored)
+		// The logger does not survive JSON.
+		stored.Logger = nil
+		return WithDefaults(s.t, &stored)
+	}
+
+	_, err := os.Stat(filepath.Join(s.Dir, optionsFile))
+	require.True(s.t, os.IsNotExist(err),
+		"%s holds a deployment that was not torn down; run the test with SKIP_%s, SKIP_%s and SKIP_%s set to destroy it",
+		s.Dir, StageSetup, StageDeploy, StageValidate)
+
+	staged, err := options.Clone()
+	require.NoError(s.t, err)
+	staged.TerraformDir, err = s.copyConfiguration(options.TerraformDir)
+	require.NoError(s.t, err)
+	s.save(optionsFile, staged)
+	return WithDefaults(s.t, staged)
+}
+
+// Deploy runs terraform init and apply as the deploy stage.
+func (s *Stages) Deploy(options *terraform.Options) {
+	s.Run(StageDeploy, func() {
+		terraform.InitAndApply(s.t, options)
+	})
+}
+
+// Validate runs fn as the validate stage.
+func (s *Stages) Validate(fn func()) {
+	s.Run(StageValidate, fn)
+}
+
+// Teardown runs terraform destroy as the teardown stage. Once the last
+// deployment of the test is gone, the test's directory is removed. It is
+// meant to be deferred right after Options.
+func (s *Stages) Teardown(options *terraform.Options) {
+	s.Run(StageTeardown, func() {
+		terraform.Destroy(s.t, options)
+		require.NoError(s.t, os.RemoveAll(filepath.Join(s.Dir, workingCopy)))
+		require.NoError(s.t, os.Remove(filepath.Join(s.Dir, optionsFile)))
+
+		deployed, err := s.deployed()
+		require.NoError(s.t, err)
+		if !deployed {
+			require.NoError(s.t, os.RemoveAll(s.root))
+		}
+	})
+}
+
+// deployed reports whether any deployment of the test still has options
+// stored.
+func (s *Stages) deployed() (bool, error) {
+	found := false
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Name() == workingCopy {
+			return filepath.SkipDir
+		}
+		if entry.Name() == optionsFile {
+			found = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return found, err
+}
+
+// copyConfiguration copies the module under test and terraformDir, along with
+// the modules either calls by a relative path, into the working copy and
+// returns the path of terraformDir within it. Examples reference their module
+// by a relative path, so the module is always copied along.
+func (s *Stages) copyConfiguration(terraformDir string) (string, error) {
+	dir, err := filepath.Abs(terraformDir)
+	if err != nil {
+		return "", err
+	}
+
+	destination := filepath.Join(s.Dir, workingCopy)
+	if err := os.RemoveAll(destination); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(destination, 0o700); err != nil {
+		return "", err
+	}
+	root, err := copyModules(destination, func(below string) bool {
+		return !files.PathContainsHiddenFileOrFolder(below) && !files.PathContainsTerraformState(below)
+	}, s.moduleDir, dir)
+	if err != nil {
+		return "", err
+	}
+	relative, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+	logger.Logf(s.t, "Copied %s to %s", root, destination)
+	return filepath.Join(destination, relative), nil
+}
+
+// save stores value as JSON under Dir. Test data can hold passwords, so the
+// files are readable by the owner only and their content is not logged.
+func (s *Stages) save(name string, value interface{}) {
+	data, err := json.Marshal(value)
+	require.NoError(s.t, err)
+	require.NoError(s.t, os.MkdirAll(s.Dir, 0o700))
+	require.NoError(s.t, os.WriteFile(filepath.Join(s.Dir, name), data, dataFileMode))
+}
+
+func (s *Stages) load(name string, value interface{}) {
+	data, err := os.ReadFile(filepath.Join(s.Dir, name))
+	require.NoError(s.t, err, "no test data of an earlier setup stage in %s", s.Dir)
+	require.NoError(s.t, json.Unmarshal(data, value))
+}
@@ -0,0 +1,179 @@
+package zrrtest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTree creates the files under root, creating directories as needed.
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+// newTestStages returns stages of a module under a temporary layer directory
+// that also holds a sibling module.
+func newTestStages(t *testing.T, tt *recordingT) (*Stages, string) {
+	layer := t.TempDir()
+	writeTree(t, layer, map[string]string{
+		"module/main.tf":                             "# module",
+		"module/examples/basic/main.tf":              `module "example" { source = "../../" }`,
+		"module/terraform.tfstate":                   "{}",
+		"module/tests/integration/.test-data/x.json": "{}",
+		"sibling/examples/basic/main.tf":             `module "example" { source = "../../" }`,
+	})
+
+	var stages *Stages
+	if tt != nil {
+		stages = NewStages(tt)
+	} else {
+		stages = NewStages(t)
+	}
+	stages.moduleDir = filepath.Join(layer, "module")
+	stages.root = filepath.Join(t.TempDir(), TestDataDir, "TestStaged")
+	stages.Dir = stages.root
+	return stages, layer
+}
+
+func TestStagesRunAll(t *testing.T) {
+	stages, layer := newTestStages(t, nil)
+
+	// Validate setup stores generated values and copies the configuration
+	uniqueID := stages.UniqueID()
+	password := stages.Password("administrator_password", MySQLPasswordPolicy, "mysqladmin")
+	assert.Equal(t, SecretMask, MaskSecrets(password))
+
+	options := stages.Options(&terraform.Options{
+		TerraformDir: filepath.Join(layer, "module", "examples", "basic"),
+		Vars:         map[string]interface{}{"password": password, "count": 2},
+	})
+	assert.Equal(t, filepath.Join(stages.Dir, "terraform", "examples", "basic"), options.TerraformDir)
+	assert.FileExists(t, filepath.Join(options.TerraformDir, "main.tf"))
+	assert.FileExists(t, filepath.Join(stages.Dir, "terraform", "main.tf"))
+	assert.NoFileExists(t, filepath.Join(stages.Dir, "terraform", "terraform.tfstate"))
+	assert.NoDirExists(t, filepath.Join(stages.Dir, "terraform", "tests", "integration", ".test-data"))
+	assert.Same(t, MaskingLogger, options.Logger)
+
+	info, err := os.Stat(filepath.Join(stages.Dir, optionsFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Validate a later run that skips setup sees the same deployment
+	t.Setenv("SKIP_setup", "true")
+	assert.True(t, stages.Skipped(StageSetup))
+	assert.False(t, stages.Skipped(StageDeploy))
+	assert.Equal(t, uniqueID, stages.UniqueID())
+	assert.Equal(t, password, stages.Password("administrator_password", MySQLPasswordPolicy, "mysqladmin"))
+
+	loaded := stages.Options(&terraform.Options{TerraformDir: "ignored"})
+	assert.Equal(t, options.TerraformDir, loaded.TerraformDir)
+	assert.Equal(t, password, loaded.Vars["password"])
+	assert.EqualValues(t, 2, loaded.Vars["count"])
+	assert.Same(t, MaskingLogger, loaded.Logger)
+	assert.Equal(t, DefaultMaxRetries, loaded.MaxRetries)
+
+	// Validate skipped stages do not run and teardown keeps the data
+	t.Setenv("SKIP_validate", "true")
+	t.Setenv("SKIP_teardown", "true")
+	ran := false
+	stages.Validate(func() { ran = true })
+	assert.False(t, ran)
+	stages.Run(StageDeploy, func() { ran = true })
+	assert.True(t, ran)
+	stages.Teardown(loaded)
+	assert.DirExists(t, stages.Dir)
+}
+
+func TestStagesSetupRefusesLeftoverDeployment(t *testing.T) {
+	t.Parallel()
+
+	fake := &recordingT{}
+	stages, layer := newTestStages(t, fake)
+	options := &terraform.Options{TerraformDir: filepath.Join(layer, "module")}
+	stages.Options(options)
+	require.False(t, fake.failed)
+
+	func() {
+		defer func() { recover() }()
+		stages.Options(options)
+	}()
+	assert.True(t, fake.failed)
+}
+
+func TestStagesCopyConfigurationOutsideModule(t *testing.T) {
+	t.Parallel()
+
+	stages, layer := newTestStages(t, nil)
+	dir, err := stages.copyConfiguration(filepath.Join(layer, "sibling", "examples", "basic"))
+	require.NoError(t, err)
+
+	// The common directory of the module and the sibling is copied
+	assert.Equal(t, filepath.Join(stages.Dir, "terraform", "sibling", "examples", "basic"), dir)
+	assert.FileExists(t, filepath.Join(stages.Dir, "terraform", "module", "main.tf"))
+	assert.FileExists(t, filepath.Join(dir, "main.tf"))
+}
+
+func TestNewStagesDir(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, filepath.Join(TestDataDir, "TestNewStagesDir"), NewStages(t).Dir)
+	t.Run("sub test/with spaces", func(t *testing.T) {
+		assert.Equal(t, filepath.Join(TestDataDir, "TestNewStagesDir_sub_test_with_spaces"), NewStages(t).Dir)
+	})
+}
+
+func TestStagesDeploymentTeardown(t *testing.T) {
+	stages, layer := newTestStages(t, nil)
+	server := stages.Deployment("server")
+	assert.Equal(t, filepath.Join(stages.Dir, "server"), server.Dir)
+
+	// `true` stands in for terraform, so destroy succeeds without a binary
+	serverOptions := server.Options(&terraform.Options{
+		TerraformDir:    filepath.Join(layer, "sibling", "examples", "basic"),
+		TerraformBinary: "true",
+	})
+	options := stages.Options(&terraform.Options{
+		TerraformDir:    filepath.Join(layer, "module", "examples", "basic"),
+		TerraformBinary: "true",
+	})
+	assert.Equal(t, filepath.Join(server.Dir, "terraform", "sibling", "examples", "basic"), serverOptions.TerraformDir)
+	assert.FileExists(t, filepath.Join(server.Dir, optionsFile))
+
+	// Validate the test data stays until the last deployment is torn down
+	stages.Teardown(options)
+	assert.NoFileExists(t, filepath.Join(stages.Dir, optionsFile))
+	assert.NoDirExists(t, filepath.Join(stages.Dir, "terraform"))
+	assert.DirExists(t, server.Dir)
+
+	server.Teardown(serverOptions)
+	assert.NoDirExists(t, stages.Dir)
+}
+
+func TestStagesCopyConfigurationCalledModule(t *testing.T) {
+	t.Parallel()
+
+	stages, layer := newTestStages(t, nil)
+	writeTree(t, layer, map[string]string{
+		"module/locals.tf":      `module "region" { source = "../shared/region" }`,
+		"shared/region/main.tf": "# region",
+		"shared/other/main.tf":  "# other",
+	})
+	dir, err := stages.copyConfiguration(filepath.Join(layer, "module", "examples", "basic"))
+	require.NoError(t, err)
+
+	// The called module lands next to the module, and nothing else does
+	assert.Equal(t, filepath.Join(stages.Dir, "terraform", "module", "examples", "basic"), dir)
+	assert.FileExists(t, filepath.Join(stages.Dir, "terraform", "shared", "region", "main.tf"))
+	assert.NoDirExists(t, filepath.Join(stages.Dir, "terraform", "shared", "other"))
+	assert.NoDirExists(t, filepath.Join(stages.Dir, "terraform", "sibling"))
+}
@@ -0,0 +1,119 @@
+package tagpolicy
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/function/stdlib"
+)
+
+// callerValue stands for a tag value set by the caller of a module. Every
+// variable locals.common_tags refers to is set to a map holding each of
+// RequiredKeys with this value, so a required tag the caller can override
+// shows up as a wrong value.
+const callerValue = "<caller>"
+
+// SourceViolations returns one line per missing or wrong tag in the
+// locals.common_tags of the module at modulePath, relative to root. Unlike
+// Violations it needs no plan, so it also covers modules without examples.
+// A module without resources needs no common_tags; whether the resources use
+// local.common_tags is the common-tags lint rule's concern.
+func SourceViolations(root, modulePath string) ([]string, error) {
+	dir := filepath.Join(root, filepath.FromSlash(modulePath))
+	module, err := ModuleFromDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return nil, err
+	}
+
+	parser := hclparse.NewParser()
+	var commonTags *hclsyntax.Attribute
+	hasResources := false
+	for _, file := range files {
+		f, diags := parser.ParseHCLFile(file)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		for _, block := range f.Body.(*hclsyntax.Body).Blocks {
+			switch block.Type {
+			case "resource":
+				hasResources = true
+			case "locals":
+				if attribute, ok := block.Body.Attributes["common_tags"]; ok {
+					commonTags = attribute
+				}
+			}
+		}
+	}
+	if commonTags == nil {
+		if hasResources {
+			return []string{fmt.Sprintf("%s: no locals.common_tags", module.Path)}, nil
+		}
+		return nil, nil
+	}
+
+	at := fmt.Sprintf("%s/%s:%d", module.Path, filepath.Base(commonTags.SrcRange.Filename), commonTags.SrcRange.Start.Line)
+	tags, diags := commonTags.Expr.Value(callerContext(commonTags.Expr))
+	if diags.HasErrors() {
+		return []string{fmt.Sprintf("%s: common_tags cannot be evaluated: %s", at, diags.Errs()[0])}, nil
+	}
+	if !tags.Type().IsObjectType() && !tags.Type().IsMapType() {
+		return []string{fmt.Sprintf("%s: common_tags is a %s, not a map", at, tags.Type().FriendlyName())}, nil
+	}
+
+	expected := map[string]string{
+		"ManagedBy": ManagedBy,
+		"Module":    module.Tag(),
+		"Layer":     module.Layer,
+	}
+	values := tags.AsValueMap()
+	var violations []string
+	for _, key := range RequiredKeys {
+		value, ok := values[key]
+		if !ok || value.IsNull() {
+			violations = append(violations, fmt.Sprintf("%s: missing tag %s", at, key))
+			continue
+		}
+		if !value.IsKnown() || value.Type() != cty.String {
+			violations = append(violations, fmt.Sprintf("%s: tag %s is not a known string", at, key))
+			continue
+		}
+		if got := value.AsString(); got != expected[key] {
+			violations = append(violations, fmt.Sprintf("%s: tag %s = %q, want %q", at, key, got, expected[key]))
+		}
+	}
+	sort.Strings(violations)
+	return violations, nil
+}
+
+// callerContext returns the context evaluating expr with every variable it
+// refers to set to a map of RequiredKeys to callerValue. Terraform's merge is
+// the only function; references to anything but variables stay undefined.
+func callerContext(expr hcl.Expression) *hcl.EvalContext {
+	tags := map[string]cty.Value{}
+	for _, key := range RequiredKeys {
+		tags[key] = cty.StringVal(callerValue)
+	}
+	variables := map[string]cty.Value{}
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "var" || len(traversal) < 2 {
+			continue
+		}
+		if attr, ok := traversal[1].(hcl.TraverseAttr); ok {
+			variables[attr.Name] = cty.MapVal(tags)
+		}
+	}
+	return &hcl.EvalContext{
+		Variables: map[string]cty.Value{"var": cty.ObjectVal(variables)},
+		Functions: map[string]function.Function{"merge": stdlib.MergeFunc},
+	}
+}
@@ -0,0 +1,224 @@
+// Package tagpolicy checks that planned resources carry the tags every module
+// in the library sets in locals.common_tags:
+//
+//	ManagedBy = "Terraform"
+//	Module    = "zrr-tf-module-lib/azure/<layer>/<module>"
+//	Layer     = "<layer>"
+//
+// Module and Layer are derived from the directory of the module under test,
+// so a module that is moved or copied without updating its tags fails the
+// check. Resources whose schema has no tags attribute are skipped.
+//
+//	func TestExamplesTagPolicy(t *testing.T) {
+//		t.Parallel()
+//
+//		tagpolicy.Examples(t, nil)
+//	}
+//
+// SourceViolations checks the same tags in a module's locals.common_tags
+// without a plan; TestRepositoryTagPolicy runs it on every module of the
+// repository, including those without examples or Go tests.
+package tagpolicy
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/snapshot"
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Library is the prefix of every Module tag.
+const Library = "zrr-tf-module-lib"
+
+// ManagedBy is the required value of the ManagedBy tag.
+const ManagedBy = "Terraform"
+
+// RequiredKeys are the tags every taggable resource must carry.
+var RequiredKeys = []string{"ManagedBy", "Module", "Layer"}
+
+// Module identifies a module of the library by its directory.
+type Module struct {
+	// Path is the module directory relative to the repository root, e.g.
+	// azure/infrastructure/virtual-network.
+	Path string
+
+	// Layer is the directory below azure/, e.g. infrastructure.
+	Layer string
+}
+
+// Tag returns the expected value of the Module tag.
+func (m Module) Tag() string {
+	return Library + "/" + m.Path
+}
+
+// ModuleFromDir identifies the module in dir, which must lie below an azure/
+// directory.
+func ModuleFromDir(dir string) (Module, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return Module{}, err
+	}
+	parts := strings.Split(filepath.ToSlash(abs), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i] == "azure" && i+2 < len(parts) {
+			return Module{Path: path.Join(parts[i:]...), Layer: parts[i+1]}, nil
+		}
+	}
+	return Module{}, fmt.Errorf("%s is not a module below azure/<layer>/", abs)
+}
+
+// Current identifies the module under test.
+func Current(t terratesting.TestingT) Module {
+	module, err := ModuleFromDir(zrrtest.ModuleDir)
+	require.NoError(t, err)
+	return module
+}
+
+// Violations returns one line per missing or wrong tag on the resources of
+// the plan that belong to module. When the plan is of the module itself that
+// is every root resource; when it is of an example, it is every resource of a
+// module call whose source is the module root, "../..".
+func Violations(p *plan.Plan, module Module) []string {
+	owners := moduleCalls(p.Struct.RawPlan.Config)
+
+	var violations []string
+	for _, change := range p.Struct.RawPlan.ResourceChanges {
+		if change.Mode != tfjson.ManagedResourceMode || change.Change == nil {
+			continue
+		}
+		if change.Change.Actions.Delete() && !change.Change.Actions.Create() {
+			continue
+		}
+		if !owned(change.ModuleAddress, owners) {
+			continue
+		}
+		violations = append(violations, check(change, module)...)
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+// Assert fails the test if any resource of the plan that belongs to module
+// violates the tag policy.
+func Assert(t terratesting.TestingT, p *plan.Plan, module Module) {
+	violations := Violations(p, module)
+	assert.Empty(t, violations, "tag policy violations:\n  %s", strings.Join(violations, "\n  "))
+}
+
+// Examples plans every example of the module under test and asserts the tag
+// policy on the resources the module creates. vars holds the variables to
+// pass to an example, keyed by example name.
+func Examples(t *testing.T, vars map[string]map[string]interface{}) {
+	module := Current(t)
+	examples := snapshot.ExampleNames(t)
+	require.NotEmpty(t, examples, "module has no examples")
+
+	for _, example := range examples {
+		example := example
+		t.Run(example, func(t *testing.T) {
+			t.Parallel()
+
+			options := zrrtest.ExampleOptions(t, example, vars[example])
+			planned := plan.InitAndPlan(t, options)
+			require.NotEmpty(t, ownedAddresses(planned), "example %s does not call the module", example)
+			Assert(t, planned, module)
+		})
+	}
+}
+
+// check returns the violations of a single planned resource. Resources
+// without a tags attribute are not taggable and pass.
+func check(change *tfjson.ResourceChange, module Module) []string {
+	after, _ := change.Change.After.(map[string]interface{})
+	afterUnknown, _ := change.Change.AfterUnknown.(map[string]interface{})
+
+	tags, hasTags := after["tags"]
+	unknownTags, hasUnknownTags := afterUnknown["tags"]
+	if !hasTags && !hasUnknownTags {
+		return nil
+	}
+	if unknownTags == true {
+		// The whole map is only known after apply; nothing to check.
+		return nil
+	}
+
+	known, _ := tags.(map[string]interface{})
+	unknown, _ := unknownTags.(map[string]interface{})
+	expected := map[string]string{
+		"ManagedBy": ManagedBy,
+		"Module":    module.Tag(),
+		"Layer":     module.Layer,
+	}
+
+	var violations []string
+	for _, key := range RequiredKeys {
+		if unknown[key] == true {
+			continue
+		}
+		value, ok := known[key]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("%s: missing tag %s", change.Address, key))
+			continue
+		}
+		if value != expected[key] {
+			violations = append(violations, fmt.Sprintf("%s: tag %s = %q, want %q", change.Address, key, value, expected[key]))
+		}
+	}
+	return violations
+}
+
+// moduleCalls returns the names of the root module calls whose source is the
+// module root, plus "" for the root module itself when there are none, i.e.
+// when the module is planned directly.
+func moduleCalls(config *tfjson.Config) map[string]bool {
+	owners := map[string]bool{}
+	if config != nil && config.RootModule != nil {
+		for name, call := range config.RootModule.ModuleCalls {
+			if path.Clean(call.Source) == "../.." {
+				owners[name] = true
+			}
+		}
+	}
+	if len(owners) == 0 {
+		owners[""] = true
+	}
+	return owners
+}
+
+// owned reports whether a resource in the module at moduleAddress, e.g.
+// `module.hub_vnet` or `module.dr["east"]`, belongs to one of owners.
+// Resources of modules nested further down belong to those modules.
+func owned(moduleAddress string, owners map[string]bool) bool {
+	if moduleAddress == "" {
+		return owners[""]
+	}
+	name := strings.TrimPrefix(moduleAddress, "module.")
+	if i := strings.IndexAny(name, "[."); i >= 0 {
+		if strings.Contains(name[i:], "module.") {
+			return false
+		}
+		name = name[:i]
+	}
+	return owners[name]
+}
+
+func ownedAddresses(p *plan.Plan) []string {
+	owners := moduleCalls(p.Struct.RawPlan.Config)
+	var addresses []string
+	for _, change := range p.Struct.RawPlan.ResourceChanges {
+		if owned(change.ModuleAddress, owners) {
+			addresses = append(addresses, change.Address)
+		}
+	}
+	return addresses
+}
@@ -0,0 +1,107 @@
+package tagpolicy
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/registry"
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest/plan"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadPlan(t *testing.T) *plan.Plan {
+	t.Helper()
+	data, err := os.ReadFile("testdata/example.json")
+	require.NoError(t, err)
+	planStruct, err := terraform.ParsePlanJSON(string(data))
+	require.NoError(t, err)
+	return plan.New(t, planStruct)
+}
+
+func TestViolations(t *testing.T) {
+	t.Parallel()
+
+	module := Module{Path: "azure/infrastructure/virtual-network", Layer: "infrastructure"}
+
+	// The peering belongs to the example and the diagnostics workspace to a
+	// nested module, so neither is checked. Subnets have no tags, and tags
+	// known only after apply cannot be checked.
+	assert.Equal(t, []string{
+		`module.hub_vnet.azurerm_network_security_group.main: missing tag Module`,
+		`module.hub_vnet.azurerm_virtual_network.main: tag Module = "zrr-tf-module-lib/azure/infrastructure/vnet", want "zrr-tf-module-lib/azure/infrastructure/virtual-network"`,
+	}, Violations(loadPlan(t), module))
+}
+
+func TestViolationsOfModulePlan(t *testing.T) {
+	t.Parallel()
+
+	p := loadPlan(t)
+	p.Struct.RawPlan.Config.RootModule.ModuleCalls = nil
+
+	// Without calls to the module root the plan is of the module itself, so
+	// only root resources are checked.
+	module := Module{Path: "azure/infrastructure/virtual-network", Layer: "infrastructure"}
+	assert.Equal(t, []string{
+		"azurerm_virtual_network_peering.hub_to_spoke: missing tag Layer",
+		"azurerm_virtual_network_peering.hub_to_spoke: missing tag ManagedBy",
+		"azurerm_virtual_network_peering.hub_to_spoke: missing tag Module",
+	}, Violations(p, module))
+}
+
+func TestModuleFromDir(t *testing.T) {
+	t.Parallel()
+
+	module, err := ModuleFromDir("/src/zrr-tf-module-lib/azure/infrastructure/virtual-network/tests/unit/../..")
+	require.NoError(t, err)
+	assert.Equal(t, Module{Path: "azure/infrastructure/virtual-network", Layer: "infrastructure"}, module)
+	assert.Equal(t, "zrr-tf-module-lib/azure/infrastructure/virtual-network", module.Tag())
+
+	_, err = ModuleFromDir("/src/zrr-tf-module-lib/azure/infrastructure")
+	assert.Error(t, err)
+	_, err = ModuleFromDir("/src/zrr-tf-module-lib/zrrtest")
+	assert.Error(t, err)
+}
+
+func TestOwned(t *testing.T) {
+	t.Parallel()
+
+	owners := map[string]bool{"hub_vnet": true, "spoke": true}
+	assert.True(t, owned("module.hub_vnet", owners))
+	assert.True(t, owned(`module.spoke["a.b"]`, owners))
+	assert.True(t, owned("module.spoke[0]", owners))
+	assert.False(t, owned("", owners))
+	assert.False(t, owned("module.other", owners))
+	assert.False(t, owned("module.hub_vnet.module.diagnostics", owners))
+	assert.True(t, owned("", map[string]bool{"": true}))
+}
+
+func TestSourceViolations(t *testing.T) {
+	t.Parallel()
+
+	// var.common_tags comes after ManagedBy, so a caller can override it.
+	// Layer is only present because the caller sets it too.
+	violations, err := SourceViolations("testdata", "azure/infrastructure/widget")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`azure/infrastructure/widget/main.tf:2: tag Layer = "<caller>", want "infrastructure"`,
+		`azure/infrastructure/widget/main.tf:2: tag ManagedBy = "<caller>", want "Terraform"`,
+		`azure/infrastructure/widget/main.tf:2: tag Module = "zrr-tf-module-lib/azure/infrastructure/gadget", want "zrr-tf-module-lib/azure/infrastructure/widget"`,
+	}, violations)
+}
+
+func TestRepositoryTagPolicy(t *testing.T) {
+	t.Parallel()
+
+	paths, err := registry.Discover("../..")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, modulePath := range paths {
+		violations, err := SourceViolations("../..", modulePath)
+		require.NoError(t, err, modulePath)
+		for _, violation := range violations {
+			t.Error(violation)
+		}
+	}
+}
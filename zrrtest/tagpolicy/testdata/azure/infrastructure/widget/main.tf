@@ -0,0 +1,18 @@
+locals {
+  common_tags = merge(
+    {
+      "ManagedBy" = "Terraform"
+      "Module"    = "zrr-tf-module-lib/azure/infrastructure/gadget"
+    },
+    var.common_tags,
+    {
+      "Module" = "zrr-tf-module-lib/azure/infrastructure/gadget"
+    }
+  )
+}
+
+resource "azurerm_resource_group" "main" {
+  name     = "widget"
+  location = "eastus"
+  tags     = local.common_tags
+}
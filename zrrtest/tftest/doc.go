@@ -0,0 +1,25 @@
+// Package tftest runs a module's Terraform test files from Go.
+//
+// Some modules keep their variable validation tests in *.tftest.hcl files
+// next to their Go suites, where go test never sees them. Run runs them with
+// `terraform test -json` and reports every run block as a subtest of the
+// calling test, named after its file and run block, with Terraform's
+// assertion messages as the subtest's errors:
+//
+//	func TestTerraformTest(t *testing.T) {
+//		t.Parallel()
+//		tftest.Run(t, zrrtest.ModuleOptions(t, nil))
+//	}
+//
+//	--- FAIL: TestTerraformTest/variables_test/invalid_sku_test
+//	    variables_test.tftest.hcl:95: Missing expected failure: ...
+//
+// The test files are the ones in the calling test's directory, so a suite in
+// tests/unit runs tests/unit/*.tftest.hcl. The module is copied to a
+// temporary directory first, so parallel suites do not share a .terraform
+// directory. The run blocks plan against the real providers; see
+// offline.RunTests to mock them instead.
+//
+// Parse and Collect read the `terraform test -json` output on their own, so
+// recorded output can be checked without Terraform.
+package tftest
@@ -0,0 +1,207 @@
+package tftest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Statuses of a test file or run block.
+const (
+	StatusPending = "pending"
+	StatusSkip    = "skip"
+	StatusPass    = "pass"
+	StatusFail    = "fail"
+	StatusError   = "error"
+)
+
+// Message is a line of `terraform test -json` output. Only the fields this
+// package reads are decoded.
+type Message struct {
+	Level string `json:"@level"`
+	Text  string `json:"@message"`
+	Type  string `json:"type"`
+
+	// File and Run name the test file and run block the message is about,
+	// if any.
+	File string `json:"@testfile"`
+	Run  string `json:"@testrun"`
+
+	// Abstract lists the run blocks of every test file, before any runs.
+	Abstract map[string][]string `json:"test_abstract"`
+
+	TestFile *struct {
+		Path     string `json:"path"`
+		Progress string `json:"progress"`
+		Status   string `json:"status"`
+	} `json:"test_file"`
+
+	TestRun *struct {
+		Path     string `json:"path"`
+		Run      string `json:"run"`
+		Progress string `json:"progress"`
+		Status   string `json:"status"`
+	} `json:"test_run"`
+
+	// TestPlan is the plan of a run block, printed with -verbose.
+	TestPlan json.RawMessage `json:"test_plan"`
+
+	Diagnostic *Diagnostic `json:"diagnostic"`
+}
+
+// Diagnostic is an error or warning Terraform reported.
+type Diagnostic struct {
+	Severity string `json:"severity"`
+	Summary  string `json:"summary"`
+	Detail   string `json:"detail"`
+	Range    *struct {
+		Filename string `json:"filename"`
+		Start    struct {
+			Line int `json:"line"`
+		} `json:"start"`
+	} `json:"range"`
+}
+
+// String returns the diagnostic as file:line: summary: detail.
+func (d Diagnostic) String() string {
+	text := d.Summary
+	if d.Detail != "" {
+		text += ": " + d.Detail
+	}
+	if d.Range != nil && d.Range.Filename != "" {
+		text = fmt.Sprintf("%s:%d: %s", filepath.Base(d.Range.Filename), d.Range.Start.Line, text)
+	}
+	return text
+}
+
+// Parse reads the messages of `terraform test -json` output. Lines that are
+// not JSON messages are skipped.
+func Parse(r io.Reader) ([]Message, error) {
+	var messages []Message
+	scanner := bufio.NewScanner(r)
+	// A plan is printed on a single line and easily exceeds the default
+	// token size.
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var message Message
+		if err := json.Unmarshal([]byte(line), &message); err != nil {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// Results are the results of a `terraform test` run.
+type Results struct {
+	Files []*FileResult
+
+	// Diagnostics are those not about any test file, such as errors in the
+	// module's configuration.
+	Diagnostics []Diagnostic
+}
+
+// FileResult is the result of a test file.
+type FileResult struct {
+	// Path is the file's path relative to the module, as Terraform prints
+	// it.
+	Path   string
+	Status string
+	Runs   []*RunResult
+
+	// Diagnostics are those about the file but none of its run blocks.
+	Diagnostics []Diagnostic
+}
+
+// RunResult is the result of a run block.
+type RunResult struct {
+	Name string
+
+	// Status is the run's status; it stays StatusPending if Terraform
+	// never ran the block.
+	Status      string
+	Diagnostics []Diagnostic
+}
+
+// Name returns the file's name without its directory and .tftest.hcl.
+func (f *FileResult) Name() string {
+	return strings.TrimSuffix(filepath.Base(f.Path), ".tftest.hcl")
+}
+
+// Collect gathers the results of every test file and run block from the
+// messages. Files and runs keep the order Terraform lists them in.
+func Collect(messages []Message) *Results {
+	results := &Results{}
+	files := map[string]*FileResult{}
+	file := func(path string) *FileResult {
+		if files[path] == nil {
+			files[path] = &FileResult{Path: path, Status: StatusPending}
+			results.Files = append(results.Files, files[path])
+		}
+		return files[path]
+	}
+	run := func(path, name string) *RunResult {
+		f := file(path)
+		for _, r := range f.Runs {
+			if r.Name == name {
+				return r
+			}
+		}
+		r := &RunResult{Name: name, Status: StatusPending}
+		f.Runs = append(f.Runs, r)
+		return r
+	}
+
+	for _, message := range messages {
+		switch {
+		case message.Abstract != nil:
+			// The abstract is a map; list its files in a stable order and its
+			// runs in the order of the file.
+			paths := make([]string, 0, len(message.Abstract))
+			for path := range message.Abstract {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			for _, path := range paths {
+				for _, name := range message.Abstract[path] {
+					run(path, name)
+				}
+			}
+		case message.TestFile != nil:
+			f := file(message.TestFile.Path)
+			if message.TestFile.Progress == "complete" {
+				f.Status = message.TestFile.Status
+			}
+		case message.TestRun != nil:
+			r := run(message.TestRun.Path, message.TestRun.Run)
+			// Terraform 1.8 and later also report a run as it starts and
+			// while it runs, without its final status.
+			if message.TestRun.Progress == "complete" {
+				r.Status = message.TestRun.Status
+			}
+		case message.Diagnostic != nil:
+			switch {
+			case message.File != "" && message.Run != "":
+				r := run(message.File, message.Run)
+				r.Diagnostics = append(r.Diagnostics, *message.Diagnostic)
+			case message.File != "":
+				f := file(message.File)
+				f.Diagnostics = append(f.Diagnostics, *message.Diagnostic)
+			default:
+				results.Diagnostics = append(results.Diagnostics, *message.Diagnostic)
+			}
+		}
+	}
+	return results
+}
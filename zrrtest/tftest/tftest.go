@@ -0,0 +1,147 @@
+package tftest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ZealousRockResearch/zrr-tf-module-lib/zrrtest"
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// Run runs the Terraform test files in the current directory against the
+// module in options.TerraformDir and reports every run block as a subtest of
+// t. Variables in options are passed to every run, under the ones the test
+// files set.
+func Run(t *testing.T, options *terraform.Options) {
+	results, err := RunE(t, options)
+	require.NoError(t, err)
+	Report(t, results)
+}
+
+// RunE is like Run but returns the results instead of reporting them. A
+// failing run block is not an error; not being able to run Terraform is.
+func RunE(t terratesting.TestingT, options *terraform.Options) (*Results, error) {
+	testOptions, testDir, tempDir, err := Prepare(options)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+	return ExecE(t, testOptions, testDir)
+}
+
+// Prepare copies the module in options.TerraformDir to a temporary directory
+// and returns options pointing at the copy, along with the directory of the
+// calling test relative to the module, which holds the test files, and the
+// temporary directory, which the caller removes when done.
+func Prepare(options *terraform.Options) (*terraform.Options, string, string, error) {
+	moduleDir, err := filepath.Abs(options.TerraformDir)
+	if err != nil {
+		return nil, "", "", err
+	}
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return nil, "", "", err
+	}
+	testDir, err := filepath.Rel(moduleDir, workingDir)
+	if err != nil || testDir == "." || strings.HasPrefix(testDir, "..") {
+		return nil, "", "", fmt.Errorf("test directory %s is not below the module %s", workingDir, moduleDir)
+	}
+
+	testOptions, err := options.Clone()
+	if err != nil {
+		return nil, "", "", err
+	}
+	// terraform test has no -target flag.
+	testOptions.Targets = nil
+	// The copy is made even when a SKIP_<stage> variable is set, unlike
+	// with test_structure.
+	var tempDir string
+	testOptions.TerraformDir, tempDir, err = zrrtest.CopyModuleToTemp(moduleDir, "tftest")
+	if err != nil {
+		return nil, "", "", err
+	}
+	return testOptions, filepath.ToSlash(testDir), tempDir, nil
+}
+
+// ExecE initializes the module in options.TerraformDir and runs `terraform
+// test -json` on the test files in testDir, relative to the module, in place.
+func ExecE(t terratesting.TestingT, options *terraform.Options, testDir string) (*Results, error) {
+	testDirFlag := "-test-directory=" + testDir
+	if _, err := terraform.RunTerraformCommandE(t, options,
+		terraform.FormatArgs(options, "init", "-upgrade=false", testDirFlag)...); err != nil {
+		return nil, err
+	}
+
+	stdout, runErr := terraform.RunTerraformCommandAndGetStdoutE(t, options,
+		terraform.FormatArgs(options, "test", "-json", testDirFlag)...)
+	messages, err := Parse(strings.NewReader(stdout))
+	if err != nil {
+		return nil, err
+	}
+	// terraform test fails when a run block does; only output without a
+	// single message means Terraform did not run.
+	if len(messages) == 0 && runErr != nil {
+		return nil, runErr
+	}
+	return Collect(messages), nil
+}
+
+// Report reports every run block of results as a subtest of t, grouped by
+// file, e.g. TestTerraformTest/variables_test/valid_name_test. Error
+// diagnostics fail the subtest and warnings are logged; diagnostics outside
+// of any run fail the file's subtest or t itself.
+func Report(t *testing.T, results *Results) {
+	t.Helper()
+
+	reportDiagnostics(t, results.Diagnostics)
+	for _, file := range results.Files {
+		file := file
+		t.Run(file.Name(), func(t *testing.T) {
+			reportDiagnostics(t, file.Diagnostics)
+			if file.Status == StatusError && len(file.Runs) == 0 {
+				t.Errorf("%s: terraform test failed without running a block", file.Path)
+			}
+			for _, run := range file.Runs {
+				run := run
+				t.Run(run.Name, func(t *testing.T) {
+					reportRun(t, run)
+				})
+			}
+		})
+	}
+}
+
+func reportRun(t *testing.T, run *RunResult) {
+	failed := reportDiagnostics(t, run.Diagnostics)
+	switch run.Status {
+	case StatusPass:
+	case StatusSkip:
+		t.Skip("skipped by terraform test")
+	case StatusPending:
+		t.Error("terraform test did not run this block")
+	default:
+		if !failed {
+			t.Errorf("terraform test reported %s", run.Status)
+		}
+	}
+}
+
+// reportDiagnostics fails t with every error diagnostic and logs the rest.
+// It reports whether there were errors.
+func reportDiagnostics(t *testing.T, diagnostics []Diagnostic) bool {
+	failed := false
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == "error" {
+			t.Error(diagnostic.String())
+			failed = true
+		} else {
+			t.Log(diagnostic.String())
+		}
+	}
+	return failed
+}
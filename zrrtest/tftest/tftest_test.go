@@ -0,0 +1,98 @@
+package tftest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFile = "tests/unit/variables_test.tftest.hcl"
+
+func TestCollect(t *testing.T) {
+	t.Parallel()
+
+	output, err := os.Open("testdata/variables_test.jsonl")
+	require.NoError(t, err)
+	defer output.Close()
+	messages, err := Parse(output)
+	require.NoError(t, err)
+
+	results := Collect(messages)
+	assert.Empty(t, results.Diagnostics)
+	require.Len(t, results.Files, 1)
+	file := results.Files[0]
+	assert.Equal(t, testFile, file.Path)
+	assert.Equal(t, "variables_test", file.Name())
+	assert.Equal(t, StatusFail, file.Status)
+
+	statuses := map[string]string{}
+	var names []string
+	for _, run := range file.Runs {
+		names = append(names, run.Name)
+		statuses[run.Name] = run.Status
+	}
+	assert.Equal(t, []string{"valid_name_test", "invalid_name_length_test", "valid_sku_serverless_test", "invalid_sku_test", "valid_max_size_test"}, names)
+	assert.Equal(t, map[string]string{
+		"valid_name_test":           StatusPass,
+		"invalid_name_length_test":  StatusPass,
+		"valid_sku_serverless_test": StatusFail,
+		"invalid_sku_test":          StatusFail,
+		"valid_max_size_test":       StatusSkip,
+	}, statuses)
+
+	sku := file.Runs[2]
+	require.Len(t, sku.Diagnostics, 2)
+	assert.Equal(t, "variables_test.tftest.hcl:54: Test assertion failed: Should accept valid serverless SKU", sku.Diagnostics[0].String())
+	assert.Equal(t, "warning", sku.Diagnostics[1].Severity)
+	assert.Contains(t, file.Runs[3].Diagnostics[0].String(), "variables_test.tftest.hcl:95: Missing expected failure")
+}
+
+func TestCollectWithoutRuns(t *testing.T) {
+	t.Parallel()
+
+	// An invalid module fails before any test file starts.
+	messages, err := Parse(strings.NewReader("Initializing...\n" +
+		`{"@level":"error","@message":"Error: Unsupported argument","diagnostic":{"severity":"error","summary":"Unsupported argument","detail":"An argument named \"foo\" is not expected here.","range":{"filename":"main.tf","start":{"line":3}}},"type":"diagnostic"}` + "\n"))
+	require.NoError(t, err)
+	results := Collect(messages)
+	assert.Empty(t, results.Files)
+	require.Len(t, results.Diagnostics, 1)
+	assert.Equal(t, `main.tf:3: Unsupported argument: An argument named "foo" is not expected here.`, results.Diagnostics[0].String())
+
+	// A run Terraform never reached stays pending.
+	results = Collect([]Message{{Abstract: map[string][]string{testFile: {"first"}}}})
+	require.Len(t, results.Files, 1)
+	assert.Equal(t, StatusPending, results.Files[0].Runs[0].Status)
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	results := &Results{Files: []*FileResult{{
+		Path:   testFile,
+		Status: StatusPass,
+		Runs: []*RunResult{
+			{Name: "valid_name_test", Status: StatusPass},
+			{Name: "valid_sku_test", Status: StatusSkip},
+		},
+	}}}
+	Report(t, results)
+}
+
+func TestPrepare(t *testing.T) {
+	t.Parallel()
+
+	options, testDir, tempDir, err := Prepare(&terraform.Options{TerraformDir: ".."})
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	assert.Equal(t, "tftest", testDir)
+	assert.FileExists(t, filepath.Join(options.TerraformDir, "tftest", "tftest.go"))
+
+	_, _, _, err = Prepare(&terraform.Options{TerraformDir: "."})
+	assert.ErrorContains(t, err, "not below the module")
+}
@@ -0,0 +1,94 @@
+// Package tfvars turns the typed inputs generated for each module into
+// terraform.Options.Vars.
+//
+// The generated Inputs structs, under zrrtest/modules, tag every field with
+// the name of its variable or object attribute:
+//
+//	type Inputs struct {
+//		Name       string      `tf:"name"`
+//		Containers []Container `tf:"containers"`
+//		DNSName    *string     `tf:"dns_name_label"`
+//	}
+//
+// Vars walks the tags, so a misspelt variable fails to compile instead of
+// being ignored by the plan. Optional variables and attributes are pointers,
+// slices, maps or interfaces; left nil they are not passed, and Terraform
+// uses their defaults.
+package tfvars
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Tag is the struct tag holding the Terraform name of a field.
+const Tag = "tf"
+
+// Vars returns inputs, a struct whose fields carry a tf tag, as the
+// variables of terraform.Options. Nested structs become maps, slices stay
+// slices, and nil fields are left out.
+func Vars(inputs interface{}) map[string]interface{} {
+	value := reflect.ValueOf(inputs)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("tfvars: %T is not a struct", inputs))
+	}
+	vars, _ := convert(value).(map[string]interface{})
+	return vars
+}
+
+// convert returns value as Terraform would see it, or nil if it is nil.
+func convert(value reflect.Value) interface{} {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return convert(value.Elem())
+	case reflect.Slice:
+		if value.IsNil() {
+			return nil
+		}
+		list := make([]interface{}, value.Len())
+		for i := range list {
+			list[i] = convert(value.Index(i))
+		}
+		return list
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+		m := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			m[fmt.Sprint(iter.Key().Interface())] = convert(iter.Value())
+		}
+		return m
+	case reflect.Struct:
+		m := map[string]interface{}{}
+		ty := value.Type()
+		for i := 0; i < ty.NumField(); i++ {
+			name := strings.Split(ty.Field(i).Tag.Get(Tag), ",")[0]
+			if name == "" || name == "-" {
+				continue
+			}
+			if field := convert(value.Field(i)); field != nil {
+				m[name] = field
+			}
+		}
+		return m
+	}
+	return value.Interface()
+}
+
+// String returns a pointer to s, for an optional string.
+func String(s string) *string { return &s }
+
+// Bool returns a pointer to b, for an optional bool.
+func Bool(b bool) *bool { return &b }
+
+// Number returns a pointer to n, for an optional number.
+func Number(n float64) *float64 { return &n }
@@ -0,0 +1,63 @@
+package tfvars
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type port struct {
+	Port     float64 `tf:"port"`
+	Protocol *string `tf:"protocol"`
+}
+
+type container struct {
+	Name  string `tf:"name"`
+	Ports []port `tf:"ports"`
+}
+
+type inputs struct {
+	Name       string            `tf:"name"`
+	Label      *string           `tf:"dns_name_label"`
+	Enabled    *bool             `tf:"enabled"`
+	Containers []container       `tf:"containers"`
+	Tags       map[string]string `tf:"tags"`
+	Settings   interface{}       `tf:"settings"`
+	Ignored    string
+}
+
+func TestVars(t *testing.T) {
+	t.Parallel()
+
+	vars := Vars(inputs{
+		Name:    "aci-test",
+		Enabled: Bool(false),
+		Containers: []container{
+			{Name: "app", Ports: []port{{Port: 80, Protocol: String("TCP")}, {Port: 443}}},
+		},
+		Tags:    map[string]string{},
+		Ignored: "x",
+	})
+	assert.Equal(t, map[string]interface{}{
+		"name":    "aci-test",
+		"enabled": false,
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name": "app",
+				"ports": []interface{}{
+					map[string]interface{}{"port": float64(80), "protocol": "TCP"},
+					map[string]interface{}{"port": float64(443)},
+				},
+			},
+		},
+		"tags": map[string]interface{}{},
+	}, vars)
+
+	assert.Equal(t, vars, Vars(&inputs{
+		Name:       "aci-test",
+		Enabled:    Bool(false),
+		Containers: []container{{Name: "app", Ports: []port{{Port: 80, Protocol: String("TCP")}, {Port: 443}}}},
+		Tags:       map[string]string{},
+	}))
+	assert.Panics(t, func() { Vars(map[string]string{}) })
+}